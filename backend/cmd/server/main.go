@@ -3,12 +3,29 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"noteapp/internal/api"
+	"noteapp/internal/repository/blobrepo"
 	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/dailyrepo"
 	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/reminderrepo"
+	"noteapp/internal/repository/savedsearchrepo"
+	"noteapp/internal/repository/templaterepo"
+	"noteapp/internal/scheduler"
+	"noteapp/internal/usecase/blobuc"
 	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/discoveryuc"
+	"noteapp/internal/usecase/linkuc"
 	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/reminderuc"
+	"noteapp/internal/usecase/savedsearchuc"
+	"noteapp/internal/usecase/templateuc"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,11 +36,55 @@ func main() {
 	// 1. Dependency Injection
 	noteRepo := noterepo.NewInMemoryNoteRepository()
 	contentRepo := contentrepo.NewInMemoryContentRepository()
+	savedSearchRepo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	templateRepo := templaterepo.NewInMemoryTemplateRepository()
+	dailyRepo := dailyrepo.NewInMemoryDailyNoteRepository()
+	blobDir := os.Getenv("NOTEAPP_BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "data/blobs"
+	}
+	blobStore, err := blobrepo.NewFileSystemBlobStore(blobDir)
+	if err != nil {
+		log.Fatalf("Failed to create blob store: %v", err)
+	}
+	reminderFile := os.Getenv("NOTEAPP_REMINDER_FILE")
+	if reminderFile == "" {
+		reminderFile = "data/reminders.json"
+	}
+	reminderRepo, err := reminderrepo.NewFileReminderRepository(reminderFile)
+	if err != nil {
+		log.Fatalf("Failed to create reminder repository: %v", err)
+	}
 
 	noteUsecase := noteuc.NewNoteUsecase(noteRepo)
 	contentUsecase := contentuc.NewContentUsecase(contentRepo)
+	discoveryUsecase := discoveryuc.NewDiscoveryUsecase(noteRepo, contentRepo)
+	linkUsecase := linkuc.NewLinkUsecase(noteRepo, contentRepo)
+	savedSearchUsecase := savedsearchuc.NewSavedSearchUsecase(savedSearchRepo, noteRepo)
+	templateUsecase := templateuc.NewTemplateUsecase(templateRepo)
+	dailyUsecase := dailyuc.NewDailyUsecase(dailyRepo, noteRepo)
+	reminderUsecase := reminderuc.NewReminderUsecase(reminderRepo, noteRepo)
+	blobUsecase := blobuc.NewBlobUsecase(blobStore)
+	if sizes := os.Getenv("NOTEAPP_THUMBNAIL_SIZES"); sizes != "" {
+		var thumbnailSizes []int
+		for _, size := range strings.Split(sizes, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(size))
+			if err != nil {
+				log.Fatalf("Invalid thumbnail size %q: %v", size, err)
+			}
+			thumbnailSizes = append(thumbnailSizes, n)
+		}
+		blobUsecase = blobuc.NewBlobUsecaseWithThumbnailSizes(blobStore, thumbnailSizes)
+	}
 
-	noteHandler := api.NewNoteHandler(noteUsecase, contentUsecase)
+	noteHandler := api.NewNoteHandler(noteUsecase, contentUsecase, savedSearchUsecase, blobUsecase, templateUsecase)
+	discoveryHandler := api.NewDiscoveryHandler(discoveryUsecase)
+	linkHandler := api.NewLinkHandler(linkUsecase)
+	savedSearchHandler := api.NewSavedSearchHandler(savedSearchUsecase)
+	templateHandler := api.NewTemplateHandler(templateUsecase)
+	dailyHandler := api.NewDailyNoteHandler(noteHandler, dailyUsecase)
+	reminderHandler := api.NewReminderHandler(noteHandler, reminderUsecase)
+	blobHandler := api.NewBlobHandler(blobUsecase, noteUsecase, contentUsecase)
 
 	// test data
 	n1, err := noteUsecase.CreateNote("", "Test Note 1", "testUser1")
@@ -34,11 +95,11 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create test note: %v", err)
 	}
-	c1, err := contentUsecase.CreateContent(n1, "", "Content for Note 1", "text")
+	c1, err := contentUsecase.CreateContent(n1, "testUser1", "", "Content for Note 1", "text")
 	if err != nil {
 		log.Fatalf("Failed to create test content: %v", err)
 	}
-	noteUsecase.AddContent(n1, c1, -1, 0)
+	noteUsecase.AddContent(n1, "testUser1", c1, -1, 0)
 
 	// 2. Routing
 	router := chi.NewRouter()
@@ -60,7 +121,7 @@ func main() {
 		AllowedOrigins:   []string{"http://localhost:4200", "vscode-file://vscode-app"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"Link", "X-Total-Count", "X-Next-Cursor", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any major browsers
 	}))
@@ -68,15 +129,74 @@ func main() {
 	router.Post("/notes", noteHandler.CreateNote)
 	router.Get("/notes/{id}", noteHandler.GetNoteByID)
 	router.Delete("/notes/{id}", noteHandler.DeleteNote)
+	router.Post("/notes/{id}/duplicate", noteHandler.DuplicateNote)
+	router.Post("/notes/{id}/split", noteHandler.SplitNote)
+	router.Post("/notes/{id}/merge", noteHandler.MergeNotes)
 	router.Put("/notes/{id}", noteHandler.UpdateNote)
 	router.Post("/notes/{id}/contents", noteHandler.AddContent)
+	router.Put("/notes/{id}/contents/order", noteHandler.ReorderContents)
+	router.Post("/notes/{id}/contents/images", noteHandler.UploadImage)
+	router.Post("/notes/{id}/contents/attachments", noteHandler.UploadAttachment)
+	router.Get("/notes/{id}/contents/{contentId}/file", noteHandler.DownloadAttachment)
 	router.Put("/notes/{id}/contents/{contentId}", noteHandler.UpdateContent)
 	router.Delete("/notes/{id}/contents/{contentId}", noteHandler.DeleteContent)
+	router.Post("/notes/{id}/contents/{contentId}/move", noteHandler.MoveContent)
+	router.Post("/notes/{id}/contents/{contentId}/copy", noteHandler.CopyContent)
+	router.Get("/notes/{id}/render", noteHandler.RenderNote)
+	router.Get("/notes/{id}/contents/{contentId}/render", noteHandler.RenderContent)
+	router.Post("/notes/{id}/contents/{contentId}/items", noteHandler.AddChecklistItem)
+	router.Post("/notes/{id}/contents/{contentId}/items/{itemId}/toggle", noteHandler.ToggleChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/items/{itemId}/position", noteHandler.MoveChecklistItem)
+	router.Delete("/notes/{id}/contents/{contentId}/items/{itemId}", noteHandler.RemoveChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/rows/{rowId}/cells/{columnId}", noteHandler.UpdateTableCell)
+	router.Post("/notes/{id}/contents/{contentId}/rows", noteHandler.InsertTableRow)
+	router.Delete("/notes/{id}/contents/{contentId}/rows/{rowId}", noteHandler.DeleteTableRow)
+	router.Post("/notes/{id}/contents/{contentId}/columns", noteHandler.InsertTableColumn)
+	router.Delete("/notes/{id}/contents/{contentId}/columns/{columnId}", noteHandler.DeleteTableColumn)
 	router.Get("/users/{userID}/accessible-notes", noteHandler.GetAccessibleNotesForUser)
+	router.Post("/users/{userID}/notes/{noteID}/keyword", noteHandler.TagNote)
+	router.Get("/users/{userID}/notes", noteHandler.FindNotesByKeyword)
+	router.Delete("/users/{userID}/notes/{noteID}/keyword/{keyword}", noteHandler.UntagNote)
+	router.Put("/users/{userID}/notes/{noteID}/state", noteHandler.UpdateNoteState)
+	router.Get("/users/{userID}/keywords/tree", noteHandler.GetKeywordTree)
+	router.Get("/users/{userID}/keywords/suggest", noteHandler.SuggestKeywords)
+	router.Get("/users/{userID}/graph", linkHandler.GetGraph)
 	router.Delete("/users/{ownerID}/notes/{noteID}/shares", noteHandler.RevokeAccess)
+	router.Get("/notes/{id}/keyword-suggestions", discoveryHandler.SuggestKeywordsForNote)
+	router.Get("/notes/{id}/related", discoveryHandler.FindRelatedNotes)
+	router.Get("/notes/{id}/backlinks", linkHandler.GetBacklinks)
+	router.Get("/notes/{id}/outlinks", linkHandler.GetOutlinks)
+	router.Post("/users/{userID}/saved-searches", savedSearchHandler.CreateSavedSearch)
+	router.Get("/users/{userID}/saved-searches", savedSearchHandler.ListSavedSearches)
+	router.Get("/users/{userID}/saved-searches/{searchID}", savedSearchHandler.GetSavedSearch)
+	router.Put("/users/{userID}/saved-searches/{searchID}", savedSearchHandler.UpdateSavedSearch)
+	router.Delete("/users/{userID}/saved-searches/{searchID}", savedSearchHandler.DeleteSavedSearch)
+	router.Get("/users/{userID}/saved-searches/{searchID}/notes", savedSearchHandler.EvaluateSavedSearch)
+	router.Get("/users/{userID}/daily", dailyHandler.ListDailyNotes)
+	router.Get("/users/{userID}/daily/settings", dailyHandler.GetDailySettings)
+	router.Put("/users/{userID}/daily/settings", dailyHandler.UpdateDailySettings)
+	router.Get("/users/{userID}/daily/{date}", dailyHandler.GetDailyNote)
+	router.Get("/users/{userID}/daily/{date}/previous", dailyHandler.PreviousDailyNote)
+	router.Get("/users/{userID}/daily/{date}/next", dailyHandler.NextDailyNote)
+	router.Post("/users/{userID}/notes/{noteID}/reminders", reminderHandler.CreateReminder)
+	router.Get("/users/{userID}/reminders", reminderHandler.ListReminders)
+	router.Get("/users/{userID}/reminders/{reminderID}", reminderHandler.GetReminder)
+	router.Put("/users/{userID}/reminders/{reminderID}", reminderHandler.UpdateReminder)
+	router.Delete("/users/{userID}/reminders/{reminderID}", reminderHandler.DeleteReminder)
+	router.Post("/templates", templateHandler.CreateTemplate)
+	router.Get("/templates", templateHandler.ListTemplates)
+	router.Get("/templates/{templateID}", templateHandler.GetTemplate)
+	router.Put("/templates/{templateID}", templateHandler.UpdateTemplate)
+	router.Delete("/templates/{templateID}", templateHandler.DeleteTemplate)
+	router.Get("/blobs/{hash}", blobHandler.GetBlob)
 	router.Get("/notes/{noteID}/ws", noteHandler.HandleWebSocket)
+	router.Get("/users/{userID}/ws", noteHandler.HandleUserWebSocket)
+
+	// 3. Reminder Scheduler
+	reminderScheduler := scheduler.NewScheduler(reminderUsecase, 15*time.Second, reminderHandler)
+	reminderScheduler.Start()
 
-	// 3. Server Startup
+	// 4. Server Startup
 	port := ":8080"
 	log.Printf("Server starting on port %s", port)
 	if err := http.ListenAndServe(port, router); err != nil {
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/repository/blobrepo"
+	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/noteuc"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// testPDF is a small document sniffed as a PDF.
+var testPDF = []byte("%PDF-1.7 quarterly report")
+
+// setupAttachmentTest initializes a router with the attachment endpoints, a storage quota of
+// quota bytes per user and a blob store the test can inspect.
+func setupAttachmentTest(quota int64) (*chi.Mux, *noteuc.NoteUsecase, *blobrepo.InMemoryBlobStore) {
+	ht := newHandlerTestWithStorageQuota(time.Now(), quota)
+	router, handler := ht.router, ht.handler
+
+	router.Delete("/notes/{id}", handler.DeleteNote)
+	router.Post("/notes/{id}/contents", handler.AddContent)
+	router.Delete("/notes/{id}/contents/{contentId}", handler.DeleteContent)
+	router.Post("/notes/{id}/contents/attachments", handler.UploadAttachment)
+	router.Get("/notes/{id}/contents/{contentId}/file", handler.DownloadAttachment)
+	return router, ht.nuc, ht.blobStore
+}
+
+// uploadAttachment uploads data as an attachment named filename and returns the response recorder.
+func uploadAttachment(router http.Handler, noteID string, noteVersion int, filename string, data []byte) *httptest.ResponseRecorder {
+	target := "/notes/" + noteID + "/contents/attachments?user_id=owner-1&filename=" + filename + "&note_version=" + strconv.Itoa(noteVersion)
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(data))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNoteHandler_UploadAttachment_Success(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+
+	// Act
+	rr := uploadAttachment(router, noteID, 0, "report.pdf", testPDF)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var contentDTO contentuc.ContentDTO
+	if err := json.NewDecoder(rr.Body).Decode(&contentDTO); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if contentDTO.Type != "attachment" || contentDTO.Filename != "report.pdf" {
+		t.Errorf("expected an attachment named report.pdf; got %s %q", contentDTO.Type, contentDTO.Filename)
+	}
+	if contentDTO.Size != int64(len(testPDF)) || contentDTO.MimeType != "application/pdf" {
+		t.Errorf("expected a %d byte application/pdf; got %d byte %s", len(testPDF), contentDTO.Size, contentDTO.MimeType)
+	}
+	noteDTO, _ := nuc.GetNoteByID(noteID)
+	if len(noteDTO.ContentIDs) != 1 || noteDTO.ContentIDs[0] != contentDTO.ID {
+		t.Errorf("expected note to contain content %s; got %v", contentDTO.ID, noteDTO.ContentIDs)
+	}
+}
+
+func TestNoteHandler_DownloadAttachment(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	var uploaded contentuc.ContentDTO
+	json.NewDecoder(uploadAttachment(router, noteID, 0, "report.pdf", testPDF).Body).Decode(&uploaded)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/contents/"+uploaded.ID+"/file?user_id=owner-1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	if !bytes.Equal(rr.Body.Bytes(), testPDF) {
+		t.Errorf("expected the uploaded bytes to be returned")
+	}
+	if got := rr.Header().Get("Content-Disposition"); got != `attachment; filename=report.pdf` {
+		t.Errorf("expected the filename in Content-Disposition; got %q", got)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("expected Content-Type application/pdf; got %s", got)
+	}
+	if got := rr.Header().Get("Content-Security-Policy"); got != "sandbox" {
+		t.Errorf("expected a sandbox Content-Security-Policy; got %q", got)
+	}
+}
+
+func TestNoteHandler_DownloadAttachment_RequiresViewAccess(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	var uploaded contentuc.ContentDTO
+	json.NewDecoder(uploadAttachment(router, noteID, 0, "report.pdf", testPDF).Body).Decode(&uploaded)
+	nuc.ShareNote(noteID, "owner-1", "reader-1", "read", 1)
+
+	for _, tt := range []struct {
+		query string
+		want  int
+	}{
+		{"?user_id=reader-1", http.StatusOK},
+		{"?user_id=stranger", http.StatusForbidden},
+		{"", http.StatusBadRequest},
+	} {
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/contents/"+uploaded.ID+"/file"+tt.query, nil))
+
+		// Assert
+		if rr.Code != tt.want {
+			t.Errorf("expected status %d for '%s'; got %d", tt.want, tt.query, rr.Code)
+		}
+	}
+}
+
+func TestNoteHandler_UploadAttachment_QuotaExceeded(t *testing.T) {
+	// Arrange
+	router, nuc, blobStore := setupAttachmentTest(int64(len(testPDF)) + 5)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	uploadAttachment(router, noteID, 0, "report.pdf", testPDF)
+	second := []byte("%PDF-1.7 another report")
+
+	// Act
+	rr := uploadAttachment(router, noteID, 1, "other.pdf", second)
+
+	// Assert
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d; got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+	if exists, _ := blobStore.Exists(blobrepo.HashOf(second)); exists {
+		t.Errorf("expected the rejected file to be removed from storage")
+	}
+}
+
+func TestNoteHandler_UploadAttachment_StreamedPastQuota(t *testing.T) {
+	// Arrange
+	router, nuc, blobStore := setupAttachmentTest(int64(len(testPDF)) - 1)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	target := "/notes/" + noteID + "/contents/attachments?user_id=owner-1&filename=report.pdf&note_version=0"
+	req := httptest.NewRequest(http.MethodPost, target, io.MultiReader(bytes.NewReader(testPDF)))
+	req.ContentLength = -1
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d; got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+	if exists, _ := blobStore.Exists(blobrepo.HashOf(testPDF)); exists {
+		t.Errorf("expected a file over the quota not to be stored")
+	}
+}
+
+func TestNoteHandler_UploadAttachment_RequiresEditAccess(t *testing.T) {
+	// Arrange
+	router, nuc, blobStore := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	nuc.ShareNote(noteID, "owner-1", "reader-1", "read", 0)
+
+	for _, tt := range []struct {
+		query string
+		want  int
+	}{
+		{"user_id=reader-1&", http.StatusForbidden},
+		{"", http.StatusBadRequest},
+	} {
+		target := "/notes/" + noteID + "/contents/attachments?" + tt.query + "filename=report.pdf&note_version=1"
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, target, bytes.NewReader(testPDF)))
+
+		// Assert
+		if rr.Code != tt.want {
+			t.Errorf("expected status %d for '%s'; got %d", tt.want, tt.query, rr.Code)
+		}
+	}
+	if exists, _ := blobStore.Exists(blobrepo.HashOf(testPDF)); exists {
+		t.Errorf("expected rejected uploads not to be stored")
+	}
+}
+
+func TestNoteHandler_DeleteContent_ReleasesUnreferencedBlob(t *testing.T) {
+	// Arrange
+	router, nuc, blobStore := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	var first, second contentuc.ContentDTO
+	json.NewDecoder(uploadAttachment(router, noteID, 0, "report.pdf", testPDF).Body).Decode(&first)
+	json.NewDecoder(uploadAttachment(router, noteID, 1, "copy.pdf", testPDF).Body).Decode(&second)
+	hash := blobrepo.HashOf(testPDF)
+
+	deleteContent := func(contentID string, noteVersion int) {
+		body, _ := json.Marshal(DeleteContentRequest{ContentVersion: intPtr(0), NoteVersion: intPtr(noteVersion)})
+		req := httptest.NewRequest(http.MethodDelete, "/notes/"+noteID+"/contents/"+contentID, bytes.NewBuffer(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNoContent {
+			t.Fatalf("expected status %d; got %d", http.StatusNoContent, rr.Code)
+		}
+	}
+
+	// Act & Assert
+	deleteContent(first.ID, 2)
+	if exists, _ := blobStore.Exists(hash); !exists {
+		t.Fatalf("expected the file to be kept while another content references it")
+	}
+	deleteContent(second.ID, 3)
+	if exists, _ := blobStore.Exists(hash); exists {
+		t.Errorf("expected the file to be removed once no content references it")
+	}
+}
+
+func TestNoteHandler_DeleteNote_ReleasesBlobs(t *testing.T) {
+	// Arrange
+	router, nuc, blobStore := setupAttachmentTest(contentuc.DefaultStorageQuota)
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	uploadAttachment(router, noteID, 0, "report.pdf", testPDF)
+
+	body, _ := json.Marshal(DeleteNoteRequest{NoteVersion: intPtr(1)})
+	req := httptest.NewRequest(http.MethodDelete, "/notes/"+noteID, bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d; got %d", http.StatusNoContent, rr.Code)
+	}
+	if exists, _ := blobStore.Exists(blobrepo.HashOf(testPDF)); exists {
+		t.Errorf("expected the file to be removed with its note")
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"noteapp/internal/usecase/blobuc"
+	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/noteuc"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// blobCacheControl lets the client cache blobs indefinitely, since a blob's hash changes with
+// its data. Blobs are only served to users who can view them, so shared caches must not keep them.
+const blobCacheControl = "private, max-age=31536000, immutable"
+
+// blobContentSecurityPolicy keeps served files from running scripts or loading other
+// resources, even when a browser opens them as a page.
+const blobContentSecurityPolicy = "sandbox"
+
+// BlobHandler handles HTTP requests for binary data such as images.
+type BlobHandler struct {
+	blobUsecase    *blobuc.BlobUsecase
+	noteUsecase    *noteuc.NoteUsecase
+	contentUsecase *contentuc.ContentUsecase
+}
+
+// NewBlobHandler creates a new BlobHandler.
+func NewBlobHandler(buc *blobuc.BlobUsecase, nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase) *BlobHandler {
+	return &BlobHandler{blobUsecase: buc, noteUsecase: nuc, contentUsecase: cuc}
+}
+
+// GetBlob is the handler for the GET /blobs/{hash}?user_id={userID} endpoint. It serves images
+// and their thumbnails to users who can view a note with an image content referencing them.
+// Attachments are only served by the DownloadAttachment endpoint of their note.
+func (h *BlobHandler) GetBlob(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	blob, file, err := h.blobUsecase.GetBlob(hash)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	defer file.Close()
+
+	if !strings.HasPrefix(blob.MimeType, "image/") {
+		mapErrorToHTTPStatus(w, blobuc.ErrBlobNotFound)
+		return
+	}
+	if err := checkCanViewBlob(h.noteUsecase, h.contentUsecase, hash, callerID(r), contentuc.ImageContentType); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", blob.MimeType)
+	w.Header().Set("ETag", `"`+blob.Hash+`"`)
+	w.Header().Set("Cache-Control", blobCacheControl)
+	w.Header().Set("Content-Security-Policy", blobContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// ServeContent streams the data and answers conditional and range requests from the ETag.
+	http.ServeContent(w, r, "", time.Time{}, file)
+}
+
+// checkCanViewBlob checks that a user can view a note with a content of the given type that
+// references a blob, as its file or, for images, as one of its thumbnails.
+func checkCanViewBlob(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase, hash, userID string, contentType contentuc.ContentType) error {
+	contents, err := cuc.GetContentsByBlob(hash)
+	if err != nil {
+		return err
+	}
+	denied := error(blobuc.ErrBlobNotFound)
+	for _, contentDTO := range contents {
+		if contentDTO.Type != string(contentType) {
+			continue
+		}
+		err := nuc.CheckCanView(contentDTO.NoteID, userID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, noteuc.ErrNoteNotFound) {
+			denied = err
+		}
+	}
+	return denied
+}
@@ -0,0 +1,370 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/repository/blobrepo"
+	"strconv"
+	"testing"
+)
+
+// testPNG is a small blank PNG image.
+var testPNG = encodeTestPNG(4, 4)
+
+// encodeTestPNG returns a blank width x height PNG image.
+func encodeTestPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+// newImageUploadRequest builds a multipart upload of data to the note's image endpoint.
+func newImageUploadRequest(t *testing.T, noteID string, noteVersion int, data []byte) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("note_version", strconv.Itoa(noteVersion))
+	part, err := writer.CreateFormFile("file", "image.png")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents/images?user_id=owner-1", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestNoteHandler_UploadImage_Success(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	req := newImageUploadRequest(t, noteID, 0, testPNG)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var response UploadImageResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if response.MimeType != "image/png" {
+		t.Errorf("expected mime type image/png; got %s", response.MimeType)
+	}
+	contentDTO, err := cuc.GetContentByID(response.ID)
+	if err != nil {
+		t.Fatalf("failed to get content: %v", err)
+	}
+	if contentDTO.Type != "image" || contentDTO.Data != response.Hash {
+		t.Errorf("expected an image content referencing %s; got %s content %q", response.Hash, contentDTO.Type, contentDTO.Data)
+	}
+	noteDTO, _ := nuc.GetNoteByID(noteID)
+	if len(noteDTO.ContentIDs) != 1 || noteDTO.ContentIDs[0] != response.ID {
+		t.Errorf("expected note to contain content %s; got %v", response.ID, noteDTO.ContentIDs)
+	}
+}
+
+func TestNoteHandler_GetAccessibleNotesForUser_PreviewsImageThumbnail(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, encodeTestPNG(600, 300)))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/owner-1/accessible-notes", nil)
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	var notes []GetNoteByIDResponse
+	if err := json.NewDecoder(rr.Body).Decode(&notes); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(notes) != 1 || len(notes[0].Contents) != 1 {
+		t.Fatalf("expected 1 note with 1 content; got %+v", notes)
+	}
+	preview := notes[0].Contents[0]
+	if preview.Data != uploaded.Thumbnails[128] {
+		t.Errorf("expected the preview to reference the smallest thumbnail %s; got %s", uploaded.Thumbnails[128], preview.Data)
+	}
+	if preview.Width != 600 || preview.Height != 300 || preview.Format != "png" {
+		t.Errorf("expected the preview to describe a 600x300 png; got %dx%d %s", preview.Width, preview.Height, preview.Format)
+	}
+}
+
+func TestNoteHandler_UploadImage_UnsupportedMediaType(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	req := newImageUploadRequest(t, noteID, 0, []byte("plain text, not an image"))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status %d; got %d", http.StatusUnsupportedMediaType, rr.Code)
+	}
+}
+
+func TestNoteHandler_UpdateContent_ImageNotEditable(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, testPNG))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+
+	body, _ := json.Marshal(UpdateContentRequest{Data: "replaced", ContentVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/"+uploaded.ID, bytes.NewBuffer(body))
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestNoteHandler_AddContent_ImageRequiresStoredBlob(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{
+		Type:        "image",
+		Data:        "0000000000000000000000000000000000000000000000000000000000000000",
+		NoteVersion: intPtr(0),
+		Index:       intPtr(-1),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestNoteHandler_AddContent_ImageRequiresViewAccess(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, testPNG))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+	ownerNoteID, _ := nuc.CreateNote("", "Other", "owner-1")
+	strangerNoteID, _ := nuc.CreateNote("", "Stranger", "stranger")
+
+	addImage := func(noteID, userID string) int {
+		body, _ := json.Marshal(AddContentRequest{Type: "image", Data: uploaded.Hash, NoteVersion: intPtr(0), Index: intPtr(-1)})
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents?user_id="+userID, bytes.NewBuffer(body)))
+		return rr.Code
+	}
+
+	// Act
+	ownerCode := addImage(ownerNoteID, "owner-1")
+	strangerCode := addImage(strangerNoteID, "stranger")
+
+	// Assert
+	if ownerCode != http.StatusCreated {
+		t.Errorf("expected status %d for a user who can view the image; got %d", http.StatusCreated, ownerCode)
+	}
+	if strangerCode != http.StatusForbidden {
+		t.Errorf("expected status %d for a user who cannot view the image; got %d", http.StatusForbidden, strangerCode)
+	}
+	if note, _ := nuc.GetNoteByID(strangerNoteID); len(note.ContentIDs) != 0 {
+		t.Errorf("expected no content added to the stranger's note; got %v", note.ContentIDs)
+	}
+}
+
+func TestBlobHandler_GetBlob(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, testPNG))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+
+	req := httptest.NewRequest(http.MethodGet, "/blobs/"+uploaded.Hash+"?user_id=owner-1", nil)
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	if !bytes.Equal(rr.Body.Bytes(), testPNG) {
+		t.Errorf("expected the uploaded bytes to be returned")
+	}
+	if got := rr.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("expected Content-Type image/png; got %s", got)
+	}
+	if got := rr.Header().Get("ETag"); got != `"`+uploaded.Hash+`"` {
+		t.Errorf("expected ETag of the hash; got %s", got)
+	}
+	if got := rr.Header().Get("Cache-Control"); got != blobCacheControl {
+		t.Errorf("expected Cache-Control %q; got %q", blobCacheControl, got)
+	}
+	if got := rr.Header().Get("Content-Security-Policy"); got != "sandbox" {
+		t.Errorf("expected a sandbox Content-Security-Policy; got %q", got)
+	}
+}
+
+func TestBlobHandler_GetBlob_RequiresViewAccess(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, encodeTestPNG(600, 300)))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+	nuc.ShareNote(noteID, "owner-1", "reader-1", "read", 1)
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"collaborator", "/blobs/" + uploaded.Hash + "?user_id=reader-1", http.StatusOK},
+		{"collaborator viewing a thumbnail", "/blobs/" + uploaded.Thumbnails[128] + "?user_id=reader-1", http.StatusOK},
+		{"stranger", "/blobs/" + uploaded.Hash + "?user_id=stranger", http.StatusForbidden},
+		{"no user", "/blobs/" + uploaded.Hash, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			// Assert
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestBlobHandler_GetBlob_DoesNotServeAttachments(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	page := []byte("<html><script>alert(document.cookie)</script></html>")
+	target := "/notes/" + noteID + "/contents/attachments?user_id=owner-1&filename=page.html&note_version=0"
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, target, bytes.NewReader(page)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	req := httptest.NewRequest(http.MethodGet, "/blobs/"+blobrepo.HashOf(page)+"?user_id=owner-1", nil)
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestBlobHandler_GetBlob_NotModified(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, testPNG))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+
+	req := httptest.NewRequest(http.MethodGet, "/blobs/"+uploaded.Hash+"?user_id=owner-1", nil)
+	req.Header.Set("If-None-Match", `"`+uploaded.Hash+`"`)
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNotModified {
+		t.Errorf("expected status %d; got %d", http.StatusNotModified, rr.Code)
+	}
+}
+
+func TestBlobHandler_GetBlob_Range(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, newImageUploadRequest(t, noteID, 0, testPNG))
+	var uploaded UploadImageResponse
+	json.NewDecoder(rr.Body).Decode(&uploaded)
+
+	req := httptest.NewRequest(http.MethodGet, "/blobs/"+uploaded.Hash+"?user_id=owner-1", nil)
+	req.Header.Set("Range", "bytes=1-3")
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusPartialContent {
+		t.Fatalf("expected status %d; got %d", http.StatusPartialContent, rr.Code)
+	}
+	if !bytes.Equal(rr.Body.Bytes(), testPNG[1:4]) {
+		t.Errorf("expected bytes 1-3 of the blob; got %q", rr.Body.Bytes())
+	}
+}
+
+func TestBlobHandler_GetBlob_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+		want int
+	}{
+		{"invalid hash", "not-a-hash", http.StatusBadRequest},
+		{"unknown hash", "0000000000000000000000000000000000000000000000000000000000000000", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router, _, _ := setupTest()
+			req := httptest.NewRequest(http.MethodGet, "/blobs/"+tt.hash, nil)
+			rr := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rr, req)
+
+			// Assert
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"noteapp/internal/usecase/contentuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AddChecklistItemRequest represents the request body for adding an item to a checklist.
+type AddChecklistItemRequest struct {
+	Text           string `json:"text"`
+	Assignee       string `json:"assignee,omitempty"`
+	Index          *int   `json:"index,omitempty"`
+	ContentVersion *int   `json:"content_version"`
+}
+
+// MoveChecklistItemRequest represents the request body for moving a checklist item.
+type MoveChecklistItemRequest struct {
+	Index          *int `json:"index"`
+	ContentVersion *int `json:"content_version"`
+}
+
+// ChecklistItemRequest represents the request body for toggling or removing a checklist item.
+type ChecklistItemRequest struct {
+	ContentVersion *int `json:"content_version"`
+}
+
+// AddChecklistItem is the handler for the POST /notes/{id}/contents/{contentId}/items endpoint.
+func (h *NoteHandler) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	var req AddChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+	index := -1
+	if req.Index != nil {
+		index = *req.Index
+	}
+
+	item, err := h.contentUsecase.AddChecklistItem(chi.URLParam(r, "contentId"), callerID(r), req.Text, req.Assignee, index, *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastChecklistEvent(r, "add_checklist_item", item, index, *req.ContentVersion)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(item)
+}
+
+// ToggleChecklistItem is the handler for the POST /notes/{id}/contents/{contentId}/items/{itemId}/toggle endpoint.
+func (h *NoteHandler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	var req ChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.contentUsecase.ToggleChecklistItem(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "itemId"), *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastChecklistEvent(r, "toggle_checklist_item", item, 0, *req.ContentVersion)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(item)
+}
+
+// MoveChecklistItem is the handler for the PUT /notes/{id}/contents/{contentId}/items/{itemId}/position endpoint.
+func (h *NoteHandler) MoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	var req MoveChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Index == nil || req.ContentVersion == nil {
+		http.Error(w, "index and content_version are required", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.contentUsecase.MoveChecklistItem(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "itemId"), *req.Index, *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastChecklistEvent(r, "move_checklist_item", item, *req.Index, *req.ContentVersion)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(item)
+}
+
+// RemoveChecklistItem is the handler for the DELETE /notes/{id}/contents/{contentId}/items/{itemId} endpoint.
+func (h *NoteHandler) RemoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	var req ChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.contentUsecase.RemoveChecklistItem(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "itemId"), *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastChecklistEvent(r, "remove_checklist_item", item, 0, *req.ContentVersion)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// broadcastChecklistEvent sends an item-level checklist change to the clients connected to the note.
+func (h *NoteHandler) broadcastChecklistEvent(r *http.Request, eventType string, item *contentuc.ChecklistItemDTO, index, contentVersion int) {
+	noteID := chi.URLParam(r, "id")
+	contentID := chi.URLParam(r, "contentId")
+	event := WebSocketEvent{
+		Type:           eventType,
+		NoteID:         noteID,
+		ContentID:      contentID,
+		ContentVersion: contentVersion + 1,
+		Index:          index,
+		Item:           item,
+	}
+	h.stampContentEvent(&event, contentID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+}
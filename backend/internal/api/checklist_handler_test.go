@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestNoteHandler_ChecklistItems(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Groceries", "owner-1")
+	contentID, _ := cuc.CreateContent(noteID, "owner-1", "", "", contentuc.ChecklistContentType)
+	nuc.AddContent(noteID, "owner-1", contentID, -1, 0)
+	itemsURL := "/notes/" + noteID + "/contents/" + contentID + "/items"
+
+	serve := func(method, target string, body any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, target, bytes.NewBuffer(data)))
+		return rr
+	}
+
+	// Act
+	rr := serve(http.MethodPost, itemsURL, AddChecklistItemRequest{Text: "milk", ContentVersion: intPtr(0)})
+	var milk contentuc.ChecklistItemDTO
+	json.NewDecoder(rr.Body).Decode(&milk)
+	serve(http.MethodPost, itemsURL, AddChecklistItemRequest{Text: "eggs", Assignee: "bob", Index: intPtr(0), ContentVersion: intPtr(1)})
+	toggled := serve(http.MethodPost, itemsURL+"/"+milk.ID+"/toggle", ChecklistItemRequest{ContentVersion: intPtr(2)})
+	moved := serve(http.MethodPut, itemsURL+"/"+milk.ID+"/position", MoveChecklistItemRequest{Index: intPtr(0), ContentVersion: intPtr(3)})
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, rr.Code)
+	}
+	if toggled.Code != http.StatusOK || moved.Code != http.StatusOK {
+		t.Fatalf("expected toggle and move to succeed; got %d and %d", toggled.Code, moved.Code)
+	}
+	contentDTO, _ := cuc.GetContentByID(contentID)
+	var items []contentuc.ChecklistItemDTO
+	if err := json.Unmarshal([]byte(contentDTO.Data), &items); err != nil {
+		t.Fatalf("failed to decode checklist data: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != milk.ID || !items[0].Checked || items[1].Text != "eggs" || items[1].Assignee != "bob" {
+		t.Errorf("expected [checked milk, eggs for bob]; got %+v", items)
+	}
+	if contentDTO.Version != 4 {
+		t.Errorf("expected content version 4; got %d", contentDTO.Version)
+	}
+}
+
+func TestNoteHandler_ChecklistItems_Errors(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Groceries", "owner-1")
+	checklistID, _ := cuc.CreateContent(noteID, "owner-1", "", "", contentuc.ChecklistContentType)
+	textID, _ := cuc.CreateContent(noteID, "owner-1", "", "plain", contentuc.TextContentType)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		want   int
+	}{
+		{"not a checklist", http.MethodPost, "/notes/" + noteID + "/contents/" + textID + "/items", AddChecklistItemRequest{Text: "milk", ContentVersion: intPtr(0)}, http.StatusBadRequest},
+		{"empty text", http.MethodPost, "/notes/" + noteID + "/contents/" + checklistID + "/items", AddChecklistItemRequest{Text: " ", ContentVersion: intPtr(0)}, http.StatusBadRequest},
+		{"stale version", http.MethodPost, "/notes/" + noteID + "/contents/" + checklistID + "/items", AddChecklistItemRequest{Text: "milk", ContentVersion: intPtr(3)}, http.StatusConflict},
+		{"unknown item", http.MethodPost, "/notes/" + noteID + "/contents/" + checklistID + "/items/missing/toggle", ChecklistItemRequest{ContentVersion: intPtr(0)}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := json.Marshal(tt.body)
+			rr := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.target, bytes.NewBuffer(data)))
+
+			// Assert
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestNoteHandler_ToggleChecklistItem_Broadcast(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Groceries", "owner-1")
+	contentID, _ := cuc.CreateContent(noteID, "owner-1", "", `[{"id":"item-1","text":"milk"}]`, contentuc.ChecklistContentType)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notes/" + noteID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	// Act
+	body, _ := json.Marshal(ChecklistItemRequest{ContentVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents/"+contentID+"/items/item-1/toggle?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to toggle item: status %d", rr.Code)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read websocket message: %v", err)
+	}
+	var event WebSocketEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatalf("failed to unmarshal websocket message: %v", err)
+	}
+	if event.Type != "toggle_checklist_item" || event.ContentID != contentID || event.ContentVersion != 1 {
+		t.Errorf("expected toggle_checklist_item for %s at version 1; got %s for %s at version %d", contentID, event.Type, event.ContentID, event.ContentVersion)
+	}
+	if event.Item == nil || event.Item.ID != "item-1" || !event.Item.Checked {
+		t.Errorf("expected the checked item in the event; got %+v", event.Item)
+	}
+	if event.ContentType != "checklist" || event.LastModifiedBy != "owner-1" {
+		t.Errorf("expected a checklist change by owner-1; got %s by %s", event.ContentType, event.LastModifiedBy)
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNoteHandler_AddContent_CodeAndRender(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	noteID, _ := nuc.CreateNote("", "Snippets", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{
+		Type:        "code",
+		Data:        "func main() { fmt.Println(\"<hi>\") }",
+		Language:    "golang",
+		NoteVersion: intPtr(0),
+		Index:       intPtr(-1),
+	})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(rr.Body).Decode(&created)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/contents/"+created.ID+"/render", nil)
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("expected an HTML response; got %s", got)
+	}
+	rendered := rr.Body.String()
+	if !strings.Contains(rendered, `<span class="hl-kw">func</span> main()`) || !strings.Contains(rendered, "&lt;hi&gt;") {
+		t.Errorf("expected highlighted and escaped Go code; got %s", rendered)
+	}
+	contentDTO, _ := cuc.GetContentByID(created.ID)
+	if contentDTO.Language != "go" {
+		t.Errorf("expected language 'go'; got '%s'", contentDTO.Language)
+	}
+}
+
+func TestNoteHandler_AddContent_CodeUnsupportedLanguage(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Snippets", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{
+		Type:        "code",
+		Data:        "DISPLAY 'HI'.",
+		Language:    "cobol",
+		NoteVersion: intPtr(0),
+		Index:       intPtr(-1),
+	})
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestNoteHandler_UpdateContent_ChangesCodeLanguage(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	noteID, _ := nuc.CreateNote("", "Snippets", "owner-1")
+	contentID, _ := cuc.CreateCodeContent(noteID, "owner-1", "", "print(1)", "python")
+	language := "ruby"
+	body, _ := json.Marshal(UpdateContentRequest{Data: "puts 1", Language: &language, ContentVersion: intPtr(0)})
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/"+contentID, bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	contentDTO, _ := cuc.GetContentByID(contentID)
+	if contentDTO.Data != "puts 1" || contentDTO.Language != "ruby" {
+		t.Errorf("expected ruby code 'puts 1'; got %s code '%s'", contentDTO.Language, contentDTO.Data)
+	}
+}
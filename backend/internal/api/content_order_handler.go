@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReorderContentsRequest represents the request body for reordering the contents of a note.
+// It either moves a single content to Index, or lists the new order of all contents in
+// ContentIDs.
+type ReorderContentsRequest struct {
+	ContentID   string   `json:"content_id,omitempty"`
+	Index       *int     `json:"index,omitempty"`
+	ContentIDs  []string `json:"content_ids,omitempty"`
+	NoteVersion *int     `json:"note_version"`
+}
+
+// ReorderContentsResponse represents the response body for reordering the contents of a note.
+type ReorderContentsResponse struct {
+	ContentIDs  []string `json:"content_ids"`
+	NoteVersion int      `json:"note_version"`
+}
+
+// ReorderContents is the handler for the PUT /notes/{id}/contents/order endpoint.
+func (h *NoteHandler) ReorderContents(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+
+	var req ReorderContentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.NoteVersion == nil {
+		http.Error(w, "note_version is required", http.StatusBadRequest)
+		return
+	}
+	single := req.ContentID != "" || req.Index != nil
+	if single == (req.ContentIDs != nil) {
+		http.Error(w, "either content_id and index or content_ids is required", http.StatusBadRequest)
+		return
+	}
+
+	var order []string
+	var err error
+	if single {
+		if req.ContentID == "" || req.Index == nil {
+			http.Error(w, "content_id and index are required", http.StatusBadRequest)
+			return
+		}
+		order, err = h.noteUsecase.MoveContentWithinNote(noteID, callerID(r), req.ContentID, *req.Index, *req.NoteVersion)
+	} else {
+		order, err = h.noteUsecase.ReorderContents(noteID, callerID(r), req.ContentIDs, *req.NoteVersion)
+	}
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the new order to all connected clients.
+	event := WebSocketEvent{
+		Type:        "reorder_content",
+		NoteID:      noteID,
+		ContentID:   req.ContentID,
+		ContentIDs:  order,
+		NoteVersion: *req.NoteVersion + 1,
+	}
+	if single {
+		event.Index = *req.Index
+	}
+	h.stampNoteEvent(&event, noteID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ReorderContentsResponse{ContentIDs: order, NoteVersion: *req.NoteVersion + 1})
+}
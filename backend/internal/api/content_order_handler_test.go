@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNoteHandler_ReorderContents_SingleMove(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, first, second := setUpNoteWithContents(nuc, cuc)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	conn := dialNote(t, server, noteID)
+	defer conn.Close()
+
+	// Act
+	body, _ := json.Marshal(ReorderContentsRequest{ContentID: first, Index: intPtr(1), NoteVersion: intPtr(2)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/order?user_id=owner-1", bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp ReorderContentsResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	want := []string{second, first}
+	if !reflect.DeepEqual(resp.ContentIDs, want) || resp.NoteVersion != 3 {
+		t.Errorf("expected order %v at version 3; got %v at version %d", want, resp.ContentIDs, resp.NoteVersion)
+	}
+	event := readEvent(t, conn)
+	if event.Type != "reorder_content" || event.ContentID != first || event.Index != 1 || event.NoteVersion != 3 {
+		t.Errorf("expected a reorder_content event moving %s to 1 at version 3; got %+v", first, event)
+	}
+	if !reflect.DeepEqual(event.ContentIDs, want) || event.LastModifiedBy != "owner-1" {
+		t.Errorf("expected the new order by owner-1 in the event; got %v by %s", event.ContentIDs, event.LastModifiedBy)
+	}
+}
+
+func TestNoteHandler_ReorderContents_Permutation(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, first, second := setUpNoteWithContents(nuc, cuc)
+	contentBefore, _ := cuc.GetContentByID(first)
+
+	// Act
+	body, _ := json.Marshal(ReorderContentsRequest{ContentIDs: []string{second, first}, NoteVersion: intPtr(2)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/order?user_id=owner-1", bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	note, _ := nuc.GetNoteByID(noteID)
+	if !reflect.DeepEqual(note.ContentIDs, []string{second, first}) {
+		t.Errorf("expected the contents to be reordered; got %v", note.ContentIDs)
+	}
+	if contentAfter, _ := cuc.GetContentByID(first); contentAfter.Version != contentBefore.Version {
+		t.Errorf("expected reordering to keep the content version %d; got %d", contentBefore.Version, contentAfter.Version)
+	}
+}
+
+func TestNoteHandler_ReorderContents_Errors(t *testing.T) {
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, first, second := setUpNoteWithContents(nuc, cuc)
+	orderURL := "/notes/" + noteID + "/contents/order?user_id=owner-1"
+
+	tests := []struct {
+		name   string
+		target string
+		req    ReorderContentsRequest
+		want   int
+	}{
+		{"missing version", orderURL, ReorderContentsRequest{ContentIDs: []string{second, first}}, http.StatusBadRequest},
+		{"both forms", orderURL, ReorderContentsRequest{ContentID: first, Index: intPtr(1), ContentIDs: []string{second, first}, NoteVersion: intPtr(2)}, http.StatusBadRequest},
+		{"missing index", orderURL, ReorderContentsRequest{ContentID: first, NoteVersion: intPtr(2)}, http.StatusBadRequest},
+		{"not a permutation", orderURL, ReorderContentsRequest{ContentIDs: []string{first, first}, NoteVersion: intPtr(2)}, http.StatusBadRequest},
+		{"index out of bounds", orderURL, ReorderContentsRequest{ContentID: first, Index: intPtr(2), NoteVersion: intPtr(2)}, http.StatusBadRequest},
+		{"unknown content", orderURL, ReorderContentsRequest{ContentID: "missing", Index: intPtr(0), NoteVersion: intPtr(2)}, http.StatusNotFound},
+		{"stale version", orderURL, ReorderContentsRequest{ContentIDs: []string{second, first}, NoteVersion: intPtr(1)}, http.StatusConflict},
+		{"no edit permission", "/notes/" + noteID + "/contents/order?user_id=stranger", ReorderContentsRequest{ContentIDs: []string{second, first}, NoteVersion: intPtr(2)}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.req)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, tt.target, bytes.NewBuffer(body)))
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
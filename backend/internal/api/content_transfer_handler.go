@@ -0,0 +1,225 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"noteapp/internal/usecase/contentuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MoveContentRequest represents the request body for moving a content to another note.
+// NoteVersion is the version of the note the content is in, and TargetNoteVersion the version
+// of the note it moves to.
+type MoveContentRequest struct {
+	TargetNoteID      string `json:"target_note_id"`
+	Index             *int   `json:"index,omitempty"`
+	NoteVersion       *int   `json:"note_version"`
+	TargetNoteVersion *int   `json:"target_note_version"`
+	ContentVersion    *int   `json:"content_version"`
+}
+
+// MoveContentResponse represents the response body for moving a content, with the new
+// versions of both notes and of the content.
+type MoveContentResponse struct {
+	NoteVersion       int `json:"note_version"`
+	TargetNoteVersion int `json:"target_note_version"`
+	ContentVersion    int `json:"content_version"`
+}
+
+// CopyContentRequest represents the request body for copying a content. Without a target
+// note, the content is copied within its own note.
+type CopyContentRequest struct {
+	TargetNoteID      string `json:"target_note_id,omitempty"`
+	Index             *int   `json:"index,omitempty"`
+	TargetNoteVersion *int   `json:"target_note_version"`
+}
+
+// MoveContent is the handler for the POST /notes/{id}/contents/{contentId}/move endpoint.
+// Both notes are updated together; if the content itself cannot be updated afterwards, the
+// move of its ID is undone against the notes' current versions, and the request fails with
+// 500 if even that is not possible.
+func (h *NoteHandler) MoveContent(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	contentID := chi.URLParam(r, "contentId")
+
+	var req MoveContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.TargetNoteID == "" || req.NoteVersion == nil || req.TargetNoteVersion == nil || req.ContentVersion == nil {
+		http.Error(w, "target_note_id, note_version, target_note_version and content_version are required", http.StatusBadRequest)
+		return
+	}
+	index := -1
+	if req.Index != nil {
+		index = *req.Index
+	}
+
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if contentDTO.NoteID != noteID {
+		mapErrorToHTTPStatus(w, contentuc.ErrContentNotFound)
+		return
+	}
+	if contentDTO.Version != *req.ContentVersion {
+		mapErrorToHTTPStatus(w, contentuc.ErrConflict)
+		return
+	}
+	sourceDTO, err := h.noteUsecase.GetNoteByID(noteID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// First, move the content ID between the notes.
+	if err := h.noteUsecase.MoveContent(contentID, noteID, req.TargetNoteID, callerID(r), index, *req.NoteVersion, *req.TargetNoteVersion); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Then, move the content itself, or put its ID back where it was.
+	if err := h.contentUsecase.MoveContent(contentID, req.TargetNoteID, callerID(r), *req.ContentVersion); err != nil {
+		originalIndex := indexOf(sourceDTO.ContentIDs, contentID)
+		if undoErr := h.noteUsecase.MoveContentBack(contentID, noteID, req.TargetNoteID, callerID(r), originalIndex); undoErr != nil {
+			fmt.Printf("Warning: Could not move content %s back to note %s: %v\n", contentID, noteID, undoErr)
+			http.Error(w, "An internal error occurred while moving content", http.StatusInternalServerError)
+			return
+		}
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the move to the clients of both notes.
+	sourceEvent := WebSocketEvent{
+		Type:         "move_content",
+		NoteID:       noteID,
+		ContentID:    contentID,
+		TargetNoteID: req.TargetNoteID,
+		NoteVersion:  *req.NoteVersion + 1,
+	}
+	h.stampNoteEvent(&sourceEvent, noteID)
+	message, _ := json.Marshal(sourceEvent)
+	h.connManager.Broadcast(noteID, message)
+
+	targetEvent := WebSocketEvent{
+		Type:           "move_content",
+		NoteID:         req.TargetNoteID,
+		ContentID:      contentID,
+		SourceNoteID:   noteID,
+		Data:           contentDTO.Data,
+		NoteVersion:    *req.TargetNoteVersion + 1,
+		ContentVersion: *req.ContentVersion + 1,
+		Index:          index,
+	}
+	h.stampContentEvent(&targetEvent, contentID)
+	message, _ = json.Marshal(targetEvent)
+	h.connManager.Broadcast(req.TargetNoteID, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(MoveContentResponse{
+		NoteVersion:       *req.NoteVersion + 1,
+		TargetNoteVersion: *req.TargetNoteVersion + 1,
+		ContentVersion:    *req.ContentVersion + 1,
+	})
+}
+
+// CopyContent is the handler for the POST /notes/{id}/contents/{contentId}/copy endpoint.
+func (h *NoteHandler) CopyContent(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	contentID := chi.URLParam(r, "contentId")
+
+	var req CopyContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.TargetNoteVersion == nil {
+		http.Error(w, "target_note_version is required", http.StatusBadRequest)
+		return
+	}
+	targetNoteID := req.TargetNoteID
+	if targetNoteID == "" {
+		targetNoteID = noteID
+	}
+	index := -1
+	if req.Index != nil {
+		index = *req.Index
+	}
+
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if contentDTO.NoteID != noteID {
+		mapErrorToHTTPStatus(w, contentuc.ErrContentNotFound)
+		return
+	}
+
+	// Check the caller may view the original and edit the target note before copying anything.
+	if err := h.noteUsecase.CheckCanView(noteID, callerID(r)); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if err := h.noteUsecase.CheckCanEdit(targetNoteID, callerID(r)); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Create the copy first, then add it to the target note. The blobs of the original are
+	// held until the copy references them too.
+	release := h.blobUsecase.HoldBlobs()
+	copyID, err := h.contentUsecase.CopyContent(contentID, targetNoteID, callerID(r))
+	release()
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if err := h.noteUsecase.AddContentCopy(noteID, contentID, targetNoteID, callerID(r), copyID, index, *req.TargetNoteVersion); err != nil {
+		h.discardContent(copyID)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the new content to the clients of the target note. The data of a copied table
+	// differs from the original's in its cell versions.
+	copyDTO, err := h.contentUsecase.GetContentByID(copyID)
+	if err != nil {
+		copyDTO = contentDTO
+	}
+	event := WebSocketEvent{
+		Type:           "add_content",
+		NoteID:         targetNoteID,
+		ContentID:      copyID,
+		Data:           copyDTO.Data,
+		NoteVersion:    *req.TargetNoteVersion + 1,
+		ContentVersion: 0,
+		Index:          index,
+	}
+	h.stampContentEvent(&event, copyID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(targetNoteID, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(struct {
+		ID string `json:"id"`
+	}{ID: copyID})
+}
+
+// indexOf returns the index of value in values, or -1 if it is missing.
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dialNote(t *testing.T, server *httptest.Server, noteID string) *websocket.Conn {
+	t.Helper()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notes/" + noteID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) WebSocketEvent {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read websocket message: %v", err)
+	}
+	var event WebSocketEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatalf("failed to unmarshal websocket message: %v", err)
+	}
+	return event
+}
+
+func TestNoteHandler_MoveContent_Broadcast(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	targetID, _ := nuc.CreateNote("", "Target", "owner-1")
+	contentID, _ := cuc.CreateContent(sourceID, "owner-1", "", "A paragraph", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "owner-1", contentID, -1, 0)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	sourceConn := dialNote(t, server, sourceID)
+	defer sourceConn.Close()
+	targetConn := dialNote(t, server, targetID)
+	defer targetConn.Close()
+
+	// Act
+	body, _ := json.Marshal(MoveContentRequest{TargetNoteID: targetID, Index: intPtr(0), NoteVersion: intPtr(1), TargetNoteVersion: intPtr(0), ContentVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+sourceID+"/contents/"+contentID+"/move?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp MoveContentResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp != (MoveContentResponse{NoteVersion: 2, TargetNoteVersion: 1, ContentVersion: 1}) {
+		t.Errorf("expected the new versions of both notes and the content; got %+v", resp)
+	}
+	source, _ := nuc.GetNoteByID(sourceID)
+	target, _ := nuc.GetNoteByID(targetID)
+	moved, _ := cuc.GetContentByID(contentID)
+	if len(source.ContentIDs) != 0 || !reflect.DeepEqual(target.ContentIDs, []string{contentID}) || moved.NoteID != targetID {
+		t.Errorf("expected the content to be in the target only; got source %v, target %v, content note %s", source.ContentIDs, target.ContentIDs, moved.NoteID)
+	}
+
+	sourceEvent := readEvent(t, sourceConn)
+	if sourceEvent.Type != "move_content" || sourceEvent.NoteID != sourceID || sourceEvent.TargetNoteID != targetID || sourceEvent.NoteVersion != 2 {
+		t.Errorf("expected a move_content event out of the source at version 2; got %+v", sourceEvent)
+	}
+	targetEvent := readEvent(t, targetConn)
+	if targetEvent.Type != "move_content" || targetEvent.NoteID != targetID || targetEvent.SourceNoteID != sourceID || targetEvent.NoteVersion != 1 {
+		t.Errorf("expected a move_content event into the target at version 1; got %+v", targetEvent)
+	}
+	if targetEvent.Data != "A paragraph" || targetEvent.ContentType != "text" || targetEvent.ContentVersion != 1 {
+		t.Errorf("expected the moved content in the target event; got %+v", targetEvent)
+	}
+}
+
+func TestNoteHandler_MoveContent_Errors(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	targetID, _ := nuc.CreateNote("", "Target", "owner-2")
+	nuc.ShareNote(targetID, "owner-2", "owner-1", "read", 0)
+	contentID, _ := cuc.CreateContent(sourceID, "owner-1", "", "A paragraph", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "owner-1", contentID, -1, 0)
+	moveURL := "/notes/" + sourceID + "/contents/" + contentID + "/move?user_id=owner-1"
+
+	tests := []struct {
+		name   string
+		target string
+		req    MoveContentRequest
+		want   int
+	}{
+		{"missing versions", moveURL, MoveContentRequest{TargetNoteID: targetID}, http.StatusBadRequest},
+		{"read-only target", moveURL, MoveContentRequest{TargetNoteID: targetID, NoteVersion: intPtr(1), TargetNoteVersion: intPtr(1), ContentVersion: intPtr(0)}, http.StatusForbidden},
+		{"stale content", moveURL, MoveContentRequest{TargetNoteID: targetID, NoteVersion: intPtr(1), TargetNoteVersion: intPtr(1), ContentVersion: intPtr(3)}, http.StatusConflict},
+		{"stale note", moveURL, MoveContentRequest{TargetNoteID: targetID, NoteVersion: intPtr(0), TargetNoteVersion: intPtr(1), ContentVersion: intPtr(0)}, http.StatusConflict},
+		{"wrong source note", "/notes/" + targetID + "/contents/" + contentID + "/move?user_id=owner-1", MoveContentRequest{TargetNoteID: sourceID, NoteVersion: intPtr(1), TargetNoteVersion: intPtr(1), ContentVersion: intPtr(0)}, http.StatusNotFound},
+		{"same note", moveURL, MoveContentRequest{TargetNoteID: sourceID, NoteVersion: intPtr(1), TargetNoteVersion: intPtr(1), ContentVersion: intPtr(0)}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			body, _ := json.Marshal(tt.req)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBuffer(body)))
+
+			// Assert
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+	if content, _ := cuc.GetContentByID(contentID); content.NoteID != sourceID || content.Version != 0 {
+		t.Errorf("expected failed moves to leave the content untouched; got %+v", content)
+	}
+}
+
+func TestNoteHandler_CopyContent(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	targetID, _ := nuc.CreateNote("", "Target", "owner-1")
+	contentID, _ := cuc.CreateCodeContent(sourceID, "owner-1", "", "SELECT 1;", "sql")
+	nuc.AddContent(sourceID, "owner-1", contentID, -1, 0)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	targetConn := dialNote(t, server, targetID)
+	defer targetConn.Close()
+
+	// Act
+	body, _ := json.Marshal(CopyContentRequest{TargetNoteID: targetID, TargetNoteVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+sourceID+"/contents/"+contentID+"/copy?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(rr.Body).Decode(&created)
+	source, _ := nuc.GetNoteByID(sourceID)
+	target, _ := nuc.GetNoteByID(targetID)
+	if !reflect.DeepEqual(source.ContentIDs, []string{contentID}) || !reflect.DeepEqual(target.ContentIDs, []string{created.ID}) {
+		t.Errorf("expected the original to stay and the copy to be added; got source %v, target %v", source.ContentIDs, target.ContentIDs)
+	}
+	event := readEvent(t, targetConn)
+	if event.Type != "add_content" || event.ContentID != created.ID || event.Data != "SELECT 1;" || event.Language != "sql" {
+		t.Errorf("expected an add_content event for the copy; got %+v", event)
+	}
+}
+
+func TestNoteHandler_CopyContent_DiscardsCopyOnFailure(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	targetID, _ := nuc.CreateNote("", "Target", "owner-1")
+	contentID, _ := cuc.CreateContent(sourceID, "owner-1", "", "A paragraph", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "owner-1", contentID, -1, 0)
+
+	// Act
+	body, _ := json.Marshal(CopyContentRequest{TargetNoteID: targetID, TargetNoteVersion: intPtr(3)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+sourceID+"/contents/"+contentID+"/copy?user_id=owner-1", bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected status %d; got %d", http.StatusConflict, rr.Code)
+	}
+	if contents, _ := cuc.GetContentsByNoteID(targetID); len(contents) != 0 {
+		t.Errorf("expected the rejected copy to be discarded; got %d contents", len(contents))
+	}
+}
+
+func TestNoteHandler_CopyContent_RequiresAccess(t *testing.T) {
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	otherTargetID, _ := nuc.CreateNote("", "Other target", "owner-2")
+	strangerTargetID, _ := nuc.CreateNote("", "Stranger target", "stranger")
+	contentID, _ := cuc.CreateContent(sourceID, "owner-1", "", "A paragraph", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "owner-1", contentID, -1, 0)
+
+	tests := []struct {
+		name     string
+		userID   string
+		targetID string
+	}{
+		{"cannot view the original", "stranger", strangerTargetID},
+		{"cannot edit the target", "owner-1", otherTargetID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(CopyContentRequest{TargetNoteID: tt.targetID, TargetNoteVersion: intPtr(0)})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+sourceID+"/contents/"+contentID+"/copy?user_id="+tt.userID, bytes.NewBuffer(body)))
+
+			if rr.Code != http.StatusForbidden {
+				t.Errorf("expected status %d; got %d", http.StatusForbidden, rr.Code)
+			}
+			if contents, _ := cuc.GetContentsByNoteID(tt.targetID); len(contents) != 0 {
+				t.Errorf("expected no copy to be created; got %d contents", len(contents))
+			}
+		})
+	}
+}
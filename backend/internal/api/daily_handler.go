@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/templateuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DailyNoteHandler handles HTTP requests for daily notes. It creates the notes through the
+// note handler, from the template in the user's daily note settings.
+type DailyNoteHandler struct {
+	notes        *NoteHandler
+	dailyUsecase *dailyuc.DailyUsecase
+}
+
+// NewDailyNoteHandler creates a new DailyNoteHandler.
+func NewDailyNoteHandler(notes *NoteHandler, duc *dailyuc.DailyUsecase) *DailyNoteHandler {
+	return &DailyNoteHandler{notes: notes, dailyUsecase: duc}
+}
+
+// UpdateDailySettingsRequest represents the request body for updating a user's daily note
+// settings. An empty time zone stands for UTC, and an empty template ID for empty notes.
+type UpdateDailySettingsRequest struct {
+	TimeZone   string `json:"time_zone"`
+	TemplateID string `json:"template_id"`
+	Version    *int   `json:"version"`
+}
+
+// DailyNoteResponse represents a daily note along with its date.
+type DailyNoteResponse struct {
+	Date string `json:"date"`
+	GetNoteByIDResponse
+}
+
+// GetDailySettings is the handler for the GET /users/{userID}/daily/settings endpoint.
+func (h *DailyNoteHandler) GetDailySettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.dailyUsecase.GetSettings(chi.URLParam(r, "userID"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+// UpdateDailySettings is the handler for the PUT /users/{userID}/daily/settings endpoint.
+func (h *DailyNoteHandler) UpdateDailySettings(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	var req UpdateDailySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.TemplateID != "" {
+		if _, err := h.notes.templateUsecase.GetTemplate(req.TemplateID); err != nil {
+			mapErrorToHTTPStatus(w, err)
+			return
+		}
+	}
+
+	if err := h.dailyUsecase.UpdateSettings(userID, req.TimeZone, req.TemplateID, *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListDailyNotes is the handler for the GET /users/{userID}/daily endpoint. The optional
+// from and to query parameters bound the dates listed, both inclusive.
+func (h *DailyNoteHandler) ListDailyNotes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	notes, err := h.dailyUsecase.ListDailyNotes(chi.URLParam(r, "userID"), query.Get("from"), query.Get("to"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(notes)
+}
+
+// GetDailyNote is the handler for the GET /users/{userID}/daily/{date} endpoint, where date
+// is YYYY-MM-DD or "today" in the user's time zone. The daily note is created on first
+// access, from the user's template and tagged with daily/YYYY-MM-DD.
+func (h *DailyNoteHandler) GetDailyNote(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	day, err := h.dailyUsecase.ResolveDay(userID, chi.URLParam(r, "date"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	noteID, err := h.findOrCreateDailyNote(userID, day)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	note, err := h.notes.getNoteWithContents(noteID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(DailyNoteResponse{Date: day.Date, GetNoteByIDResponse: *note})
+}
+
+// PreviousDailyNote is the handler for the GET /users/{userID}/daily/{date}/previous endpoint.
+// It returns the latest existing daily note before the date, without creating any.
+func (h *DailyNoteHandler) PreviousDailyNote(w http.ResponseWriter, r *http.Request) {
+	h.writeAdjacentDailyNote(w, r, h.dailyUsecase.PreviousDailyNote)
+}
+
+// NextDailyNote is the handler for the GET /users/{userID}/daily/{date}/next endpoint.
+// It returns the earliest existing daily note after the date, without creating any.
+func (h *DailyNoteHandler) NextDailyNote(w http.ResponseWriter, r *http.Request) {
+	h.writeAdjacentDailyNote(w, r, h.dailyUsecase.NextDailyNote)
+}
+
+func (h *DailyNoteHandler) writeAdjacentDailyNote(w http.ResponseWriter, r *http.Request, find func(userID, date string) (*dailyuc.DailyNoteDTO, error)) {
+	note, err := find(chi.URLParam(r, "userID"), chi.URLParam(r, "date"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(note)
+}
+
+// findOrCreateDailyNote returns the ID of the user's daily note for a day, creating it if
+// the day has none. If another request creates one at the same time, the note created here
+// is discarded in favour of the one recorded first.
+func (h *DailyNoteHandler) findOrCreateDailyNote(userID string, day *dailyuc.DayDTO) (string, error) {
+	found, err := h.dailyUsecase.FindDailyNote(userID, day.Date)
+	if err == nil {
+		return found.NoteID, nil
+	}
+	if !errors.Is(err, dailyuc.ErrDailyNoteNotFound) {
+		return "", err
+	}
+
+	draft, err := h.draftDailyNote(userID, day)
+	if err != nil {
+		return "", err
+	}
+	noteID, err := h.notes.createNoteFromDraft(draft, userID, day.Keyword)
+	if err != nil {
+		return "", err
+	}
+
+	err = h.dailyUsecase.RecordDailyNote(userID, day.Date, noteID)
+	if err == nil {
+		// The note is tagged with the day's keyword, so it may enter the user's saved searches.
+		h.notes.refreshSavedSearches(userID)
+		return noteID, nil
+	}
+	h.notes.discardNoteWithContents(noteID)
+	if !errors.Is(err, dailyuc.ErrDailyNoteExists) {
+		return "", err
+	}
+	found, err = h.dailyUsecase.FindDailyNote(userID, day.Date)
+	if err != nil {
+		return "", err
+	}
+	return found.NoteID, nil
+}
+
+// draftDailyNote drafts a daily note from the user's template. Without a template, or if it
+// has been deleted, the note is empty and titled with its date.
+func (h *DailyNoteHandler) draftDailyNote(userID string, day *dailyuc.DayDTO) (*templateuc.NoteDraftDTO, error) {
+	if day.TemplateID != "" {
+		draft, err := h.notes.templateUsecase.InstantiateTemplateAt(day.TemplateID, userID, day.CreatedAt)
+		if err == nil {
+			return draft, nil
+		}
+		if !errors.Is(err, templateuc.ErrTemplateNotFound) {
+			return nil, err
+		}
+		fmt.Printf("Warning: Daily note template %s of user %s no longer exists\n", day.TemplateID, userID)
+	}
+	return &templateuc.NoteDraftDTO{Title: day.Date}, nil
+}
+
+// discardNoteWithContents deletes a new note that could not be completed, along with its
+// contents.
+func (h *NoteHandler) discardNoteWithContents(noteID string) {
+	noteDTO, err := h.noteUsecase.GetNoteByID(noteID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve note %s to discard: %v\n", noteID, err)
+		return
+	}
+	for _, contentID := range noteDTO.ContentIDs {
+		h.discardContent(contentID)
+	}
+	h.discardNote(noteID)
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/repository/dailyrepo"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/templateuc"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupDailyTest initializes a router with the daily note endpoints, with the clock at
+// 2024-03-04 20:15 UTC.
+func setupDailyTest() (*chi.Mux, *noteuc.NoteUsecase, *templateuc.TemplateUsecase) {
+	ht := newHandlerTest(time.Date(2024, 3, 4, 20, 15, 0, 0, time.UTC))
+	duc := dailyuc.NewDailyUsecaseWithClock(dailyrepo.NewInMemoryDailyNoteRepository(), ht.noteRepo, ht.clock)
+	handler := NewDailyNoteHandler(ht.handler, duc)
+	router := ht.router
+
+	router.Get("/users/{userID}/daily", handler.ListDailyNotes)
+	router.Get("/users/{userID}/daily/settings", handler.GetDailySettings)
+	router.Put("/users/{userID}/daily/settings", handler.UpdateDailySettings)
+	router.Get("/users/{userID}/daily/{date}", handler.GetDailyNote)
+	router.Get("/users/{userID}/daily/{date}/previous", handler.PreviousDailyNote)
+	router.Get("/users/{userID}/daily/{date}/next", handler.NextDailyNote)
+	return router, ht.nuc, ht.tuc
+}
+
+func getDailyNote(t *testing.T, router *chi.Mux, url string) DailyNoteResponse {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var note DailyNoteResponse
+	if err := json.NewDecoder(rr.Body).Decode(&note); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	return note
+}
+
+func TestDailyNoteHandler_GetDailyNote_CreatesOnFirstAccess(t *testing.T) {
+	// Arrange
+	router, _, _ := setupDailyTest()
+
+	// Act
+	first := getDailyNote(t, router, "/users/user-1/daily/today")
+	second := getDailyNote(t, router, "/users/user-1/daily/2024-03-04")
+
+	// Assert
+	if first.Date != "2024-03-04" || first.Title != "2024-03-04" || first.OwnerID != "user-1" {
+		t.Errorf("expected an empty note titled with today's date; got %+v", first)
+	}
+	if keywords := first.Keywords["user-1"]; len(keywords) != 1 || keywords[0] != "daily/2024-03-04" {
+		t.Errorf("expected the note tagged daily/2024-03-04; got %v", first.Keywords)
+	}
+	if second.ID != first.ID {
+		t.Errorf("expected the same daily note on later access; got %s and %s", first.ID, second.ID)
+	}
+}
+
+func TestDailyNoteHandler_GetDailyNote_FromTemplateInTimeZone(t *testing.T) {
+	// Arrange
+	router, _, tuc := setupDailyTest()
+	templateID, _ := tuc.CreateTemplate("user-1", "Journal", "Journal {{date}}", []*templateuc.TemplateBlockDTO{
+		{Type: "text", Data: "{{weekday}}, {{time}}"},
+	})
+	body, _ := json.Marshal(UpdateDailySettingsRequest{TimeZone: "Asia/Tokyo", TemplateID: templateID, Version: intPtr(0)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/users/user-1/daily/settings", bytes.NewBuffer(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+
+	// Act
+	note := getDailyNote(t, router, "/users/user-1/daily/today")
+
+	// Assert
+	if note.Date != "2024-03-05" || note.Title != "Journal 2024-03-05" {
+		t.Errorf("expected the note for 2024-03-05 in Tokyo; got date %s titled '%s'", note.Date, note.Title)
+	}
+	if len(note.Contents) != 1 || note.Contents[0].Data != "Tuesday, 05:15" {
+		t.Errorf("expected the template's block expanded in Tokyo time; got %+v", note.Contents)
+	}
+}
+
+func TestDailyNoteHandler_Settings(t *testing.T) {
+	router, _, _ := setupDailyTest()
+
+	tests := []struct {
+		name       string
+		body       UpdateDailySettingsRequest
+		wantStatus int
+	}{
+		{"without version", UpdateDailySettingsRequest{TimeZone: "Europe/Paris"}, http.StatusBadRequest},
+		{"unknown time zone", UpdateDailySettingsRequest{TimeZone: "Mars/Olympus_Mons", Version: intPtr(0)}, http.StatusBadRequest},
+		{"missing template", UpdateDailySettingsRequest{TemplateID: "missing", Version: intPtr(0)}, http.StatusNotFound},
+		{"valid settings", UpdateDailySettingsRequest{TimeZone: "Europe/Paris", Version: intPtr(0)}, http.StatusOK},
+		{"stale version", UpdateDailySettingsRequest{TimeZone: "UTC", Version: intPtr(1)}, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/users/user-1/daily/settings", bytes.NewBuffer(body)))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d; got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/daily/settings", nil))
+	var settings dailyuc.SettingsDTO
+	json.NewDecoder(rr.Body).Decode(&settings)
+	if settings.TimeZone != "Europe/Paris" || settings.Version != 0 {
+		t.Errorf("expected the saved settings at version 0; got %+v", settings)
+	}
+}
+
+func TestDailyNoteHandler_ListAndNavigate(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupDailyTest()
+	first := getDailyNote(t, router, "/users/user-1/daily/2024-03-01")
+	deleted := getDailyNote(t, router, "/users/user-1/daily/2024-03-02")
+	last := getDailyNote(t, router, "/users/user-1/daily/2024-03-06")
+	nuc.DeleteNote(deleted.ID, deleted.Version)
+	getDailyNote(t, router, "/users/user-2/daily/2024-03-03")
+
+	// Act
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/daily?to=2024-03-05", nil))
+
+	// Assert
+	var notes []*dailyuc.DailyNoteDTO
+	if err := json.NewDecoder(rr.Body).Decode(&notes); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(notes) != 1 || notes[0].NoteID != first.ID {
+		t.Errorf("expected only the user's daily note of 2024-03-01; got %+v", notes)
+	}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantNoteID string
+	}{
+		{"previous of today", "/users/user-1/daily/today/previous", http.StatusOK, first.ID},
+		{"next of today", "/users/user-1/daily/today/next", http.StatusOK, last.ID},
+		{"nothing before the first note", "/users/user-1/daily/2024-03-01/previous", http.StatusNotFound, ""},
+		{"invalid date", "/users/user-1/daily/2024-3-1/next", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d; got %d", tt.wantStatus, rr.Code)
+			}
+			if tt.wantNoteID == "" {
+				return
+			}
+			var note dailyuc.DailyNoteDTO
+			json.NewDecoder(rr.Body).Decode(&note)
+			if note.NoteID != tt.wantNoteID {
+				t.Errorf("expected note %s; got %+v", tt.wantNoteID, note)
+			}
+		})
+	}
+
+	if note := getDailyNote(t, router, "/users/user-1/daily/2024-03-02"); note.ID == deleted.ID {
+		t.Errorf("expected a new daily note in place of the deleted one")
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"noteapp/internal/usecase/discoveryuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DiscoveryHandler handles HTTP requests that help users organize and rediscover notes.
+type DiscoveryHandler struct {
+	discoveryUsecase *discoveryuc.DiscoveryUsecase
+}
+
+// NewDiscoveryHandler creates a new DiscoveryHandler.
+func NewDiscoveryHandler(duc *discoveryuc.DiscoveryUsecase) *DiscoveryHandler {
+	return &DiscoveryHandler{discoveryUsecase: duc}
+}
+
+// SuggestKeywordsForNote is the handler for the GET /notes/{id}/keyword-suggestions?user_id={userID} endpoint.
+func (h *DiscoveryHandler) SuggestKeywordsForNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	userID := r.URL.Query().Get("user_id")
+
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	suggestions, err := h.discoveryUsecase.SuggestKeywordsForNote(noteID, userID, limit)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+// FindRelatedNotes is the handler for the GET /notes/{id}/related?user_id={userID} endpoint.
+func (h *DiscoveryHandler) FindRelatedNotes(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	userID := r.URL.Query().Get("user_id")
+
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	related, err := h.discoveryUsecase.FindRelatedNotes(noteID, userID, limit)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(related)
+}
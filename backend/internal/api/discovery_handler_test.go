@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/discoveryuc"
+	"noteapp/internal/usecase/noteuc"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupDiscoveryTest initializes a router with the discovery endpoints backed by shared repositories.
+func setupDiscoveryTest() (*chi.Mux, *noteuc.NoteUsecase, *contentuc.ContentUsecase) {
+	ht := newHandlerTest(time.Now())
+	handler := NewDiscoveryHandler(discoveryuc.NewDiscoveryUsecase(ht.noteRepo, ht.contentRepo))
+	router := ht.router
+
+	router.Get("/notes/{id}/keyword-suggestions", handler.SuggestKeywordsForNote)
+	router.Get("/notes/{id}/related", handler.FindRelatedNotes)
+	return router, ht.nuc, ht.cuc
+}
+
+func addTextContent(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase, noteID, data string, noteVersion int) {
+	contentID, _ := cuc.CreateContent(noteID, "user-1", "", data, contentuc.TextContentType)
+	nuc.AddContent(noteID, "user-1", contentID, -1, noteVersion)
+}
+
+func TestDiscoveryHandler_SuggestKeywordsForNote(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupDiscoveryTest()
+	noteID, _ := nuc.CreateNote("", "Concurrency", "user-1")
+	addTextContent(nuc, cuc, noteID, "Goroutines communicate over channels. Channels block.", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/keyword-suggestions?user_id=user-1&limit=2", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var suggestions []*discoveryuc.KeywordSuggestionDTO
+	if err := json.NewDecoder(rr.Body).Decode(&suggestions); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+	}
+	if suggestions[0].Keyword != "channels" {
+		t.Errorf("expected 'channels' to be the top suggestion, got '%s'", suggestions[0].Keyword)
+	}
+}
+
+func TestDiscoveryHandler_SuggestKeywordsForNote_Forbidden(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupDiscoveryTest()
+	noteID, _ := nuc.CreateNote("", "Private", "user-1")
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/keyword-suggestions?user_id=user-2", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d; got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestDiscoveryHandler_SuggestKeywordsForNote_MissingUser(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupDiscoveryTest()
+	noteID, _ := nuc.CreateNote("", "Private", "user-1")
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/keyword-suggestions", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestDiscoveryHandler_FindRelatedNotes(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupDiscoveryTest()
+	project, _ := nuc.CreateNote("", "ProjectX", "user-1")
+	addTextContent(nuc, cuc, project, "Worker pool guarded by a mutex.", 0)
+	osNote, _ := nuc.CreateNote("", "OS lecture", "user-1")
+	addTextContent(nuc, cuc, osNote, "Mutex and semaphore.", 0)
+	nuc.CreateNote("", "Groceries", "user-1")
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+project+"/related?user_id=user-1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var related []*discoveryuc.RelatedNoteDTO
+	if err := json.NewDecoder(rr.Body).Decode(&related); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(related) != 1 || related[0].NoteID != osNote {
+		t.Fatalf("expected only the OS note to be related, got %+v", related)
+	}
+}
+
+func TestDiscoveryHandler_FindRelatedNotes_NotFound(t *testing.T) {
+	// Arrange
+	router, _, _ := setupDiscoveryTest()
+	req := httptest.NewRequest(http.MethodGet, "/notes/missing/related?user_id=user-1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, rr.Code)
+	}
+}
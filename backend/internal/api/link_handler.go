@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"noteapp/internal/usecase/linkuc"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ErrUnsupportedGraphFormat is returned when a graph is requested in a format other than JSON or DOT.
+var ErrUnsupportedGraphFormat = errors.New("graph format must be json or dot")
+
+// LinkHandler handles HTTP requests that navigate the links between notes.
+type LinkHandler struct {
+	linkUsecase *linkuc.LinkUsecase
+}
+
+// NewLinkHandler creates a new LinkHandler.
+func NewLinkHandler(luc *linkuc.LinkUsecase) *LinkHandler {
+	return &LinkHandler{linkUsecase: luc}
+}
+
+// GetBacklinks is the handler for the GET /notes/{id}/backlinks?user_id={userID} endpoint.
+func (h *LinkHandler) GetBacklinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.linkUsecase.GetBacklinks(chi.URLParam(r, "id"), r.URL.Query().Get("user_id"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(links)
+}
+
+// GetOutlinks is the handler for the GET /notes/{id}/outlinks?user_id={userID} endpoint.
+func (h *LinkHandler) GetOutlinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.linkUsecase.GetOutlinks(chi.URLParam(r, "id"), r.URL.Query().Get("user_id"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(links)
+}
+
+// GetGraph is the handler for the GET /users/{userID}/graph endpoint. The keyword, start and
+// depth query parameters select part of the graph, and format=dot returns it in the GraphViz
+// DOT language instead of the JSON Graph Format.
+func (h *LinkHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
+	query := linkuc.GraphQuery{
+		Keyword:     r.URL.Query().Get("keyword"),
+		StartNoteID: r.URL.Query().Get("start"),
+	}
+	if depthParam := r.URL.Query().Get("depth"); depthParam != "" {
+		depth, err := strconv.Atoi(depthParam)
+		if err != nil {
+			http.Error(w, linkuc.ErrInvalidDepth.Error(), http.StatusBadRequest)
+			return
+		}
+		query.Depth = depth
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" {
+		http.Error(w, ErrUnsupportedGraphFormat.Error(), http.StatusBadRequest)
+		return
+	}
+
+	graph, err := h.linkUsecase.GetGraph(chi.URLParam(r, "userID"), query)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	if format == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(linkuc.FormatDOT(graph)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(graph)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/linkuc"
+	"noteapp/internal/usecase/noteuc"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupLinkTest initializes a router with the link endpoints backed by shared repositories.
+func setupLinkTest() (*chi.Mux, *noteuc.NoteUsecase, *contentuc.ContentUsecase) {
+	ht := newHandlerTest(time.Now())
+	handler := NewLinkHandler(linkuc.NewLinkUsecase(ht.noteRepo, ht.contentRepo))
+	router := ht.router
+
+	router.Get("/notes/{id}/backlinks", handler.GetBacklinks)
+	router.Get("/notes/{id}/outlinks", handler.GetOutlinks)
+	router.Get("/users/{userID}/graph", handler.GetGraph)
+	return router, ht.nuc, ht.cuc
+}
+
+func TestLinkHandler_GetBacklinks(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupLinkTest()
+	targetID, _ := nuc.CreateNote("", "Target", "user-1")
+	sourceID, _ := nuc.CreateNote("", "Source", "user-1")
+	addTextContent(nuc, cuc, sourceID, "See [["+targetID+"|the target]].", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+targetID+"/backlinks?user_id=user-1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var links []*linkuc.LinkDTO
+	if err := json.NewDecoder(rr.Body).Decode(&links); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(links) != 1 || links[0].NoteID != sourceID || links[0].Title != "Source" {
+		t.Fatalf("expected a backlink from the source note, got %+v", links)
+	}
+	if len(links[0].ContentIDs) != 1 {
+		t.Errorf("expected the linking content to be listed, got %v", links[0].ContentIDs)
+	}
+}
+
+func TestLinkHandler_GetOutlinks_BrokenLink(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupLinkTest()
+	targetID, _ := nuc.CreateNote("", "Target", "user-1")
+	sourceID, _ := nuc.CreateNote("", "Source", "user-1")
+	addTextContent(nuc, cuc, sourceID, "See [["+targetID+"]].", 0)
+	nuc.DeleteNote(targetID, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+sourceID+"/outlinks?user_id=user-1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var links []*linkuc.LinkDTO
+	if err := json.NewDecoder(rr.Body).Decode(&links); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(links) != 1 || links[0].NoteID != targetID || !links[0].Broken {
+		t.Errorf("expected a broken link to the deleted note, got %+v", links)
+	}
+}
+
+func TestLinkHandler_Errors(t *testing.T) {
+	router, nuc, _ := setupLinkTest()
+	noteID, _ := nuc.CreateNote("", "Mine", "user-1")
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"missing user", "/notes/" + noteID + "/outlinks", http.StatusBadRequest},
+		{"not found", "/notes/missing/backlinks?user_id=user-1", http.StatusNotFound},
+		{"no access", "/notes/" + noteID + "/backlinks?user_id=user-2", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestLinkHandler_GetGraph(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupLinkTest()
+	targetID, _ := nuc.CreateNote("", "Target", "user-1")
+	sourceID, _ := nuc.CreateNote("", "Source", "user-1")
+	addTextContent(nuc, cuc, sourceID, "See [["+targetID+"]].", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/graph?start="+sourceID+"&depth=1", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var graph linkuc.GraphDTO
+	if err := json.NewDecoder(rr.Body).Decode(&graph); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(graph.Graph.Nodes) != 2 || len(graph.Graph.Edges) != 1 {
+		t.Fatalf("expected 2 nodes and 1 edge, got %d nodes and %d edges", len(graph.Graph.Nodes), len(graph.Graph.Edges))
+	}
+	if edge := graph.Graph.Edges[0]; edge.Source != "note:"+sourceID || edge.Target != "note:"+targetID {
+		t.Errorf("expected a link from the source to the target, got %+v", edge)
+	}
+}
+
+func TestLinkHandler_GetGraph_DOT(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupLinkTest()
+	nuc.CreateNote("n1", "Only", "user-1")
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/graph?format=dot", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "text/vnd.graphviz; charset=utf-8" {
+		t.Errorf("expected a DOT content type, got %q", got)
+	}
+	want := "digraph notes {\n  \"note:n1\" [label=\"Only\", shape=box];\n}\n"
+	if rr.Body.String() != want {
+		t.Errorf("expected body %q, got %q", want, rr.Body.String())
+	}
+}
+
+func TestLinkHandler_GetGraph_BadRequest(t *testing.T) {
+	router, _, _ := setupLinkTest()
+
+	for _, query := range []string{"format=svg", "depth=two", "depth=-1", "keyword=a//b"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/graph?"+query, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d; got %d", query, http.StatusBadRequest, rr.Code)
+		}
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"noteapp/internal/repository/contentrepo"
-	"noteapp/internal/repository/noterepo"
 	"noteapp/internal/usecase/contentuc"
 	"noteapp/internal/usecase/noteuc"
 	"strings"
@@ -19,29 +17,42 @@ import (
 )
 
 func setupTestForBroadcast() (*chi.Mux, *noteuc.NoteUsecase, *contentuc.ContentUsecase, *ConnectionManager) {
-	noteRepo := noterepo.NewInMemoryNoteRepository()
-	contentRepo := contentrepo.NewInMemoryContentRepository()
-	nuc := noteuc.NewNoteUsecase(noteRepo)
-	cuc := contentuc.NewContentUsecase(contentRepo)
-	handler := NewNoteHandler(nuc, cuc)
+	ht := newHandlerTest(time.Now())
+	router, handler := ht.router, ht.handler
 
-	router := chi.NewRouter()
 	router.Post("/notes", handler.CreateNote)
 	router.Get("/notes/{id}", handler.GetNoteByID)
 	router.Delete("/notes/{id}", handler.DeleteNote)
+	router.Post("/notes/{id}/duplicate", handler.DuplicateNote)
+	router.Post("/notes/{id}/split", handler.SplitNote)
+	router.Post("/notes/{id}/merge", handler.MergeNotes)
 	router.Put("/notes/{id}", handler.UpdateNote)
 	router.Post("/notes/{id}/contents", handler.AddContent)
+	router.Put("/notes/{id}/contents/order", handler.ReorderContents)
 	router.Put("/notes/{id}/contents/{contentId}", handler.UpdateContent)
 	router.Delete("/notes/{id}/contents/{contentId}", handler.DeleteContent)
+	router.Post("/notes/{id}/contents/{contentId}/move", handler.MoveContent)
+	router.Post("/notes/{id}/contents/{contentId}/copy", handler.CopyContent)
+	router.Post("/notes/{id}/contents/{contentId}/items", handler.AddChecklistItem)
+	router.Post("/notes/{id}/contents/{contentId}/items/{itemId}/toggle", handler.ToggleChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/items/{itemId}/position", handler.MoveChecklistItem)
+	router.Delete("/notes/{id}/contents/{contentId}/items/{itemId}", handler.RemoveChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/rows/{rowId}/cells/{columnId}", handler.UpdateTableCell)
+	router.Post("/notes/{id}/contents/{contentId}/rows", handler.InsertTableRow)
+	router.Delete("/notes/{id}/contents/{contentId}/rows/{rowId}", handler.DeleteTableRow)
+	router.Post("/notes/{id}/contents/{contentId}/columns", handler.InsertTableColumn)
+	router.Delete("/notes/{id}/contents/{contentId}/columns/{columnId}", handler.DeleteTableColumn)
 	router.Post("/users/{userID}/notes/{noteID}/keyword", handler.TagNote)
 	router.Get("/users/{userID}/notes", handler.FindNotesByKeyword)
 	router.Delete("/users/{userID}/notes/{noteID}/keyword/{keyword}", handler.UntagNote)
 	router.Post("/users/{ownerID}/notes/{noteID}/shares", handler.ShareNote)
 	router.Delete("/users/{ownerID}/notes/{noteID}/shares", handler.RevokeAccess)
 	router.Get("/users/{userID}/accessible-notes", handler.GetAccessibleNotesForUser)
+	router.Get("/users/{userID}/keywords/tree", handler.GetKeywordTree)
+	router.Get("/users/{userID}/keywords/suggest", handler.SuggestKeywords)
 
 	router.Get("/ws/notes/{noteID}", handler.HandleWebSocket)
-	return router, nuc, cuc, handler.connManager
+	return router, ht.nuc, ht.cuc, handler.connManager
 }
 
 func setUpNoteWithContents(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase) (string, string, string) {
@@ -50,20 +61,20 @@ func setUpNoteWithContents(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecas
 		fmt.Printf("setup: failed to create note: %v", err)
 	}
 
-	contentID, err := cuc.CreateContent(noteID, "", "Test content", "text")
+	contentID, err := cuc.CreateContent(noteID, "owner-1", "", "Test content", "text")
 	if err != nil {
 		fmt.Printf("setup: failed to create content: %v", err)
 	}
-	err = nuc.AddContent(noteID, contentID, -1, 0)
+	err = nuc.AddContent(noteID, "owner-1", contentID, -1, 0)
 	if err != nil {
 		fmt.Printf("setup: failed to add content to note: %v", err)
 	}
 
-	contentID1, err := cuc.CreateContent(noteID, "", "Test content", "text")
+	contentID1, err := cuc.CreateContent(noteID, "owner-1", "", "Test content", "text")
 	if err != nil {
 		fmt.Printf("setup: failed to create content: %v", err)
 	}
-	err = nuc.AddContent(noteID, contentID1, -1, 1)
+	err = nuc.AddContent(noteID, "owner-1", contentID1, -1, 1)
 	if err != nil {
 		fmt.Printf("setup: failed to add content to note: %v", err)
 	}
@@ -443,3 +454,49 @@ func TestNoteHandler_UpdateNote_Broadcast(t *testing.T) {
 		t.Fatal("timed out waiting for websocket message")
 	}
 }
+
+func TestNoteHandler_UpdateNote_BroadcastIncludesModification(t *testing.T) {
+	// Arrange
+	router, nuc, _, _ := setupTestForBroadcast()
+	noteID, err := nuc.CreateNote("", "Original Title", "owner-1")
+	if err != nil {
+		t.Fatalf("setup: failed to create note: %v", err)
+	}
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notes/" + noteID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	// Act
+	body, _ := json.Marshal(UpdateNoteRequest{Title: "Edited", NoteVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"?user_id=editor-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to update note: status %d", rr.Code)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read websocket message: %v", err)
+	}
+	var event WebSocketEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatalf("failed to unmarshal websocket message: %v", err)
+	}
+	if event.LastModifiedBy != "editor-1" {
+		t.Errorf("expected last_modified_by 'editor-1', got '%s'", event.LastModifiedBy)
+	}
+	noteDTO, _ := nuc.GetNoteByID(noteID)
+	if event.UpdatedAt == nil || !event.UpdatedAt.Equal(noteDTO.UpdatedAt) {
+		t.Errorf("expected updated_at %v, got %v", noteDTO.UpdatedAt, event.UpdatedAt)
+	}
+}
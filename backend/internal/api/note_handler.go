@@ -4,9 +4,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"noteapp/internal/usecase/blobuc"
 	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/discoveryuc"
+	"noteapp/internal/usecase/linkuc"
 	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/reminderuc"
+	"noteapp/internal/usecase/savedsearchuc"
+	"noteapp/internal/usecase/templateuc"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -14,17 +28,25 @@ import (
 
 // NoteHandler handles HTTP requests for notes.
 type NoteHandler struct {
-	noteUsecase    *noteuc.NoteUsecase
-	contentUsecase *contentuc.ContentUsecase
-	connManager    *ConnectionManager
+	noteUsecase        *noteuc.NoteUsecase
+	contentUsecase     *contentuc.ContentUsecase
+	savedSearchUsecase *savedsearchuc.SavedSearchUsecase
+	blobUsecase        *blobuc.BlobUsecase
+	templateUsecase    *templateuc.TemplateUsecase
+	connManager        *ConnectionManager
+	userConnManager    *ConnectionManager
 }
 
 // NewNoteHandler creates a new NoteHandler.
-func NewNoteHandler(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase) *NoteHandler {
+func NewNoteHandler(nuc *noteuc.NoteUsecase, cuc *contentuc.ContentUsecase, suc *savedsearchuc.SavedSearchUsecase, buc *blobuc.BlobUsecase, tuc *templateuc.TemplateUsecase) *NoteHandler {
 	return &NoteHandler{
-		noteUsecase:    nuc,
-		contentUsecase: cuc,
-		connManager:    NewConnectionManager(),
+		noteUsecase:        nuc,
+		contentUsecase:     cuc,
+		savedSearchUsecase: suc,
+		blobUsecase:        buc,
+		templateUsecase:    tuc,
+		connManager:        NewConnectionManager(),
+		userConnManager:    NewConnectionManager(),
 	}
 }
 
@@ -46,6 +68,31 @@ type WebSocketEvent struct {
 	NoteVersion    int    `json:"note_version"`
 	ContentVersion int    `json:"content_version"`
 	Index          int    `json:"index"`
+	SavedSearchID  string `json:"saved_search_id,omitempty"`
+	// Language is the programming language of a code content.
+	Language string `json:"language,omitempty"`
+	// Format is "markdown" for Markdown text contents.
+	Format string `json:"format,omitempty"`
+	// Item is the checklist item a checklist event is about, after the change.
+	Item *contentuc.ChecklistItemDTO `json:"item,omitempty"`
+	// Cell, Row and Column are the table cell, row or column a table event is about.
+	Cell   *contentuc.TableCellDTO   `json:"cell,omitempty"`
+	Row    *contentuc.TableRowDTO    `json:"row,omitempty"`
+	Column *contentuc.TableColumnDTO `json:"column,omitempty"`
+	// ContentIDs is the order of the contents of the note after a reorder_content or
+	// merge_note event, or the contents split off it in a split_note event.
+	ContentIDs []string `json:"content_ids,omitempty"`
+	// SourceNoteID and TargetNoteID are the notes a content moved between. TargetNoteID is also
+	// the note created by a split_note event, and the note merged into by a merge_note event.
+	SourceNoteID string `json:"source_note_id,omitempty"`
+	TargetNoteID string `json:"target_note_id,omitempty"`
+	// State is the user's state of the note after a note_state event.
+	State *noteuc.NoteStateDTO `json:"state,omitempty"`
+	// Reminder is the occurrence of a reminder that fired in a reminder event.
+	Reminder *reminderuc.FiredReminderDTO `json:"reminder,omitempty"`
+	// UpdatedAt and LastModifiedBy describe the change of the note or content the event is about.
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+	LastModifiedBy string     `json:"last_modified_by,omitempty"`
 }
 
 // CreateNoteRequest represents the request body for creating a note.
@@ -63,14 +110,27 @@ type CreateNoteResponse struct {
 type AddContentRequest struct {
 	Type        string `json:"type"`
 	Data        string `json:"data"`
+	Filename    string `json:"filename,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Format      string `json:"format,omitempty"`
 	Index       *int   `json:"index,omitempty"`
 	NoteVersion *int   `json:"note_version"`
 }
 
 // UpdateContentRequest represents the request body for updating content in a note.
 type UpdateContentRequest struct {
-	Data           string `json:"data"`
-	ContentVersion *int   `json:"content_version"`
+	Data string `json:"data"`
+	// Language changes the programming language of a code content.
+	Language *string `json:"language,omitempty"`
+	// Format changes the format of a text content: "markdown", or "plain".
+	Format         *string `json:"format,omitempty"`
+	ContentVersion *int    `json:"content_version"`
+}
+
+// UploadImageResponse represents the response body for uploading an image content.
+type UploadImageResponse struct {
+	ID string `json:"id"`
+	blobuc.ImageDTO
 }
 
 // DeleteContentRequest represents the request body for deleting content in a note.
@@ -90,6 +150,22 @@ type TagNoteRequest struct {
 	NoteVersion *int   `json:"note_version"`
 }
 
+// UpdateNoteStateRequest represents the request body for changing how a user keeps a note.
+// Flags left out keep their current value.
+type UpdateNoteStateRequest struct {
+	Pinned      *bool `json:"pinned,omitempty"`
+	Archived    *bool `json:"archived,omitempty"`
+	Favourite   *bool `json:"favourite,omitempty"`
+	NoteVersion *int  `json:"note_version"`
+}
+
+// UpdateNoteStateResponse represents the user's state of a note after a change, along with
+// the new version of the note.
+type UpdateNoteStateResponse struct {
+	noteuc.NoteStateDTO
+	NoteVersion int `json:"note_version"`
+}
+
 // UntagNoteRequest represents the request body for untagging a note.
 type UntagNoteRequest struct {
 	NoteVersion *int `json:"note_version"`
@@ -121,7 +197,17 @@ type UpdateNoteRequest struct {
 
 var ErrUnsupportedContentType = errors.New("unsupported content type")
 
+// renderedHTMLContentSecurityPolicy allows rendered notes to show images, but not to run scripts.
+const renderedHTMLContentSecurityPolicy = "default-src 'none'; img-src 'self' https:; style-src 'unsafe-inline'"
+
+// maxMultipartOverhead is the room allowed for multipart headers and form fields on top of an image upload.
+const maxMultipartOverhead = 1 << 20
+
+// ErrInvalidLimit is returned when the limit query parameter is not a positive integer.
+var ErrInvalidLimit = errors.New("limit must be a positive integer")
+
 // CreateNote is the handler for the POST /notes endpoint.
+// With a template query parameter, the note is created from that template.
 func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 	var req CreateNoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -129,6 +215,11 @@ func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if templateID := r.URL.Query().Get("template"); templateID != "" {
+		h.createNoteFromTemplate(w, templateID, req)
+		return
+	}
+
 	noteID, err := h.noteUsecase.CreateNote("", req.Title, req.OwnerID)
 	if err != nil {
 		mapErrorToHTTPStatus(w, err)
@@ -145,12 +236,24 @@ func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 func (h *NoteHandler) GetNoteByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	noteDTO, err := h.noteUsecase.GetNoteByID(id)
+	response, err := h.getNoteWithContents(id)
 	if err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getNoteWithContents retrieves a note along with its contents, in order.
+func (h *NoteHandler) getNoteWithContents(id string) (*GetNoteByIDResponse, error) {
+	noteDTO, err := h.noteUsecase.GetNoteByID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	var contents []*contentuc.ContentDTO
 	for _, contentID := range noteDTO.ContentIDs {
 		contentDTO, err := h.contentUsecase.GetContentByID(contentID)
@@ -164,14 +267,10 @@ func (h *NoteHandler) GetNoteByID(w http.ResponseWriter, r *http.Request) {
 		contents = append(contents, contentDTO)
 	}
 
-	response := GetNoteByIDResponse{
+	return &GetNoteByIDResponse{
 		NoteDTO:  *noteDTO,
 		Contents: contents,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	}, nil
 }
 
 // UpdateNote is the handler for the PUT /notes/{id} endpoint.
@@ -189,7 +288,7 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.noteUsecase.ChangeTitle(noteID, req.Title, *req.NoteVersion); err != nil {
+	if err := h.noteUsecase.ChangeTitle(noteID, callerID(r), req.Title, *req.NoteVersion); err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
@@ -201,6 +300,7 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 		Data:        req.Title,
 		NoteVersion: *req.NoteVersion + 1,
 	}
+	h.stampNoteEvent(&event, noteID)
 	message, _ := json.Marshal(event)
 	h.connManager.Broadcast(noteID, message)
 
@@ -226,11 +326,21 @@ func (h *NoteHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	contents, err := h.contentUsecase.GetContentsByNoteID(id)
+	if err != nil {
+		http.Error(w, "An internal error occurred while deleting content", http.StatusInternalServerError)
+		return
+	}
 	// First, delete all associated content.
 	if err := h.contentUsecase.DeleteAllContentsByNoteID(id); err != nil {
 		http.Error(w, "An internal error occurred while deleting content", http.StatusInternalServerError)
 		return
 	}
+	var hashes []string
+	for _, contentDTO := range contents {
+		hashes = append(hashes, blobHashes(contentDTO)...)
+	}
+	h.releaseBlobs(hashes...)
 
 	// Broadcast the delete event to all connected clients.
 	event := WebSocketEvent{
@@ -241,6 +351,7 @@ func (h *NoteHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
 	message, _ := json.Marshal(event)
 	h.connManager.Broadcast(id, message)
 	h.connManager.CloseById(id)
+	h.broadcastMembershipChanges(h.savedSearchUsecase.RemoveNoteFromMemberships(id))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -272,15 +383,14 @@ func (h *NoteHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create the content first.
-	contentID, err := h.contentUsecase.CreateContent(noteID, "", req.Data, contentType)
+	contentID, err := h.createContent(noteID, callerID(r), req, contentType)
 	if err != nil {
-		// Error handling for content creation can be added here.
-		http.Error(w, "Failed to create content", http.StatusInternalServerError)
+		mapErrorToHTTPStatus(w, err)
 		return
 	}
 
 	// Then, add the content ID to the note.
-	if err := h.noteUsecase.AddContent(noteID, contentID, *req.Index, *req.NoteVersion); err != nil {
+	if err := h.noteUsecase.AddContent(noteID, callerID(r), contentID, *req.Index, *req.NoteVersion); err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
@@ -296,6 +406,7 @@ func (h *NoteHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 		ContentVersion: 0,
 		Index:          *req.Index,
 	}
+	h.stampContentEvent(&event, contentID)
 	message, _ := json.Marshal(event)
 	h.connManager.Broadcast(noteID, message)
 
@@ -306,6 +417,330 @@ func (h *NoteHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 	}{ID: contentID})
 }
 
+// createContent creates the content described by an AddContentRequest. Image and attachment
+// contents reference an already uploaded blob by its hash, which the author must already be
+// able to view through a content of the same type.
+func (h *NoteHandler) createContent(noteID, authorID string, req AddContentRequest, contentType contentuc.ContentType) (string, error) {
+	if contentType == contentuc.ImageContentType || contentType == contentuc.AttachmentContentType {
+		// Hold the blob until the content references it, so it is not deleted meanwhile.
+		defer h.blobUsecase.HoldBlobs()()
+		if err := checkCanViewBlob(h.noteUsecase, h.contentUsecase, req.Data, authorID, contentType); err != nil {
+			return "", err
+		}
+	}
+	switch contentType {
+	case contentuc.ImageContentType:
+		image, err := h.blobUsecase.GetImage(req.Data)
+		if err != nil {
+			return "", err
+		}
+		return h.contentUsecase.CreateImageContent(noteID, authorID, "", image.Hash, toImageMetadataDTO(image))
+	case contentuc.AttachmentContentType:
+		blob, err := h.blobUsecase.StatBlob(req.Data)
+		if err != nil {
+			return "", err
+		}
+		return h.contentUsecase.CreateAttachmentContent(noteID, authorID, "", blob.Hash, contentuc.AttachmentMetadataDTO{
+			Filename: req.Filename,
+			Size:     int64(blob.Size),
+			MimeType: blob.MimeType,
+		})
+	case contentuc.CodeContentType:
+		return h.contentUsecase.CreateCodeContent(noteID, authorID, "", req.Data, req.Language)
+	case contentuc.TextContentType:
+		return h.contentUsecase.CreateTextContent(noteID, authorID, "", req.Data, req.Format)
+	default:
+		return h.contentUsecase.CreateContent(noteID, authorID, "", req.Data, contentType)
+	}
+}
+
+// UploadImage is the handler for the POST /notes/{id}/contents/images endpoint.
+// It accepts a multipart form with the image in the "file" field, the "note_version" and an optional "index".
+func (h *NoteHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, blobuc.MaxImageSize+maxMultipartOverhead)
+	if err := r.ParseMultipartForm(maxMultipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			mapErrorToHTTPStatus(w, blobuc.ErrBlobTooLarge)
+			return
+		}
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	noteVersion, index, err := parseUploadPosition(r.FormValue("note_version"), r.FormValue("index"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	// Hold the blobs until the image content references them, so they are not deleted meanwhile.
+	release := h.blobUsecase.HoldBlobs()
+	image, err := h.blobUsecase.StoreImage(data)
+	if err != nil {
+		release()
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	contentID, err := h.contentUsecase.CreateImageContent(noteID, callerID(r), "", image.Hash, toImageMetadataDTO(image))
+	release()
+	if err != nil {
+		h.releaseBlobs(image.Hash)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	if err := h.noteUsecase.AddContent(noteID, callerID(r), contentID, index, noteVersion); err != nil {
+		h.discardContent(contentID)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the new image to all connected clients.
+	event := WebSocketEvent{
+		Type:           "add_content",
+		NoteID:         noteID,
+		ContentID:      contentID,
+		Data:           image.Hash,
+		ContentType:    string(contentuc.ImageContentType),
+		NoteVersion:    noteVersion + 1,
+		ContentVersion: 0,
+		Index:          index,
+	}
+	h.stampContentEvent(&event, contentID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(UploadImageResponse{ID: contentID, ImageDTO: *image})
+}
+
+// UploadAttachment is the handler for the POST /notes/{id}/contents/attachments endpoint.
+// The request body is the file itself, streamed into storage; the "filename", "note_version"
+// and optional "index" are passed as query parameters.
+func (h *NoteHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	query := r.URL.Query()
+
+	noteVersion, index, err := parseUploadPosition(query.Get("note_version"), query.Get("index"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filename := query.Get("filename")
+	if filename == "" {
+		http.Error(w, "filename is required", http.StatusBadRequest)
+		return
+	}
+
+	// Check access and the remaining quota before streaming, so that files which cannot be
+	// attached are not stored. The quota is reserved when the attachment content is created.
+	if err := h.noteUsecase.CheckCanEdit(noteID, callerID(r)); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	remaining, err := h.contentUsecase.RemainingStorage(callerID(r))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if r.ContentLength > remaining {
+		mapErrorToHTTPStatus(w, contentuc.ErrStorageQuotaExceeded)
+		return
+	}
+
+	blob, err := h.blobUsecase.StoreAttachment(r.Body, remaining)
+	if errors.Is(err, blobuc.ErrBlobTooLarge) && remaining < blobuc.MaxAttachmentSize {
+		err = contentuc.ErrStorageQuotaExceeded
+	}
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// The file may have been stored before and lost its last reference while this upload
+	// streamed, so check it is still there once no blob can be deleted.
+	release := h.blobUsecase.HoldBlobs()
+	if _, err := h.blobUsecase.StatBlob(blob.Hash); err != nil {
+		release()
+		if errors.Is(err, blobuc.ErrBlobNotFound) {
+			http.Error(w, "The file was deleted while it was uploaded; try again", http.StatusConflict)
+			return
+		}
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	contentID, err := h.contentUsecase.CreateAttachmentContent(noteID, callerID(r), "", blob.Hash, contentuc.AttachmentMetadataDTO{
+		Filename: filename,
+		Size:     int64(blob.Size),
+		MimeType: blob.MimeType,
+	})
+	release()
+	if err != nil {
+		h.releaseBlobs(blob.Hash)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	if err := h.noteUsecase.AddContent(noteID, callerID(r), contentID, index, noteVersion); err != nil {
+		h.discardContent(contentID)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the new attachment to all connected clients.
+	event := WebSocketEvent{
+		Type:           "add_content",
+		NoteID:         noteID,
+		ContentID:      contentID,
+		Data:           blob.Hash,
+		ContentType:    string(contentuc.AttachmentContentType),
+		NoteVersion:    noteVersion + 1,
+		ContentVersion: 0,
+		Index:          index,
+	}
+	h.stampContentEvent(&event, contentID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(contentDTO)
+}
+
+// DownloadAttachment is the handler for the GET /notes/{id}/contents/{contentId}/file?user_id={userID}
+// endpoint. Attachments are always downloaded rather than shown, and only to users who can view the note.
+func (h *NoteHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	contentID := chi.URLParam(r, "contentId")
+
+	if err := h.noteUsecase.CheckCanView(noteID, callerID(r)); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if contentDTO.NoteID != noteID {
+		mapErrorToHTTPStatus(w, contentuc.ErrContentNotFound)
+		return
+	}
+	if contentDTO.Type != string(contentuc.AttachmentContentType) {
+		http.Error(w, "content is not an attachment", http.StatusBadRequest)
+		return
+	}
+
+	file, err := h.blobUsecase.OpenBlob(contentDTO.Data)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", contentDTO.MimeType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": contentDTO.Filename}))
+	w.Header().Set("ETag", `"`+contentDTO.Data+`"`)
+	w.Header().Set("Cache-Control", blobCacheControl)
+	w.Header().Set("Content-Security-Policy", blobContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", contentDTO.CreatedAt, file)
+}
+
+// RenderContent is the handler for the GET /notes/{id}/contents/{contentId}/render endpoint.
+// It returns the content as an HTML fragment.
+func (h *NoteHandler) RenderContent(w http.ResponseWriter, r *http.Request) {
+	rendered, err := h.contentUsecase.RenderContent(chi.URLParam(r, "contentId"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	writeRenderedHTML(w, rendered)
+}
+
+// RenderNote is the handler for the GET /notes/{id}/render endpoint. It renders the title and
+// the contents of a note, in order, as an HTML fragment that is safe to show to other users.
+// Images and attachments link to their files on behalf of the user_id of the request.
+func (h *NoteHandler) RenderNote(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	noteDTO, err := h.noteUsecase.GetNoteByID(id)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(`<article class="note">` + "\n")
+	b.WriteString("<h1>" + html.EscapeString(noteDTO.Title) + "</h1>\n")
+	for _, contentID := range noteDTO.ContentIDs {
+		contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+		if err != nil {
+			fmt.Printf("Warning: Could not retrieve content %s for note %s: %v\n", contentID, id, err)
+			continue
+		}
+		rendered, err := h.renderContent(contentDTO, callerID(r))
+		if err != nil {
+			fmt.Printf("Warning: Could not render content %s for note %s: %v\n", contentID, id, err)
+			continue
+		}
+		fmt.Fprintf(&b, `<div class="content content-%s" id="content-%s">`+"\n", html.EscapeString(contentDTO.Type), html.EscapeString(contentDTO.ID))
+		b.WriteString(rendered)
+		b.WriteString("</div>\n")
+	}
+	b.WriteString("</article>\n")
+
+	writeRenderedHTML(w, b.String())
+}
+
+// renderContent renders a content of a note as HTML. Images and attachments link to their
+// files on behalf of userID, and the other types are rendered by the ContentUsecase.
+func (h *NoteHandler) renderContent(contentDTO *contentuc.ContentDTO, userID string) (string, error) {
+	var query string
+	if userID != "" {
+		query = "?" + url.Values{"user_id": {userID}}.Encode()
+	}
+	switch contentuc.ContentType(contentDTO.Type) {
+	case contentuc.ImageContentType:
+		src := "/blobs/" + url.PathEscape(contentDTO.Data) + query
+		img := fmt.Sprintf(`<img src="%s" alt=""`, html.EscapeString(src))
+		if contentDTO.Width > 0 && contentDTO.Height > 0 {
+			img += fmt.Sprintf(` width="%d" height="%d"`, contentDTO.Width, contentDTO.Height)
+		}
+		return "<figure>" + img + "></figure>\n", nil
+	case contentuc.AttachmentContentType:
+		href := fmt.Sprintf("/notes/%s/contents/%s/file", url.PathEscape(contentDTO.NoteID), url.PathEscape(contentDTO.ID)) + query
+		return fmt.Sprintf(`<p><a href="%s">%s</a></p>`+"\n", html.EscapeString(href), html.EscapeString(contentDTO.Filename)), nil
+	default:
+		return h.contentUsecase.RenderContent(contentDTO.ID)
+	}
+}
+
 // UpdateContent is the handler for the PUT /notes/{id}/contents/{contentId} endpoint.
 func (h *NoteHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 	noteID := chi.URLParam(r, "id")
@@ -322,7 +757,16 @@ func (h *NoteHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.contentUsecase.UpdateContent(contentID, req.Data, *req.ContentVersion); err != nil {
+	var err error
+	switch {
+	case req.Language != nil:
+		err = h.contentUsecase.UpdateCodeContent(contentID, callerID(r), req.Data, *req.Language, *req.ContentVersion)
+	case req.Format != nil:
+		err = h.contentUsecase.UpdateTextContent(contentID, callerID(r), req.Data, *req.Format, *req.ContentVersion)
+	default:
+		err = h.contentUsecase.UpdateContent(contentID, callerID(r), req.Data, *req.ContentVersion)
+	}
+	if err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
@@ -333,9 +777,9 @@ func (h *NoteHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 		NoteID:         noteID,
 		ContentID:      contentID,
 		Data:           req.Data,
-		ContentType:    "text", // Assuming text content for now
 		ContentVersion: *req.ContentVersion + 1,
 	}
+	h.stampContentEvent(&event, contentID)
 	message, _ := json.Marshal(event)
 	h.connManager.Broadcast(noteID, message)
 
@@ -358,17 +802,24 @@ func (h *NoteHandler) DeleteContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
 	// First, remove the content ID from the note.
-	if err := h.noteUsecase.RemoveContent(noteID, contentID, *req.NoteVersion); err != nil {
+	if err := h.noteUsecase.RemoveContent(noteID, callerID(r), contentID, *req.NoteVersion); err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
 
-	// Then, delete the content itself.
+	// Then, delete the content itself and the files only it referenced.
 	if err := h.contentUsecase.DeleteContent(contentID, *req.ContentVersion); err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	h.releaseBlobs(blobHashes(contentDTO)...)
 
 	// Broadcast the delete event to all connected clients.
 	event := WebSocketEvent{
@@ -377,6 +828,7 @@ func (h *NoteHandler) DeleteContent(w http.ResponseWriter, r *http.Request) {
 		ContentID:   contentID,
 		NoteVersion: *req.NoteVersion + 1,
 	}
+	h.stampNoteEvent(&event, noteID)
 	message, _ := json.Marshal(event)
 	h.connManager.Broadcast(noteID, message)
 
@@ -403,18 +855,84 @@ func (h *NoteHandler) TagNote(w http.ResponseWriter, r *http.Request) {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	h.refreshSavedSearches(userID)
 
 	w.WriteHeader(http.StatusCreated)
 }
 
+// UpdateNoteState is the handler for the PUT /users/{userID}/notes/{noteID}/state endpoint.
+// It pins, archives or favourites a note for the user only, and sends a note_state event to
+// the user's sync channel so their other devices follow, and a note_version event to the note's
+// room since the note moves to a new version.
+func (h *NoteHandler) UpdateNoteState(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	noteID := chi.URLParam(r, "noteID")
+
+	var req UpdateNoteStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.NoteVersion == nil {
+		http.Error(w, "note_version is required", http.StatusBadRequest)
+		return
+	}
+
+	update := noteuc.NoteStateUpdateDTO{Pinned: req.Pinned, Archived: req.Archived, Favourite: req.Favourite}
+	state, err := h.noteUsecase.UpdateNoteState(noteID, userID, update, *req.NoteVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	newVersion := *req.NoteVersion + 1
+
+	event := WebSocketEvent{
+		Type:        "note_state",
+		NoteID:      noteID,
+		NoteVersion: newVersion,
+		State:       state,
+	}
+	message, _ := json.Marshal(event)
+	h.userConnManager.Broadcast(userID, message)
+
+	// The state is saved with the note, so tell everyone editing it about the new version
+	// without revealing the user's state.
+	versionEvent := WebSocketEvent{
+		Type:        "note_version",
+		NoteID:      noteID,
+		NoteVersion: newVersion,
+	}
+	message, _ = json.Marshal(versionEvent)
+	h.connManager.Broadcast(noteID, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(UpdateNoteStateResponse{NoteStateDTO: *state, NoteVersion: newVersion})
+}
+
 // FindNotesByKeyword is the handler for the GET /users/{userID}/notes?keyword={keyword} endpoint.
+// When descendants=true, notes tagged with hierarchical keywords below the keyword are included.
+// When fuzzy=true, keywords and titles similar to the keyword are matched as well, with an
+// optional threshold between 0 and 1, and the matches are returned ordered by similarity.
 func (h *NoteHandler) FindNotesByKeyword(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "userID")
 	keyword := r.URL.Query().Get("keyword")
 
-	notes, err := h.noteUsecase.FindNotesByKeyword(userID, keyword)
+	if r.URL.Query().Get("fuzzy") == "true" {
+		h.findNotesByFuzzyMatch(w, r, userID, keyword)
+		return
+	}
+
+	var notes []*noteuc.NoteDTO
+	var err error
+	if r.URL.Query().Get("descendants") == "true" {
+		notes, err = h.noteUsecase.FindNotesByKeywordWithDescendants(userID, keyword)
+	} else {
+		notes, err = h.noteUsecase.FindNotesByKeyword(userID, keyword)
+	}
 	if err != nil {
-		http.Error(w, "An internal error occurred", http.StatusInternalServerError)
+		mapErrorToHTTPStatus(w, err)
 		return
 	}
 
@@ -423,11 +941,75 @@ func (h *NoteHandler) FindNotesByKeyword(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(notes)
 }
 
+func (h *NoteHandler) findNotesByFuzzyMatch(w http.ResponseWriter, r *http.Request, userID, query string) {
+	var threshold float64
+	if thresholdParam := r.URL.Query().Get("threshold"); thresholdParam != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(thresholdParam, 64)
+		if err != nil {
+			http.Error(w, noteuc.ErrInvalidThreshold.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	matches, err := h.noteUsecase.FindNotesByFuzzyMatch(userID, query, threshold)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(matches)
+}
+
+// GetKeywordTree is the handler for the GET /users/{userID}/keywords/tree endpoint.
+func (h *NoteHandler) GetKeywordTree(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	tree, err := h.noteUsecase.GetKeywordTree(userID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tree)
+}
+
+// SuggestKeywords is the handler for the GET /users/{userID}/keywords/suggest?prefix={prefix}&limit={limit} endpoint.
+func (h *NoteHandler) SuggestKeywords(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	prefix := r.URL.Query().Get("prefix")
+
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	suggestions, err := h.noteUsecase.SuggestKeywords(userID, prefix, limit)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(suggestions)
+}
+
 // UntagNote is the handler for the DELETE /users/{userID}/notes/{noteID}/keyword/{keyword} endpoint.
+// Hierarchical keywords must have their separators escaped, e.g. cs%2Fos.
 func (h *NoteHandler) UntagNote(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "userID")
 	noteID := chi.URLParam(r, "noteID")
-	keyword := chi.URLParam(r, "keyword")
+	keyword, err := url.PathUnescape(chi.URLParam(r, "keyword"))
+	if err != nil {
+		http.Error(w, "Invalid keyword", http.StatusBadRequest)
+		return
+	}
 
 	var req UntagNoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -444,6 +1026,7 @@ func (h *NoteHandler) UntagNote(w http.ResponseWriter, r *http.Request) {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	h.refreshSavedSearches(userID)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -472,15 +1055,38 @@ func (h *NoteHandler) ShareNote(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
-// GetAccessibleNotesForUser is the handler for the GET /users/{userID}/notes endpoint.
+// GetAccessibleNotesForUser is the handler for the GET /users/{userID}/accessible-notes endpoint.
+// It accepts the optional query parameters ownership, permission, keyword, archived, favourite,
+// sort, order, cursor and limit. Notes the user pinned come first, and notes the user archived
+// are left out unless archived is "include" or "only". The response body is the page of notes;
+// the total number of matching notes and the cursor of the next page are returned in the
+// X-Total-Count and X-Next-Cursor headers.
 func (h *NoteHandler) GetAccessibleNotesForUser(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "userID")
 
-	notesDTO, err := h.noteUsecase.GetAccessibleNotesForUser(userID)
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	page, err := h.noteUsecase.ListAccessibleNotes(userID, noteuc.ListNotesOptions{
+		Ownership:      query.Get("ownership"),
+		Permission:     query.Get("permission"),
+		Keyword:        query.Get("keyword"),
+		Archived:       query.Get("archived"),
+		FavouritesOnly: query.Get("favourite") == "true",
+		SortBy:         query.Get("sort"),
+		Order:          query.Get("order"),
+		Cursor:         query.Get("cursor"),
+		Limit:          limit,
+	})
 	if err != nil {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	notesDTO := page.Notes
 
 	var responseNotes []GetNoteByIDResponse
 	for _, noteDTO := range notesDTO {
@@ -496,7 +1102,7 @@ func (h *NoteHandler) GetAccessibleNotesForUser(w http.ResponseWriter, r *http.R
 				fmt.Printf("Warning: Could not retrieve content %s for note %s: %v\n", contentID, noteDTO.ID, err)
 				continue
 			}
-			contents = append(contents, contentDTO)
+			contents = append(contents, previewContent(contentDTO))
 		}
 		responseNotes = append(responseNotes, GetNoteByIDResponse{
 			NoteDTO:  *noteDTO,
@@ -505,6 +1111,10 @@ func (h *NoteHandler) GetAccessibleNotesForUser(w http.ResponseWriter, r *http.R
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(responseNotes)
 }
@@ -529,6 +1139,8 @@ func (h *NoteHandler) RevokeAccess(w http.ResponseWriter, r *http.Request) {
 		mapErrorToHTTPStatus(w, err)
 		return
 	}
+	// The collaborator's keywords on the note are gone, so it may leave their saved searches.
+	h.refreshSavedSearches(req.UserID)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -552,12 +1164,216 @@ func (h *NoteHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleUserWebSocket handles a user's sync channel, which receives events about the user's
+// saved searches. Connecting establishes the current result sets as the baseline.
+func (h *NoteHandler) HandleUserWebSocket(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	if _, err := h.savedSearchUsecase.RefreshMemberships(userID); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		http.Error(w, "Failed to upgrade connection", http.StatusInternalServerError)
+		return
+	}
+
+	h.userConnManager.Add(userID, conn)
+
+	// When the client closes the connection, remove it from the manager.
+	conn.SetCloseHandler(func(code int, text string) error {
+		h.userConnManager.Remove(userID, conn)
+		return nil
+	})
+}
+
+// refreshSavedSearches re-evaluates the user's saved searches after their keywords changed
+// and pushes the resulting membership changes to the user's sync channel.
+func (h *NoteHandler) refreshSavedSearches(userID string) {
+	changes, err := h.savedSearchUsecase.RefreshMemberships(userID)
+	if err != nil {
+		fmt.Printf("Warning: Could not refresh saved searches for user %s: %v\n", userID, err)
+		return
+	}
+	h.broadcastMembershipChanges(changes)
+}
+
+// broadcastMembershipChanges sends a saved_search_note_added or saved_search_note_removed
+// event to the sync channel of the saved search's owner for every change.
+func (h *NoteHandler) broadcastMembershipChanges(changes []*savedsearchuc.MembershipChangeDTO) {
+	for _, change := range changes {
+		eventType := "saved_search_note_removed"
+		if change.Entered {
+			eventType = "saved_search_note_added"
+		}
+		event := WebSocketEvent{
+			Type:          eventType,
+			NoteID:        change.NoteID,
+			SavedSearchID: change.SavedSearchID,
+		}
+		message, _ := json.Marshal(event)
+		h.userConnManager.Broadcast(change.UserID, message)
+	}
+}
+
+// discardContent deletes a content that could not be added to its note, along with its files.
+func (h *NoteHandler) discardContent(contentID string) {
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve content %s to discard: %v\n", contentID, err)
+		return
+	}
+	if err := h.contentUsecase.DeleteContent(contentID, contentDTO.Version); err != nil {
+		fmt.Printf("Warning: Could not discard content %s: %v\n", contentID, err)
+		return
+	}
+	h.releaseBlobs(blobHashes(contentDTO)...)
+}
+
+// releaseBlobs deletes the blobs that no content references any more.
+func (h *NoteHandler) releaseBlobs(hashes ...string) {
+	seen := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		_, err := h.blobUsecase.DeleteUnreferencedBlob(hash, h.contentUsecase.IsBlobReferenced)
+		if err != nil && !errors.Is(err, blobuc.ErrBlobNotFound) {
+			fmt.Printf("Warning: Could not delete blob %s: %v\n", hash, err)
+		}
+	}
+}
+
+// blobHashes returns the hashes of the blobs a content references.
+func blobHashes(contentDTO *contentuc.ContentDTO) []string {
+	switch contentDTO.Type {
+	case string(contentuc.ImageContentType):
+		hashes := []string{contentDTO.Data}
+		for _, hash := range contentDTO.Thumbnails {
+			hashes = append(hashes, hash)
+		}
+		return hashes
+	case string(contentuc.AttachmentContentType):
+		return []string{contentDTO.Data}
+	default:
+		return nil
+	}
+}
+
+// stampNoteEvent fills in when and by whom the note of an event was last modified.
+func (h *NoteHandler) stampNoteEvent(event *WebSocketEvent, noteID string) {
+	noteDTO, err := h.noteUsecase.GetNoteByID(noteID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve note %s for event: %v\n", noteID, err)
+		return
+	}
+	event.UpdatedAt = &noteDTO.UpdatedAt
+	event.LastModifiedBy = noteDTO.LastModifiedBy
+}
+
+// stampContentEvent fills in the type of the content of an event, and when and by whom it was last modified.
+func (h *NoteHandler) stampContentEvent(event *WebSocketEvent, contentID string) {
+	contentDTO, err := h.contentUsecase.GetContentByID(contentID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve content %s for event: %v\n", contentID, err)
+		return
+	}
+	event.ContentType = contentDTO.Type
+	event.Language = contentDTO.Language
+	if contentDTO.Type == string(contentuc.TextContentType) {
+		event.Format = contentDTO.Format
+	}
+	event.UpdatedAt = &contentDTO.UpdatedAt
+	event.LastModifiedBy = contentDTO.LastModifiedBy
+}
+
+// writeRenderedHTML writes rendered note HTML, with a Content-Security-Policy that keeps
+// browsers from running scripts or loading resources from other origins should any slip through.
+func writeRenderedHTML(w http.ResponseWriter, rendered string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", renderedHTMLContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, rendered)
+}
+
+// callerID returns the user making a change, passed as the optional "user_id" query parameter.
+func callerID(r *http.Request) string {
+	return r.URL.Query().Get("user_id")
+}
+
+// parseUploadPosition reads the note version and optional index of an uploaded content.
+// The index defaults to -1, which appends the content.
+func parseUploadPosition(noteVersionParam, indexParam string) (int, int, error) {
+	noteVersion, err := strconv.Atoi(noteVersionParam)
+	if err != nil {
+		return 0, 0, errors.New("note_version is required")
+	}
+	index := -1
+	if indexParam != "" {
+		if index, err = strconv.Atoi(indexParam); err != nil {
+			return 0, 0, errors.New("index must be an integer")
+		}
+	}
+	return noteVersion, index, nil
+}
+
+// parseLimitParam reads the optional "limit" query parameter. It returns 0 when the parameter is absent.
+func parseLimitParam(r *http.Request) (int, error) {
+	limitParam := r.URL.Query().Get("limit")
+	if limitParam == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil || limit < 1 {
+		return 0, ErrInvalidLimit
+	}
+	return limit, nil
+}
+
+// previewContent adapts a content for the note listing. Image contents point at their
+// smallest thumbnail so listings do not load full-resolution images.
+func previewContent(contentDTO *contentuc.ContentDTO) *contentuc.ContentDTO {
+	if contentDTO.Type != string(contentuc.ImageContentType) || len(contentDTO.Thumbnails) == 0 {
+		return contentDTO
+	}
+	smallest := -1
+	for size := range contentDTO.Thumbnails {
+		if smallest == -1 || size < smallest {
+			smallest = size
+		}
+	}
+	preview := *contentDTO
+	preview.Data = contentDTO.Thumbnails[smallest]
+	return &preview
+}
+
+func toImageMetadataDTO(image *blobuc.ImageDTO) contentuc.ImageMetadataDTO {
+	return contentuc.ImageMetadataDTO{
+		Width:      image.Width,
+		Height:     image.Height,
+		Format:     image.Format,
+		Thumbnails: image.Thumbnails,
+	}
+}
+
 func mapToContentUsecaseContentType(ct string) (contentuc.ContentType, error) {
 	switch ct {
 	case "text":
 		return contentuc.TextContentType, nil
 	case "image":
 		return contentuc.ImageContentType, nil
+	case "attachment":
+		return contentuc.AttachmentContentType, nil
+	case "checklist":
+		return contentuc.ChecklistContentType, nil
+	case "code":
+		return contentuc.CodeContentType, nil
+	case "table":
+		return contentuc.TableContentType, nil
 	default:
 		return contentuc.TextContentType, ErrUnsupportedContentType
 	}
@@ -574,8 +1390,17 @@ func mapErrorToHTTPStatus(w http.ResponseWriter, err error) {
 	case errors.Is(err, noteuc.ErrInvalidID),
 		errors.Is(err, noteuc.ErrEmptyTitle),
 		errors.Is(err, noteuc.ErrEmptyKeyword),
+		errors.Is(err, noteuc.ErrInvalidKeywordPath),
+		errors.Is(err, noteuc.ErrInvalidThreshold),
+		errors.Is(err, noteuc.ErrInvalidCursor),
+		errors.Is(err, noteuc.ErrUnsupportedSortField),
+		errors.Is(err, noteuc.ErrUnsupportedOwnershipFilter),
+		errors.Is(err, noteuc.ErrUnsupportedArchivedFilter),
 		errors.Is(err, noteuc.ErrUnsupportedPermissionType),
-		errors.Is(err, noteuc.ErrIndexOutOfBounds):
+		errors.Is(err, noteuc.ErrIndexOutOfBounds),
+		errors.Is(err, noteuc.ErrSameNote),
+		errors.Is(err, noteuc.ErrInvalidMergeSources),
+		errors.Is(err, noteuc.ErrInvalidContentOrder):
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, noteuc.ErrPermissionDenied):
 		http.Error(w, err.Error(), http.StatusForbidden)
@@ -585,9 +1410,113 @@ func mapErrorToHTTPStatus(w http.ResponseWriter, err error) {
 	// ContentUsecase errors
 	case errors.Is(err, contentuc.ErrContentNotFound):
 		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, contentuc.ErrContentNotEditable),
+		errors.Is(err, contentuc.ErrEmptyFilename),
+		errors.Is(err, contentuc.ErrUnsupportedContentType),
+		errors.Is(err, contentuc.ErrUnsupportedLanguage),
+		errors.Is(err, contentuc.ErrUnsupportedTextFormat),
+		errors.Is(err, contentuc.ErrRenderNotSupported),
+		errors.Is(err, contentuc.ErrNotAChecklist),
+		errors.Is(err, contentuc.ErrInvalidChecklist),
+		errors.Is(err, contentuc.ErrEmptyChecklistItem),
+		errors.Is(err, contentuc.ErrNotATable),
+		errors.Is(err, contentuc.ErrInvalidTable),
+		errors.Is(err, contentuc.ErrIndexOutOfBounds):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, contentuc.ErrChecklistItemNotFound),
+		errors.Is(err, contentuc.ErrTableRowNotFound),
+		errors.Is(err, contentuc.ErrTableColumnNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, contentuc.ErrStorageQuotaExceeded):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
 	case errors.Is(err, contentuc.ErrConflict):
 		http.Error(w, err.Error(), http.StatusConflict)
 
+	// BlobUsecase errors
+	case errors.Is(err, blobuc.ErrBlobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, blobuc.ErrInvalidHash),
+		errors.Is(err, blobuc.ErrEmptyBlob),
+		errors.Is(err, blobuc.ErrInvalidImage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, blobuc.ErrBlobTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, blobuc.ErrUnsupportedMediaType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+
+	// DiscoveryUsecase errors
+	case errors.Is(err, discoveryuc.ErrNoteNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, discoveryuc.ErrInvalidID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, discoveryuc.ErrPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+
+	// LinkUsecase errors
+	case errors.Is(err, linkuc.ErrNoteNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, linkuc.ErrInvalidID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, linkuc.ErrPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, linkuc.ErrInvalidKeyword), errors.Is(err, linkuc.ErrInvalidDepth):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+	// SavedSearchUsecase errors
+	case errors.Is(err, savedsearchuc.ErrSavedSearchNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, savedsearchuc.ErrInvalidID),
+		errors.Is(err, savedsearchuc.ErrEmptyName),
+		errors.Is(err, savedsearchuc.ErrEmptyQuery),
+		errors.Is(err, savedsearchuc.ErrInvalidQuery),
+		errors.Is(err, savedsearchuc.ErrUnsupportedSortOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, savedsearchuc.ErrPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, savedsearchuc.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+
+	// TemplateUsecase errors
+	case errors.Is(err, templateuc.ErrTemplateNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, templateuc.ErrInvalidID),
+		errors.Is(err, templateuc.ErrEmptyName),
+		errors.Is(err, templateuc.ErrEmptyTitlePattern),
+		errors.Is(err, templateuc.ErrUnsupportedBlockType),
+		errors.Is(err, templateuc.ErrUnknownPlaceholder),
+		errors.Is(err, templateuc.ErrInvalidBlock):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, templateuc.ErrPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, templateuc.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+
+	// DailyUsecase errors
+	case errors.Is(err, dailyuc.ErrDailyNoteNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, dailyuc.ErrInvalidID),
+		errors.Is(err, dailyuc.ErrInvalidDate),
+		errors.Is(err, dailyuc.ErrInvalidTimeZone):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, dailyuc.ErrConflict),
+		errors.Is(err, dailyuc.ErrDailyNoteExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+
+	// ReminderUsecase errors
+	case errors.Is(err, reminderuc.ErrReminderNotFound),
+		errors.Is(err, reminderuc.ErrNoteNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, reminderuc.ErrInvalidID),
+		errors.Is(err, reminderuc.ErrInvalidTime),
+		errors.Is(err, reminderuc.ErrDueTimeTooOld),
+		errors.Is(err, reminderuc.ErrInvalidTimeZone),
+		errors.Is(err, reminderuc.ErrInvalidRecurrence):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, reminderuc.ErrPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, reminderuc.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+
 	default:
 		http.Error(w, "An internal error occurred", http.StatusInternalServerError)
 	}
@@ -6,41 +6,112 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/blobrepo"
 	"noteapp/internal/repository/contentrepo"
 	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/savedsearchrepo"
+	"noteapp/internal/repository/templaterepo"
+	"noteapp/internal/usecase/blobuc"
 	"noteapp/internal/usecase/contentuc"
 	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/savedsearchuc"
+	"noteapp/internal/usecase/templateuc"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
 )
 
-// setupTest initializes the necessary components for the tests.
+// handlerTest holds a note handler and the usecases behind it, backed by shared in-memory
+// repositories, for the setup of each feature to route.
+type handlerTest struct {
+	router      *chi.Mux
+	clock       *clock.FakeClock
+	noteRepo    *noterepo.InMemoryNoteRepository
+	contentRepo *contentrepo.InMemoryContentRepository
+	blobStore   *blobrepo.InMemoryBlobStore
+	nuc         *noteuc.NoteUsecase
+	cuc         *contentuc.ContentUsecase
+	suc         *savedsearchuc.SavedSearchUsecase
+	buc         *blobuc.BlobUsecase
+	tuc         *templateuc.TemplateUsecase
+	handler     *NoteHandler
+}
+
+// newHandlerTest creates a handlerTest with an empty router and the default storage quota.
+// Templates, and the usecases a setup adds, read the time from a fake clock at now.
+func newHandlerTest(now time.Time) *handlerTest {
+	return newHandlerTestWithStorageQuota(now, contentuc.DefaultStorageQuota)
+}
+
+// newHandlerTestWithStorageQuota creates a handlerTest whose users may upload quota bytes of
+// attachments each.
+func newHandlerTestWithStorageQuota(now time.Time, quota int64) *handlerTest {
+	ht := &handlerTest{
+		router:      chi.NewRouter(),
+		clock:       clock.NewFakeClock(now),
+		noteRepo:    noterepo.NewInMemoryNoteRepository(),
+		contentRepo: contentrepo.NewInMemoryContentRepository(),
+		blobStore:   blobrepo.NewInMemoryBlobStore(),
+	}
+	ht.nuc = noteuc.NewNoteUsecase(ht.noteRepo)
+	ht.cuc = contentuc.NewContentUsecaseWithStorageQuota(ht.contentRepo, clock.NewSystemClock(), quota)
+	ht.suc = savedsearchuc.NewSavedSearchUsecase(savedsearchrepo.NewInMemorySavedSearchRepository(), ht.noteRepo)
+	ht.buc = blobuc.NewBlobUsecase(ht.blobStore)
+	ht.tuc = templateuc.NewTemplateUsecaseWithClock(templaterepo.NewInMemoryTemplateRepository(), ht.clock)
+	ht.handler = NewNoteHandler(ht.nuc, ht.cuc, ht.suc, ht.buc, ht.tuc)
+	return ht
+}
+
+// setupTest initializes a router with the note endpoints.
 func setupTest() (*chi.Mux, *noteuc.NoteUsecase, *contentuc.ContentUsecase) {
-	noteRepo := noterepo.NewInMemoryNoteRepository()
-	contentRepo := contentrepo.NewInMemoryContentRepository()
-	nuc := noteuc.NewNoteUsecase(noteRepo)
-	cuc := contentuc.NewContentUsecase(contentRepo)
-	handler := NewNoteHandler(nuc, cuc)
+	ht := newHandlerTest(time.Now())
+	router, handler := ht.router, ht.handler
 
-	router := chi.NewRouter()
 	router.Post("/notes", handler.CreateNote)
 	router.Get("/notes/{id}", handler.GetNoteByID)
 	router.Put("/notes/{id}", handler.UpdateNote)
 	router.Delete("/notes/{id}", handler.DeleteNote)
+	router.Post("/notes/{id}/duplicate", handler.DuplicateNote)
+	router.Post("/notes/{id}/split", handler.SplitNote)
+	router.Post("/notes/{id}/merge", handler.MergeNotes)
 	router.Post("/notes/{id}/contents", handler.AddContent)
+	router.Put("/notes/{id}/contents/order", handler.ReorderContents)
+	router.Post("/notes/{id}/contents/images", handler.UploadImage)
+	router.Post("/notes/{id}/contents/attachments", handler.UploadAttachment)
+	router.Get("/notes/{id}/contents/{contentId}/file", handler.DownloadAttachment)
 	router.Put("/notes/{id}/contents/{contentId}", handler.UpdateContent)
 	router.Delete("/notes/{id}/contents/{contentId}", handler.DeleteContent)
+	router.Post("/notes/{id}/contents/{contentId}/move", handler.MoveContent)
+	router.Post("/notes/{id}/contents/{contentId}/copy", handler.CopyContent)
+	router.Get("/notes/{id}/render", handler.RenderNote)
+	router.Get("/notes/{id}/contents/{contentId}/render", handler.RenderContent)
+	router.Post("/notes/{id}/contents/{contentId}/items", handler.AddChecklistItem)
+	router.Post("/notes/{id}/contents/{contentId}/items/{itemId}/toggle", handler.ToggleChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/items/{itemId}/position", handler.MoveChecklistItem)
+	router.Delete("/notes/{id}/contents/{contentId}/items/{itemId}", handler.RemoveChecklistItem)
+	router.Put("/notes/{id}/contents/{contentId}/rows/{rowId}/cells/{columnId}", handler.UpdateTableCell)
+	router.Post("/notes/{id}/contents/{contentId}/rows", handler.InsertTableRow)
+	router.Delete("/notes/{id}/contents/{contentId}/rows/{rowId}", handler.DeleteTableRow)
+	router.Post("/notes/{id}/contents/{contentId}/columns", handler.InsertTableColumn)
+	router.Delete("/notes/{id}/contents/{contentId}/columns/{columnId}", handler.DeleteTableColumn)
 	router.Post("/users/{userID}/notes/{noteID}/keyword", handler.TagNote)
 	router.Get("/users/{userID}/notes", handler.FindNotesByKeyword)
 	router.Delete("/users/{userID}/notes/{noteID}/keyword/{keyword}", handler.UntagNote)
 	router.Post("/users/{ownerID}/notes/{noteID}/shares", handler.ShareNote)
 	router.Delete("/users/{ownerID}/notes/{noteID}/shares", handler.RevokeAccess)
 	router.Get("/users/{userID}/accessible-notes", handler.GetAccessibleNotesForUser)
+	router.Put("/users/{userID}/notes/{noteID}/state", handler.UpdateNoteState)
+	router.Get("/users/{userID}/ws", handler.HandleUserWebSocket)
+	router.Get("/users/{userID}/keywords/tree", handler.GetKeywordTree)
+	router.Get("/users/{userID}/keywords/suggest", handler.SuggestKeywords)
+	router.Get("/blobs/{hash}", NewBlobHandler(ht.buc, ht.nuc, ht.cuc).GetBlob)
 
 	router.Get("/ws/notes/{noteID}", handler.HandleWebSocket)
-	return router, nuc, cuc
+	return router, ht.nuc, ht.cuc
 }
 
 func TestNoteHandler_FindNotesByKeyword(t *testing.T) {
@@ -393,20 +464,20 @@ func TestNoteHandler_GetNoteByID_Success(t *testing.T) {
 
 	// Add content to the note
 	contentData1 := "First content"
-	contentID1, err := cuc.CreateContent(noteID, "", contentData1, contentuc.TextContentType)
+	contentID1, err := cuc.CreateContent(noteID, "owner-1", "", contentData1, contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content 1: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID1, -1, 0); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID1, -1, 0); err != nil {
 		t.Fatalf("setup: failed to add content 1 to note: %v", err)
 	}
 
 	contentData2 := "Second content"
-	contentID2, err := cuc.CreateContent(noteID, "", contentData2, contentuc.TextContentType)
+	contentID2, err := cuc.CreateContent(noteID, "owner-1", "", contentData2, contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content 2: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID2, -1, 1); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID2, -1, 1); err != nil {
 		t.Fatalf("setup: failed to add content 2 to note: %v", err)
 	}
 
@@ -539,11 +610,11 @@ func TestNoteHandler_UpdateContent_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("setup: failed to create note: %v", err)
 	}
-	contentID, err := cuc.CreateContent(noteID, "", "Initial Content", contentuc.TextContentType)
+	contentID, err := cuc.CreateContent(noteID, "owner-1", "", "Initial Content", contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID, -1, 0); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID, -1, 0); err != nil {
 		t.Fatalf("setup: failed to add content to note: %v", err)
 	}
 
@@ -641,8 +712,8 @@ func TestNoteHandler_UpdateContent_MissingVersion(t *testing.T) {
 	// Arrange
 	router, nuc, cuc := setupTest()
 	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
-	contentID, _ := cuc.CreateContent(noteID, "", "Initial Content", contentuc.TextContentType)
-	nuc.AddContent(noteID, contentID, -1, 0)
+	contentID, _ := cuc.CreateContent(noteID, "owner-1", "", "Initial Content", contentuc.TextContentType)
+	nuc.AddContent(noteID, "owner-1", contentID, -1, 0)
 
 	// Request body without version
 	requestBody := map[string]string{
@@ -668,11 +739,11 @@ func TestNoteHandler_DeleteContent_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("setup: failed to create note: %v", err)
 	}
-	contentID, err := cuc.CreateContent(noteID, "", "Initial Content", contentuc.TextContentType)
+	contentID, err := cuc.CreateContent(noteID, "owner-1", "", "Initial Content", contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID, -1, 0); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID, -1, 0); err != nil {
 		t.Fatalf("setup: failed to add content to note: %v", err)
 	}
 
@@ -757,8 +828,8 @@ func TestNoteHandler_DeleteContent_MissingVersion(t *testing.T) {
 	// Arrange
 	router, nuc, cuc := setupTest()
 	noteID, _ := nuc.CreateNote("", "Test Title", "owner-1")
-	contentID, _ := cuc.CreateContent(noteID, "", "Initial Content", contentuc.TextContentType)
-	nuc.AddContent(noteID, contentID, -1, 0)
+	contentID, _ := cuc.CreateContent(noteID, "owner-1", "", "Initial Content", contentuc.TextContentType)
+	nuc.AddContent(noteID, "owner-1", contentID, -1, 0)
 
 	// Empty body
 	req := httptest.NewRequest(http.MethodDelete, "/notes/"+noteID+"/contents/"+contentID, nil)
@@ -1173,6 +1244,192 @@ func TestNoteHandler_GetAccessibleNotesForUser(t *testing.T) {
 	}
 }
 
+func TestNoteHandler_GetAccessibleNotesForUser_Paginated(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	nc.CreateNote("", "Charlie", "user-1")
+	nc.CreateNote("", "Alpha", "user-1")
+	nc.CreateNote("", "Bravo", "user-1")
+
+	firstReq := httptest.NewRequest(http.MethodGet, "/users/user-1/accessible-notes?sort=title&limit=2", nil)
+	firstRR := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(firstRR, firstReq)
+	cursor := firstRR.Header().Get("X-Next-Cursor")
+	secondReq := httptest.NewRequest(http.MethodGet, "/users/user-1/accessible-notes?sort=title&limit=2&cursor="+cursor, nil)
+	secondRR := httptest.NewRecorder()
+	router.ServeHTTP(secondRR, secondReq)
+
+	// Assert
+	if firstRR.Code != http.StatusOK || secondRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d and %d", http.StatusOK, firstRR.Code, secondRR.Code)
+	}
+	if total := firstRR.Header().Get("X-Total-Count"); total != "3" {
+		t.Errorf("expected X-Total-Count 3, got %q", total)
+	}
+	if cursor == "" {
+		t.Fatalf("expected an X-Next-Cursor header on the first page")
+	}
+	var first, second []GetNoteByIDResponse
+	json.NewDecoder(firstRR.Body).Decode(&first)
+	json.NewDecoder(secondRR.Body).Decode(&second)
+	if len(first) != 2 || first[0].Title != "Alpha" || first[1].Title != "Bravo" {
+		t.Errorf("expected the first page to be [Alpha Bravo], got %+v", first)
+	}
+	if len(second) != 1 || second[0].Title != "Charlie" || secondRR.Header().Get("X-Next-Cursor") != "" {
+		t.Errorf("expected the last page to be [Charlie] without a next cursor, got %+v", second)
+	}
+}
+
+func TestNoteHandler_GetAccessibleNotesForUser_InvalidOptions(t *testing.T) {
+	// Arrange
+	router, _, _ := setupTest()
+
+	for _, query := range []string{"sort=size", "ownership=borrowed", "permission=admin", "cursor=%25%25", "limit=0", "archived=forever"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/user-1/accessible-notes?"+query, nil)
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s; got %d", http.StatusBadRequest, query, rr.Code)
+		}
+	}
+}
+
+func TestNoteHandler_UpdateNoteState(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Test Note", "owner-1")
+	nc.ShareNote(noteID, "owner-1", "user-1", "read", 0)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/users/user-1/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+	noteConn := dialNote(t, server, noteID)
+	defer noteConn.Close()
+
+	pinned := true
+	body, _ := json.Marshal(UpdateNoteStateRequest{Pinned: &pinned, Favourite: &pinned, NoteVersion: intPtr(1)})
+	req := httptest.NewRequest(http.MethodPut, "/users/user-1/notes/"+noteID+"/state", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+	event := readEvent(t, conn)
+	versionEvent := readEvent(t, noteConn)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp UpdateNoteStateResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if !resp.Pinned || !resp.Favourite || resp.Archived || resp.NoteVersion != 2 {
+		t.Errorf("expected the note pinned and favourite at version 2; got %+v", resp)
+	}
+	if event.Type != "note_state" || event.NoteID != noteID || event.NoteVersion != 2 || event.State == nil || !event.State.Pinned {
+		t.Errorf("expected a note_state event for the note; got %+v", event)
+	}
+	if versionEvent.Type != "note_version" || versionEvent.NoteID != noteID || versionEvent.NoteVersion != 2 || versionEvent.State != nil {
+		t.Errorf("expected a note_version event without the state to the note's room; got %+v", versionEvent)
+	}
+	note, _ := nc.GetNoteByID(noteID)
+	if state := note.States["user-1"]; !state.Pinned || !state.Favourite {
+		t.Errorf("expected the state saved for user-1; got %+v", note.States)
+	}
+	if _, ok := note.States["owner-1"]; ok {
+		t.Errorf("expected the owner's state untouched; got %+v", note.States)
+	}
+}
+
+func TestNoteHandler_UpdateNoteState_Errors(t *testing.T) {
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Test Note", "owner-1")
+	archived := true
+
+	tests := []struct {
+		name       string
+		userID     string
+		body       UpdateNoteStateRequest
+		wantStatus int
+	}{
+		{"without note version", "owner-1", UpdateNoteStateRequest{Archived: &archived}, http.StatusBadRequest},
+		{"without access", "stranger", UpdateNoteStateRequest{Archived: &archived, NoteVersion: intPtr(0)}, http.StatusForbidden},
+		{"stale version", "owner-1", UpdateNoteStateRequest{Archived: &archived, NoteVersion: intPtr(3)}, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/users/"+tt.userID+"/notes/"+noteID+"/state", bytes.NewBuffer(body)))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d; got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestNoteHandler_GetAccessibleNotesForUser_States(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	alpha, _ := nc.CreateNote("", "Alpha", "user-1")
+	bravo, _ := nc.CreateNote("", "Bravo", "user-1")
+	charlie, _ := nc.CreateNote("", "Charlie", "user-1")
+	nc.CreateNote("", "Delta", "user-1")
+	yes := true
+	nc.UpdateNoteState(charlie, "user-1", noteuc.NoteStateUpdateDTO{Pinned: &yes}, 0)
+	nc.UpdateNoteState(bravo, "user-1", noteuc.NoteStateUpdateDTO{Archived: &yes, Favourite: &yes}, 0)
+	nc.UpdateNoteState(alpha, "user-1", noteuc.NoteStateUpdateDTO{Favourite: &yes}, 0)
+
+	tests := []struct {
+		query      string
+		wantTitles []string
+	}{
+		{"", []string{"Charlie", "Alpha", "Delta"}},
+		{"archived=include", []string{"Charlie", "Alpha", "Bravo", "Delta"}},
+		{"archived=only", []string{"Bravo"}},
+		{"favourite=true", []string{"Alpha"}},
+		{"favourite=true&archived=include", []string{"Alpha", "Bravo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/accessible-notes?"+tt.query, nil))
+
+			// Assert
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+			}
+			var notes []*noteuc.NoteDTO
+			if err := json.NewDecoder(rr.Body).Decode(&notes); err != nil {
+				t.Fatalf("failed to decode response body: %v", err)
+			}
+			var titles []string
+			for _, note := range notes {
+				titles = append(titles, note.Title)
+			}
+			if strings.Join(titles, ",") != strings.Join(tt.wantTitles, ",") {
+				t.Errorf("expected %v; got %v", tt.wantTitles, titles)
+			}
+		})
+	}
+}
+
 func TestNoteHandler_RevokeAccess_Success(t *testing.T) {
 	// Arrange
 	router, nc, _ := setupTest()
@@ -1264,18 +1521,18 @@ func TestNoteHandler_DeleteNote_DeletesAssociatedContent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("setup: failed to create note: %v", err)
 	}
-	contentID1, err := cuc.CreateContent(noteID, "", "Content 1", contentuc.TextContentType)
+	contentID1, err := cuc.CreateContent(noteID, "owner-1", "", "Content 1", contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content 1: %v", err)
 	}
-	contentID2, err := cuc.CreateContent(noteID, "", "Content 2", contentuc.TextContentType)
+	contentID2, err := cuc.CreateContent(noteID, "owner-1", "", "Content 2", contentuc.TextContentType)
 	if err != nil {
 		t.Fatalf("setup: failed to create content 2: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID1, -1, 0); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID1, -1, 0); err != nil {
 		t.Fatalf("setup: failed to add content 1 to note: %v", err)
 	}
-	if err := nuc.AddContent(noteID, contentID2, -1, 1); err != nil {
+	if err := nuc.AddContent(noteID, "owner-1", contentID2, -1, 1); err != nil {
 		t.Fatalf("setup: failed to add content 2 to note: %v", err)
 	}
 
@@ -1350,3 +1607,166 @@ func TestNoteHandler_ChangeTitle_Success(t *testing.T) {
 		t.Errorf("expected note version 1; got %d", updatedNote.Version)
 	}
 }
+
+func TestNoteHandler_FindNotesByKeyword_WithDescendants(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	note1, _ := nc.CreateNote("", "OS", "user-1")
+	note2, _ := nc.CreateNote("", "Concurrency", "user-1")
+	nc.TagNote(note1, "user-1", "cs/os", 0)
+	nc.TagNote(note2, "user-1", "cs/os/concurrency", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/notes?keyword=cs/os&descendants=true", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var notes []*noteuc.NoteDTO
+	if err := json.NewDecoder(rr.Body).Decode(&notes); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+}
+
+func TestNoteHandler_FindNotesByKeyword_Fuzzy(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Threads", "user-1")
+	nc.TagNote(noteID, "user-1", "concurrency", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/notes?keyword=concurency&fuzzy=true&threshold=0.8", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var matches []*noteuc.NoteMatchDTO
+	if err := json.NewDecoder(rr.Body).Decode(&matches); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Note.ID != noteID || matches[0].MatchedText != "concurrency" {
+		t.Errorf("expected a single match on 'concurrency', got %+v", matches)
+	}
+}
+
+func TestNoteHandler_FindNotesByKeyword_FuzzyInvalidThreshold(t *testing.T) {
+	// Arrange
+	router, _, _ := setupTest()
+
+	for _, threshold := range []string{"abc", "2"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/user-1/notes?keyword=go&fuzzy=true&threshold="+threshold, nil)
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for threshold %s; got %d", http.StatusBadRequest, threshold, rr.Code)
+		}
+	}
+}
+
+func TestNoteHandler_GetKeywordTree(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Concurrency", "user-1")
+	nc.TagNote(noteID, "user-1", "cs/os/concurrency", 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/keywords/tree", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var tree []*noteuc.KeywordNodeDTO
+	if err := json.NewDecoder(rr.Body).Decode(&tree); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(tree) != 1 || tree[0].Path != "cs" || tree[0].TotalCount != 1 {
+		t.Fatalf("expected a single 'cs' root counting 1 note, got %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Path != "cs/os" {
+		t.Errorf("expected 'cs/os' under 'cs', got %+v", tree[0].Children)
+	}
+}
+
+func TestNoteHandler_UntagNote_HierarchicalKeyword(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Test Title", "owner-1")
+	nc.TagNote(noteID, "user-1", "cs/os", 0)
+	body, _ := json.Marshal(UntagNoteRequest{NoteVersion: intPtr(1)})
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/user-1/notes/"+noteID+"/keyword/cs%2Fos", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected status %d; got %d", http.StatusNoContent, rr.Code)
+	}
+	note, _ := nc.GetNoteByID(noteID)
+	if len(note.Keywords["user-1"]) != 0 {
+		t.Errorf("expected 0 keywords for user-1, got %d", len(note.Keywords["user-1"]))
+	}
+}
+
+func TestNoteHandler_SuggestKeywords(t *testing.T) {
+	// Arrange
+	router, nc, _ := setupTest()
+	noteID, _ := nc.CreateNote("", "Test Title", "user-1")
+	nc.TagNote(noteID, "user-1", "concurrency", 0)
+	nc.TagNote(noteID, "user-1", "consensus", 1)
+	nc.TagNote(noteID, "user-1", "go", 2)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/keywords/suggest?prefix=con&limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var suggestions []*noteuc.KeywordSuggestionDTO
+	if err := json.NewDecoder(rr.Body).Decode(&suggestions); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+	}
+}
+
+func TestNoteHandler_SuggestKeywords_InvalidLimit(t *testing.T) {
+	// Arrange
+	router, _, _ := setupTest()
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/keywords/suggest?prefix=con&limit=abc", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"strings"
+	"testing"
+)
+
+func TestNoteHandler_RenderNote(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	noteID, _ := nuc.CreateNote("", "Plan <v2>", "owner-1")
+	markdownID, _ := cuc.CreateTextContent(noteID, "owner-1", "", "## Goals\n\n- *ship* it\n\n<img src=x onerror=alert(1)>", "markdown")
+	plainID, _ := cuc.CreateContent(noteID, "owner-1", "", "*literal* <b>", contentuc.TextContentType)
+	codeID, _ := cuc.CreateCodeContent(noteID, "owner-1", "", "return 1", "go")
+	// Contents are rendered in note order, not creation order.
+	nuc.AddContent(noteID, "owner-1", markdownID, -1, 0)
+	nuc.AddContent(noteID, "owner-1", codeID, -1, 1)
+	nuc.AddContent(noteID, "owner-1", plainID, 1, 2)
+
+	req := httptest.NewRequest(http.MethodGet, "/notes/"+noteID+"/render", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("expected an HTML response; got %s", got)
+	}
+	if rr.Header().Get("Content-Security-Policy") == "" {
+		t.Error("expected a Content-Security-Policy header")
+	}
+
+	rendered := rr.Body.String()
+	if strings.Contains(rendered, "onerror") || strings.Contains(rendered, "<b>") {
+		t.Errorf("expected unsafe markup to be removed or escaped; got %s", rendered)
+	}
+	fragments := []string{
+		"<h1>Plan &lt;v2&gt;</h1>",
+		"<h2>Goals</h2>",
+		"<li><em>ship</em> it</li>",
+		`<img src="x">`,
+		"<p>*literal* &lt;b&gt;</p>",
+		`<span class="hl-kw">return</span>`,
+	}
+	last := -1
+	for _, fragment := range fragments {
+		i := strings.Index(rendered, fragment)
+		if i < 0 {
+			t.Fatalf("expected rendered note to contain %q; got %s", fragment, rendered)
+		}
+		if i < last {
+			t.Errorf("expected %q to follow the previous contents; got %s", fragment, rendered)
+		}
+		last = i
+	}
+}
+
+func TestNoteHandler_RenderNote_NotFound(t *testing.T) {
+	// Arrange
+	router, _, _ := setupTest()
+	req := httptest.NewRequest(http.MethodGet, "/notes/missing/render", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestNoteHandler_AddAndUpdateContent_MarkdownFormat(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	noteID, _ := nuc.CreateNote("", "Notes", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{Type: "text", Data: "# Title", Format: "markdown", NoteVersion: intPtr(0), Index: intPtr(-1)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(rr.Body).Decode(&created)
+
+	plain := "plain"
+	body, _ = json.Marshal(UpdateContentRequest{Data: "# Title", Format: &plain, ContentVersion: intPtr(0)})
+	rr = httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/"+created.ID, bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	contentDTO, _ := cuc.GetContentByID(created.ID)
+	if contentDTO.Format != "" || contentDTO.Version != 1 {
+		t.Errorf("expected plain text at version 1; got format '%s' at version %d", contentDTO.Format, contentDTO.Version)
+	}
+}
+
+func TestNoteHandler_AddContent_UnsupportedTextFormat(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupTest()
+	noteID, _ := nuc.CreateNote("", "Notes", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{Type: "text", Data: "x", Format: "asciidoc", NoteVersion: intPtr(0), Index: intPtr(-1)})
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body)))
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"noteapp/internal/usecase/noteuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DuplicateNoteRequest represents the optional request body for duplicating a note. Without a
+// title, the duplicate is named after the original.
+type DuplicateNoteRequest struct {
+	Title        string `json:"title,omitempty"`
+	KeepKeywords bool   `json:"keep_keywords,omitempty"`
+}
+
+// DuplicateNote is the handler for the POST /notes/{id}/duplicate endpoint. The duplicate is
+// owned by the caller, who only needs to be able to view the original.
+func (h *NoteHandler) DuplicateNote(w http.ResponseWriter, r *http.Request) {
+	sourceID := chi.URLParam(r, "id")
+	userID := callerID(r)
+
+	var req DuplicateNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Create the new note first, then copy the contents into it, holding the blobs of the
+	// originals until the copies reference them too.
+	noteID, contentIDs, err := h.noteUsecase.DuplicateNote(sourceID, userID, req.Title, req.KeepKeywords)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	release := h.blobUsecase.HoldBlobs()
+	copyIDs, err := h.contentUsecase.CopyContents(contentIDs, noteID, userID)
+	release()
+	if err != nil {
+		h.discardNote(noteID)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if err := h.noteUsecase.AddContents(noteID, userID, copyIDs, -1, 0); err != nil {
+		for _, copyID := range copyIDs {
+			h.discardContent(copyID)
+		}
+		h.discardNote(noteID)
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if req.KeepKeywords {
+		h.refreshSavedSearches(userID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateNoteResponse{ID: noteID})
+}
+
+// discardNote deletes a new note that could not be completed.
+func (h *NoteHandler) discardNote(noteID string) {
+	noteDTO, err := h.noteUsecase.GetNoteByID(noteID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve note %s to discard: %v\n", noteID, err)
+		return
+	}
+	if err := h.noteUsecase.DeleteNote(noteID, noteDTO.Version); err != nil {
+		fmt.Printf("Warning: Could not discard note %s: %v\n", noteID, err)
+	}
+}
+
+// SplitNoteRequest represents the request body for splitting a note. The contents from Index
+// onwards move to a new note; without a title, it is named after the original.
+type SplitNoteRequest struct {
+	Index       *int   `json:"index"`
+	Title       string `json:"title,omitempty"`
+	NoteVersion *int   `json:"note_version"`
+}
+
+// SplitNoteResponse represents the response body for splitting a note, with the ID of the new
+// note and the contents moved to it.
+type SplitNoteResponse struct {
+	ID          string   `json:"id"`
+	ContentIDs  []string `json:"content_ids"`
+	NoteVersion int      `json:"note_version"`
+}
+
+// MergeNotesRequest represents the request body for merging other notes into a note, in order.
+type MergeNotesRequest struct {
+	Notes       []MergeNoteRequest `json:"notes"`
+	NoteVersion *int               `json:"note_version"`
+}
+
+// MergeNoteRequest identifies a note to merge, at the version the merge is based on.
+type MergeNoteRequest struct {
+	NoteID      string `json:"note_id"`
+	NoteVersion *int   `json:"note_version"`
+}
+
+// MergeNotesResponse represents the response body for merging notes.
+type MergeNotesResponse struct {
+	ContentIDs  []string `json:"content_ids"`
+	NoteVersion int      `json:"note_version"`
+}
+
+// SplitNote is the handler for the POST /notes/{id}/split endpoint. Both notes are saved
+// together; if the contents cannot be moved afterwards, the new note is merged back.
+func (h *NoteHandler) SplitNote(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	userID := callerID(r)
+
+	var req SplitNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Index == nil || req.NoteVersion == nil {
+		http.Error(w, "index and note_version are required", http.StatusBadRequest)
+		return
+	}
+
+	// First, split the content IDs off into the new note.
+	splitID, contentIDs, err := h.noteUsecase.SplitNote(noteID, userID, *req.Index, req.Title, *req.NoteVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Then, move the contents themselves, or merge the new note back.
+	if err := h.contentUsecase.MoveContents(contentIDs, splitID, userID); err != nil {
+		sources := []noteuc.MergeSourceDTO{{NoteID: splitID, Version: 0}}
+		if _, undoErr := h.noteUsecase.MergeNotes(noteID, userID, *req.NoteVersion+1, sources); undoErr != nil {
+			fmt.Printf("Warning: Could not merge note %s back into note %s: %v\n", splitID, noteID, undoErr)
+		}
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the split to all connected clients.
+	event := WebSocketEvent{
+		Type:         "split_note",
+		NoteID:       noteID,
+		TargetNoteID: splitID,
+		ContentIDs:   contentIDs,
+		NoteVersion:  *req.NoteVersion + 1,
+		Index:        *req.Index,
+	}
+	h.stampNoteEvent(&event, noteID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+	h.refreshSavedSearchesOfNote(splitID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(SplitNoteResponse{ID: splitID, ContentIDs: contentIDs, NoteVersion: *req.NoteVersion + 1})
+}
+
+// MergeNotes is the handler for the POST /notes/{id}/merge endpoint. The notes are checked
+// first, then the contents of the merged notes are moved; if the notes cannot be merged
+// afterwards, the contents are moved back.
+func (h *NoteHandler) MergeNotes(w http.ResponseWriter, r *http.Request) {
+	noteID := chi.URLParam(r, "id")
+	userID := callerID(r)
+
+	var req MergeNotesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.NoteVersion == nil || len(req.Notes) == 0 {
+		http.Error(w, "notes and note_version are required", http.StatusBadRequest)
+		return
+	}
+	sources := make([]noteuc.MergeSourceDTO, 0, len(req.Notes))
+	for _, source := range req.Notes {
+		if source.NoteID == "" || source.NoteVersion == nil {
+			http.Error(w, "note_id and note_version are required for each note", http.StatusBadRequest)
+			return
+		}
+		sources = append(sources, noteuc.MergeSourceDTO{NoteID: source.NoteID, Version: *source.NoteVersion})
+	}
+
+	// First, check the notes can be merged, so no content moves otherwise.
+	contentIDs, err := h.noteUsecase.CheckMerge(noteID, userID, *req.NoteVersion, sources)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	var moved []string
+	for _, source := range sources {
+		moved = append(moved, contentIDs[source.NoteID]...)
+	}
+
+	// Then, move the contents into the note.
+	if err := h.contentUsecase.MoveContents(moved, noteID, userID); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Finally, merge the notes, or move the contents back where they were if a note changed
+	// in the meantime.
+	order, err := h.noteUsecase.MergeNotes(noteID, userID, *req.NoteVersion, sources)
+	if err != nil {
+		for _, source := range sources {
+			if undoErr := h.contentUsecase.MoveContents(contentIDs[source.NoteID], source.NoteID, userID); undoErr != nil {
+				fmt.Printf("Warning: Could not move contents back to note %s: %v\n", source.NoteID, undoErr)
+			}
+		}
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	// Broadcast the merge to the clients of every note, and close the merged notes.
+	event := WebSocketEvent{
+		Type:        "merge_note",
+		NoteID:      noteID,
+		ContentIDs:  order,
+		NoteVersion: *req.NoteVersion + 1,
+	}
+	h.stampNoteEvent(&event, noteID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(noteID, message)
+
+	for _, source := range sources {
+		sourceEvent := WebSocketEvent{
+			Type:         "merge_note",
+			NoteID:       source.NoteID,
+			TargetNoteID: noteID,
+			NoteVersion:  source.Version + 1,
+		}
+		message, _ := json.Marshal(sourceEvent)
+		h.connManager.Broadcast(source.NoteID, message)
+		h.connManager.CloseById(source.NoteID)
+		h.broadcastMembershipChanges(h.savedSearchUsecase.RemoveNoteFromMemberships(source.NoteID))
+	}
+	h.refreshSavedSearchesOfNote(noteID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(MergeNotesResponse{ContentIDs: order, NoteVersion: *req.NoteVersion + 1})
+}
+
+// refreshSavedSearchesOfNote re-evaluates the saved searches of every user with keywords on a
+// note whose keywords were copied from or merged with other notes.
+func (h *NoteHandler) refreshSavedSearchesOfNote(noteID string) {
+	noteDTO, err := h.noteUsecase.GetNoteByID(noteID)
+	if err != nil {
+		fmt.Printf("Warning: Could not retrieve note %s to refresh saved searches: %v\n", noteID, err)
+		return
+	}
+	for userID := range noteDTO.Keywords {
+		h.refreshSavedSearches(userID)
+	}
+}
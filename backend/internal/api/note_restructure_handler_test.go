@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"reflect"
+	"testing"
+)
+
+func TestNoteHandler_DuplicateNote(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	sourceID, firstID, secondID := setUpNoteWithContents(nuc, cuc)
+	nuc.ShareNote(sourceID, "owner-1", "user-1", "read", 2)
+	nuc.TagNote(sourceID, "user-1", "go", 3)
+
+	// Act
+	body, _ := json.Marshal(DuplicateNoteRequest{KeepKeywords: true})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+sourceID+"/duplicate?user_id=user-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var resp CreateNoteResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	duplicate, err := nuc.GetNoteByID(resp.ID)
+	if err != nil {
+		t.Fatalf("expected the duplicate to exist; got %v", err)
+	}
+	if duplicate.OwnerID != "user-1" || duplicate.Title != "Test Title (copy)" || len(duplicate.Collaborators) != 0 {
+		t.Errorf("expected an unshared copy owned by user-1; got %+v", duplicate)
+	}
+	if len(duplicate.Keywords["user-1"]) != 1 {
+		t.Errorf("expected user-1's keywords to be kept; got %v", duplicate.Keywords)
+	}
+	if len(duplicate.ContentIDs) != 2 {
+		t.Fatalf("expected two contents; got %v", duplicate.ContentIDs)
+	}
+	for i, originalID := range []string{firstID, secondID} {
+		copyID := duplicate.ContentIDs[i]
+		original, _ := cuc.GetContentByID(originalID)
+		copied, _ := cuc.GetContentByID(copyID)
+		if copyID == originalID || copied.NoteID != resp.ID || copied.Data != original.Data {
+			t.Errorf("expected content %d to be a copy of %s in the duplicate; got %+v", i, originalID, copied)
+		}
+	}
+	if source, _ := nuc.GetNoteByID(sourceID); len(source.ContentIDs) != 2 || source.Version != 4 {
+		t.Errorf("expected the original to be unchanged; got %+v", source)
+	}
+}
+
+func TestNoteHandler_DuplicateNote_Errors(t *testing.T) {
+	router, nuc, cuc := setupTest()
+	sourceID, _, _ := setUpNoteWithContents(nuc, cuc)
+
+	tests := []struct {
+		name   string
+		noteID string
+		userID string
+		body   string
+		status int
+	}{
+		{"no body", sourceID, "owner-1", "", http.StatusCreated},
+		{"stranger", sourceID, "someone-else", "", http.StatusForbidden},
+		{"missing note", "missing-note", "owner-1", "", http.StatusNotFound},
+		{"invalid body", sourceID, "owner-1", "{", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+tt.noteID+"/duplicate?user_id="+tt.userID, bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("expected status %d; got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestNoteHandler_SplitNote_Broadcast(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, firstID, secondID := setUpNoteWithContents(nuc, cuc)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	conn := dialNote(t, server, noteID)
+	defer conn.Close()
+
+	// Act
+	body, _ := json.Marshal(SplitNoteRequest{Index: intPtr(1), Title: "Second half", NoteVersion: intPtr(2)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/split?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var resp SplitNoteResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if !reflect.DeepEqual(resp.ContentIDs, []string{secondID}) || resp.NoteVersion != 3 {
+		t.Errorf("expected %s to be split off at version 3; got %+v", secondID, resp)
+	}
+	source, _ := nuc.GetNoteByID(noteID)
+	split, _ := nuc.GetNoteByID(resp.ID)
+	if !reflect.DeepEqual(source.ContentIDs, []string{firstID}) || split.Title != "Second half" || !reflect.DeepEqual(split.ContentIDs, []string{secondID}) {
+		t.Errorf("expected the contents to be divided; got %v and %+v", source.ContentIDs, split)
+	}
+	if moved, _ := cuc.GetContentByID(secondID); moved.NoteID != resp.ID {
+		t.Errorf("expected the content to belong to the new note; got %s", moved.NoteID)
+	}
+
+	event := readEvent(t, conn)
+	if event.Type != "split_note" || event.TargetNoteID != resp.ID || !reflect.DeepEqual(event.ContentIDs, []string{secondID}) || event.NoteVersion != 3 {
+		t.Errorf("expected a split_note event to the new note at version 3; got %+v", event)
+	}
+}
+
+func TestNoteHandler_MergeNotes_Broadcast(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	targetID, firstID, secondID := setUpNoteWithContents(nuc, cuc)
+	sourceID, _ := nuc.CreateNote("", "Source", "owner-1")
+	thirdID, _ := cuc.CreateContent(sourceID, "owner-1", "", "Third", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "owner-1", thirdID, -1, 0)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	targetConn := dialNote(t, server, targetID)
+	defer targetConn.Close()
+	sourceConn := dialNote(t, server, sourceID)
+	defer sourceConn.Close()
+
+	// Act
+	body, _ := json.Marshal(MergeNotesRequest{Notes: []MergeNoteRequest{{NoteID: sourceID, NoteVersion: intPtr(1)}}, NoteVersion: intPtr(2)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+targetID+"/merge?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	want := []string{firstID, secondID, thirdID}
+	var resp MergeNotesResponse
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if !reflect.DeepEqual(resp.ContentIDs, want) || resp.NoteVersion != 3 {
+		t.Errorf("expected %v at version 3; got %+v", want, resp)
+	}
+	if _, err := nuc.GetNoteByID(sourceID); err == nil {
+		t.Errorf("expected the merged note to be deleted")
+	}
+	if moved, _ := cuc.GetContentByID(thirdID); moved.NoteID != targetID {
+		t.Errorf("expected the content to belong to the target; got %s", moved.NoteID)
+	}
+
+	targetEvent := readEvent(t, targetConn)
+	if targetEvent.Type != "merge_note" || !reflect.DeepEqual(targetEvent.ContentIDs, want) || targetEvent.NoteVersion != 3 {
+		t.Errorf("expected a merge_note event with the new order at version 3; got %+v", targetEvent)
+	}
+	sourceEvent := readEvent(t, sourceConn)
+	if sourceEvent.Type != "merge_note" || sourceEvent.NoteID != sourceID || sourceEvent.TargetNoteID != targetID {
+		t.Errorf("expected a merge_note event pointing to the target; got %+v", sourceEvent)
+	}
+}
+
+func TestNoteHandler_MergeNotes_DoesNotMoveContentsWithoutAccess(t *testing.T) {
+	// Arrange
+	router, nuc, cuc := setupTest()
+	targetID, _, _ := setUpNoteWithContents(nuc, cuc)
+	sourceID, _ := nuc.CreateNote("", "Source", "someone-else")
+	contentID, _ := cuc.CreateContent(sourceID, "someone-else", "", "Private", contentuc.TextContentType)
+	nuc.AddContent(sourceID, "someone-else", contentID, -1, 0)
+
+	// Act
+	body, _ := json.Marshal(MergeNotesRequest{Notes: []MergeNoteRequest{{NoteID: sourceID, NoteVersion: intPtr(1)}}, NoteVersion: intPtr(2)})
+	req := httptest.NewRequest(http.MethodPost, "/notes/"+targetID+"/merge?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusForbidden, rr.Code, rr.Body.String())
+	}
+	if source, err := nuc.GetNoteByID(sourceID); err != nil || len(source.ContentIDs) != 1 {
+		t.Errorf("expected the note to be kept with its content; got %+v, %v", source, err)
+	}
+	if content, _ := cuc.GetContentByID(contentID); content.NoteID != sourceID {
+		t.Errorf("expected the content to stay in its note; got note %s", content.NoteID)
+	}
+}
+
+func TestNoteHandler_SplitAndMerge_BadRequests(t *testing.T) {
+	router, nuc, cuc := setupTest()
+	noteID, _, _ := setUpNoteWithContents(nuc, cuc)
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+	}{
+		{"split without index", "/split", `{"note_version":2}`, http.StatusBadRequest},
+		{"split at the start", "/split", `{"index":0,"note_version":2}`, http.StatusBadRequest},
+		{"split a stale note", "/split", `{"index":1,"note_version":1}`, http.StatusConflict},
+		{"merge without notes", "/merge", `{"note_version":2}`, http.StatusBadRequest},
+		{"merge without version", "/merge", `{"notes":[{"note_id":"other"}],"note_version":2}`, http.StatusBadRequest},
+		{"merge a missing note", "/merge", `{"notes":[{"note_id":"missing","note_version":0}],"note_version":2}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+noteID+tt.path+"?user_id=owner-1", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("expected status %d; got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"noteapp/internal/usecase/reminderuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReminderHandler handles HTTP requests for reminders on notes. It also delivers fired
+// reminders as reminder events on the user's sync channel, through the note handler.
+type ReminderHandler struct {
+	notes           *NoteHandler
+	reminderUsecase *reminderuc.ReminderUsecase
+}
+
+// NewReminderHandler creates a new ReminderHandler.
+func NewReminderHandler(notes *NoteHandler, ruc *reminderuc.ReminderUsecase) *ReminderHandler {
+	return &ReminderHandler{notes: notes, reminderUsecase: ruc}
+}
+
+// CreateReminderRequest represents the request body for setting a reminder on a note.
+type CreateReminderRequest struct {
+	reminderuc.ReminderInputDTO
+}
+
+// CreateReminderResponse represents the response body for setting a reminder on a note.
+type CreateReminderResponse struct {
+	ID string `json:"id"`
+}
+
+// UpdateReminderRequest represents the request body for updating a reminder.
+type UpdateReminderRequest struct {
+	reminderuc.ReminderInputDTO
+	Version *int `json:"version"`
+}
+
+// DeleteReminderRequest represents the request body for deleting a reminder.
+type DeleteReminderRequest struct {
+	Version *int `json:"version"`
+}
+
+// CreateReminder is the handler for the POST /users/{userID}/notes/{noteID}/reminders endpoint.
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	noteID := chi.URLParam(r, "noteID")
+
+	var req CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.reminderUsecase.CreateReminder(noteID, userID, &req.ReminderInputDTO)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/users/%s/reminders/%s", userID, id))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateReminderResponse{ID: id})
+}
+
+// ListReminders is the handler for the GET /users/{userID}/reminders endpoint. The optional
+// note query parameter restricts the reminders to those on a note.
+func (h *ReminderHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	reminders, err := h.reminderUsecase.ListReminders(chi.URLParam(r, "userID"), r.URL.Query().Get("note"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reminders)
+}
+
+// GetReminder is the handler for the GET /users/{userID}/reminders/{reminderID} endpoint.
+func (h *ReminderHandler) GetReminder(w http.ResponseWriter, r *http.Request) {
+	reminder, err := h.reminderUsecase.GetReminder(chi.URLParam(r, "reminderID"), chi.URLParam(r, "userID"))
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reminder)
+}
+
+// UpdateReminder is the handler for the PUT /users/{userID}/reminders/{reminderID} endpoint.
+func (h *ReminderHandler) UpdateReminder(w http.ResponseWriter, r *http.Request) {
+	var req UpdateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.reminderUsecase.UpdateReminder(chi.URLParam(r, "reminderID"), chi.URLParam(r, "userID"), &req.ReminderInputDTO, *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteReminder is the handler for the DELETE /users/{userID}/reminders/{reminderID} endpoint.
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	var req DeleteReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.reminderUsecase.DeleteReminder(chi.URLParam(r, "reminderID"), chi.URLParam(r, "userID"), *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Notify delivers a fired reminder as a reminder event to the sync channel of its user, so the
+// handler can serve as a notifier of the reminder scheduler. Users who are not connected miss
+// the event, but still see when the reminder last fired in their reminders.
+func (h *ReminderHandler) Notify(reminder *reminderuc.FiredReminderDTO) error {
+	event := WebSocketEvent{
+		Type:     "reminder",
+		NoteID:   reminder.NoteID,
+		Reminder: reminder,
+	}
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	h.notes.userConnManager.Broadcast(reminder.UserID, message)
+	return nil
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/reminderrepo"
+	"noteapp/internal/scheduler"
+	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/reminderuc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+// setupReminderTest initializes a router with the reminder endpoints and the user sync
+// channel, and a scheduler delivering to it, with the clock at 2024-03-04 08:00 UTC.
+func setupReminderTest() (*chi.Mux, *noteuc.NoteUsecase, *scheduler.Scheduler, *clock.FakeClock) {
+	ht := newHandlerTest(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC))
+	ruc := reminderuc.NewReminderUsecaseWithClock(reminderrepo.NewInMemoryReminderRepository(), ht.noteRepo, ht.clock)
+	noteHandler := ht.handler
+	handler := NewReminderHandler(noteHandler, ruc)
+	router := ht.router
+
+	router.Post("/users/{userID}/notes/{noteID}/reminders", handler.CreateReminder)
+	router.Get("/users/{userID}/reminders", handler.ListReminders)
+	router.Get("/users/{userID}/reminders/{reminderID}", handler.GetReminder)
+	router.Put("/users/{userID}/reminders/{reminderID}", handler.UpdateReminder)
+	router.Delete("/users/{userID}/reminders/{reminderID}", handler.DeleteReminder)
+	router.Get("/users/{userID}/ws", noteHandler.HandleUserWebSocket)
+	return router, ht.nuc, scheduler.NewScheduler(ruc, time.Minute, handler), ht.clock
+}
+
+func createReminder(t *testing.T, router *chi.Mux, userID, noteID string, input reminderuc.ReminderInputDTO) string {
+	t.Helper()
+	body, _ := json.Marshal(CreateReminderRequest{ReminderInputDTO: input})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/users/"+userID+"/notes/"+noteID+"/reminders", bytes.NewBuffer(body)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var resp CreateReminderResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	return resp.ID
+}
+
+func TestReminderHandler_CreateAndGet(t *testing.T) {
+	// Arrange
+	router, nuc, _, _ := setupReminderTest()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	id := createReminder(t, router, "user-1", noteID, reminderuc.ReminderInputDTO{
+		DueAt:      "2024-03-04T09:30",
+		TimeZone:   "Europe/Paris",
+		Recurrence: &reminderuc.RecurrenceDTO{Frequency: "weekly", Interval: 2},
+		Message:    "Prepare the demo",
+	})
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/reminders/"+id, nil))
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var reminder reminderuc.ReminderDTO
+	if err := json.NewDecoder(rr.Body).Decode(&reminder); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if reminder.NoteID != noteID || reminder.DueAt != "2024-03-04T09:30" || reminder.Recurrence == nil || reminder.Recurrence.Interval != 2 {
+		t.Errorf("expected the reminder as created; got %+v", reminder)
+	}
+	if want := time.Date(2024, 3, 4, 8, 30, 0, 0, time.UTC); reminder.NextDueAt == nil || !reminder.NextDueAt.Equal(want) {
+		t.Errorf("expected the reminder next due at %v; got %v", want, reminder.NextDueAt)
+	}
+}
+
+func TestReminderHandler_Errors(t *testing.T) {
+	router, nuc, _, _ := setupReminderTest()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	id := createReminder(t, router, "user-1", noteID, reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:30"})
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       interface{}
+		wantStatus int
+	}{
+		{"reminder on a missing note", http.MethodPost, "/users/user-1/notes/missing/reminders", reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:30"}, http.StatusNotFound},
+		{"reminder on another user's note", http.MethodPost, "/users/user-2/notes/" + noteID + "/reminders", reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:30"}, http.StatusForbidden},
+		{"invalid due time", http.MethodPost, "/users/user-1/notes/" + noteID + "/reminders", reminderuc.ReminderInputDTO{DueAt: "soon"}, http.StatusBadRequest},
+		{"due time far in the past", http.MethodPost, "/users/user-1/notes/" + noteID + "/reminders", reminderuc.ReminderInputDTO{DueAt: "2020-03-04T09:30"}, http.StatusBadRequest},
+		{"another user's reminder", http.MethodGet, "/users/user-2/reminders/" + id, nil, http.StatusForbidden},
+		{"update without version", http.MethodPut, "/users/user-1/reminders/" + id, UpdateReminderRequest{ReminderInputDTO: reminderuc.ReminderInputDTO{DueAt: "2024-03-04T10:00"}}, http.StatusBadRequest},
+		{"update", http.MethodPut, "/users/user-1/reminders/" + id, UpdateReminderRequest{ReminderInputDTO: reminderuc.ReminderInputDTO{DueAt: "2024-03-04T10:00"}, Version: intPtr(0)}, http.StatusOK},
+		{"stale update", http.MethodPut, "/users/user-1/reminders/" + id, UpdateReminderRequest{ReminderInputDTO: reminderuc.ReminderInputDTO{DueAt: "2024-03-04T11:00"}, Version: intPtr(0)}, http.StatusConflict},
+		{"delete", http.MethodDelete, "/users/user-1/reminders/" + id, DeleteReminderRequest{Version: intPtr(1)}, http.StatusNoContent},
+		{"deleted reminder", http.MethodGet, "/users/user-1/reminders/" + id, nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			if tt.body != nil {
+				json.NewEncoder(&body).Encode(tt.body)
+			}
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.url, &body))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d; got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestReminderHandler_ListReminders(t *testing.T) {
+	// Arrange
+	router, nuc, _, _ := setupReminderTest()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	otherID, _ := nuc.CreateNote("", "Taxes", "user-1")
+	later := createReminder(t, router, "user-1", noteID, reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00"})
+	sooner := createReminder(t, router, "user-1", otherID, reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:00"})
+
+	for _, tt := range []struct {
+		query   string
+		wantIDs []string
+	}{
+		{"", []string{sooner, later}},
+		{"?note=" + noteID, []string{later}},
+	} {
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/reminders"+tt.query, nil))
+
+		// Assert
+		var reminders []*reminderuc.ReminderDTO
+		if err := json.NewDecoder(rr.Body).Decode(&reminders); err != nil {
+			t.Fatalf("failed to decode response body: %v", err)
+		}
+		var ids []string
+		for _, reminder := range reminders {
+			ids = append(ids, reminder.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+			t.Errorf("expected %v for '%s'; got %v", tt.wantIDs, tt.query, ids)
+		}
+	}
+}
+
+func TestReminderHandler_DeliversFiredRemindersOnUserChannel(t *testing.T) {
+	// Arrange
+	router, nuc, s, c := setupReminderTest()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	id := createReminder(t, router, "user-1", noteID, reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:00", Message: "Join the call"})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/users/user-1/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	// Act
+	c.Advance(time.Hour)
+	s.Tick()
+	event := readEvent(t, conn)
+
+	// Assert
+	if event.Type != "reminder" || event.NoteID != noteID || event.Reminder == nil {
+		t.Fatalf("expected a reminder event for the note; got %+v", event)
+	}
+	if event.Reminder.ReminderID != id || event.Reminder.Message != "Join the call" || event.Reminder.NoteTitle != "Standup" {
+		t.Errorf("expected the fired reminder in the event; got %+v", event.Reminder)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/user-1/reminders/"+id, nil))
+	var reminder reminderuc.ReminderDTO
+	json.NewDecoder(rr.Body).Decode(&reminder)
+	if reminder.NextDueAt != nil || reminder.LastFiredAt == nil {
+		t.Errorf("expected the reminder recorded as fired; got %+v", reminder)
+	}
+}
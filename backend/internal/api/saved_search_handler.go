@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"noteapp/internal/usecase/savedsearchuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SavedSearchHandler handles HTTP requests for saved searches.
+type SavedSearchHandler struct {
+	savedSearchUsecase *savedsearchuc.SavedSearchUsecase
+}
+
+// NewSavedSearchHandler creates a new SavedSearchHandler.
+func NewSavedSearchHandler(suc *savedsearchuc.SavedSearchUsecase) *SavedSearchHandler {
+	return &SavedSearchHandler{savedSearchUsecase: suc}
+}
+
+// CreateSavedSearchRequest represents the request body for creating a saved search.
+type CreateSavedSearchRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Sort  string `json:"sort"`
+}
+
+// CreateSavedSearchResponse represents the response body for creating a saved search.
+type CreateSavedSearchResponse struct {
+	ID string `json:"id"`
+}
+
+// UpdateSavedSearchRequest represents the request body for updating a saved search.
+type UpdateSavedSearchRequest struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Sort    string `json:"sort"`
+	Version *int   `json:"version"`
+}
+
+// DeleteSavedSearchRequest represents the request body for deleting a saved search.
+type DeleteSavedSearchRequest struct {
+	Version *int `json:"version"`
+}
+
+// CreateSavedSearch is the handler for the POST /users/{userID}/saved-searches endpoint.
+func (h *SavedSearchHandler) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	var req CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.savedSearchUsecase.CreateSavedSearch(userID, req.Name, req.Query, req.Sort)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateSavedSearchResponse{ID: id})
+}
+
+// ListSavedSearches is the handler for the GET /users/{userID}/saved-searches endpoint.
+func (h *SavedSearchHandler) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+
+	searches, err := h.savedSearchUsecase.ListSavedSearches(userID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(searches)
+}
+
+// GetSavedSearch is the handler for the GET /users/{userID}/saved-searches/{searchID} endpoint.
+func (h *SavedSearchHandler) GetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	searchID := chi.URLParam(r, "searchID")
+
+	search, err := h.savedSearchUsecase.GetSavedSearch(searchID, userID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(search)
+}
+
+// UpdateSavedSearch is the handler for the PUT /users/{userID}/saved-searches/{searchID} endpoint.
+func (h *SavedSearchHandler) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	searchID := chi.URLParam(r, "searchID")
+
+	var req UpdateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.savedSearchUsecase.UpdateSavedSearch(searchID, userID, req.Name, req.Query, req.Sort, *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteSavedSearch is the handler for the DELETE /users/{userID}/saved-searches/{searchID} endpoint.
+func (h *SavedSearchHandler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	searchID := chi.URLParam(r, "searchID")
+
+	var req DeleteSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.savedSearchUsecase.DeleteSavedSearch(searchID, userID, *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EvaluateSavedSearch is the handler for the GET /users/{userID}/saved-searches/{searchID}/notes endpoint.
+// It runs the saved search live and returns the matching notes in the search's sort order.
+func (h *SavedSearchHandler) EvaluateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	searchID := chi.URLParam(r, "searchID")
+
+	notes, err := h.savedSearchUsecase.EvaluateSavedSearch(searchID, userID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(notes)
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/repository/dailyrepo"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/savedsearchuc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+// setupSavedSearchTest initializes a router with the saved search endpoints and the
+// note endpoints that change saved search membership, backed by shared repositories.
+func setupSavedSearchTest() (*chi.Mux, *noteuc.NoteUsecase, *savedsearchuc.SavedSearchUsecase) {
+	ht := newHandlerTest(time.Now())
+	noteHandler := ht.handler
+	dailyHandler := NewDailyNoteHandler(noteHandler, dailyuc.NewDailyUsecaseWithClock(dailyrepo.NewInMemoryDailyNoteRepository(), ht.noteRepo, ht.clock))
+	handler := NewSavedSearchHandler(ht.suc)
+	router := ht.router
+
+	router.Post("/users/{userID}/saved-searches", handler.CreateSavedSearch)
+	router.Get("/users/{userID}/saved-searches", handler.ListSavedSearches)
+	router.Get("/users/{userID}/saved-searches/{searchID}", handler.GetSavedSearch)
+	router.Put("/users/{userID}/saved-searches/{searchID}", handler.UpdateSavedSearch)
+	router.Delete("/users/{userID}/saved-searches/{searchID}", handler.DeleteSavedSearch)
+	router.Get("/users/{userID}/saved-searches/{searchID}/notes", handler.EvaluateSavedSearch)
+	router.Post("/users/{userID}/notes/{noteID}/keyword", noteHandler.TagNote)
+	router.Delete("/users/{userID}/notes/{noteID}/keyword/{keyword}", noteHandler.UntagNote)
+	router.Post("/notes/{id}/duplicate", noteHandler.DuplicateNote)
+	router.Post("/notes/{id}/split", noteHandler.SplitNote)
+	router.Post("/notes/{id}/merge", noteHandler.MergeNotes)
+	router.Get("/users/{userID}/daily/{date}", dailyHandler.GetDailyNote)
+	router.Get("/users/{userID}/ws", noteHandler.HandleUserWebSocket)
+	return router, ht.nuc, ht.suc
+}
+
+func TestSavedSearchHandler_CreateAndEvaluateSavedSearch(t *testing.T) {
+	// Arrange
+	router, nuc, _ := setupSavedSearchTest()
+	n1, _ := nuc.CreateNote("", "Beta", "user-1")
+	nuc.TagNote(n1, "user-1", "go", 0)
+	n2, _ := nuc.CreateNote("", "Alpha", "user-1")
+	nuc.TagNote(n2, "user-1", "go/generics", 0)
+	n3, _ := nuc.CreateNote("", "Gamma", "user-1")
+	nuc.TagNote(n3, "user-1", "rust", 0)
+
+	body, _ := json.Marshal(CreateSavedSearchRequest{Name: "Go", Query: "go/*"})
+	createReq := httptest.NewRequest(http.MethodPost, "/users/user-1/saved-searches", bytes.NewBuffer(body))
+	createRR := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(createRR, createReq)
+
+	// Assert
+	if createRR.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, createRR.Code)
+	}
+	var created CreateSavedSearchResponse
+	if err := json.NewDecoder(createRR.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-1/saved-searches/"+created.ID+"/notes", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+	var notes []*savedsearchuc.SavedSearchNoteDTO
+	if err := json.NewDecoder(rr.Body).Decode(&notes); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(notes) != 2 || notes[0].ID != n2 || notes[1].ID != n1 {
+		t.Errorf("expected notes [Alpha Beta], got %+v", notes)
+	}
+}
+
+func TestSavedSearchHandler_CreateSavedSearch_InvalidQuery(t *testing.T) {
+	// Arrange
+	router, _, _ := setupSavedSearchTest()
+	body, _ := json.Marshal(CreateSavedSearchRequest{Name: "Broken", Query: "a//b"})
+	req := httptest.NewRequest(http.MethodPost, "/users/user-1/saved-searches", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestSavedSearchHandler_GetSavedSearch_Forbidden(t *testing.T) {
+	// Arrange
+	router, _, suc := setupSavedSearchTest()
+	id, _ := suc.CreateSavedSearch("user-1", "Go", "go", "")
+	req := httptest.NewRequest(http.MethodGet, "/users/user-2/saved-searches/"+id, nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status %d; got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestSavedSearchHandler_UpdateAndListSavedSearches(t *testing.T) {
+	// Arrange
+	router, _, suc := setupSavedSearchTest()
+	id, _ := suc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	conflictBody, _ := json.Marshal(UpdateSavedSearchRequest{Name: "Rust", Query: "rust", Version: intPtr(3)})
+	conflictReq := httptest.NewRequest(http.MethodPut, "/users/user-1/saved-searches/"+id, bytes.NewBuffer(conflictBody))
+	conflictRR := httptest.NewRecorder()
+	body, _ := json.Marshal(UpdateSavedSearchRequest{Name: "Rust", Query: "rust", Sort: "title_desc", Version: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPut, "/users/user-1/saved-searches/"+id, bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(conflictRR, conflictReq)
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if conflictRR.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a stale version; got %d", http.StatusConflict, conflictRR.Code)
+	}
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, rr.Code)
+	}
+
+	listReq := httptest.NewRequest(http.MethodGet, "/users/user-1/saved-searches", nil)
+	listRR := httptest.NewRecorder()
+	router.ServeHTTP(listRR, listReq)
+
+	var searches []*savedsearchuc.SavedSearchDTO
+	if err := json.NewDecoder(listRR.Body).Decode(&searches); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if len(searches) != 1 || searches[0].Name != "Rust" || searches[0].Sort != "title_desc" || searches[0].Version != 1 {
+		t.Errorf("unexpected saved searches after update: %+v", searches)
+	}
+}
+
+func TestSavedSearchHandler_DeleteSavedSearch(t *testing.T) {
+	// Arrange
+	router, _, suc := setupSavedSearchTest()
+	id, _ := suc.CreateSavedSearch("user-1", "Go", "go", "")
+	body, _ := json.Marshal(DeleteSavedSearchRequest{Version: intPtr(0)})
+	req := httptest.NewRequest(http.MethodDelete, "/users/user-1/saved-searches/"+id, bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d; got %d", http.StatusNoContent, rr.Code)
+	}
+	getReq := httptest.NewRequest(http.MethodGet, "/users/user-1/saved-searches/"+id, nil)
+	getRR := httptest.NewRecorder()
+	router.ServeHTTP(getRR, getReq)
+	if getRR.Code != http.StatusNotFound {
+		t.Errorf("expected status %d after delete; got %d", http.StatusNotFound, getRR.Code)
+	}
+}
+
+func TestSavedSearchHandler_WebSocket_PushesMembershipChanges(t *testing.T) {
+	// Arrange
+	router, nuc, suc := setupSavedSearchTest()
+	noteID, _ := nuc.CreateNote("", "Generics", "user-1")
+	searchID, _ := suc.CreateSavedSearch("user-1", "Go", "go/*", "")
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/users/user-1/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	readEvent := func() WebSocketEvent {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		var event WebSocketEvent
+		if err := json.Unmarshal(msg, &event); err != nil {
+			t.Fatalf("failed to unmarshal event: %v", err)
+		}
+		return event
+	}
+
+	// Act
+	tagBody, _ := json.Marshal(TagNoteRequest{Keyword: "go/generics", NoteVersion: intPtr(0)})
+	tagRR := httptest.NewRecorder()
+	router.ServeHTTP(tagRR, httptest.NewRequest(http.MethodPost, "/users/user-1/notes/"+noteID+"/keyword", bytes.NewBuffer(tagBody)))
+	added := readEvent()
+
+	untagBody, _ := json.Marshal(UntagNoteRequest{NoteVersion: intPtr(1)})
+	untagRR := httptest.NewRecorder()
+	router.ServeHTTP(untagRR, httptest.NewRequest(http.MethodDelete, "/users/user-1/notes/"+noteID+"/keyword/go%2Fgenerics", bytes.NewBuffer(untagBody)))
+	removed := readEvent()
+
+	// Assert
+	if tagRR.Code != http.StatusCreated || untagRR.Code != http.StatusNoContent {
+		t.Fatalf("failed to tag and untag note: status %d, %d", tagRR.Code, untagRR.Code)
+	}
+	if added.Type != "saved_search_note_added" || added.NoteID != noteID || added.SavedSearchID != searchID {
+		t.Errorf("expected a saved_search_note_added event for the note, got %+v", added)
+	}
+	if removed.Type != "saved_search_note_removed" || removed.NoteID != noteID || removed.SavedSearchID != searchID {
+		t.Errorf("expected a saved_search_note_removed event for the note, got %+v", removed)
+	}
+}
+
+func TestSavedSearchHandler_RefreshesMembershipsAfterCopyingKeywords(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		path  func(nuc *noteuc.NoteUsecase) (string, string)
+	}{
+		{"daily note", "daily/*", func(nuc *noteuc.NoteUsecase) (string, string) {
+			return "/users/user-1/daily/2024-03-04", ""
+		}},
+		{"duplicate keeping keywords", "go", func(nuc *noteuc.NoteUsecase) (string, string) {
+			noteID, _ := nuc.CreateNote("", "Go", "user-1")
+			nuc.TagNote(noteID, "user-1", "go", 0)
+			return "/notes/" + noteID + "/duplicate?user_id=user-1", `{"keep_keywords":true}`
+		}},
+		{"split", "go", func(nuc *noteuc.NoteUsecase) (string, string) {
+			noteID, _ := nuc.CreateNote("", "Go", "user-1")
+			nuc.AddContents(noteID, "user-1", []string{"c1", "c2"}, -1, 0)
+			nuc.TagNote(noteID, "user-1", "go", 1)
+			return "/notes/" + noteID + "/split?user_id=user-1", `{"index":1,"note_version":2}`
+		}},
+		{"merge", "go", func(nuc *noteuc.NoteUsecase) (string, string) {
+			noteID, _ := nuc.CreateNote("", "Target", "user-1")
+			sourceID, _ := nuc.CreateNote("", "Go", "user-1")
+			nuc.TagNote(sourceID, "user-1", "go", 0)
+			return "/notes/" + noteID + "/merge?user_id=user-1", `{"notes":[{"note_id":"` + sourceID + `","note_version":1}],"note_version":0}`
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router, nuc, suc := setupSavedSearchTest()
+			suc.CreateSavedSearch("user-1", "Search", tt.query, "")
+			path, body := tt.path(nuc)
+			suc.RefreshMemberships("user-1")
+			method := http.MethodPost
+			if body == "" {
+				method = http.MethodGet
+			}
+
+			// Act
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+
+			// Assert
+			if rr.Code >= 300 {
+				t.Fatalf("expected a successful status; got %d: %s", rr.Code, rr.Body.String())
+			}
+			if changes, _ := suc.RefreshMemberships("user-1"); len(changes) != 0 {
+				t.Errorf("expected the saved searches to be refreshed already; got %d changes", len(changes))
+			}
+		})
+	}
+}
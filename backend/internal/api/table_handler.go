@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// UpdateTableCellRequest represents the request body for updating a table cell. ContentVersion
+// is the version of the content the edit is based on.
+type UpdateTableCellRequest struct {
+	Value          string `json:"value"`
+	ContentVersion *int   `json:"content_version"`
+}
+
+// InsertTableRowRequest represents the request body for inserting a row into a table.
+// Cells maps column IDs to the initial values of the row's cells.
+type InsertTableRowRequest struct {
+	Cells          map[string]string `json:"cells,omitempty"`
+	Index          *int              `json:"index,omitempty"`
+	ContentVersion *int              `json:"content_version"`
+}
+
+// InsertTableColumnRequest represents the request body for inserting a column into a table.
+type InsertTableColumnRequest struct {
+	Name           string `json:"name"`
+	Index          *int   `json:"index,omitempty"`
+	ContentVersion *int   `json:"content_version"`
+}
+
+// TableChangeRequest represents the request body for deleting a table row or column.
+type TableChangeRequest struct {
+	ContentVersion *int `json:"content_version"`
+}
+
+// UpdateTableCell is the handler for the PUT /notes/{id}/contents/{contentId}/rows/{rowId}/cells/{columnId} endpoint.
+func (h *NoteHandler) UpdateTableCell(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTableCellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+
+	cell, err := h.contentUsecase.UpdateTableCell(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "rowId"), chi.URLParam(r, "columnId"), req.Value, *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	// The cell carries the new version of the content, which may be later than the base version plus one.
+	h.broadcastTableEvent(r, WebSocketEvent{Type: "update_table_cell", Cell: cell}, cell.Version-1)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(cell)
+}
+
+// InsertTableRow is the handler for the POST /notes/{id}/contents/{contentId}/rows endpoint.
+func (h *NoteHandler) InsertTableRow(w http.ResponseWriter, r *http.Request) {
+	var req InsertTableRowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+	index := -1
+	if req.Index != nil {
+		index = *req.Index
+	}
+
+	row, err := h.contentUsecase.InsertTableRow(chi.URLParam(r, "contentId"), callerID(r), index, req.Cells, *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastTableEvent(r, WebSocketEvent{Type: "insert_table_row", Index: index, Row: row}, *req.ContentVersion)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(row)
+}
+
+// DeleteTableRow is the handler for the DELETE /notes/{id}/contents/{contentId}/rows/{rowId} endpoint.
+func (h *NoteHandler) DeleteTableRow(w http.ResponseWriter, r *http.Request) {
+	var req TableChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+
+	row, err := h.contentUsecase.DeleteTableRow(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "rowId"), *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastTableEvent(r, WebSocketEvent{Type: "delete_table_row", Row: row}, *req.ContentVersion)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// InsertTableColumn is the handler for the POST /notes/{id}/contents/{contentId}/columns endpoint.
+func (h *NoteHandler) InsertTableColumn(w http.ResponseWriter, r *http.Request) {
+	var req InsertTableColumnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+	index := -1
+	if req.Index != nil {
+		index = *req.Index
+	}
+
+	column, err := h.contentUsecase.InsertTableColumn(chi.URLParam(r, "contentId"), callerID(r), req.Name, index, *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastTableEvent(r, WebSocketEvent{Type: "insert_table_column", Index: index, Column: column}, *req.ContentVersion)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(column)
+}
+
+// DeleteTableColumn is the handler for the DELETE /notes/{id}/contents/{contentId}/columns/{columnId} endpoint.
+func (h *NoteHandler) DeleteTableColumn(w http.ResponseWriter, r *http.Request) {
+	var req TableChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ContentVersion == nil {
+		http.Error(w, "content_version is required", http.StatusBadRequest)
+		return
+	}
+
+	column, err := h.contentUsecase.DeleteTableColumn(chi.URLParam(r, "contentId"), callerID(r), chi.URLParam(r, "columnId"), *req.ContentVersion)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	h.broadcastTableEvent(r, WebSocketEvent{Type: "delete_table_column", Column: column}, *req.ContentVersion)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// broadcastTableEvent sends a table change, made on top of contentVersion, to the clients connected to the note.
+func (h *NoteHandler) broadcastTableEvent(r *http.Request, event WebSocketEvent, contentVersion int) {
+	event.NoteID = chi.URLParam(r, "id")
+	event.ContentID = chi.URLParam(r, "contentId")
+	event.ContentVersion = contentVersion + 1
+	h.stampContentEvent(&event, event.ContentID)
+	message, _ := json.Marshal(event)
+	h.connManager.Broadcast(event.NoteID, message)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/contentuc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const testTableData = `{"columns":[{"id":"plan","name":"Plan"},{"id":"price","name":"Price"}],"rows":[{"id":"r1"}]}`
+
+func TestNoteHandler_TableRowsAndColumns(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Pricing", "owner-1")
+	body, _ := json.Marshal(AddContentRequest{Type: "table", Data: testTableData, NoteVersion: intPtr(0), Index: intPtr(-1)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes/"+noteID+"/contents", bytes.NewBuffer(body)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(rr.Body).Decode(&created)
+	contentURL := "/notes/" + noteID + "/contents/" + created.ID
+
+	serve := func(method, target string, body any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, target, bytes.NewBuffer(data)))
+		return rr
+	}
+
+	// Act
+	columnRR := serve(http.MethodPost, contentURL+"/columns", InsertTableColumnRequest{Name: "Seats", Index: intPtr(1), ContentVersion: intPtr(0)})
+	var seats contentuc.TableColumnDTO
+	json.NewDecoder(columnRR.Body).Decode(&seats)
+	rowRR := serve(http.MethodPost, contentURL+"/rows", InsertTableRowRequest{Cells: map[string]string{"plan": "Pro", seats.ID: "10"}, ContentVersion: intPtr(1)})
+	deletedRow := serve(http.MethodDelete, contentURL+"/rows/r1", TableChangeRequest{ContentVersion: intPtr(2)})
+	deletedColumn := serve(http.MethodDelete, contentURL+"/columns/price", TableChangeRequest{ContentVersion: intPtr(3)})
+
+	// Assert
+	if columnRR.Code != http.StatusCreated || rowRR.Code != http.StatusCreated {
+		t.Fatalf("expected the column and row to be created; got %d and %d", columnRR.Code, rowRR.Code)
+	}
+	if deletedRow.Code != http.StatusNoContent || deletedColumn.Code != http.StatusNoContent {
+		t.Fatalf("expected the row and column to be deleted; got %d and %d", deletedRow.Code, deletedColumn.Code)
+	}
+	rendered, _ := cuc.RenderContent(created.ID)
+	if !strings.Contains(rendered, "<th>Plan</th><th>Seats</th></tr>") || !strings.Contains(rendered, "<tr><td>Pro</td><td>10</td></tr>") {
+		t.Errorf("expected a Plan and Seats table with one Pro row; got %s", rendered)
+	}
+}
+
+func TestNoteHandler_UpdateTableCell_Broadcast(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Pricing", "owner-1")
+	contentID, _ := cuc.CreateContent(noteID, "owner-1", "", testTableData, contentuc.TableContentType)
+	cuc.UpdateTableCell(contentID, "owner-2", "r1", "plan", "Basic", 0)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notes/" + noteID
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	// Act
+	// The edit is based on version 0, but another cell changed since.
+	body, _ := json.Marshal(UpdateTableCellRequest{Value: "9.99", ContentVersion: intPtr(0)})
+	req := httptest.NewRequest(http.MethodPut, "/notes/"+noteID+"/contents/"+contentID+"/rows/r1/cells/price?user_id=owner-1", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to update cell: status %d", rr.Code)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read websocket message: %v", err)
+	}
+	var event WebSocketEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatalf("failed to unmarshal websocket message: %v", err)
+	}
+	if event.Type != "update_table_cell" || event.ContentID != contentID || event.ContentVersion != 2 {
+		t.Errorf("expected update_table_cell for %s at version 2; got %s for %s at version %d", contentID, event.Type, event.ContentID, event.ContentVersion)
+	}
+	if event.Cell == nil || event.Cell.RowID != "r1" || event.Cell.ColumnID != "price" || event.Cell.Value != "9.99" {
+		t.Errorf("expected the changed cell in the event; got %+v", event.Cell)
+	}
+	if event.ContentType != "table" || event.LastModifiedBy != "owner-1" {
+		t.Errorf("expected a table change by owner-1; got %s by %s", event.ContentType, event.LastModifiedBy)
+	}
+}
+
+func TestNoteHandler_Table_Errors(t *testing.T) {
+	// Arrange
+	router, nuc, cuc, _ := setupTestForBroadcast()
+	noteID, _ := nuc.CreateNote("", "Pricing", "owner-1")
+	tableID, _ := cuc.CreateContent(noteID, "owner-1", "", testTableData, contentuc.TableContentType)
+	cuc.UpdateTableCell(tableID, "owner-2", "r1", "plan", "Basic", 0)
+	textID, _ := cuc.CreateContent(noteID, "owner-1", "", "plain", contentuc.TextContentType)
+	tableURL := "/notes/" + noteID + "/contents/" + tableID
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		want   int
+	}{
+		{"same cell changed", http.MethodPut, tableURL + "/rows/r1/cells/plan", UpdateTableCellRequest{Value: "Pro", ContentVersion: intPtr(0)}, http.StatusConflict},
+		{"unknown row", http.MethodPut, tableURL + "/rows/missing/cells/plan", UpdateTableCellRequest{Value: "Pro", ContentVersion: intPtr(1)}, http.StatusNotFound},
+		{"unknown column", http.MethodDelete, tableURL + "/columns/missing", TableChangeRequest{ContentVersion: intPtr(1)}, http.StatusNotFound},
+		{"stale structural change", http.MethodPost, tableURL + "/rows", InsertTableRowRequest{ContentVersion: intPtr(0)}, http.StatusConflict},
+		{"missing version", http.MethodPost, tableURL + "/columns", InsertTableColumnRequest{Name: "x"}, http.StatusBadRequest},
+		{"not a table", http.MethodPost, "/notes/" + noteID + "/contents/" + textID + "/rows", InsertTableRowRequest{ContentVersion: intPtr(0)}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := json.Marshal(tt.body)
+			rr := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.target, bytes.NewBuffer(data)))
+
+			// Assert
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d; got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"noteapp/internal/usecase/templateuc"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TemplateHandler handles HTTP requests for note templates.
+type TemplateHandler struct {
+	templateUsecase *templateuc.TemplateUsecase
+}
+
+// NewTemplateHandler creates a new TemplateHandler.
+func NewTemplateHandler(tuc *templateuc.TemplateUsecase) *TemplateHandler {
+	return &TemplateHandler{templateUsecase: tuc}
+}
+
+// CreateTemplateRequest represents the request body for creating a template.
+type CreateTemplateRequest struct {
+	OwnerID      string                         `json:"owner_id"`
+	Name         string                         `json:"name"`
+	TitlePattern string                         `json:"title_pattern"`
+	Blocks       []*templateuc.TemplateBlockDTO `json:"blocks"`
+}
+
+// CreateTemplateResponse represents the response body for creating a template.
+type CreateTemplateResponse struct {
+	ID string `json:"id"`
+}
+
+// UpdateTemplateRequest represents the request body for updating a template.
+type UpdateTemplateRequest struct {
+	Name         string                         `json:"name"`
+	TitlePattern string                         `json:"title_pattern"`
+	Blocks       []*templateuc.TemplateBlockDTO `json:"blocks"`
+	Version      *int                           `json:"version"`
+}
+
+// DeleteTemplateRequest represents the request body for deleting a template.
+type DeleteTemplateRequest struct {
+	Version *int `json:"version"`
+}
+
+// CreateTemplate is the handler for the POST /templates endpoint.
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.templateUsecase.CreateTemplate(req.OwnerID, req.Name, req.TitlePattern, req.Blocks)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/templates/%s", id))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateTemplateResponse{ID: id})
+}
+
+// ListTemplates is the handler for the GET /templates endpoint.
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateUsecase.ListTemplates()
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(templates)
+}
+
+// GetTemplate is the handler for the GET /templates/{templateID} endpoint.
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "templateID")
+
+	template, err := h.templateUsecase.GetTemplate(templateID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateTemplate is the handler for the PUT /templates/{templateID} endpoint.
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "templateID")
+
+	var req UpdateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.templateUsecase.UpdateTemplate(templateID, callerID(r), req.Name, req.TitlePattern, req.Blocks, *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteTemplate is the handler for the DELETE /templates/{templateID} endpoint.
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "templateID")
+
+	var req DeleteTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Version == nil {
+		http.Error(w, "version is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.templateUsecase.DeleteTemplate(templateID, callerID(r), *req.Version); err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createNoteFromDraft creates a note owned by ownerID with the title and contents of a note
+// drafted from a template, tagged with the owner's keywords, and returns its ID. If any step
+// fails, the note and the contents created so far are removed.
+func (h *NoteHandler) createNoteFromDraft(draft *templateuc.NoteDraftDTO, ownerID string, keywords ...string) (string, error) {
+	noteID, err := h.noteUsecase.CreateNote("", draft.Title, ownerID)
+	if err != nil {
+		return "", err
+	}
+
+	var contentIDs []string
+	discard := func() {
+		for _, contentID := range contentIDs {
+			h.discardContent(contentID)
+		}
+		h.discardNote(noteID)
+	}
+	for _, block := range draft.Blocks {
+		contentType, err := mapToContentUsecaseContentType(block.Type)
+		if err != nil {
+			discard()
+			return "", err
+		}
+		req := AddContentRequest{Type: block.Type, Data: block.Data, Language: block.Language, Format: block.Format}
+		contentID, err := h.createContent(noteID, ownerID, req, contentType)
+		if err != nil {
+			discard()
+			return "", err
+		}
+		contentIDs = append(contentIDs, contentID)
+	}
+	if err := h.noteUsecase.AddContents(noteID, ownerID, contentIDs, -1, 0); err != nil {
+		discard()
+		return "", err
+	}
+	// No one else knows the note yet, so each save moves it up exactly one version.
+	for i, keyword := range keywords {
+		if err := h.noteUsecase.TagNote(noteID, ownerID, keyword, i+1); err != nil {
+			discard()
+			return "", err
+		}
+	}
+	return noteID, nil
+}
+
+// createNoteFromTemplate handles POST /notes?template={id}, creating a note and its contents
+// from a template. A title in the request replaces the template's title pattern.
+func (h *NoteHandler) createNoteFromTemplate(w http.ResponseWriter, templateID string, req CreateNoteRequest) {
+	draft, err := h.templateUsecase.InstantiateTemplate(templateID, req.OwnerID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+	if req.Title != "" {
+		draft.Title = req.Title
+	}
+
+	noteID, err := h.createNoteFromDraft(draft, req.OwnerID)
+	if err != nil {
+		mapErrorToHTTPStatus(w, err)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/notes/%s", noteID))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateNoteResponse{ID: noteID})
+}
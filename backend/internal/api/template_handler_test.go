@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"noteapp/internal/usecase/templateuc"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupTemplateTest initializes a router with the template endpoints and note creation,
+// with templates instantiated at 2024-03-04 09:30 UTC.
+func setupTemplateTest() (*chi.Mux, *templateuc.TemplateUsecase) {
+	ht := newHandlerTest(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+	noteHandler := ht.handler
+	handler := NewTemplateHandler(ht.tuc)
+	router := ht.router
+
+	router.Post("/templates", handler.CreateTemplate)
+	router.Get("/templates", handler.ListTemplates)
+	router.Get("/templates/{templateID}", handler.GetTemplate)
+	router.Put("/templates/{templateID}", handler.UpdateTemplate)
+	router.Delete("/templates/{templateID}", handler.DeleteTemplate)
+	router.Post("/notes", noteHandler.CreateNote)
+	router.Get("/notes/{id}", noteHandler.GetNoteByID)
+	return router, ht.tuc
+}
+
+func TestTemplateHandler_CreateAndGetTemplate(t *testing.T) {
+	// Arrange
+	router, _ := setupTemplateTest()
+	body, _ := json.Marshal(CreateTemplateRequest{
+		OwnerID:      "user-1",
+		Name:         "Meeting",
+		TitlePattern: "Meeting {{date}}",
+		Blocks:       []*templateuc.TemplateBlockDTO{{Type: "text", Data: "Attendees: {{user}}"}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/templates", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, rr.Code)
+	}
+	var created CreateTemplateResponse
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if location := rr.Header().Get("Location"); location != "/templates/"+created.ID {
+		t.Errorf("expected Location '/templates/%s'; got '%s'", created.ID, location)
+	}
+
+	getRR := httptest.NewRecorder()
+	router.ServeHTTP(getRR, httptest.NewRequest(http.MethodGet, "/templates/"+created.ID, nil))
+	if getRR.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, getRR.Code)
+	}
+	var tmpl templateuc.TemplateDTO
+	if err := json.NewDecoder(getRR.Body).Decode(&tmpl); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if tmpl.Name != "Meeting" || tmpl.TitlePattern != "Meeting {{date}}" || len(tmpl.Blocks) != 1 {
+		t.Errorf("expected the template as created; got %+v", tmpl)
+	}
+}
+
+func TestTemplateHandler_CreateTemplate_UnknownPlaceholder(t *testing.T) {
+	router, _ := setupTemplateTest()
+	body, _ := json.Marshal(CreateTemplateRequest{OwnerID: "user-1", Name: "Meeting", TitlePattern: "Meeting in {{room}}"})
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/templates", bytes.NewBuffer(body)))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestTemplateHandler_UpdateAndDeleteTemplate(t *testing.T) {
+	router, tuc := setupTemplateTest()
+	id, _ := tuc.CreateTemplate("user-1", "Meeting", "Meeting", nil)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       interface{}
+		wantStatus int
+	}{
+		{"update by another user", http.MethodPut, "/templates/" + id + "?user_id=user-2", UpdateTemplateRequest{Name: "Stand-up", TitlePattern: "Stand-up", Version: intPtr(0)}, http.StatusForbidden},
+		{"update without version", http.MethodPut, "/templates/" + id + "?user_id=user-1", UpdateTemplateRequest{Name: "Stand-up", TitlePattern: "Stand-up"}, http.StatusBadRequest},
+		{"update by the owner", http.MethodPut, "/templates/" + id + "?user_id=user-1", UpdateTemplateRequest{Name: "Stand-up", TitlePattern: "Stand-up", Version: intPtr(0)}, http.StatusOK},
+		{"update with a stale version", http.MethodPut, "/templates/" + id + "?user_id=user-1", UpdateTemplateRequest{Name: "Retro", TitlePattern: "Retro", Version: intPtr(0)}, http.StatusConflict},
+		{"delete by another user", http.MethodDelete, "/templates/" + id + "?user_id=user-2", DeleteTemplateRequest{Version: intPtr(1)}, http.StatusForbidden},
+		{"delete by the owner", http.MethodDelete, "/templates/" + id + "?user_id=user-1", DeleteTemplateRequest{Version: intPtr(1)}, http.StatusNoContent},
+		{"delete a missing template", http.MethodDelete, "/templates/" + id + "?user_id=user-1", DeleteTemplateRequest{Version: intPtr(1)}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.url, bytes.NewBuffer(body)))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d; got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestNoteHandler_CreateNoteFromTemplate(t *testing.T) {
+	// Arrange
+	router, tuc := setupTemplateTest()
+	id, _ := tuc.CreateTemplate("user-1", "Meeting", "Meeting {{date}}", []*templateuc.TemplateBlockDTO{
+		{Type: "text", Data: "# {{weekday}} stand-up", Format: "markdown"},
+		{Type: "checklist", Data: `[{"text":"Send minutes for {{user}}"}]`},
+		{Type: "code", Data: "// {{time}}", Language: "go"},
+	})
+	body, _ := json.Marshal(CreateNoteRequest{OwnerID: "user-2"})
+	req := httptest.NewRequest(http.MethodPost, "/notes?template="+id, bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, rr.Code)
+	}
+	var created CreateNoteResponse
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	getRR := httptest.NewRecorder()
+	router.ServeHTTP(getRR, httptest.NewRequest(http.MethodGet, "/notes/"+created.ID, nil))
+	var note GetNoteByIDResponse
+	if err := json.NewDecoder(getRR.Body).Decode(&note); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+	if note.Title != "Meeting 2024-03-04" || note.OwnerID != "user-2" {
+		t.Errorf("expected note 'Meeting 2024-03-04' owned by user-2; got %+v", note.NoteDTO)
+	}
+	if len(note.Contents) != 3 {
+		t.Fatalf("expected 3 contents; got %d", len(note.Contents))
+	}
+	if note.Contents[0].Data != "# Monday stand-up" || note.Contents[0].Format != "markdown" {
+		t.Errorf("expected the text content first; got %+v", note.Contents[0])
+	}
+	if note.Contents[1].Type != "checklist" || !bytes.Contains([]byte(note.Contents[1].Data), []byte("Send minutes for user-2")) {
+		t.Errorf("expected the checklist content second; got %+v", note.Contents[1])
+	}
+	if note.Contents[2].Data != "// 09:30" || note.Contents[2].Language != "go" {
+		t.Errorf("expected the code content last; got %+v", note.Contents[2])
+	}
+}
+
+func TestNoteHandler_CreateNoteFromTemplate_TitleAndErrors(t *testing.T) {
+	router, tuc := setupTemplateTest()
+	id, _ := tuc.CreateTemplate("user-1", "Meeting", "Meeting {{date}}", nil)
+
+	tests := []struct {
+		name       string
+		template   string
+		request    CreateNoteRequest
+		wantStatus int
+		wantTitle  string
+	}{
+		{"title overrides the pattern", id, CreateNoteRequest{OwnerID: "user-2", Title: "Kick-off"}, http.StatusCreated, "Kick-off"},
+		{"missing template", "missing", CreateNoteRequest{OwnerID: "user-2"}, http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.request)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/notes?template="+tt.template, bytes.NewBuffer(body)))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d; got %d", tt.wantStatus, rr.Code)
+			}
+			if tt.wantTitle == "" {
+				return
+			}
+			var created CreateNoteResponse
+			json.NewDecoder(rr.Body).Decode(&created)
+			getRR := httptest.NewRecorder()
+			router.ServeHTTP(getRR, httptest.NewRequest(http.MethodGet, "/notes/"+created.ID, nil))
+			var note GetNoteByIDResponse
+			json.NewDecoder(getRR.Body).Decode(&note)
+			if note.Title != tt.wantTitle {
+				t.Errorf("expected title '%s'; got '%s'", tt.wantTitle, note.Title)
+			}
+		})
+	}
+}
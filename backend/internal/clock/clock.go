@@ -0,0 +1,57 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Usecases depend on it instead of calling time.Now
+// directly so that tests can control time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock backed by the system time.
+type SystemClock struct{}
+
+// NewSystemClock creates a new SystemClock.
+func NewSystemClock() SystemClock {
+	return SystemClock{}
+}
+
+// Now returns the current system time in UTC.
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// FakeClock is a Clock whose time only changes when it is set or advanced.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a new FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set changes the fake current time.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the fake current time forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
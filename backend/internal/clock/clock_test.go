@@ -0,0 +1,25 @@
+package clock_test
+
+import (
+	"noteapp/internal/clock"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClock(start)
+
+	// Act
+	c.Advance(90 * time.Minute)
+
+	// Assert
+	if expected := start.Add(90 * time.Minute); !c.Now().Equal(expected) {
+		t.Errorf("Expected %v after advancing, but got %v", expected, c.Now())
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("Expected %v after setting, but got %v", start, c.Now())
+	}
+}
@@ -0,0 +1,156 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotAChecklist is returned when a checklist operation is applied to another content type.
+var ErrNotAChecklist = errors.New("content is not a checklist")
+
+// ErrInvalidChecklist is returned when checklist data is not a valid list of items.
+var ErrInvalidChecklist = errors.New("invalid checklist data")
+
+// ErrEmptyChecklistItem is returned when a checklist item has no text.
+var ErrEmptyChecklistItem = errors.New("checklist item text cannot be empty")
+
+// ErrChecklistItemNotFound is returned when a checklist item is not found.
+var ErrChecklistItemNotFound = errors.New("checklist item not found")
+
+// ErrIndexOutOfBounds is returned when a checklist item is placed outside the list.
+var ErrIndexOutOfBounds = errors.New("index out of bounds")
+
+// ChecklistItem is an entry of a checklist content. The Data of a checklist content is the
+// JSON encoding of its items.
+type ChecklistItem struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	Checked  bool   `json:"checked"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// NormalizeChecklist validates checklist data and returns it re-encoded, with an ID assigned
+// to each item that lacks one. Empty data is an empty checklist.
+func NormalizeChecklist(data string) (string, error) {
+	items, err := parseChecklist(data)
+	if err != nil {
+		return "", err
+	}
+	seen := make(map[string]bool, len(items))
+	for i := range items {
+		items[i].Text = strings.TrimSpace(items[i].Text)
+		if items[i].Text == "" {
+			return "", ErrEmptyChecklistItem
+		}
+		if items[i].ID == "" || seen[items[i].ID] {
+			items[i].ID = uuid.New().String()
+		}
+		seen[items[i].ID] = true
+	}
+	return encodeChecklist(items), nil
+}
+
+// ChecklistItems returns the items of a checklist content.
+func (c *Content) ChecklistItems() ([]ChecklistItem, error) {
+	if c.Type != ChecklistContentType {
+		return nil, ErrNotAChecklist
+	}
+	return parseChecklist(c.Data)
+}
+
+// AddChecklistItem inserts a new unchecked item at index, or appends it when index is -1.
+func (c *Content) AddChecklistItem(text, assignee string, index int) (ChecklistItem, error) {
+	items, err := c.ChecklistItems()
+	if err != nil {
+		return ChecklistItem{}, err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ChecklistItem{}, ErrEmptyChecklistItem
+	}
+	if index < -1 || index > len(items) {
+		return ChecklistItem{}, ErrIndexOutOfBounds
+	}
+	if index == -1 {
+		index = len(items)
+	}
+
+	item := ChecklistItem{ID: uuid.New().String(), Text: text, Assignee: strings.TrimSpace(assignee)}
+	items = append(items[:index], append([]ChecklistItem{item}, items[index:]...)...)
+	c.Data = encodeChecklist(items)
+	return item, nil
+}
+
+// ToggleChecklistItem flips the checked state of an item and returns the updated item.
+func (c *Content) ToggleChecklistItem(itemID string) (ChecklistItem, error) {
+	items, i, err := c.findChecklistItem(itemID)
+	if err != nil {
+		return ChecklistItem{}, err
+	}
+	items[i].Checked = !items[i].Checked
+	c.Data = encodeChecklist(items)
+	return items[i], nil
+}
+
+// MoveChecklistItem moves an item to index, shifting the items in between.
+func (c *Content) MoveChecklistItem(itemID string, index int) (ChecklistItem, error) {
+	items, i, err := c.findChecklistItem(itemID)
+	if err != nil {
+		return ChecklistItem{}, err
+	}
+	if index < 0 || index >= len(items) {
+		return ChecklistItem{}, ErrIndexOutOfBounds
+	}
+
+	item := items[i]
+	items = append(items[:i], items[i+1:]...)
+	items = append(items[:index], append([]ChecklistItem{item}, items[index:]...)...)
+	c.Data = encodeChecklist(items)
+	return item, nil
+}
+
+// RemoveChecklistItem removes an item and returns it.
+func (c *Content) RemoveChecklistItem(itemID string) (ChecklistItem, error) {
+	items, i, err := c.findChecklistItem(itemID)
+	if err != nil {
+		return ChecklistItem{}, err
+	}
+	item := items[i]
+	c.Data = encodeChecklist(append(items[:i], items[i+1:]...))
+	return item, nil
+}
+
+func (c *Content) findChecklistItem(itemID string) ([]ChecklistItem, int, error) {
+	items, err := c.ChecklistItems()
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, item := range items {
+		if item.ID == itemID {
+			return items, i, nil
+		}
+	}
+	return nil, 0, ErrChecklistItemNotFound
+}
+
+func parseChecklist(data string) ([]ChecklistItem, error) {
+	if strings.TrimSpace(data) == "" {
+		return []ChecklistItem{}, nil
+	}
+	var items []ChecklistItem
+	if err := json.Unmarshal([]byte(data), &items); err != nil {
+		return nil, ErrInvalidChecklist
+	}
+	if items == nil {
+		items = []ChecklistItem{}
+	}
+	return items, nil
+}
+
+func encodeChecklist(items []ChecklistItem) string {
+	data, _ := json.Marshal(items)
+	return string(data)
+}
@@ -0,0 +1,140 @@
+package content_test
+
+import (
+	"errors"
+	"noteapp/internal/domain/content"
+	"testing"
+)
+
+func newChecklist(t *testing.T, texts ...string) (*content.Content, []content.ChecklistItem) {
+	t.Helper()
+	c := content.NewContent("c1", "n1", "", content.ChecklistContentType, 0)
+	var items []content.ChecklistItem
+	for _, text := range texts {
+		item, err := c.AddChecklistItem(text, "", -1)
+		if err != nil {
+			t.Fatalf("Failed to add checklist item: %v", err)
+		}
+		items = append(items, item)
+	}
+	return c, items
+}
+
+func checklistTexts(t *testing.T, c *content.Content) []string {
+	t.Helper()
+	items, err := c.ChecklistItems()
+	if err != nil {
+		t.Fatalf("Failed to read checklist items: %v", err)
+	}
+	var texts []string
+	for _, item := range items {
+		texts = append(texts, item.Text)
+	}
+	return texts
+}
+
+func TestContent_AddChecklistItem(t *testing.T) {
+	c, _ := newChecklist(t, "first", "third")
+
+	item, err := c.AddChecklistItem("  second ", "alice", 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if item.ID == "" || item.Text != "second" || item.Assignee != "alice" || item.Checked {
+		t.Errorf("Expected an unchecked 'second' item assigned to alice, but got %+v", item)
+	}
+	texts := checklistTexts(t, c)
+	if len(texts) != 3 || texts[0] != "first" || texts[1] != "second" || texts[2] != "third" {
+		t.Errorf("Expected [first second third], but got %v", texts)
+	}
+}
+
+func TestContent_AddChecklistItem_Errors(t *testing.T) {
+	c, _ := newChecklist(t, "first")
+	text := content.NewContent("c2", "n1", "plain", content.TextContentType, 0)
+
+	if _, err := c.AddChecklistItem(" ", "", -1); !errors.Is(err, content.ErrEmptyChecklistItem) {
+		t.Errorf("Expected ErrEmptyChecklistItem, but got %v", err)
+	}
+	if _, err := c.AddChecklistItem("late", "", 5); !errors.Is(err, content.ErrIndexOutOfBounds) {
+		t.Errorf("Expected ErrIndexOutOfBounds, but got %v", err)
+	}
+	if _, err := text.AddChecklistItem("item", "", -1); !errors.Is(err, content.ErrNotAChecklist) {
+		t.Errorf("Expected ErrNotAChecklist, but got %v", err)
+	}
+}
+
+func TestContent_ToggleChecklistItem(t *testing.T) {
+	c, items := newChecklist(t, "first")
+
+	toggled, err := c.ToggleChecklistItem(items[0].ID)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !toggled.Checked {
+		t.Errorf("Expected item to be checked")
+	}
+	toggled, _ = c.ToggleChecklistItem(items[0].ID)
+	if toggled.Checked {
+		t.Errorf("Expected item to be unchecked after a second toggle")
+	}
+	if _, err := c.ToggleChecklistItem("missing"); !errors.Is(err, content.ErrChecklistItemNotFound) {
+		t.Errorf("Expected ErrChecklistItemNotFound, but got %v", err)
+	}
+}
+
+func TestContent_MoveChecklistItem(t *testing.T) {
+	c, items := newChecklist(t, "a", "b", "c")
+
+	if _, err := c.MoveChecklistItem(items[0].ID, 2); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	texts := checklistTexts(t, c)
+	if len(texts) != 3 || texts[0] != "b" || texts[1] != "c" || texts[2] != "a" {
+		t.Errorf("Expected [b c a], but got %v", texts)
+	}
+	if _, err := c.MoveChecklistItem(items[0].ID, 3); !errors.Is(err, content.ErrIndexOutOfBounds) {
+		t.Errorf("Expected ErrIndexOutOfBounds, but got %v", err)
+	}
+}
+
+func TestContent_RemoveChecklistItem(t *testing.T) {
+	c, items := newChecklist(t, "a", "b")
+
+	removed, err := c.RemoveChecklistItem(items[0].ID)
+
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if removed.Text != "a" {
+		t.Errorf("Expected removed item 'a', but got '%s'", removed.Text)
+	}
+	texts := checklistTexts(t, c)
+	if len(texts) != 1 || texts[0] != "b" {
+		t.Errorf("Expected [b], but got %v", texts)
+	}
+}
+
+func TestNormalizeChecklist(t *testing.T) {
+	data, err := content.NormalizeChecklist(`[{"text":"buy milk"},{"id":"x","text":"call bob","checked":true}]`)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	c := content.NewContent("c1", "n1", data, content.ChecklistContentType, 0)
+	items, _ := c.ChecklistItems()
+	if len(items) != 2 || items[0].ID == "" || items[1].ID != "x" || !items[1].Checked {
+		t.Errorf("Expected IDs to be assigned and existing items kept, but got %+v", items)
+	}
+
+	if _, err := content.NormalizeChecklist("not json"); !errors.Is(err, content.ErrInvalidChecklist) {
+		t.Errorf("Expected ErrInvalidChecklist, but got %v", err)
+	}
+	if _, err := content.NormalizeChecklist(`[{"text":""}]`); !errors.Is(err, content.ErrEmptyChecklistItem) {
+		t.Errorf("Expected ErrEmptyChecklistItem, but got %v", err)
+	}
+	if data, _ := content.NormalizeChecklist(""); data != "[]" {
+		t.Errorf("Expected empty data to be an empty checklist, but got %s", data)
+	}
+}
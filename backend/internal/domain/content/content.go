@@ -1,6 +1,10 @@
 package content
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // ContentType defines the type of content.
 type ContentType string
@@ -10,8 +14,20 @@ const (
 	TextContentType ContentType = "text"
 	// ImageContentType is an image content type.
 	ImageContentType ContentType = "image"
+	// AttachmentContentType is a file attachment content type.
+	AttachmentContentType ContentType = "attachment"
+	// ChecklistContentType is a checklist content type.
+	ChecklistContentType ContentType = "checklist"
+	// CodeContentType is a source code content type.
+	CodeContentType ContentType = "code"
+	// TableContentType is a table content type.
+	TableContentType ContentType = "table"
 )
 
+// MarkdownFormat is the format of text contents written in Markdown. Text contents without
+// a format are plain text.
+const MarkdownFormat = "markdown"
+
 // Content represents a block of content within a note.
 type Content struct {
 	ID      string
@@ -19,6 +35,37 @@ type Content struct {
 	Data    string
 	Type    ContentType
 	Version int
+	// CreatedAt and UpdatedAt record when the content was created and last changed,
+	// and LastModifiedBy records who made the last change.
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastModifiedBy string
+	// Language is the programming language of a code content.
+	Language string
+	// Format is the markup of a text content: empty for plain text, or MarkdownFormat.
+	Format string
+	// Image describes the image of an image content, whose Data is the hash of the image blob.
+	Image *ImageMetadata
+	// Attachment describes the file of an attachment content, whose Data is the hash of the file blob.
+	Attachment *AttachmentMetadata
+}
+
+// ImageMetadata describes the image referenced by an image content.
+type ImageMetadata struct {
+	Width  int
+	Height int
+	Format string
+	// Thumbnails maps the longest-edge length of each thumbnail to the hash of its blob.
+	Thumbnails map[int]string
+}
+
+// AttachmentMetadata describes the file referenced by an attachment content.
+type AttachmentMetadata struct {
+	Filename string
+	Size     int64
+	MimeType string
+	// UploaderID is the user whose storage quota the file counts against.
+	UploaderID string
 }
 
 // NewContent creates a new Content object.
@@ -35,3 +82,53 @@ func NewContent(id, noteID string, data string, contentType ContentType, version
 		Version: version,
 	}
 }
+
+// IsEditable reports whether the data of the content can be changed after creation.
+// Images and attachments are immutable; replacing one means adding a new content.
+func (c *Content) IsEditable() bool {
+	switch c.Type {
+	case ImageContentType, AttachmentContentType:
+		return false
+	default:
+		return true
+	}
+}
+
+// MarkCreated records that the content was created by creatorID at the given time.
+func (c *Content) MarkCreated(creatorID string, at time.Time) {
+	c.CreatedAt = at
+	c.MarkModified(creatorID, at)
+}
+
+// MarkModified records that the content was changed by editorID at the given time.
+func (c *Content) MarkModified(editorID string, at time.Time) {
+	c.UpdatedAt = at
+	c.LastModifiedBy = editorID
+}
+
+// Copy returns a copy of the content with a new ID, in the note noteID and at version 0. The
+// cells of a copied table forget the versions they were changed in, which belong to the original.
+func (c *Content) Copy(noteID string) *Content {
+	copied := *c
+	copied.ID = uuid.New().String()
+	copied.NoteID = noteID
+	copied.Version = 0
+	if c.Image != nil {
+		image := *c.Image
+		image.Thumbnails = make(map[int]string, len(c.Image.Thumbnails))
+		for size, hash := range c.Image.Thumbnails {
+			image.Thumbnails[size] = hash
+		}
+		copied.Image = &image
+	}
+	if c.Attachment != nil {
+		attachment := *c.Attachment
+		copied.Attachment = &attachment
+	}
+	if c.Type == TableContentType {
+		if data, err := NormalizeTable(c.Data); err == nil {
+			copied.Data = data
+		}
+	}
+	return &copied
+}
@@ -3,6 +3,7 @@ package content_test
 import (
 	"noteapp/internal/domain/content"
 	"testing"
+	"time"
 )
 
 func TestNewContent_WithID(t *testing.T) {
@@ -47,3 +48,70 @@ func TestNewContent_GenerateID(t *testing.T) {
 		t.Errorf("Expected Version to be 0, but got %d", c.Version)
 	}
 }
+
+func TestContent_MarkCreatedAndModified(t *testing.T) {
+	// Arrange
+	c := content.NewContent("", "test-note-id", "Test Content", content.TextContentType, 0)
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	modified := created.Add(time.Hour)
+
+	// Act
+	c.MarkCreated("user-1", created)
+	c.MarkModified("user-2", modified)
+
+	// Assert
+	if !c.CreatedAt.Equal(created) {
+		t.Errorf("Expected CreatedAt to be %v, but got %v", created, c.CreatedAt)
+	}
+	if !c.UpdatedAt.Equal(modified) {
+		t.Errorf("Expected UpdatedAt to be %v, but got %v", modified, c.UpdatedAt)
+	}
+	if c.LastModifiedBy != "user-2" {
+		t.Errorf("Expected LastModifiedBy to be 'user-2', but got '%s'", c.LastModifiedBy)
+	}
+}
+
+func TestContent_IsEditable(t *testing.T) {
+	text := content.NewContent("c1", "n1", "text", content.TextContentType, 0)
+	image := content.NewContent("c2", "n1", "hash", content.ImageContentType, 0)
+	attachment := content.NewContent("c3", "n1", "hash", content.AttachmentContentType, 0)
+
+	if !text.IsEditable() {
+		t.Errorf("Expected text content to be editable")
+	}
+	if image.IsEditable() {
+		t.Errorf("Expected image content not to be editable")
+	}
+	if attachment.IsEditable() {
+		t.Errorf("Expected attachment content not to be editable")
+	}
+}
+
+func TestContent_Copy(t *testing.T) {
+	original := content.NewContent("c1", "n1", "hash", content.ImageContentType, 4)
+	original.Image = &content.ImageMetadata{Width: 10, Height: 5, Format: "png", Thumbnails: map[int]string{64: "thumb"}}
+
+	copied := original.Copy("n2")
+
+	if copied.ID == "" || copied.ID == original.ID {
+		t.Errorf("Expected the copy to get a new ID, but got '%s'", copied.ID)
+	}
+	if copied.NoteID != "n2" || copied.Version != 0 || copied.Data != "hash" {
+		t.Errorf("Expected a version 0 copy of the data in n2, but got %+v", copied)
+	}
+	copied.Image.Thumbnails[128] = "other"
+	if len(original.Image.Thumbnails) != 1 {
+		t.Errorf("Expected the copy not to share thumbnails with the original")
+	}
+}
+
+func TestContent_Copy_ResetsTableCellVersions(t *testing.T) {
+	original := content.NewContent("c1", "n1", `{"columns":[{"id":"a"}],"rows":[{"id":"r","cells":{"a":{"value":"x","version":3}}}]}`, content.TableContentType, 3)
+
+	copied := original.Copy("n2")
+
+	table, _ := copied.Table()
+	if cell := table.Rows[0].Cells["a"]; cell.Value != "x" || cell.Version != 0 {
+		t.Errorf("Expected the copied cell to keep its value at version 0, but got %+v", cell)
+	}
+}
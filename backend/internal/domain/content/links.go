@@ -0,0 +1,36 @@
+package content
+
+import "strings"
+
+// LinkedNoteIDs returns the IDs of the notes a text content links to with wiki-style links,
+// [[note-id]] or [[note-id|label]], in order of first appearance. Other content types have no links.
+func (c *Content) LinkedNoteIDs() []string {
+	if c.Type != TextContentType {
+		return nil
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for rest := c.Data; ; {
+		start := strings.Index(rest, "[[")
+		if start < 0 {
+			return ids
+		}
+		end := strings.Index(rest[start+2:], "]]")
+		if end < 0 {
+			return ids
+		}
+		target, _, _ := strings.Cut(rest[start+2:start+2+end], "|")
+		target = strings.TrimSpace(target)
+		if target == "" || strings.ContainsAny(target, "[]\n") {
+			// Not a link; look for one starting inside it instead.
+			rest = rest[start+1:]
+			continue
+		}
+		rest = rest[start+2+end+2:]
+		if !seen[target] {
+			seen[target] = true
+			ids = append(ids, target)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package content_test
+
+import (
+	"noteapp/internal/domain/content"
+	"reflect"
+	"testing"
+)
+
+func TestContent_LinkedNoteIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		contentType content.ContentType
+		want        []string
+	}{
+		{"plain and labelled links", "See [[n1]] and [[ n2 | the plan]], then [[n1]] again.", content.TextContentType, []string{"n1", "n2"}},
+		{"not links", "[[]] [n3] [[a\nb]] [[unclosed", content.TextContentType, nil},
+		{"link after brackets", "[[[n4]]]", content.TextContentType, []string{"n4"}},
+		{"other content types", "[[n5]]", content.CodeContentType, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := content.NewContent("c1", "n0", tt.data, tt.contentType, 0)
+
+			if got := c.LinkedNoteIDs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LinkedNoteIDs() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotATable is returned when a table operation is applied to another content type.
+var ErrNotATable = errors.New("content is not a table")
+
+// ErrInvalidTable is returned when table data is not a valid table.
+var ErrInvalidTable = errors.New("invalid table data")
+
+// ErrTableRowNotFound is returned when a table row is not found.
+var ErrTableRowNotFound = errors.New("table row not found")
+
+// ErrTableColumnNotFound is returned when a table column is not found.
+var ErrTableColumnNotFound = errors.New("table column not found")
+
+// ErrTableCellConflict is returned when a cell was changed after the version an edit is based on.
+var ErrTableCellConflict = errors.New("table cell was changed concurrently")
+
+// Table is the schema and the cells of a table content. The Data of a table content is the
+// JSON encoding of its table.
+type Table struct {
+	Columns []TableColumn `json:"columns"`
+	Rows    []TableRow    `json:"rows"`
+}
+
+// TableColumn is a column of a table.
+type TableColumn struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TableRow is a row of a table. Cells maps column IDs to the cells of the row; a missing
+// cell is empty.
+type TableRow struct {
+	ID    string               `json:"id"`
+	Cells map[string]TableCell `json:"cells,omitempty"`
+}
+
+// TableCell is a cell of a table. Version is the version of the content in which the cell was
+// last changed, so that edits of different cells do not conflict with each other.
+type TableCell struct {
+	Value   string `json:"value"`
+	Version int    `json:"version"`
+}
+
+// NormalizeTable validates table data and returns it re-encoded, with an ID assigned to each
+// column and row that lacks one, cells of unknown columns dropped and cell versions reset.
+// Empty data is an empty table.
+func NormalizeTable(data string) (string, error) {
+	table, err := parseTable(data)
+	if err != nil {
+		return "", err
+	}
+
+	columns := make(map[string]bool, len(table.Columns))
+	for i := range table.Columns {
+		column := &table.Columns[i]
+		column.Name = strings.TrimSpace(column.Name)
+		if column.ID == "" || columns[column.ID] {
+			column.ID = uuid.New().String()
+		}
+		columns[column.ID] = true
+	}
+
+	rows := make(map[string]bool, len(table.Rows))
+	for i := range table.Rows {
+		row := &table.Rows[i]
+		if row.ID == "" || rows[row.ID] {
+			row.ID = uuid.New().String()
+		}
+		rows[row.ID] = true
+		for columnID, cell := range row.Cells {
+			if !columns[columnID] || cell.Value == "" {
+				delete(row.Cells, columnID)
+				continue
+			}
+			row.Cells[columnID] = TableCell{Value: cell.Value}
+		}
+	}
+	return encodeTable(table), nil
+}
+
+// Table returns the table of a table content.
+func (c *Content) Table() (Table, error) {
+	if c.Type != TableContentType {
+		return Table{}, ErrNotATable
+	}
+	return parseTable(c.Data)
+}
+
+// ReplaceTable replaces the table of a table content with normalized table data. Cells whose
+// value changes are stamped with the next version of the content, and the others keep theirs.
+func (c *Content) ReplaceTable(data string) error {
+	old, err := c.Table()
+	if err != nil {
+		return err
+	}
+	table, err := parseTable(data)
+	if err != nil {
+		return err
+	}
+
+	oldRows := make(map[string]TableRow, len(old.Rows))
+	for _, row := range old.Rows {
+		oldRows[row.ID] = row
+	}
+	for _, row := range table.Rows {
+		oldRow := oldRows[row.ID]
+		for columnID, cell := range row.Cells {
+			if oldCell, ok := oldRow.Cells[columnID]; ok && oldCell.Value == cell.Value {
+				row.Cells[columnID] = oldCell
+			} else {
+				row.Cells[columnID] = TableCell{Value: cell.Value, Version: c.Version + 1}
+			}
+		}
+	}
+	c.Data = encodeTable(table)
+	return nil
+}
+
+// UpdateTableCell sets the value of a cell, unless the cell was changed after baseVersion, the
+// version of the content the edit is based on. The cell is stamped with the next version of
+// the content.
+func (c *Content) UpdateTableCell(rowID, columnID, value string, baseVersion int) (TableCell, error) {
+	table, err := c.Table()
+	if err != nil {
+		return TableCell{}, err
+	}
+	if table.columnIndex(columnID) < 0 {
+		return TableCell{}, ErrTableColumnNotFound
+	}
+	i := table.rowIndex(rowID)
+	if i < 0 {
+		return TableCell{}, ErrTableRowNotFound
+	}
+
+	row := &table.Rows[i]
+	if row.Cells[columnID].Version > baseVersion {
+		return TableCell{}, ErrTableCellConflict
+	}
+	if row.Cells == nil {
+		row.Cells = make(map[string]TableCell)
+	}
+	cell := TableCell{Value: value, Version: c.Version + 1}
+	row.Cells[columnID] = cell
+	c.Data = encodeTable(table)
+	return cell, nil
+}
+
+// InsertTableRow inserts a row at index, or appends it when index is -1. Values maps column
+// IDs to the initial values of the row's cells.
+func (c *Content) InsertTableRow(index int, values map[string]string) (TableRow, error) {
+	table, err := c.Table()
+	if err != nil {
+		return TableRow{}, err
+	}
+	if index < -1 || index > len(table.Rows) {
+		return TableRow{}, ErrIndexOutOfBounds
+	}
+	if index == -1 {
+		index = len(table.Rows)
+	}
+
+	row := TableRow{ID: uuid.New().String()}
+	for columnID, value := range values {
+		if table.columnIndex(columnID) < 0 {
+			return TableRow{}, ErrTableColumnNotFound
+		}
+		if value == "" {
+			continue
+		}
+		if row.Cells == nil {
+			row.Cells = make(map[string]TableCell)
+		}
+		row.Cells[columnID] = TableCell{Value: value, Version: c.Version + 1}
+	}
+	table.Rows = append(table.Rows[:index], append([]TableRow{row}, table.Rows[index:]...)...)
+	c.Data = encodeTable(table)
+	return row, nil
+}
+
+// DeleteTableRow removes a row and returns it.
+func (c *Content) DeleteTableRow(rowID string) (TableRow, error) {
+	table, err := c.Table()
+	if err != nil {
+		return TableRow{}, err
+	}
+	i := table.rowIndex(rowID)
+	if i < 0 {
+		return TableRow{}, ErrTableRowNotFound
+	}
+	row := table.Rows[i]
+	table.Rows = append(table.Rows[:i], table.Rows[i+1:]...)
+	c.Data = encodeTable(table)
+	return row, nil
+}
+
+// InsertTableColumn inserts an empty column at index, or appends it when index is -1.
+func (c *Content) InsertTableColumn(name string, index int) (TableColumn, error) {
+	table, err := c.Table()
+	if err != nil {
+		return TableColumn{}, err
+	}
+	if index < -1 || index > len(table.Columns) {
+		return TableColumn{}, ErrIndexOutOfBounds
+	}
+	if index == -1 {
+		index = len(table.Columns)
+	}
+
+	column := TableColumn{ID: uuid.New().String(), Name: strings.TrimSpace(name)}
+	table.Columns = append(table.Columns[:index], append([]TableColumn{column}, table.Columns[index:]...)...)
+	c.Data = encodeTable(table)
+	return column, nil
+}
+
+// DeleteTableColumn removes a column, and its cells from every row, and returns it.
+func (c *Content) DeleteTableColumn(columnID string) (TableColumn, error) {
+	table, err := c.Table()
+	if err != nil {
+		return TableColumn{}, err
+	}
+	i := table.columnIndex(columnID)
+	if i < 0 {
+		return TableColumn{}, ErrTableColumnNotFound
+	}
+	column := table.Columns[i]
+	table.Columns = append(table.Columns[:i], table.Columns[i+1:]...)
+	for _, row := range table.Rows {
+		delete(row.Cells, columnID)
+	}
+	c.Data = encodeTable(table)
+	return column, nil
+}
+
+func (t Table) rowIndex(rowID string) int {
+	for i, row := range t.Rows {
+		if row.ID == rowID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t Table) columnIndex(columnID string) int {
+	for i, column := range t.Columns {
+		if column.ID == columnID {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseTable(data string) (Table, error) {
+	table := Table{Columns: []TableColumn{}, Rows: []TableRow{}}
+	if strings.TrimSpace(data) == "" {
+		return table, nil
+	}
+	if err := json.Unmarshal([]byte(data), &table); err != nil {
+		return Table{}, ErrInvalidTable
+	}
+	if table.Columns == nil {
+		table.Columns = []TableColumn{}
+	}
+	if table.Rows == nil {
+		table.Rows = []TableRow{}
+	}
+	return table, nil
+}
+
+func encodeTable(table Table) string {
+	data, _ := json.Marshal(table)
+	return string(data)
+}
@@ -0,0 +1,151 @@
+package content_test
+
+import (
+	"errors"
+	"noteapp/internal/domain/content"
+	"testing"
+)
+
+func newTable(t *testing.T, version int) *content.Content {
+	t.Helper()
+	data, err := content.NormalizeTable(`{"columns":[{"id":"name","name":" Name "},{"id":"price","name":"Price"}],"rows":[{"id":"r1","cells":{"name":{"value":"Basic","version":7},"ghost":{"value":"x"}}}]}`)
+	if err != nil {
+		t.Fatalf("Failed to normalize table: %v", err)
+	}
+	return content.NewContent("c1", "n1", data, content.TableContentType, version)
+}
+
+func readTable(t *testing.T, c *content.Content) content.Table {
+	t.Helper()
+	table, err := c.Table()
+	if err != nil {
+		t.Fatalf("Failed to read table: %v", err)
+	}
+	return table
+}
+
+func TestNormalizeTable(t *testing.T) {
+	table := readTable(t, newTable(t, 0))
+
+	if len(table.Columns) != 2 || table.Columns[0].Name != "Name" {
+		t.Fatalf("Expected the two columns with trimmed names, got %+v", table.Columns)
+	}
+	cells := table.Rows[0].Cells
+	if len(cells) != 1 || cells["name"].Value != "Basic" || cells["name"].Version != 0 {
+		t.Errorf("Expected only the known cell with a reset version, got %+v", cells)
+	}
+	if _, err := content.NormalizeTable(`[1,2]`); !errors.Is(err, content.ErrInvalidTable) {
+		t.Errorf("Expected ErrInvalidTable, got %v", err)
+	}
+}
+
+func TestContent_UpdateTableCell(t *testing.T) {
+	c := newTable(t, 3)
+
+	cell, err := c.UpdateTableCell("r1", "price", "10", 1)
+	if err != nil {
+		t.Fatalf("UpdateTableCell() returned an unexpected error: %v", err)
+	}
+
+	if cell.Value != "10" || cell.Version != 4 {
+		t.Errorf("Expected the cell to be stamped with the next version 4, got %+v", cell)
+	}
+	if got := readTable(t, c).Rows[0].Cells["price"]; got != cell {
+		t.Errorf("Expected the table to contain %+v, got %+v", cell, got)
+	}
+}
+
+func TestContent_UpdateTableCell_Conflict(t *testing.T) {
+	c := newTable(t, 3)
+	c.UpdateTableCell("r1", "price", "10", 3)
+	c.Version = 4
+
+	// Another cell was not changed since version 1, so it can still be edited from there.
+	if _, err := c.UpdateTableCell("r1", "name", "Pro", 1); err != nil {
+		t.Errorf("Expected an edit of another cell to succeed, got %v", err)
+	}
+	if _, err := c.UpdateTableCell("r1", "price", "12", 3); !errors.Is(err, content.ErrTableCellConflict) {
+		t.Errorf("Expected ErrTableCellConflict, got %v", err)
+	}
+	if _, err := c.UpdateTableCell("r2", "price", "12", 4); !errors.Is(err, content.ErrTableRowNotFound) {
+		t.Errorf("Expected ErrTableRowNotFound, got %v", err)
+	}
+	if _, err := c.UpdateTableCell("r1", "ghost", "12", 4); !errors.Is(err, content.ErrTableColumnNotFound) {
+		t.Errorf("Expected ErrTableColumnNotFound, got %v", err)
+	}
+}
+
+func TestContent_InsertAndDeleteTableRow(t *testing.T) {
+	c := newTable(t, 0)
+
+	row, err := c.InsertTableRow(0, map[string]string{"name": "Free"})
+	if err != nil {
+		t.Fatalf("InsertTableRow() returned an unexpected error: %v", err)
+	}
+	table := readTable(t, c)
+	if len(table.Rows) != 2 || table.Rows[0].ID != row.ID || table.Rows[0].Cells["name"].Value != "Free" {
+		t.Fatalf("Expected the new row first, got %+v", table.Rows)
+	}
+
+	if _, err := c.InsertTableRow(0, map[string]string{"ghost": "x"}); !errors.Is(err, content.ErrTableColumnNotFound) {
+		t.Errorf("Expected ErrTableColumnNotFound, got %v", err)
+	}
+	if _, err := c.InsertTableRow(5, nil); !errors.Is(err, content.ErrIndexOutOfBounds) {
+		t.Errorf("Expected ErrIndexOutOfBounds, got %v", err)
+	}
+
+	deleted, err := c.DeleteTableRow("r1")
+	if err != nil || deleted.Cells["name"].Value != "Basic" {
+		t.Fatalf("Expected the deleted row to be returned, got %+v, %v", deleted, err)
+	}
+	if rows := readTable(t, c).Rows; len(rows) != 1 || rows[0].ID != row.ID {
+		t.Errorf("Expected only the new row to remain, got %+v", rows)
+	}
+}
+
+func TestContent_InsertAndDeleteTableColumn(t *testing.T) {
+	c := newTable(t, 0)
+
+	column, err := c.InsertTableColumn(" Notes ", 1)
+	if err != nil {
+		t.Fatalf("InsertTableColumn() returned an unexpected error: %v", err)
+	}
+	if columns := readTable(t, c).Columns; len(columns) != 3 || columns[1].ID != column.ID || columns[1].Name != "Notes" {
+		t.Fatalf("Expected the new column in the middle, got %+v", columns)
+	}
+
+	if _, err := c.DeleteTableColumn("name"); err != nil {
+		t.Fatalf("DeleteTableColumn() returned an unexpected error: %v", err)
+	}
+	table := readTable(t, c)
+	if len(table.Columns) != 2 || len(table.Rows[0].Cells) != 0 {
+		t.Errorf("Expected the column and its cells to be removed, got %+v", table)
+	}
+	if _, err := c.DeleteTableColumn("name"); !errors.Is(err, content.ErrTableColumnNotFound) {
+		t.Errorf("Expected ErrTableColumnNotFound, got %v", err)
+	}
+}
+
+func TestContent_ReplaceTable_KeepsVersionsOfUnchangedCells(t *testing.T) {
+	c := newTable(t, 0)
+	c.UpdateTableCell("r1", "price", "10", 0)
+	c.Version = 1
+
+	data, _ := content.NormalizeTable(`{"columns":[{"id":"name"},{"id":"price"}],"rows":[{"id":"r1","cells":{"name":{"value":"Pro"},"price":{"value":"10"}}}]}`)
+	if err := c.ReplaceTable(data); err != nil {
+		t.Fatalf("ReplaceTable() returned an unexpected error: %v", err)
+	}
+
+	cells := readTable(t, c).Rows[0].Cells
+	if cells["name"].Version != 2 || cells["price"].Version != 1 {
+		t.Errorf("Expected only the changed cell to be stamped, got %+v", cells)
+	}
+}
+
+func TestContent_Table_NotATable(t *testing.T) {
+	c := content.NewContent("c1", "n1", "", content.TextContentType, 0)
+
+	if _, err := c.InsertTableColumn("x", -1); !errors.Is(err, content.ErrNotATable) {
+		t.Errorf("Expected ErrNotATable, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package daily
+
+import "time"
+
+// DateLayout is the layout of the dates daily notes are keyed by.
+const DateLayout = "2006-01-02"
+
+// Today is the date argument that stands for the current date in the user's time zone.
+const Today = "today"
+
+// KeywordPrefix is the root of the keywords daily notes are tagged with, as daily/2006-01-02.
+const KeywordPrefix = "daily"
+
+// DefaultTimeZone is the time zone of users who have not chosen one.
+const DefaultTimeZone = "UTC"
+
+// Settings are a user's preferences for daily notes. TemplateID is the template new daily
+// notes are created from; without one, they are created empty and titled with their date.
+type Settings struct {
+	UserID     string
+	TimeZone   string
+	TemplateID string
+	Version    int
+}
+
+// NewSettings creates new Settings. An empty time zone stands for DefaultTimeZone.
+func NewSettings(userID, timeZone, templateID string, version int) (*Settings, error) {
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+	return &Settings{
+		UserID:     userID,
+		TimeZone:   timeZone,
+		TemplateID: templateID,
+		Version:    version,
+	}, nil
+}
+
+// Location returns the time zone of the settings.
+func (s *Settings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ResolveDate returns the date a daily note is asked for by, checking its format. Today
+// resolves to the date of now in the user's time zone.
+func (s *Settings) ResolveDate(date string, now time.Time) (string, error) {
+	if date == Today {
+		return now.In(s.Location()).Format(DateLayout), nil
+	}
+	if err := CheckDate(date); err != nil {
+		return "", err
+	}
+	return date, nil
+}
+
+// CreationTime returns the time a daily note for date is created at: the date with the
+// time of day of now, both in the user's time zone. Templates expand their placeholders
+// with it, so a note created ahead of or after its day still shows its own date.
+func (s *Settings) CreationTime(date string, now time.Time) (time.Time, error) {
+	loc := s.Location()
+	day, err := time.ParseInLocation(DateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	now = now.In(loc)
+	return time.Date(day.Year(), day.Month(), day.Day(), now.Hour(), now.Minute(), now.Second(), 0, loc), nil
+}
+
+// Keyword returns the keyword the daily note for date is tagged with.
+func Keyword(date string) string {
+	return KeywordPrefix + "/" + date
+}
+
+// CheckDate checks that date is written as 2006-01-02.
+func CheckDate(date string) error {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return ErrInvalidDate
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package daily_test
+
+import (
+	"noteapp/internal/domain/daily"
+	"testing"
+	"time"
+)
+
+func TestNewSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		wantZone string
+		wantErr  error
+	}{
+		{"default time zone", "", daily.DefaultTimeZone, nil},
+		{"named time zone", "Asia/Tokyo", "Asia/Tokyo", nil},
+		{"unknown time zone", "Mars/Olympus_Mons", "", daily.ErrInvalidTimeZone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := daily.NewSettings("user-1", tt.timeZone, "", 0)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && s.TimeZone != tt.wantZone {
+				t.Errorf("Expected time zone '%s', got '%s'", tt.wantZone, s.TimeZone)
+			}
+		})
+	}
+}
+
+func TestSettings_ResolveDate(t *testing.T) {
+	s, _ := daily.NewSettings("user-1", "Asia/Tokyo", "", 0)
+	now := time.Date(2024, 3, 4, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    string
+		want    string
+		wantErr error
+	}{
+		{"today in the user's time zone", daily.Today, "2024-03-05", nil},
+		{"explicit date", "2024-02-29", "2024-02-29", nil},
+		{"not a date", "yesterday", "", daily.ErrInvalidDate},
+		{"impossible date", "2024-02-30", "", daily.ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ResolveDate(tt.date, now)
+
+			if err != tt.wantErr || got != tt.want {
+				t.Errorf("Expected ('%s', %v), got ('%s', %v)", tt.want, tt.wantErr, got, err)
+			}
+		})
+	}
+}
+
+func TestSettings_CreationTime(t *testing.T) {
+	s, _ := daily.NewSettings("user-1", "Asia/Tokyo", "", 0)
+	now := time.Date(2024, 3, 4, 20, 15, 0, 0, time.UTC)
+
+	at, err := s.CreationTime("2024-03-01", now)
+
+	if err != nil {
+		t.Fatalf("CreationTime returned an unexpected error: %v", err)
+	}
+	if got := at.Format("2006-01-02 15:04 MST"); got != "2024-03-01 05:15 JST" {
+		t.Errorf("Expected '2024-03-01 05:15 JST', got '%s'", got)
+	}
+}
@@ -0,0 +1,9 @@
+package daily
+
+import "errors"
+
+// ErrInvalidDate is returned when a date is not written as 2006-01-02.
+var ErrInvalidDate = errors.New("date must be formatted as YYYY-MM-DD")
+
+// ErrInvalidTimeZone is returned when a time zone is not a known IANA time zone name.
+var ErrInvalidTimeZone = errors.New("unknown time zone")
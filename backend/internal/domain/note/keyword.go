@@ -1,24 +1,55 @@
 package note
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // ErrEmptyKeyword is returned when a keyword is created with empty text.
 var ErrEmptyKeyword = errors.New("keyword text cannot be empty")
 
+// ErrInvalidKeywordPath is returned when a hierarchical keyword contains an empty segment.
+var ErrInvalidKeywordPath = errors.New("keyword path cannot contain empty segments")
+
+// KeywordPathSeparator separates the segments of a hierarchical keyword such as "cs/os/concurrency".
+const KeywordPathSeparator = "/"
+
 // Keyword is a value object representing a keyword.
+// A keyword may be hierarchical, with segments separated by KeywordPathSeparator.
 type Keyword struct {
 	text string
 }
 
-// NewKeyword creates a new Keyword. It returns an error if the text is empty.
+// NewKeyword creates a new Keyword. It returns an error if the text is empty
+// or if any segment of a hierarchical keyword is empty.
 func NewKeyword(text string) (Keyword, error) {
 	if text == "" {
 		return Keyword{}, ErrEmptyKeyword
 	}
+	for _, segment := range strings.Split(text, KeywordPathSeparator) {
+		if segment == "" {
+			return Keyword{}, ErrInvalidKeywordPath
+		}
+	}
 	return Keyword{text: text}, nil
 }
 
 // String returns the keyword text.
 func (k Keyword) String() string {
 	return k.text
-}
\ No newline at end of file
+}
+
+// Segments returns the path segments of the keyword, from root to leaf.
+func (k Keyword) Segments() []string {
+	return strings.Split(k.text, KeywordPathSeparator)
+}
+
+// IsDescendantOf reports whether the keyword lies strictly below ancestor in the keyword hierarchy.
+func (k Keyword) IsDescendantOf(ancestor Keyword) bool {
+	return strings.HasPrefix(k.text, ancestor.text+KeywordPathSeparator)
+}
+
+// Matches reports whether the keyword equals query or, if includeDescendants is set, lies below it.
+func (k Keyword) Matches(query Keyword, includeDescendants bool) bool {
+	return k == query || (includeDescendants && k.IsDescendantOf(query))
+}
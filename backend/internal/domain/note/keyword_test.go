@@ -26,3 +26,45 @@ func TestNewKeyword_EmptyText(t *testing.T) {
 		t.Errorf("Expected error to be '%v', but got '%v'", ErrEmptyKeyword, err)
 	}
 }
+
+func TestNewKeyword_InvalidPath(t *testing.T) {
+	for _, text := range []string{"/cs", "cs/", "cs//os"} {
+		_, err := NewKeyword(text)
+		if err != ErrInvalidKeywordPath {
+			t.Errorf("Expected error to be '%v' for '%s', but got '%v'", ErrInvalidKeywordPath, text, err)
+		}
+	}
+}
+
+func TestKeyword_Segments(t *testing.T) {
+	keyword, _ := NewKeyword("cs/os/concurrency")
+
+	segments := keyword.Segments()
+
+	if len(segments) != 3 {
+		t.Fatalf("Expected 3 segments, but got %d", len(segments))
+	}
+	if segments[0] != "cs" || segments[1] != "os" || segments[2] != "concurrency" {
+		t.Errorf("Expected segments [cs os concurrency], but got %v", segments)
+	}
+}
+
+func TestKeyword_Matches(t *testing.T) {
+	query, _ := NewKeyword("cs/os")
+	exact, _ := NewKeyword("cs/os")
+	child, _ := NewKeyword("cs/os/concurrency")
+	sibling, _ := NewKeyword("cs/osx")
+
+	if !exact.Matches(query, false) {
+		t.Error("Expected exact keyword to match without descendants")
+	}
+	if child.Matches(query, false) {
+		t.Error("Expected child keyword not to match without descendants")
+	}
+	if !child.Matches(query, true) {
+		t.Error("Expected child keyword to match with descendants")
+	}
+	if sibling.Matches(query, true) {
+		t.Error("Expected sibling keyword sharing a text prefix not to match")
+	}
+}
@@ -2,6 +2,7 @@ package note
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -24,6 +25,10 @@ var ErrPermissionDenied = errors.New("permission denied")
 // ErrIndexOutOfBounds is returned when an index is out of bounds.
 var ErrIndexOutOfBounds = errors.New("index out of bounds")
 
+// ErrInvalidContentOrder is returned when a new order of contents is not a permutation of the
+// note's contents.
+var ErrInvalidContentOrder = errors.New("content order must list each content of the note exactly once")
+
 // Permission defines the access level for a collaborator.
 type Permission string
 
@@ -42,7 +47,13 @@ type Note struct {
 	Version       int
 	ContentIDs    []string
 	keywords      map[string][]Keyword
+	states        map[string]State
 	Collaborators map[string]Permission
+	// CreatedAt and UpdatedAt record when the note was created and last changed,
+	// and LastModifiedBy records who made the last change.
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastModifiedBy string
 }
 
 // NewNoteWithVersion creates a new Note instance with a specific version.
@@ -63,6 +74,7 @@ func NewNoteWithVersion(id, title, ownerID string, version int) (*Note, error) {
 		Version:       version,
 		ContentIDs:    []string{},
 		keywords:      make(map[string][]Keyword),
+		states:        make(map[string]State),
 		Collaborators: make(map[string]Permission),
 	}, nil
 }
@@ -73,6 +85,18 @@ func NewNote(id, title, ownerID string) (*Note, error) {
 	return NewNoteWithVersion(id, title, ownerID, 0)
 }
 
+// MarkCreated records that the note was created by creatorID at the given time.
+func (n *Note) MarkCreated(creatorID string, at time.Time) {
+	n.CreatedAt = at
+	n.MarkModified(creatorID, at)
+}
+
+// MarkModified records that the note was changed by editorID at the given time.
+func (n *Note) MarkModified(editorID string, at time.Time) {
+	n.UpdatedAt = at
+	n.LastModifiedBy = editorID
+}
+
 // ChangeTitle updates the title of the note.
 func (n *Note) ChangeTitle(newTitle string) error {
 	if newTitle == "" {
@@ -101,9 +125,22 @@ func (n *Note) RemoveCollaborator(callerID string, collaboratorID string) error
 	}
 	delete(n.Collaborators, collaboratorID)
 	delete(n.keywords, collaboratorID)
+	delete(n.states, collaboratorID)
 	return nil
 }
 
+// CanView reports whether a user may view the note: its owner and all its collaborators may.
+func (n *Note) CanView(userID string) bool {
+	_, ok := n.Collaborators[userID]
+	return n.OwnerID == userID || ok
+}
+
+// CanEdit reports whether a user may change the note: its owner and its read-write
+// collaborators may.
+func (n *Note) CanEdit(userID string) bool {
+	return n.OwnerID == userID || n.Collaborators[userID] == ReadWrite
+}
+
 // Keywords returns a deep copy of the note's keywords.
 func (n *Note) Keywords() map[string][]Keyword {
 	keywordsCopy := make(map[string][]Keyword)
@@ -139,6 +176,89 @@ func (n *Note) AddContentID(id string, index int) error {
 	return nil
 }
 
+// MoveContentID moves a content ID of the note to newIndex, shifting the contents in between.
+func (n *Note) MoveContentID(id string, newIndex int) error {
+	from := -1
+	for i, contentID := range n.ContentIDs {
+		if contentID == id {
+			from = i
+			break
+		}
+	}
+	if from < 0 {
+		return ErrContentNotFound
+	}
+	if newIndex < 0 || newIndex >= len(n.ContentIDs) {
+		return ErrIndexOutOfBounds
+	}
+
+	n.ContentIDs = append(n.ContentIDs[:from], n.ContentIDs[from+1:]...)
+	n.ContentIDs = append(n.ContentIDs[:newIndex], append([]string{id}, n.ContentIDs[newIndex:]...)...)
+	return nil
+}
+
+// ReorderContentIDs replaces the order of the note's contents. The new order must list each
+// content of the note exactly once.
+func (n *Note) ReorderContentIDs(order []string) error {
+	if len(order) != len(n.ContentIDs) {
+		return ErrInvalidContentOrder
+	}
+	remaining := make(map[string]int, len(n.ContentIDs))
+	for _, id := range n.ContentIDs {
+		remaining[id]++
+	}
+	for _, id := range order {
+		if remaining[id] == 0 {
+			return ErrInvalidContentOrder
+		}
+		remaining[id]--
+	}
+	n.ContentIDs = append([]string{}, order...)
+	return nil
+}
+
+// SplitContentIDs removes the contents from index onwards and returns them. Both parts must
+// keep at least one content.
+func (n *Note) SplitContentIDs(index int) ([]string, error) {
+	if index <= 0 || index >= len(n.ContentIDs) {
+		return nil, ErrIndexOutOfBounds
+	}
+	tail := append([]string{}, n.ContentIDs[index:]...)
+	n.ContentIDs = n.ContentIDs[:index]
+	return tail, nil
+}
+
+// Merge appends the contents of other to the note and adds, for each user who can view the
+// note, the keywords on other that the note lacks. The keywords of other users are dropped,
+// so they cannot find the note by them. It returns the keywords it added, by user. The title
+// and collaborators of the note are kept as they are.
+func (n *Note) Merge(other *Note) map[string][]Keyword {
+	n.ContentIDs = append(n.ContentIDs, other.ContentIDs...)
+	added := make(map[string][]Keyword)
+	for userID, keywords := range other.keywords {
+		if !n.CanView(userID) {
+			continue
+		}
+		for _, keyword := range keywords {
+			if !n.HasKeyword(userID, keyword) {
+				n.AddKeyword(userID, keyword)
+				added[userID] = append(added[userID], keyword)
+			}
+		}
+	}
+	return added
+}
+
+// HasKeyword reports whether a user tagged the note with a keyword.
+func (n *Note) HasKeyword(userID string, keyword Keyword) bool {
+	for _, k := range n.keywords[userID] {
+		if k == keyword {
+			return true
+		}
+	}
+	return false
+}
+
 // AddKeyword adds a new keyword to the note for a specific user.
 func (n *Note) AddKeyword(userID string, keyword Keyword) {
 	n.keywords[userID] = append(n.keywords[userID], keyword)
@@ -1,7 +1,9 @@
 package note
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewNote_ValidCreation_WithInjectedID(t *testing.T) {
@@ -336,3 +338,181 @@ func TestNote_RemoveCollaborator_CollaboratorNotFound(t *testing.T) {
 		t.Errorf("Expected 1 collaborator, but got %d", len(note.Collaborators))
 	}
 }
+
+func TestNote_MarkCreatedAndModified(t *testing.T) {
+	// Arrange
+	note, _ := NewNote("", "Test Note", "owner-1")
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	modified := created.Add(time.Hour)
+
+	// Act
+	note.MarkCreated("owner-1", created)
+	note.MarkModified("user-2", modified)
+
+	// Assert
+	if !note.CreatedAt.Equal(created) {
+		t.Errorf("Expected CreatedAt to be %v, but got %v", created, note.CreatedAt)
+	}
+	if !note.UpdatedAt.Equal(modified) {
+		t.Errorf("Expected UpdatedAt to be %v, but got %v", modified, note.UpdatedAt)
+	}
+	if note.LastModifiedBy != "user-2" {
+		t.Errorf("Expected LastModifiedBy to be 'user-2', but got '%s'", note.LastModifiedBy)
+	}
+}
+
+func TestNote_CanViewAndCanEdit(t *testing.T) {
+	note, _ := NewNote("note1", "Test Note", "owner1")
+	note.AddCollaborator("owner1", "writer", ReadWrite)
+	note.AddCollaborator("owner1", "reader", ReadOnly)
+
+	tests := []struct {
+		userID  string
+		canView bool
+		canEdit bool
+	}{
+		{"owner1", true, true},
+		{"writer", true, true},
+		{"reader", true, false},
+		{"stranger", false, false},
+	}
+	for _, tt := range tests {
+		if got := note.CanView(tt.userID); got != tt.canView {
+			t.Errorf("CanView(%q) = %v; want %v", tt.userID, got, tt.canView)
+		}
+		if got := note.CanEdit(tt.userID); got != tt.canEdit {
+			t.Errorf("CanEdit(%q) = %v; want %v", tt.userID, got, tt.canEdit)
+		}
+	}
+}
+
+func TestNote_MoveContentID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		newIndex int
+		want     []string
+		wantErr  error
+	}{
+		{"move forward", "a", 2, []string{"b", "c", "a", "d"}, nil},
+		{"move backward", "d", 1, []string{"a", "d", "b", "c"}, nil},
+		{"same position", "b", 1, []string{"a", "b", "c", "d"}, nil},
+		{"index past the end", "a", 4, []string{"a", "b", "c", "d"}, ErrIndexOutOfBounds},
+		{"unknown content", "x", 0, []string{"a", "b", "c", "d"}, ErrContentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			note, _ := NewNote("note-1", "Test Note", "owner-1")
+			note.ContentIDs = []string{"a", "b", "c", "d"}
+
+			// Act
+			err := note.MoveContentID(tt.id, tt.newIndex)
+
+			// Assert
+			if err != tt.wantErr {
+				t.Fatalf("Expected error to be '%v', but got '%v'", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(note.ContentIDs, tt.want) {
+				t.Errorf("Expected content IDs %v, but got %v", tt.want, note.ContentIDs)
+			}
+		})
+	}
+}
+
+func TestNote_ReorderContentIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []string
+		wantErr error
+	}{
+		{"permutation", []string{"c", "a", "b"}, nil},
+		{"missing content", []string{"c", "a"}, ErrInvalidContentOrder},
+		{"duplicate content", []string{"c", "a", "a"}, ErrInvalidContentOrder},
+		{"unknown content", []string{"c", "a", "x"}, ErrInvalidContentOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, _ := NewNote("note-1", "Test Note", "owner-1")
+			note.ContentIDs = []string{"a", "b", "c"}
+
+			err := note.ReorderContentIDs(tt.order)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error to be '%v', but got '%v'", tt.wantErr, err)
+			}
+			want := tt.order
+			if err != nil {
+				want = []string{"a", "b", "c"}
+			}
+			if !reflect.DeepEqual(note.ContentIDs, want) {
+				t.Errorf("Expected content IDs %v, but got %v", want, note.ContentIDs)
+			}
+		})
+	}
+}
+
+func TestNote_SplitContentIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    int
+		wantTail []string
+		wantErr  error
+	}{
+		{"middle", 1, []string{"b", "c"}, nil},
+		{"last", 2, []string{"c"}, nil},
+		{"first", 0, nil, ErrIndexOutOfBounds},
+		{"past the end", 3, nil, ErrIndexOutOfBounds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, _ := NewNote("note-1", "Test Note", "owner-1")
+			note.ContentIDs = []string{"a", "b", "c"}
+
+			tail, err := note.SplitContentIDs(tt.index)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error to be '%v', but got '%v'", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(tail, tt.wantTail) {
+				t.Errorf("Expected split off content IDs %v, but got %v", tt.wantTail, tail)
+			}
+			if len(note.ContentIDs)+len(tail) != 3 {
+				t.Errorf("Expected the note to keep the other contents, but got %v", note.ContentIDs)
+			}
+		})
+	}
+}
+
+func TestNote_Merge(t *testing.T) {
+	goKeyword, _ := NewKeyword("go")
+	testingKeyword, _ := NewKeyword("testing")
+	javaKeyword, _ := NewKeyword("java")
+	note, _ := NewNote("note-1", "Target", "owner-1")
+	note.ContentIDs = []string{"a"}
+	note.AddCollaborator("owner-1", "user-1", ReadWrite)
+	note.AddKeyword("user-1", goKeyword)
+	other, _ := NewNote("note-2", "Other", "owner-2")
+	other.ContentIDs = []string{"b", "c"}
+	other.AddCollaborator("owner-2", "user-2", ReadOnly)
+	other.AddKeyword("user-1", goKeyword)
+	other.AddKeyword("user-1", testingKeyword)
+	other.AddKeyword("user-2", javaKeyword)
+
+	added := note.Merge(other)
+
+	if !reflect.DeepEqual(note.ContentIDs, []string{"a", "b", "c"}) {
+		t.Errorf("Expected the contents to be concatenated, but got %v", note.ContentIDs)
+	}
+	wantKeywords := map[string][]Keyword{"user-1": {goKeyword, testingKeyword}}
+	if !reflect.DeepEqual(note.Keywords(), wantKeywords) {
+		t.Errorf("Expected keywords %v without those of user-2, who cannot view the note, but got %v", wantKeywords, note.Keywords())
+	}
+	wantAdded := map[string][]Keyword{"user-1": {testingKeyword}}
+	if !reflect.DeepEqual(added, wantAdded) {
+		t.Errorf("Expected added keywords %v, but got %v", wantAdded, added)
+	}
+	if note.Title != "Target" || !reflect.DeepEqual(note.Collaborators, map[string]Permission{"user-1": ReadWrite}) {
+		t.Errorf("Expected the title and collaborators to be kept, but got %q and %v", note.Title, note.Collaborators)
+	}
+}
@@ -0,0 +1,45 @@
+package note
+
+// State is how a user keeps a note in their own lists. Like keywords, it belongs to the user:
+// pinning, archiving or favouriting a shared note does not change it for anyone else.
+type State struct {
+	// Pinned notes are listed before all others.
+	Pinned bool
+	// Archived notes are left out of lists unless asked for.
+	Archived bool
+	// Favourite notes can be listed on their own.
+	Favourite bool
+}
+
+// IsZero reports whether the state is the default state of a note nobody has marked.
+func (s State) IsZero() bool {
+	return s == State{}
+}
+
+// State returns the state of the note for a specific user.
+func (n *Note) State(userID string) State {
+	return n.states[userID]
+}
+
+// States returns a copy of the states of the note, by user. Users whose state is the default
+// are left out.
+func (n *Note) States() map[string]State {
+	statesCopy := make(map[string]State, len(n.states))
+	for userID, state := range n.states {
+		statesCopy[userID] = state
+	}
+	return statesCopy
+}
+
+// SetState changes the state of the note for a user who can view it.
+func (n *Note) SetState(userID string, state State) error {
+	if !n.CanView(userID) {
+		return ErrPermissionDenied
+	}
+	if state.IsZero() {
+		delete(n.states, userID)
+		return nil
+	}
+	n.states[userID] = state
+	return nil
+}
@@ -0,0 +1,50 @@
+package note
+
+import (
+	"testing"
+)
+
+func TestNote_SetState(t *testing.T) {
+	n, _ := NewNote("note-1", "Title", "owner-1")
+	n.AddCollaborator("owner-1", "collab-1", ReadOnly)
+
+	if err := n.SetState("collab-1", State{Pinned: true}); err != nil {
+		t.Fatalf("SetState returned an unexpected error: %v", err)
+	}
+	if err := n.SetState("owner-1", State{Archived: true, Favourite: true}); err != nil {
+		t.Fatalf("SetState returned an unexpected error: %v", err)
+	}
+
+	if state := n.State("collab-1"); state != (State{Pinned: true}) {
+		t.Errorf("Expected the collaborator's note to be pinned only, got %+v", state)
+	}
+	if state := n.State("owner-1"); state != (State{Archived: true, Favourite: true}) {
+		t.Errorf("Expected the owner's note to be archived and favourite, got %+v", state)
+	}
+	if err := n.SetState("stranger", State{Pinned: true}); err != ErrPermissionDenied {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrPermissionDenied, err)
+	}
+}
+
+func TestNote_SetState_DefaultStateIsDropped(t *testing.T) {
+	n, _ := NewNote("note-1", "Title", "owner-1")
+	n.SetState("owner-1", State{Pinned: true})
+
+	n.SetState("owner-1", State{})
+
+	if states := n.States(); len(states) != 0 {
+		t.Errorf("Expected no states once the note is unpinned, got %+v", states)
+	}
+}
+
+func TestNote_RemoveCollaborator_DropsState(t *testing.T) {
+	n, _ := NewNote("note-1", "Title", "owner-1")
+	n.AddCollaborator("owner-1", "collab-1", ReadWrite)
+	n.SetState("collab-1", State{Favourite: true})
+
+	n.RemoveCollaborator("owner-1", "collab-1")
+
+	if state := n.State("collab-1"); !state.IsZero() {
+		t.Errorf("Expected the former collaborator's state to be dropped, got %+v", state)
+	}
+}
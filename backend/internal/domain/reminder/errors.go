@@ -0,0 +1,17 @@
+package reminder
+
+import "errors"
+
+// ErrInvalidTime is returned when a due time is not written as 2006-01-02T15:04.
+var ErrInvalidTime = errors.New("time must be formatted as YYYY-MM-DDTHH:MM")
+
+// ErrInvalidTimeZone is returned when a time zone is not a known IANA time zone name.
+var ErrInvalidTimeZone = errors.New("unknown time zone")
+
+// ErrInvalidRecurrence is returned when a recurrence has an unknown frequency, a negative
+// interval, or ends before the reminder is first due.
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// ErrDueTimeTooOld is returned when a reminder would first be due more than MaxOverdue before
+// it is set.
+var ErrDueTimeTooOld = errors.New("due time is too far in the past")
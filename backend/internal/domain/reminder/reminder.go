@@ -0,0 +1,259 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocalTimeLayout is the layout of the local dates and times reminders are due at.
+const LocalTimeLayout = "2006-01-02T15:04"
+
+// DefaultTimeZone is the time zone of reminders created without one.
+const DefaultTimeZone = "UTC"
+
+// MaxOverdue is how long before it is set a reminder may first be due. Due times further in the
+// past are more likely mistakes than reminders of what was already missed.
+const MaxOverdue = 30 * 24 * time.Hour
+
+// Frequencies a reminder can recur at. Once is a reminder that does not recur.
+const (
+	Once    = ""
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	Yearly  = "yearly"
+)
+
+// Recurrence describes how a reminder repeats: every Interval days, weeks, months or years,
+// up to and including Until. A zero Until repeats forever.
+type Recurrence struct {
+	Frequency string
+	Interval  int
+	Until     time.Time
+}
+
+// Reminder reminds a user of a note at a due time, and again at each recurrence. Occurrences
+// keep their wall-clock time in the reminder's time zone across daylight saving changes.
+type Reminder struct {
+	ID         string
+	NoteID     string
+	UserID     string
+	DueAt      time.Time
+	TimeZone   string
+	Recurrence Recurrence
+	Message    string
+	// Occurrence is the index of the next occurrence, counting from DueAt.
+	Occurrence int
+	// NextAt is when the next occurrence is due, and is zero once no occurrence is left.
+	NextAt time.Time
+	// LastFiredAt is when the latest occurrence that fired was due.
+	LastFiredAt time.Time
+	Version     int
+}
+
+// NewReminder creates a new Reminder, due first at dueAt.
+// If id is empty, a new UUID will be generated.
+func NewReminder(id, noteID, userID string, dueAt time.Time, timeZone string, recurrence Recurrence, message string, version int) (*Reminder, error) {
+	r := &Reminder{
+		ID:      id,
+		NoteID:  noteID,
+		UserID:  userID,
+		Message: message,
+		Version: version,
+	}
+	if err := r.Reschedule(dueAt, timeZone, recurrence); err != nil {
+		return nil, err
+	}
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return r, nil
+}
+
+// Reschedule changes when the reminder is due and how it recurs, starting over from its
+// first occurrence. An empty time zone stands for DefaultTimeZone.
+func (r *Reminder) Reschedule(dueAt time.Time, timeZone string, recurrence Recurrence) error {
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return ErrInvalidTimeZone
+	}
+	if dueAt.IsZero() {
+		return ErrInvalidTime
+	}
+	recurrence, err = normalizeRecurrence(recurrence, dueAt)
+	if err != nil {
+		return err
+	}
+
+	r.DueAt = dueAt.In(loc)
+	r.TimeZone = timeZone
+	r.Recurrence = recurrence
+	r.Occurrence = 0
+	r.NextAt = r.DueAt
+	return nil
+}
+
+// Location returns the time zone of the reminder.
+func (r *Reminder) Location() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsDue reports whether an occurrence of the reminder is due at now.
+func (r *Reminder) IsDue(now time.Time) bool {
+	return !r.NextAt.IsZero() && !r.NextAt.After(now)
+}
+
+// Fire marks the due occurrences of the reminder as fired and moves on to the first
+// occurrence after now. Occurrences missed while nothing fired them are folded into one,
+// so Fire returns the latest of them, or false if none is due.
+func (r *Reminder) Fire(now time.Time) (time.Time, bool) {
+	if !r.IsDue(now) {
+		return time.Time{}, false
+	}
+	loc := r.Location()
+	last := now
+	if until := r.Recurrence.Until; !until.IsZero() && until.Before(now) {
+		last = until
+	}
+
+	// Estimate the latest occurrence due by last from the days, months or years elapsed since
+	// the first one, instead of stepping through each missed occurrence. The estimate is at
+	// most one occurrence too late, when last falls before the time of day the reminder is due.
+	n := max(r.Occurrence, r.elapsedOccurrences(last, loc))
+	fired := r.occurrenceAt(n, loc)
+	for n > r.Occurrence && (fired.IsZero() || fired.After(last)) {
+		n--
+		fired = r.occurrenceAt(n, loc)
+	}
+
+	r.Occurrence = n + 1
+	r.NextAt = r.occurrenceAt(r.Occurrence, loc)
+	r.LastFiredAt = fired
+	return fired, true
+}
+
+// elapsedOccurrences returns how many recurrence intervals start between the date the reminder
+// is first due and the date of t, in the reminder's time zone.
+func (r *Reminder) elapsedOccurrences(t time.Time, loc *time.Location) int {
+	dueYear, dueMonth, dueDay := r.DueAt.In(loc).Date()
+	year, month, day := t.In(loc).Date()
+
+	var elapsed int
+	switch r.Recurrence.Frequency {
+	case Daily:
+		elapsed = daysBetween(dueYear, dueMonth, dueDay, year, month, day)
+	case Weekly:
+		elapsed = daysBetween(dueYear, dueMonth, dueDay, year, month, day) / 7
+	case Monthly:
+		elapsed = (year-dueYear)*12 + int(month-dueMonth)
+	case Yearly:
+		elapsed = year - dueYear
+	default:
+		return 0
+	}
+	return max(elapsed/r.Recurrence.Interval, 0)
+}
+
+// occurrenceAt returns when the nth occurrence of the reminder is due in loc, the reminder's
+// time zone, or zero if there is no such occurrence.
+func (r *Reminder) occurrenceAt(n int, loc *time.Location) time.Time {
+	if n == 0 {
+		return r.DueAt
+	}
+	step := n * r.Recurrence.Interval
+	due := r.DueAt.In(loc)
+	year, month, day := due.Date()
+
+	var at time.Time
+	switch r.Recurrence.Frequency {
+	case Daily:
+		at = dateIn(due, year, month, day+step)
+	case Weekly:
+		at = dateIn(due, year, month, day+7*step)
+	case Monthly:
+		at = clampedDateIn(due, year, month+time.Month(step), day)
+	case Yearly:
+		at = clampedDateIn(due, year+step, month, day)
+	default:
+		return time.Time{}
+	}
+	if !r.Recurrence.Until.IsZero() && at.After(r.Recurrence.Until) {
+		return time.Time{}
+	}
+	return at
+}
+
+// CheckDueAt returns ErrDueTimeTooOld if a reminder set at now would first be due more than
+// MaxOverdue earlier.
+func CheckDueAt(dueAt, now time.Time) error {
+	if dueAt.Before(now.Add(-MaxOverdue)) {
+		return ErrDueTimeTooOld
+	}
+	return nil
+}
+
+// daysBetween returns the number of days from one date to another.
+func daysBetween(fromYear int, fromMonth time.Month, fromDay, toYear int, toMonth time.Month, toDay int) int {
+	from := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	to := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int((to.Unix() - from.Unix()) / (24 * 60 * 60))
+}
+
+// dateIn returns the date at the wall-clock time of due, in its time zone.
+func dateIn(due time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, due.Hour(), due.Minute(), due.Second(), 0, due.Location())
+}
+
+// clampedDateIn is dateIn with the day moved back to the last day of the month when the month
+// is too short, so that a reminder due on the 31st recurs on the last day of shorter months.
+func clampedDateIn(due time.Time, year int, month time.Month, day int) time.Time {
+	if last := dateIn(due, year, month+1, 0).Day(); day > last {
+		day = last
+	}
+	return dateIn(due, year, month, day)
+}
+
+func normalizeRecurrence(recurrence Recurrence, dueAt time.Time) (Recurrence, error) {
+	switch recurrence.Frequency {
+	case Once:
+		return Recurrence{}, nil
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	if recurrence.Interval < 0 {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	if recurrence.Interval == 0 {
+		recurrence.Interval = 1
+	}
+	if !recurrence.Until.IsZero() && recurrence.Until.Before(dueAt) {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	return recurrence, nil
+}
+
+// ParseLocalTime parses a date and time written as 2006-01-02T15:04 in a time zone. An empty
+// time zone stands for DefaultTimeZone.
+func ParseLocalTime(value, timeZone string) (time.Time, error) {
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeZone
+	}
+	t, err := time.ParseInLocation(LocalTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidTime
+	}
+	return t, nil
+}
@@ -0,0 +1,197 @@
+package reminder_test
+
+import (
+	"noteapp/internal/domain/reminder"
+	"testing"
+	"time"
+)
+
+func TestNewReminder(t *testing.T) {
+	dueAt := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		timeZone   string
+		recurrence reminder.Recurrence
+		wantErr    error
+	}{
+		{"once in the default time zone", "", reminder.Recurrence{}, nil},
+		{"daily in a named time zone", "Asia/Tokyo", reminder.Recurrence{Frequency: reminder.Daily}, nil},
+		{"unknown time zone", "Mars/Olympus_Mons", reminder.Recurrence{}, reminder.ErrInvalidTimeZone},
+		{"unknown frequency", "", reminder.Recurrence{Frequency: "hourly"}, reminder.ErrInvalidRecurrence},
+		{"negative interval", "", reminder.Recurrence{Frequency: reminder.Weekly, Interval: -1}, reminder.ErrInvalidRecurrence},
+		{"ends before it starts", "", reminder.Recurrence{Frequency: reminder.Daily, Until: dueAt.Add(-time.Hour)}, reminder.ErrInvalidRecurrence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := reminder.NewReminder("", "note-1", "user-1", dueAt, tt.timeZone, tt.recurrence, "", 0)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if r.ID == "" || !r.NextAt.Equal(dueAt) {
+				t.Errorf("Expected a new reminder next due at %v, got %+v", dueAt, r)
+			}
+			if tt.recurrence.Frequency != reminder.Once && r.Recurrence.Interval != 1 {
+				t.Errorf("Expected the interval to default to 1, got %d", r.Recurrence.Interval)
+			}
+		})
+	}
+}
+
+func TestParseLocalTime(t *testing.T) {
+	got, err := reminder.ParseLocalTime("2024-03-05T07:30", "Asia/Tokyo")
+
+	if err != nil {
+		t.Fatalf("ParseLocalTime returned an unexpected error: %v", err)
+	}
+	if want := time.Date(2024, 3, 4, 22, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if _, err := reminder.ParseLocalTime("2024-03-05 07:30", ""); err != reminder.ErrInvalidTime {
+		t.Errorf("Expected error '%v', got '%v'", reminder.ErrInvalidTime, err)
+	}
+}
+
+func TestReminder_Fire_Once(t *testing.T) {
+	dueAt := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	r, _ := reminder.NewReminder("", "note-1", "user-1", dueAt, "", reminder.Recurrence{}, "", 0)
+
+	if _, ok := r.Fire(dueAt.Add(-time.Minute)); ok {
+		t.Fatalf("Expected the reminder not to fire before it is due")
+	}
+	fired, ok := r.Fire(dueAt.Add(time.Minute))
+	if !ok || !fired.Equal(dueAt) {
+		t.Errorf("Expected the reminder to fire for %v, got %v, %v", dueAt, fired, ok)
+	}
+	if !r.NextAt.IsZero() || !r.LastFiredAt.Equal(dueAt) {
+		t.Errorf("Expected no occurrence left after firing, got %+v", r)
+	}
+	if _, ok := r.Fire(dueAt.Add(time.Hour)); ok {
+		t.Errorf("Expected the reminder to fire only once")
+	}
+}
+
+func TestReminder_Fire_Recurring(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	tests := []struct {
+		name       string
+		dueAt      time.Time
+		recurrence reminder.Recurrence
+		now        time.Time
+		wantFired  time.Time
+		wantNext   time.Time
+	}{
+		{
+			"daily across daylight saving time",
+			time.Date(2024, 3, 30, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Daily},
+			time.Date(2024, 3, 30, 9, 0, 0, 0, paris),
+			time.Date(2024, 3, 30, 9, 0, 0, 0, paris),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, paris),
+		},
+		{
+			"missed occurrences fold into the latest",
+			time.Date(2024, 3, 4, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Weekly, Interval: 2},
+			time.Date(2024, 4, 2, 12, 0, 0, 0, paris),
+			time.Date(2024, 4, 1, 9, 0, 0, 0, paris),
+			time.Date(2024, 4, 15, 9, 0, 0, 0, paris),
+		},
+		{
+			"monthly on the last day of shorter months",
+			time.Date(2024, 1, 31, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Monthly},
+			time.Date(2024, 2, 29, 10, 0, 0, 0, paris),
+			time.Date(2024, 2, 29, 9, 0, 0, 0, paris),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, paris),
+		},
+		{
+			"yearly on a leap day",
+			time.Date(2024, 2, 29, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Yearly},
+			time.Date(2024, 3, 1, 0, 0, 0, 0, paris),
+			time.Date(2024, 2, 29, 9, 0, 0, 0, paris),
+			time.Date(2025, 2, 28, 9, 0, 0, 0, paris),
+		},
+		{
+			"ten years of missed occurrences",
+			time.Date(2024, 3, 4, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Daily},
+			time.Date(2034, 3, 4, 8, 0, 0, 0, paris),
+			time.Date(2034, 3, 3, 9, 0, 0, 0, paris),
+			time.Date(2034, 3, 4, 9, 0, 0, 0, paris),
+		},
+		{
+			"missed months at an interval",
+			time.Date(2024, 1, 31, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Monthly, Interval: 3},
+			time.Date(2025, 5, 15, 9, 0, 0, 0, paris),
+			time.Date(2025, 4, 30, 9, 0, 0, 0, paris),
+			time.Date(2025, 7, 31, 9, 0, 0, 0, paris),
+		},
+		{
+			"until before the time of day it is due",
+			time.Date(2024, 3, 4, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Daily, Until: time.Date(2024, 3, 10, 8, 0, 0, 0, paris)},
+			time.Date(2024, 4, 1, 12, 0, 0, 0, paris),
+			time.Date(2024, 3, 9, 9, 0, 0, 0, paris),
+			time.Time{},
+		},
+		{
+			"until the last occurrence",
+			time.Date(2024, 3, 4, 9, 0, 0, 0, paris),
+			reminder.Recurrence{Frequency: reminder.Daily, Until: time.Date(2024, 3, 5, 9, 0, 0, 0, paris)},
+			time.Date(2024, 3, 5, 9, 30, 0, 0, paris),
+			time.Date(2024, 3, 5, 9, 0, 0, 0, paris),
+			time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := reminder.NewReminder("", "note-1", "user-1", tt.dueAt, "Europe/Paris", tt.recurrence, "", 0)
+
+			fired, ok := r.Fire(tt.now)
+
+			if !ok || !fired.Equal(tt.wantFired) {
+				t.Errorf("Expected the reminder to fire for %v, got %v, %v", tt.wantFired, fired, ok)
+			}
+			if !r.NextAt.Equal(tt.wantNext) {
+				t.Errorf("Expected the next occurrence at %v, got %v", tt.wantNext, r.NextAt)
+			}
+		})
+	}
+}
+
+func TestReminder_Fire_AfterEarlierOccurrences(t *testing.T) {
+	dueAt := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	r, _ := reminder.NewReminder("", "note-1", "user-1", dueAt, "", reminder.Recurrence{Frequency: reminder.Weekly}, "", 0)
+	r.Fire(dueAt)
+
+	if _, ok := r.Fire(dueAt.Add(6 * 24 * time.Hour)); ok {
+		t.Fatalf("Expected the reminder not to fire again before its next occurrence")
+	}
+	fired, ok := r.Fire(dueAt.Add(15 * 24 * time.Hour))
+
+	if want := dueAt.Add(14 * 24 * time.Hour); !ok || !fired.Equal(want) {
+		t.Errorf("Expected the reminder to fire for %v, got %v, %v", want, fired, ok)
+	}
+	if want := dueAt.Add(21 * 24 * time.Hour); r.Occurrence != 3 || !r.NextAt.Equal(want) {
+		t.Errorf("Expected the fourth occurrence next, at %v, got occurrence %d at %v", want, r.Occurrence, r.NextAt)
+	}
+}
+
+func TestCheckDueAt(t *testing.T) {
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	if err := reminder.CheckDueAt(now.Add(-reminder.MaxOverdue), now); err != nil {
+		t.Errorf("Expected a due time MaxOverdue ago to be accepted, got '%v'", err)
+	}
+	if err := reminder.CheckDueAt(now.AddDate(-10, 0, 0), now); err != reminder.ErrDueTimeTooOld {
+		t.Errorf("Expected error '%v', got '%v'", reminder.ErrDueTimeTooOld, err)
+	}
+}
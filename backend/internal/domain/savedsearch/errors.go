@@ -0,0 +1,18 @@
+package savedsearch
+
+import "errors"
+
+// ErrEmptyName is returned when a saved search is created with an empty name.
+var ErrEmptyName = errors.New("saved search name cannot be empty")
+
+// ErrEmptyQuery is returned when a saved search query has no terms.
+var ErrEmptyQuery = errors.New("saved search query cannot be empty")
+
+// ErrInvalidQuery is returned when a query term is not a valid keyword.
+var ErrInvalidQuery = errors.New("saved search query contains an invalid keyword")
+
+// ErrUnsupportedSortOrder is returned when an unknown sort order is requested.
+var ErrUnsupportedSortOrder = errors.New("unsupported sort order")
+
+// ErrPermissionDenied is returned when a user acts on a saved search they do not own.
+var ErrPermissionDenied = errors.New("permission denied")
@@ -0,0 +1,72 @@
+package savedsearch
+
+import (
+	"strings"
+
+	"noteapp/internal/domain/note"
+)
+
+// descendantsSuffix marks a query term that also matches hierarchical keywords below it, e.g. "cs/os/*".
+const descendantsSuffix = note.KeywordPathSeparator + "*"
+
+// Term is a single keyword condition of a query.
+type Term struct {
+	Keyword            note.Keyword
+	IncludeDescendants bool
+}
+
+// Query is a value object describing which notes a saved search matches.
+// It is written as space-separated keywords that must all be present on a note,
+// where a keyword ending in "/*" also matches the keywords below it.
+type Query struct {
+	text  string
+	terms []Term
+}
+
+// ParseQuery parses the textual form of a query.
+func ParseQuery(text string) (Query, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return Query{}, ErrEmptyQuery
+	}
+
+	terms := make([]Term, 0, len(fields))
+	for _, field := range fields {
+		includeDescendants := strings.HasSuffix(field, descendantsSuffix)
+		keyword, err := note.NewKeyword(strings.TrimSuffix(field, descendantsSuffix))
+		if err != nil {
+			return Query{}, ErrInvalidQuery
+		}
+		terms = append(terms, Term{Keyword: keyword, IncludeDescendants: includeDescendants})
+	}
+	return Query{text: strings.Join(fields, " "), terms: terms}, nil
+}
+
+// String returns the normalized textual form of the query.
+func (q Query) String() string {
+	return q.text
+}
+
+// Terms returns a copy of the query's terms.
+func (q Query) Terms() []Term {
+	terms := make([]Term, len(q.terms))
+	copy(terms, q.terms)
+	return terms
+}
+
+// Matches reports whether a set of keywords satisfies every term of the query.
+func (q Query) Matches(keywords []note.Keyword) bool {
+	for _, term := range q.terms {
+		matched := false
+		for _, keyword := range keywords {
+			if keyword.Matches(term.Keyword, term.IncludeDescendants) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package savedsearch
+
+import "github.com/google/uuid"
+
+// SortOrder defines how the results of a saved search are ordered.
+type SortOrder string
+
+const (
+	// SortByTitleAsc orders results alphabetically by title.
+	SortByTitleAsc SortOrder = "title_asc"
+	// SortByTitleDesc orders results reverse-alphabetically by title.
+	SortByTitleDesc SortOrder = "title_desc"
+)
+
+// ParseSortOrder validates a sort order. An empty string defaults to SortByTitleAsc.
+func ParseSortOrder(s string) (SortOrder, error) {
+	switch SortOrder(s) {
+	case "":
+		return SortByTitleAsc, nil
+	case SortByTitleAsc, SortByTitleDesc:
+		return SortOrder(s), nil
+	default:
+		return "", ErrUnsupportedSortOrder
+	}
+}
+
+// SavedSearch is a named keyword query owned by a user, acting as a smart collection of notes.
+type SavedSearch struct {
+	ID      string
+	OwnerID string
+	Name    string
+	Query   Query
+	Sort    SortOrder
+	Version int
+}
+
+// NewSavedSearch creates a new SavedSearch.
+// If id is empty, a new UUID will be generated.
+func NewSavedSearch(id, ownerID, name string, query Query, sort SortOrder, version int) (*SavedSearch, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return &SavedSearch{
+		ID:      id,
+		OwnerID: ownerID,
+		Name:    name,
+		Query:   query,
+		Sort:    sort,
+		Version: version,
+	}, nil
+}
+
+// Update changes the name, query and sort order of the saved search on behalf of callerID.
+func (s *SavedSearch) Update(callerID, name string, query Query, sort SortOrder) error {
+	if s.OwnerID != callerID {
+		return ErrPermissionDenied
+	}
+	if name == "" {
+		return ErrEmptyName
+	}
+	s.Name = name
+	s.Query = query
+	s.Sort = sort
+	return nil
+}
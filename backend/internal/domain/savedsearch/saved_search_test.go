@@ -0,0 +1,96 @@
+package savedsearch_test
+
+import (
+	"noteapp/internal/domain/note"
+	"noteapp/internal/domain/savedsearch"
+	"testing"
+)
+
+func keywords(texts ...string) []note.Keyword {
+	var result []note.Keyword
+	for _, text := range texts {
+		k, _ := note.NewKeyword(text)
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestParseQuery(t *testing.T) {
+	query, err := savedsearch.ParseQuery("  concurrency   cs/os/* ")
+	if err != nil {
+		t.Fatalf("ParseQuery returned an unexpected error: %v", err)
+	}
+
+	if query.String() != "concurrency cs/os/*" {
+		t.Errorf("Expected normalized query 'concurrency cs/os/*', got '%s'", query.String())
+	}
+	terms := query.Terms()
+	if len(terms) != 2 {
+		t.Fatalf("Expected 2 terms, got %d", len(terms))
+	}
+	if terms[0].IncludeDescendants || !terms[1].IncludeDescendants {
+		t.Errorf("Expected only the second term to include descendants, got %+v", terms)
+	}
+	if terms[1].Keyword.String() != "cs/os" {
+		t.Errorf("Expected second term keyword 'cs/os', got '%s'", terms[1].Keyword.String())
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	if _, err := savedsearch.ParseQuery("   "); err != savedsearch.ErrEmptyQuery {
+		t.Errorf("Expected error '%v', got '%v'", savedsearch.ErrEmptyQuery, err)
+	}
+	if _, err := savedsearch.ParseQuery("cs//os"); err != savedsearch.ErrInvalidQuery {
+		t.Errorf("Expected error '%v', got '%v'", savedsearch.ErrInvalidQuery, err)
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	query, _ := savedsearch.ParseQuery("projectx cs/os/*")
+
+	if !query.Matches(keywords("projectx", "cs/os/concurrency")) {
+		t.Error("Expected keywords with a descendant of cs/os and projectx to match")
+	}
+	if query.Matches(keywords("cs/os/concurrency")) {
+		t.Error("Expected keywords missing projectx not to match")
+	}
+	if query.Matches(keywords("projectx", "cs/networks")) {
+		t.Error("Expected keywords outside cs/os not to match")
+	}
+}
+
+func TestNewSavedSearch(t *testing.T) {
+	query, _ := savedsearch.ParseQuery("go")
+
+	s, err := savedsearch.NewSavedSearch("", "user-1", "Go notes", query, savedsearch.SortByTitleAsc, 0)
+
+	if err != nil {
+		t.Fatalf("NewSavedSearch returned an unexpected error: %v", err)
+	}
+	if s.ID == "" {
+		t.Error("Expected ID to be generated, but it is empty")
+	}
+	if _, err := savedsearch.NewSavedSearch("", "user-1", "", query, savedsearch.SortByTitleAsc, 0); err != savedsearch.ErrEmptyName {
+		t.Errorf("Expected error '%v', got '%v'", savedsearch.ErrEmptyName, err)
+	}
+}
+
+func TestSavedSearch_Update_NotOwner(t *testing.T) {
+	query, _ := savedsearch.ParseQuery("go")
+	s, _ := savedsearch.NewSavedSearch("s1", "user-1", "Go notes", query, savedsearch.SortByTitleAsc, 0)
+
+	err := s.Update("user-2", "Renamed", query, savedsearch.SortByTitleDesc)
+
+	if err != savedsearch.ErrPermissionDenied {
+		t.Errorf("Expected error '%v', got '%v'", savedsearch.ErrPermissionDenied, err)
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	if sort, _ := savedsearch.ParseSortOrder(""); sort != savedsearch.SortByTitleAsc {
+		t.Errorf("Expected default sort '%s', got '%s'", savedsearch.SortByTitleAsc, sort)
+	}
+	if _, err := savedsearch.ParseSortOrder("random"); err != savedsearch.ErrUnsupportedSortOrder {
+		t.Errorf("Expected error '%v', got '%v'", savedsearch.ErrUnsupportedSortOrder, err)
+	}
+}
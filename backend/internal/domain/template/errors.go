@@ -0,0 +1,19 @@
+package template
+
+import "errors"
+
+// ErrEmptyName is returned when a template is created with an empty name.
+var ErrEmptyName = errors.New("template name cannot be empty")
+
+// ErrEmptyTitlePattern is returned when a template is created with an empty title pattern.
+var ErrEmptyTitlePattern = errors.New("template title pattern cannot be empty")
+
+// ErrUnsupportedBlockType is returned when a block has a type that cannot be instantiated
+// from a template, such as an image or an attachment.
+var ErrUnsupportedBlockType = errors.New("unsupported template block type")
+
+// ErrUnknownPlaceholder is returned when a title pattern or block uses an unknown placeholder.
+var ErrUnknownPlaceholder = errors.New("unknown template placeholder")
+
+// ErrPermissionDenied is returned when a user acts on a template they do not own.
+var ErrPermissionDenied = errors.New("permission denied")
@@ -0,0 +1,165 @@
+package template
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types of the content blocks of a template. Images and attachments reference uploaded files
+// and cannot be part of a template.
+const (
+	TextBlockType      = "text"
+	CodeBlockType      = "code"
+	ChecklistBlockType = "checklist"
+	TableBlockType     = "table"
+)
+
+// Placeholders that title patterns and blocks may use, written as {{name}}.
+const (
+	// DatePlaceholder expands to the date the note is created, as 2006-01-02.
+	DatePlaceholder = "date"
+	// TimePlaceholder expands to the time the note is created, as 15:04.
+	TimePlaceholder = "time"
+	// WeekdayPlaceholder expands to the day of the week the note is created, as Monday.
+	WeekdayPlaceholder = "weekday"
+	// UserPlaceholder expands to the ID of the user the note is created for.
+	UserPlaceholder = "user"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Block is a content block of a template. Language is the programming language of a code
+// block, and Format the format of a text block.
+type Block struct {
+	Type     string
+	Data     string
+	Language string
+	Format   string
+}
+
+// Values are the values placeholders expand to when a template is instantiated.
+type Values struct {
+	// Now is when the note is created, in the time zone its date and time are shown in.
+	Now    time.Time
+	UserID string
+}
+
+// Template describes the title and the ordered content blocks of notes that share a structure.
+type Template struct {
+	ID           string
+	OwnerID      string
+	Name         string
+	TitlePattern string
+	Blocks       []Block
+	Version      int
+}
+
+// NewTemplate creates a new Template.
+// If id is empty, a new UUID will be generated.
+func NewTemplate(id, ownerID, name, titlePattern string, blocks []Block, version int) (*Template, error) {
+	if err := validate(name, titlePattern, blocks); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return &Template{
+		ID:           id,
+		OwnerID:      ownerID,
+		Name:         name,
+		TitlePattern: titlePattern,
+		Blocks:       append([]Block{}, blocks...),
+		Version:      version,
+	}, nil
+}
+
+// Update changes the name, title pattern and blocks of the template on behalf of callerID.
+func (t *Template) Update(callerID, name, titlePattern string, blocks []Block) error {
+	if t.OwnerID != callerID {
+		return ErrPermissionDenied
+	}
+	if err := validate(name, titlePattern, blocks); err != nil {
+		return err
+	}
+	t.Name = name
+	t.TitlePattern = titlePattern
+	t.Blocks = append([]Block{}, blocks...)
+	return nil
+}
+
+// Instantiate expands the placeholders of the template and returns the title and the blocks
+// of a new note. Placeholders in checklist and table blocks are expanded inside their JSON
+// strings, so the values are escaped.
+func (t *Template) Instantiate(values Values) (string, []Block) {
+	title := expand(t.TitlePattern, values, false)
+	blocks := make([]Block, len(t.Blocks))
+	for i, block := range t.Blocks {
+		block.Data = expand(block.Data, values, isJSONBlock(block.Type))
+		blocks[i] = block
+	}
+	return title, blocks
+}
+
+func validate(name, titlePattern string, blocks []Block) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	if titlePattern == "" {
+		return ErrEmptyTitlePattern
+	}
+	if err := checkPlaceholders(titlePattern); err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		switch block.Type {
+		case TextBlockType, CodeBlockType, ChecklistBlockType, TableBlockType:
+		default:
+			return ErrUnsupportedBlockType
+		}
+		if err := checkPlaceholders(block.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkPlaceholders(text string) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		switch match[1] {
+		case DatePlaceholder, TimePlaceholder, WeekdayPlaceholder, UserPlaceholder:
+		default:
+			return ErrUnknownPlaceholder
+		}
+	}
+	return nil
+}
+
+func expand(text string, values Values, escapeJSON bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		var value string
+		switch placeholderPattern.FindStringSubmatch(placeholder)[1] {
+		case DatePlaceholder:
+			value = values.Now.Format("2006-01-02")
+		case TimePlaceholder:
+			value = values.Now.Format("15:04")
+		case WeekdayPlaceholder:
+			value = values.Now.Weekday().String()
+		case UserPlaceholder:
+			value = values.UserID
+		default:
+			return placeholder
+		}
+		if escapeJSON {
+			quoted, _ := json.Marshal(value)
+			value = string(quoted[1 : len(quoted)-1])
+		}
+		return value
+	})
+}
+
+func isJSONBlock(blockType string) bool {
+	return blockType == ChecklistBlockType || blockType == TableBlockType
+}
@@ -0,0 +1,75 @@
+package template_test
+
+import (
+	"noteapp/internal/domain/template"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		templateName string
+		titlePattern string
+		blocks       []template.Block
+		wantErr      error
+	}{
+		{"valid", "Meeting", "Meeting {{date}}", []template.Block{{Type: template.TextBlockType, Data: "Attendees: {{ user }}"}}, nil},
+		{"empty name", "", "Meeting", nil, template.ErrEmptyName},
+		{"empty title pattern", "Meeting", "", nil, template.ErrEmptyTitlePattern},
+		{"unknown placeholder in title", "Meeting", "Meeting {{room}}", nil, template.ErrUnknownPlaceholder},
+		{"unknown placeholder in block", "Meeting", "Meeting", []template.Block{{Type: template.TextBlockType, Data: "{{room}}"}}, template.ErrUnknownPlaceholder},
+		{"image block", "Meeting", "Meeting", []template.Block{{Type: "image", Data: "hash"}}, template.ErrUnsupportedBlockType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := template.NewTemplate("", "user-1", tt.templateName, tt.titlePattern, tt.blocks, 0)
+
+			if err != tt.wantErr {
+				t.Errorf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Update(t *testing.T) {
+	tmpl, _ := template.NewTemplate("t1", "user-1", "Meeting", "Meeting", nil, 0)
+
+	if err := tmpl.Update("user-2", "Stand-up", "Stand-up", nil); err != template.ErrPermissionDenied {
+		t.Errorf("Expected error '%v', got '%v'", template.ErrPermissionDenied, err)
+	}
+	if err := tmpl.Update("user-1", "Stand-up", "Stand-up {{date}}", nil); err != nil {
+		t.Fatalf("Update returned an unexpected error: %v", err)
+	}
+	if tmpl.Name != "Stand-up" || tmpl.TitlePattern != "Stand-up {{date}}" {
+		t.Errorf("Expected the template to be updated, got %+v", tmpl)
+	}
+}
+
+func TestTemplate_Instantiate(t *testing.T) {
+	blocks := []template.Block{
+		{Type: template.TextBlockType, Data: "# Notes by {{user}} on {{weekday}} at {{time}}", Format: "markdown"},
+		{Type: template.ChecklistBlockType, Data: `[{"text":"Follow up with {{user}}"}]`},
+		{Type: template.CodeBlockType, Data: "// {{unknown", Language: "go"},
+	}
+	tmpl, _ := template.NewTemplate("t1", "user-1", "Meeting", "Meeting {{date}}", blocks, 0)
+	values := template.Values{Now: time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC), UserID: `a "quoted" user`}
+
+	title, instantiated := tmpl.Instantiate(values)
+
+	if title != "Meeting 2024-03-04" {
+		t.Errorf("Expected title 'Meeting 2024-03-04', got '%s'", title)
+	}
+	want := []template.Block{
+		{Type: template.TextBlockType, Data: `# Notes by a "quoted" user on Monday at 09:30`, Format: "markdown"},
+		{Type: template.ChecklistBlockType, Data: `[{"text":"Follow up with a \"quoted\" user"}]`},
+		{Type: template.CodeBlockType, Data: "// {{unknown", Language: "go"},
+	}
+	if !reflect.DeepEqual(instantiated, want) {
+		t.Errorf("Expected blocks %+v, got %+v", want, instantiated)
+	}
+	if tmpl.Blocks[0].Data != blocks[0].Data {
+		t.Errorf("Expected the template to be left unchanged, got %+v", tmpl.Blocks[0])
+	}
+}
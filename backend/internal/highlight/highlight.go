@@ -0,0 +1,167 @@
+// Package highlight renders source code as syntax-highlighted HTML. It uses a small
+// lexer per language that recognizes comments, strings, numbers and keywords, which is
+// enough for readable highlighting without a full parser for each language.
+package highlight
+
+import (
+	"html"
+	"strings"
+)
+
+// CSS classes of the highlighted token kinds.
+const (
+	ClassKeyword = "hl-kw"
+	ClassString  = "hl-str"
+	ClassComment = "hl-com"
+	ClassNumber  = "hl-num"
+)
+
+// HTML renders code as a <pre> block with the tokens of lang wrapped in <span> elements
+// carrying the Class constants. Unsupported languages are rendered as plain escaped text.
+func HTML(code, lang string) string {
+	name, ok := Normalize(lang)
+	if !ok {
+		name = "plaintext"
+	}
+
+	var b strings.Builder
+	b.WriteString(`<pre class="highlight language-`)
+	b.WriteString(name)
+	b.WriteString(`"><code>`)
+	highlightTokens(&b, code, languages[name])
+	b.WriteString("</code></pre>")
+	return b.String()
+}
+
+func highlightTokens(b *strings.Builder, code string, lang *language) {
+	if lang.plain {
+		b.WriteString(html.EscapeString(code))
+		return
+	}
+	plainStart := 0
+	flush := func(end int) {
+		b.WriteString(html.EscapeString(code[plainStart:end]))
+	}
+	emit := func(start, end int, class string) {
+		flush(start)
+		b.WriteString(`<span class="`)
+		b.WriteString(class)
+		b.WriteString(`">`)
+		b.WriteString(html.EscapeString(code[start:end]))
+		b.WriteString("</span>")
+		plainStart = end
+	}
+
+	for i := 0; i < len(code); {
+		if end, ok := lang.matchComment(code, i); ok {
+			emit(i, end, ClassComment)
+			i = end
+			continue
+		}
+		if end, ok := lang.matchString(code, i); ok {
+			emit(i, end, ClassString)
+			i = end
+			continue
+		}
+
+		c := code[i]
+		switch {
+		case isDigit(c) && (i == 0 || !isIdentChar(code[i-1])):
+			end := i + 1
+			for end < len(code) && (isIdentChar(code[end]) || code[end] == '.') {
+				end++
+			}
+			emit(i, end, ClassNumber)
+			i = end
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(code) && isIdentChar(code[end]) {
+				end++
+			}
+			if lang.isKeyword(code[i:end]) {
+				emit(i, end, ClassKeyword)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	flush(len(code))
+}
+
+// matchComment reports whether a comment starts at i and returns the index just past it.
+func (l *language) matchComment(code string, i int) (int, bool) {
+	for _, delims := range l.blockComments {
+		if strings.HasPrefix(code[i:], delims[0]) {
+			end := strings.Index(code[i+len(delims[0]):], delims[1])
+			if end < 0 {
+				return len(code), true
+			}
+			return i + len(delims[0]) + end + len(delims[1]), true
+		}
+	}
+	for _, prefix := range l.lineComments {
+		if strings.HasPrefix(code[i:], prefix) {
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				return len(code), true
+			}
+			return i + end, true
+		}
+	}
+	return 0, false
+}
+
+// matchString reports whether a string literal starts at i and returns the index just past it.
+// Unterminated strings end at the end of the line, or of the code for raw and triple-quoted strings.
+func (l *language) matchString(code string, i int) (int, bool) {
+	c := code[i]
+	if l.tripleQuotes && (c == '"' || c == '\'') && strings.HasPrefix(code[i:], strings.Repeat(string(c), 3)) {
+		delim := strings.Repeat(string(c), 3)
+		end := strings.Index(code[i+3:], delim)
+		if end < 0 {
+			return len(code), true
+		}
+		return i + 3 + end + 3, true
+	}
+	if strings.IndexByte(l.rawQuotes, c) >= 0 {
+		end := strings.IndexByte(code[i+1:], c)
+		if end < 0 {
+			return len(code), true
+		}
+		return i + 1 + end + 1, true
+	}
+	if strings.IndexByte(l.quotes, c) >= 0 {
+		for j := i + 1; j < len(code); j++ {
+			switch code[j] {
+			case '\\':
+				j++
+			case '\n':
+				return j, true
+			case c:
+				return j + 1, true
+			}
+		}
+		return len(code), true
+	}
+	return 0, false
+}
+
+func (l *language) isKeyword(word string) bool {
+	if l.caseInsensitive {
+		word = strings.ToLower(word)
+	}
+	return l.keywords[word]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -0,0 +1,80 @@
+package highlight_test
+
+import (
+	"noteapp/internal/highlight"
+	"strings"
+	"testing"
+)
+
+func TestHTML_Go(t *testing.T) {
+	// Arrange
+	code := "// add sums\nfunc add(a int) int { return a + 42 } // done\ns := \"a<b\""
+
+	// Act
+	out := highlight.HTML(code, "golang")
+
+	// Assert
+	for _, want := range []string{
+		`<pre class="highlight language-go"><code>`,
+		`<span class="hl-com">// add sums</span>`,
+		`<span class="hl-kw">func</span> add(a <span class="hl-kw">int</span>)`,
+		`<span class="hl-num">42</span>`,
+		`<span class="hl-str">&#34;a&lt;b&#34;</span>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q but got %s", want, out)
+		}
+	}
+}
+
+func TestHTML_EscapesUnknownLanguage(t *testing.T) {
+	// Act
+	out := highlight.HTML("<script>alert(1)</script>", "klingon")
+
+	// Assert
+	if out != `<pre class="highlight language-plaintext"><code>&lt;script&gt;alert(1)&lt;/script&gt;</code></pre>` {
+		t.Errorf("Expected escaped plain text but got %s", out)
+	}
+}
+
+func TestHTML_SQLKeywordsIgnoreCase(t *testing.T) {
+	// Act
+	out := highlight.HTML("SELECT name FROM users -- all", "sql")
+
+	// Assert
+	if !strings.Contains(out, `<span class="hl-kw">SELECT</span> name <span class="hl-kw">FROM</span>`) {
+		t.Errorf("Expected SQL keywords to be highlighted but got %s", out)
+	}
+	if !strings.Contains(out, `<span class="hl-com">-- all</span>`) {
+		t.Errorf("Expected SQL comment to be highlighted but got %s", out)
+	}
+}
+
+func TestHTML_PythonTripleQuotes(t *testing.T) {
+	// Act
+	out := highlight.HTML("def f():\n    \"\"\"doc \"quoted\" here\"\"\"\n", "py")
+
+	// Assert
+	if !strings.Contains(out, `<span class="hl-str">&#34;&#34;&#34;doc &#34;quoted&#34; here&#34;&#34;&#34;</span>`) {
+		t.Errorf("Expected the docstring to be one string token but got %s", out)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"Go", "go", true},
+		{" TS ", "typescript", true},
+		{"c++", "cpp", true},
+		{"cobol", "cobol", false},
+	}
+	for _, tt := range tests {
+		got, ok := highlight.Normalize(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Normalize(%q) = %q, %v; expected %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
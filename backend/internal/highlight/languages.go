@@ -0,0 +1,211 @@
+package highlight
+
+import (
+	"sort"
+	"strings"
+)
+
+// language describes the lexical rules used to highlight a programming language.
+type language struct {
+	// plain disables highlighting; the code is only escaped.
+	plain    bool
+	keywords map[string]bool
+	// caseInsensitive makes keywords match regardless of case, as in SQL.
+	caseInsensitive bool
+	lineComments    []string
+	blockComments   [][2]string
+	// quotes lists the characters that delimit strings with backslash escapes.
+	quotes string
+	// rawQuotes lists the characters that delimit strings without escapes, such as Go's backtick.
+	rawQuotes string
+	// tripleQuotes enables Python-style strings delimited by three quotes.
+	tripleQuotes bool
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
+
+var cComments = [][2]string{{"/*", "*/"}}
+
+// languages maps the canonical name of each supported language to its rules.
+var languages = map[string]*language{
+	"plaintext": {plain: true},
+	"go": {
+		keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var
+			true false nil iota bool byte rune string error int int8 int16 int32 int64
+			uint uint8 uint16 uint32 uint64 uintptr float32 float64 complex64 complex128 any
+			append cap close copy delete len make new panic print println recover`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`, rawQuotes: "`",
+	},
+	"python": {
+		keywords: words(`and as assert async await break class continue def del elif else except finally
+			for from global if import in is lambda nonlocal not or pass raise return try while with yield
+			True False None self print len range int str float list dict set tuple`),
+		lineComments: []string{"#"}, quotes: `"'`, tripleQuotes: true,
+	},
+	"javascript": {
+		keywords: words(`async await break case catch class const continue debugger default delete do else
+			export extends finally for function if import in instanceof let new of return static super
+			switch this throw try typeof var void while with yield true false null undefined`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`, rawQuotes: "`",
+	},
+	"typescript": {
+		keywords: words(`abstract any as async await boolean break case catch class const constructor continue
+			declare default delete do else enum export extends false finally for from function if implements
+			import in instanceof interface keyof let module namespace never new null number object of private
+			protected public readonly return static string super switch this throw true try type typeof
+			undefined unknown var void while yield`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`, rawQuotes: "`",
+	},
+	"java": {
+		keywords: words(`abstract assert boolean break byte case catch char class const continue default do
+			double else enum extends final finally float for if implements import instanceof int interface
+			long native new package private protected public return short static super switch synchronized
+			this throw throws transient try var void volatile while true false null`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`,
+	},
+	"kotlin": {
+		keywords: words(`as break class continue do else false for fun if in interface is null object package
+			return super this throw true try typealias val var when while by companion data enum import
+			internal lateinit open override private protected public sealed suspend`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`,
+	},
+	"swift": {
+		keywords: words(`associatedtype class deinit enum extension func import init inout internal let open
+			operator private protocol public static struct subscript typealias var break case continue default
+			defer do else fallthrough for guard if in repeat return switch where while as catch false is nil
+			self Self super throw throws true try async await`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"`,
+	},
+	"c": {
+		keywords: words(`auto break case char const continue default do double else enum extern float for goto
+			if inline int long register restrict return short signed sizeof static struct switch typedef union
+			unsigned void volatile while NULL true false bool`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`,
+	},
+	"cpp": {
+		keywords: words(`alignas alignof auto bool break case catch char class const constexpr const_cast continue
+			decltype default delete do double dynamic_cast else enum explicit export extern false float for friend
+			goto if inline int long mutable namespace new noexcept nullptr operator private protected public
+			register reinterpret_cast return short signed sizeof static static_cast struct switch template this
+			throw true try typedef typeid typename union unsigned using virtual void volatile while`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`,
+	},
+	"csharp": {
+		keywords: words(`abstract as base bool break byte case catch char checked class const continue decimal
+			default delegate do double else enum event explicit extern false finally fixed float for foreach goto
+			if implicit in int interface internal is lock long namespace new null object operator out override
+			params private protected public readonly ref return sbyte sealed short sizeof static string struct
+			switch this throw true try typeof uint ulong unchecked unsafe ushort using var virtual void volatile
+			while async await`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"'`,
+	},
+	"rust": {
+		keywords: words(`as async await break const continue crate dyn else enum extern false fn for if impl in let
+			loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where
+			while i8 i16 i32 i64 i128 isize u8 u16 u32 u64 u128 usize f32 f64 bool char str String Vec Option
+			Result Some None Ok Err`),
+		lineComments: []string{"//"}, blockComments: cComments, quotes: `"`,
+	},
+	"ruby": {
+		keywords: words(`alias and begin break case class def defined do else elsif end ensure false for if in
+			module next nil not or redo rescue retry return self super then true undef unless until when while
+			yield require attr_accessor puts`),
+		lineComments: []string{"#"}, quotes: `"'`,
+	},
+	"php": {
+		keywords: words(`abstract and array as break callable case catch class clone const continue declare default
+			do echo else elseif empty enddeclare endfor endforeach endif endswitch endwhile extends final finally
+			fn for foreach function global goto if implements include instanceof insteadof interface isset list
+			match namespace new or print private protected public require return static switch throw trait try
+			unset use var while yield true false null`),
+		lineComments: []string{"//", "#"}, blockComments: cComments, quotes: `"'`,
+	},
+	"shell": {
+		keywords: words(`if then else elif fi case esac for select while until do done in function time return
+			exit export local readonly declare unset shift source echo cd set`),
+		lineComments: []string{"#"}, quotes: `"`, rawQuotes: `'`,
+	},
+	"sql": {
+		keywords: words(`select from where and or not insert into values update set delete create table drop alter
+			index view join inner left right outer full on as group by order having limit offset union all
+			distinct case when then else end null is in exists between like primary key foreign references
+			default unique check begin commit rollback transaction with returning asc desc count sum avg min max`),
+		caseInsensitive: true,
+		lineComments:    []string{"--"}, blockComments: cComments, quotes: `'"`,
+	},
+	"json": {
+		keywords: words(`true false null`),
+		quotes:   `"`,
+	},
+	"yaml": {
+		keywords:     words(`true false null yes no on off`),
+		lineComments: []string{"#"}, quotes: `"'`,
+	},
+	"html": {
+		blockComments: [][2]string{{"<!--", "-->"}}, quotes: `"'`,
+	},
+	"css": {
+		keywords: words(`important inherit initial unset none auto block inline flex grid absolute relative fixed
+			sticky solid dashed`),
+		blockComments: cComments, quotes: `"'`,
+	},
+	"markdown": {plain: true},
+}
+
+// aliases maps alternative names of languages to their canonical name.
+var aliases = map[string]string{
+	"text":       "plaintext",
+	"txt":        "plaintext",
+	"golang":     "go",
+	"py":         "python",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"kt":         "kotlin",
+	"h":          "c",
+	"c++":        "cpp",
+	"cc":         "cpp",
+	"hpp":        "cpp",
+	"cs":         "csharp",
+	"c#":         "csharp",
+	"rs":         "rust",
+	"rb":         "ruby",
+	"sh":         "shell",
+	"bash":       "shell",
+	"zsh":        "shell",
+	"postgresql": "sql",
+	"mysql":      "sql",
+	"yml":        "yaml",
+	"htm":        "html",
+	"xml":        "html",
+	"md":         "markdown",
+}
+
+// Normalize returns the canonical name of a language, resolving aliases and ignoring case.
+// It reports false if the language is not supported.
+func Normalize(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+	_, ok := languages[name]
+	return name, ok
+}
+
+// Languages returns the canonical names of the supported languages in alphabetical order.
+func Languages() []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,424 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// renderInline renders the inline content of a block: emphasis, code spans, links, images,
+// autolinks, raw HTML, entities and line breaks.
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				b.WriteString("<br>\n")
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && isASCIIPunct(s[i+1]) {
+				b.WriteString(html.EscapeString(s[i+1 : i+2]))
+				i += 2
+				continue
+			}
+		case '`':
+			if end, code, ok := matchCodeSpan(s, i); ok {
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i = end
+				continue
+			}
+			// An unmatched backtick run is literal text.
+			run := runLength(s, i)
+			b.WriteString(s[i : i+run])
+			i += run
+			continue
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if end, label, dest, title, ok := matchLink(s, i+1); ok {
+					b.WriteString(`<img src="` + html.EscapeString(dest) + `" alt="` + html.EscapeString(plainText(label)) + `"`)
+					writeTitle(&b, title)
+					b.WriteString(">")
+					i = end
+					continue
+				}
+			}
+		case '[':
+			if end, label, dest, title, ok := matchLink(s, i); ok {
+				b.WriteString(`<a href="` + html.EscapeString(dest) + `"`)
+				writeTitle(&b, title)
+				b.WriteString(">" + renderInline(label) + "</a>")
+				i = end
+				continue
+			}
+		case '<':
+			if end, dest, text, ok := matchAutolink(s, i); ok {
+				b.WriteString(`<a href="` + html.EscapeString(dest) + `">` + html.EscapeString(text) + "</a>")
+				i = end
+				continue
+			}
+			if end, ok := matchRawHTML(s, i); ok {
+				b.WriteString(s[i:end])
+				i = end
+				continue
+			}
+		case '&':
+			if end, ok := matchEntity(s, i); ok {
+				b.WriteString(s[i:end])
+				i = end
+				continue
+			}
+		case '*', '_', '~':
+			if end, rendered, ok := matchEmphasis(s, i); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+			run := runLength(s, i)
+			b.WriteString(s[i : i+run])
+			i += run
+			continue
+		case '\n':
+			// Two or more trailing spaces make a hard line break.
+			text := b.String()
+			if trimmed := strings.TrimRight(text, " "); len(text)-len(trimmed) >= 2 {
+				b.Reset()
+				b.WriteString(trimmed)
+				b.WriteString("<br>\n")
+			} else {
+				b.WriteString("\n")
+			}
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+func writeTitle(b *strings.Builder, title string) {
+	if title != "" {
+		b.WriteString(` title="` + html.EscapeString(title) + `"`)
+	}
+}
+
+// matchCodeSpan matches a code span opened by the backtick run at i, closed by a run of the
+// same length. Line endings become spaces, and one space is stripped from each end when the
+// code is padded on both sides.
+func matchCodeSpan(s string, i int) (int, string, bool) {
+	run := runLength(s, i)
+	for j := i + run; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		closing := runLength(s, j)
+		if closing == run {
+			code := strings.ReplaceAll(s[i+run:j], "\n", " ")
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			return j + closing, code, true
+		}
+		j += closing
+	}
+	return 0, "", false
+}
+
+// matchLink matches an inline link or image body starting with the "[" at i:
+// [label](destination "title").
+func matchLink(s string, i int) (end int, label, dest, title string, ok bool) {
+	closeLabel := matchBracket(s, i)
+	if closeLabel < 0 || closeLabel+1 >= len(s) || s[closeLabel+1] != '(' {
+		return 0, "", "", "", false
+	}
+	label = s[i+1 : closeLabel]
+
+	j := skipSpaces(s, closeLabel+2)
+	if j < len(s) && s[j] == '<' {
+		closeDest := strings.IndexAny(s[j+1:], ">\n")
+		if closeDest < 0 || s[j+1+closeDest] != '>' {
+			return 0, "", "", "", false
+		}
+		dest = s[j+1 : j+1+closeDest]
+		j += closeDest + 2
+	} else {
+		start, depth := j, 0
+		for ; j < len(s) && s[j] > ' '; j++ {
+			if s[j] == '\\' && j+1 < len(s) && isASCIIPunct(s[j+1]) {
+				j++
+			} else if s[j] == '(' {
+				depth++
+			} else if s[j] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		dest = s[start:j]
+	}
+
+	j = skipSpaces(s, j)
+	if j < len(s) && (s[j] == '"' || s[j] == '\'' || s[j] == '(') {
+		closer := s[j]
+		if closer == '(' {
+			closer = ')'
+		}
+		closeTitle := strings.IndexByte(s[j+1:], closer)
+		if closeTitle < 0 {
+			return 0, "", "", "", false
+		}
+		title = unescapeMarkdown(html.UnescapeString(s[j+1 : j+1+closeTitle]))
+		j = skipSpaces(s, j+closeTitle+2)
+	}
+	if j >= len(s) || s[j] != ')' {
+		return 0, "", "", "", false
+	}
+	return j + 1, label, unescapeMarkdown(html.UnescapeString(dest)), title, true
+}
+
+// matchBracket returns the index of the "]" closing the "[" at i, or -1. Escaped brackets
+// and brackets inside code spans do not count.
+func matchBracket(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if end, _, ok := matchCodeSpan(s, j); ok {
+				j = end - 1
+			} else {
+				j += runLength(s, j) - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// matchAutolink matches an absolute URI or an email address in angle brackets, and returns
+// the link destination and text.
+func matchAutolink(s string, i int) (end int, dest, text string, ok bool) {
+	closing := strings.IndexAny(s[i+1:], "<> \n")
+	if closing < 0 || s[i+1+closing] != '>' {
+		return 0, "", "", false
+	}
+	text = s[i+1 : i+1+closing]
+	end = i + closing + 2
+	if colon := strings.IndexByte(text, ':'); colon >= 2 && colon <= 32 && isScheme(text[:colon]) {
+		return end, text, text, true
+	}
+	if at := strings.IndexByte(text, '@'); at > 0 && at < len(text)-1 && !strings.ContainsAny(text, "\\\"") {
+		return end, "mailto:" + text, text, true
+	}
+	return 0, "", "", false
+}
+
+// matchRawHTML matches an HTML tag or comment starting with the "<" at i.
+func matchRawHTML(s string, i int) (int, bool) {
+	rest := s[i:]
+	if strings.HasPrefix(rest, "<!--") {
+		end := strings.Index(rest[4:], "-->")
+		if end < 0 {
+			return 0, false
+		}
+		return i + 4 + end + 3, true
+	}
+	name := strings.TrimPrefix(rest[1:], "/")
+	if name == "" || !isASCIILetter(name[0]) {
+		return 0, false
+	}
+	var quote byte
+	for j := len(rest) - len(name); j < len(rest); j++ {
+		switch c := rest[j]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '<':
+			return 0, false
+		case c == '>':
+			return i + j + 1, true
+		}
+	}
+	return 0, false
+}
+
+// matchEntity matches a named or numeric character reference starting with the "&" at i.
+func matchEntity(s string, i int) (int, bool) {
+	semicolon := strings.IndexByte(s[i:], ';')
+	if semicolon < 2 || semicolon > 33 {
+		return 0, false
+	}
+	ref := s[i : i+semicolon+1]
+	if html.UnescapeString(ref) == ref {
+		return 0, false
+	}
+	return i + semicolon + 1, true
+}
+
+// matchEmphasis matches emphasis (*text* or _text_), strong emphasis (**text** or __text__)
+// or strikethrough (~~text~~) opened by the delimiter run at i, and returns it rendered.
+func matchEmphasis(s string, i int) (int, string, bool) {
+	c := s[i]
+	run := runLength(s, i)
+	if !isLeftFlanking(s, i, run) {
+		return 0, "", false
+	}
+
+	if c == '~' {
+		if run != 2 {
+			return 0, "", false
+		}
+		if closing := findCloser(s, i+2, c, 2); closing >= 0 {
+			return closing + 2, "<del>" + renderInline(s[i+2:closing]) + "</del>", true
+		}
+		return 0, "", false
+	}
+
+	if run >= 2 {
+		if closing := findCloser(s, i+2, c, 2); closing >= 0 {
+			return closing + 2, "<strong>" + renderInline(s[i+2:closing]) + "</strong>", true
+		}
+	}
+	if closing := findCloser(s, i+1, c, 1); closing >= 0 {
+		return closing + 1, "<em>" + renderInline(s[i+1:closing]) + "</em>", true
+	}
+	return 0, "", false
+}
+
+// findCloser returns the index of the last n delimiters of the first right-flanking run of c
+// after from that can close emphasis of n delimiters, or -1. Single delimiters are not closed
+// by runs of two, which belong to nested strong emphasis.
+func findCloser(s string, from int, c byte, n int) int {
+	for j := from; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			if end, _, ok := matchCodeSpan(s, j); ok {
+				j = end
+				continue
+			}
+		case c:
+			run := runLength(s, j)
+			fits := run >= n && (n != 1 || run != 2)
+			if fits && j > from && isRightFlanking(s, j, run) {
+				return j + run - n
+			}
+			j += run
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// isLeftFlanking reports whether the delimiter run at i can open emphasis: it is followed by
+// a non-space, and underscores do not open emphasis inside words.
+func isLeftFlanking(s string, i, run int) bool {
+	if i+run >= len(s) || isSpaceByte(s[i+run]) {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isAlphanumeric(s[i-1])
+}
+
+// isRightFlanking reports whether the delimiter run at i can close emphasis: it follows a
+// non-space, and underscores do not close emphasis inside words.
+func isRightFlanking(s string, i, run int) bool {
+	if isSpaceByte(s[i-1]) {
+		return false
+	}
+	return s[i] != '_' || i+run >= len(s) || !isAlphanumeric(s[i+run])
+}
+
+// plainText returns the text of inline Markdown, as used for the alt text of images.
+func plainText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			i++
+			b.WriteByte(s[i])
+		case c == '*' || c == '_' || c == '`' || c == '[' || c == ']' || c == '~':
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// unescapeMarkdown removes the backslashes escaping ASCII punctuation.
+func unescapeMarkdown(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func isScheme(s string) bool {
+	if !isASCIILetter(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isASCIILetter(c) && !isASCIIDigit(c) && c != '+' && c != '.' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlphanumeric(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c) || c >= 0x80
+}
+
+func isASCIIPunct(c byte) bool {
+	return c > ' ' && c < 0x7f && !isASCIILetter(c) && !isASCIIDigit(c)
+}
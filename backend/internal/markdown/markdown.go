@@ -0,0 +1,434 @@
+// Package markdown renders Markdown text as HTML. It supports the commonly used subset of
+// CommonMark: ATX and setext headings, paragraphs, block quotes, nested bullet and ordered
+// lists (with GitHub-style task items), fenced and indented code blocks, thematic breaks,
+// emphasis, strikethrough, code spans, links, images, autolinks and hard line breaks.
+// Fenced code blocks are syntax-highlighted by their info string.
+//
+// Like CommonMark, the renderer passes raw HTML through, so its output must be cleaned with
+// sanitize.HTML before it is shown to anyone but the author.
+package markdown
+
+import (
+	"html"
+	"noteapp/internal/highlight"
+	"strconv"
+	"strings"
+)
+
+// ToHTML renders the Markdown document src as an HTML fragment.
+func ToHTML(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"), false)
+	return b.String()
+}
+
+// renderBlocks renders a sequence of block-level lines. In tight lists, paragraphs are
+// rendered without <p> elements.
+func renderBlocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case isFence(line):
+			i = renderFencedCode(b, lines, i)
+		case isHeading(line):
+			renderHeading(b, line)
+			i++
+		case isThematicBreak(line):
+			b.WriteString("<hr>\n")
+			i++
+		case isBlockQuote(line):
+			i = renderBlockQuote(b, lines, i)
+		case isListItem(line):
+			i = renderList(b, lines, i)
+		case indentation(line) >= 4:
+			i = renderIndentedCode(b, lines, i)
+		case isHTMLBlock(line):
+			i = renderHTMLBlock(b, lines, i)
+		default:
+			i = renderParagraph(b, lines, i, tight)
+		}
+	}
+}
+
+func renderFencedCode(b *strings.Builder, lines []string, start int) int {
+	opening := strings.TrimLeft(lines[start], " ")
+	fenceChar := opening[0]
+	fenceLen := len(opening) - len(strings.TrimLeft(opening, string(fenceChar)))
+	info := strings.Fields(opening[fenceLen:])
+	lang := ""
+	if len(info) > 0 {
+		lang = info[0]
+	}
+
+	var code []string
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if indentation(lines[i]) < 4 && len(trimmed) >= fenceLen && strings.Trim(trimmed, string(fenceChar)) == "" {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	source := strings.Join(code, "\n")
+	if len(code) > 0 {
+		source += "\n"
+	}
+	b.WriteString(highlight.HTML(source, lang))
+	b.WriteString("\n")
+	return i
+}
+
+func renderIndentedCode(b *strings.Builder, lines []string, start int) int {
+	var code []string
+	i := start
+	for ; i < len(lines) && (isBlank(lines[i]) || indentation(lines[i]) >= 4); i++ {
+		if isBlank(lines[i]) {
+			code = append(code, "")
+		} else {
+			code = append(code, lines[i][4:])
+		}
+	}
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	b.WriteString("<pre><code>")
+	b.WriteString(html.EscapeString(strings.Join(code, "\n") + "\n"))
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+func renderHeading(b *strings.Builder, line string) {
+	trimmed := strings.TrimLeft(line, " ")
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	text := strings.TrimSpace(trimmed[level:])
+	// A closing sequence of #s is not part of the heading.
+	if closed := strings.TrimRight(text, "#"); closed == "" || strings.HasSuffix(closed, " ") {
+		text = strings.TrimSpace(closed)
+	}
+	writeHeading(b, level, text)
+}
+
+func writeHeading(b *strings.Builder, level int, text string) {
+	tag := "h" + strconv.Itoa(level)
+	b.WriteString("<" + tag + ">")
+	b.WriteString(renderInline(text))
+	b.WriteString("</" + tag + ">\n")
+}
+
+func renderBlockQuote(b *strings.Builder, lines []string, start int) int {
+	var quoted []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlockQuote(line) {
+			line = strings.TrimLeft(line, " ")[1:]
+			line = strings.TrimPrefix(line, " ")
+		} else if isBlank(line) || startsBlock(line) || len(quoted) == 0 || isBlank(quoted[len(quoted)-1]) {
+			break
+		}
+		// Other lines continue the quoted paragraph lazily.
+		quoted = append(quoted, line)
+	}
+	b.WriteString("<blockquote>\n")
+	renderBlocks(b, quoted, false)
+	b.WriteString("</blockquote>\n")
+	return i
+}
+
+// listMarker describes the marker of a list item.
+type listMarker struct {
+	ordered bool
+	// delim is the bullet character of bullet lists, or the "." or ")" of ordered lists.
+	delim byte
+	start int
+	// offset is the column where the content of the item starts.
+	offset int
+}
+
+func parseListMarker(line string) (listMarker, bool) {
+	indent := indentation(line)
+	if indent >= 4 {
+		return listMarker{}, false
+	}
+	rest := line[indent:]
+	var m listMarker
+	var markerLen int
+	switch {
+	case rest != "" && strings.IndexByte("-*+", rest[0]) >= 0:
+		m.delim = rest[0]
+		markerLen = 1
+	default:
+		digits := 0
+		for digits < len(rest) && digits < 9 && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || digits >= len(rest) || (rest[digits] != '.' && rest[digits] != ')') {
+			return listMarker{}, false
+		}
+		m.ordered = true
+		m.delim = rest[digits]
+		m.start, _ = strconv.Atoi(rest[:digits])
+		markerLen = digits + 1
+	}
+
+	after := rest[markerLen:]
+	if after == "" {
+		m.offset = indent + markerLen + 1
+		return m, true
+	}
+	spaces := len(after) - len(strings.TrimLeft(after, " "))
+	if spaces == 0 {
+		return listMarker{}, false
+	}
+	if spaces > 4 || strings.TrimSpace(after) == "" {
+		spaces = 1
+	}
+	m.offset = indent + markerLen + spaces
+	return m, true
+}
+
+func renderList(b *strings.Builder, lines []string, start int) int {
+	first, _ := parseListMarker(lines[start])
+	var items [][]string
+	loose := false
+
+	i := start
+	for i < len(lines) {
+		marker, ok := parseListMarker(lines[i])
+		if !ok || marker.ordered != first.ordered || marker.delim != first.delim || isThematicBreak(lines[i]) {
+			break
+		}
+		item := []string{contentAfter(lines[i], marker.offset)}
+		i++
+		for ; i < len(lines); i++ {
+			line := lines[i]
+			switch {
+			case isBlank(line):
+				item = append(item, "")
+				continue
+			case indentation(line) >= marker.offset:
+				item = append(item, line[marker.offset:])
+				continue
+			case !isBlank(item[len(item)-1]) && !startsBlock(line) && !isListItem(line):
+				// A lazy continuation of the item's paragraph.
+				item = append(item, strings.TrimLeft(line, " "))
+				continue
+			}
+			break
+		}
+
+		// Blank lines between the blocks of an item or between items make the list loose.
+		trailing := 0
+		for trailing < len(item) && isBlank(item[len(item)-1-trailing]) {
+			trailing++
+		}
+		item = item[:len(item)-trailing]
+		if hasBlankLineBetweenBlocks(item) || (trailing > 0 && i < len(lines) && isSameList(lines[i], first)) {
+			loose = true
+		}
+		items = append(items, item)
+	}
+
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag)
+	if first.ordered && first.start != 1 {
+		b.WriteString(` start="` + strconv.Itoa(first.start) + `"`)
+	}
+	b.WriteString(">\n")
+	for _, item := range items {
+		b.WriteString("<li>")
+		if len(item) > 0 {
+			item[0] = renderTaskMarker(b, item[0])
+		}
+		var content strings.Builder
+		renderBlocks(&content, item, !loose)
+		b.WriteString(strings.TrimSuffix(content.String(), "\n"))
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderTaskMarker writes a checkbox for a task list item starting with "[ ]" or "[x]", and
+// returns the first line of the item without the marker.
+func renderTaskMarker(b *strings.Builder, line string) string {
+	if len(line) < 4 || line[0] != '[' || line[2] != ']' || line[3] != ' ' {
+		return line
+	}
+	switch line[1] {
+	case ' ':
+		b.WriteString(`<input type="checkbox" disabled> `)
+	case 'x', 'X':
+		b.WriteString(`<input type="checkbox" checked disabled> `)
+	default:
+		return line
+	}
+	return line[4:]
+}
+
+func isSameList(line string, first listMarker) bool {
+	marker, ok := parseListMarker(line)
+	return ok && marker.ordered == first.ordered && marker.delim == first.delim && !isThematicBreak(line)
+}
+
+func hasBlankLineBetweenBlocks(item []string) bool {
+	for _, line := range item {
+		if isBlank(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func contentAfter(line string, offset int) string {
+	if offset >= len(line) {
+		return ""
+	}
+	return line[offset:]
+}
+
+func renderHTMLBlock(b *strings.Builder, lines []string, start int) int {
+	i := start
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+	return i
+}
+
+func renderParagraph(b *strings.Builder, lines []string, start int, tight bool) int {
+	text := []string{strings.TrimLeft(lines[start], " ")}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if level := setextLevel(line); level > 0 {
+			writeHeading(b, level, strings.TrimSpace(strings.Join(text, "\n")))
+			return i + 1
+		}
+		if isBlank(line) || startsBlock(line) || interruptsParagraph(line) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	rendered := renderInline(strings.TrimRight(strings.Join(text, "\n"), " "))
+	if tight {
+		b.WriteString(rendered)
+		b.WriteString("\n")
+		return i
+	}
+	b.WriteString("<p>")
+	b.WriteString(rendered)
+	b.WriteString("</p>\n")
+	return i
+}
+
+// startsBlock reports whether line starts a block that interrupts a paragraph.
+func startsBlock(line string) bool {
+	return isFence(line) || isHeading(line) || isThematicBreak(line) || isBlockQuote(line) || isHTMLBlock(line)
+}
+
+// interruptsParagraph reports whether line starts a list that can interrupt a paragraph:
+// bullet lists, and ordered lists starting at 1, with content on their first line.
+func interruptsParagraph(line string) bool {
+	marker, ok := parseListMarker(line)
+	if !ok || strings.TrimSpace(contentAfter(line, marker.offset)) == "" {
+		return false
+	}
+	return !marker.ordered || marker.start == 1
+}
+
+func setextLevel(line string) int {
+	if indentation(line) >= 4 {
+		return 0
+	}
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return 0
+	case strings.Trim(trimmed, "=") == "":
+		return 1
+	case strings.Trim(trimmed, "-") == "":
+		return 2
+	default:
+		return 0
+	}
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isFence(line string) bool {
+	if indentation(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	if strings.HasPrefix(trimmed, "```") {
+		// The info string of a backtick fence cannot contain backticks.
+		return !strings.Contains(strings.TrimLeft(trimmed, "`"), "`")
+	}
+	return strings.HasPrefix(trimmed, "~~~")
+}
+
+func isHeading(line string) bool {
+	if indentation(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	return level >= 1 && level <= 6 && (len(trimmed) == level || trimmed[level] == ' ')
+}
+
+func isThematicBreak(line string) bool {
+	if indentation(line) >= 4 {
+		return false
+	}
+	trimmed := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(trimmed) < 3 || strings.IndexByte("-*_", trimmed[0]) < 0 {
+		return false
+	}
+	return strings.Trim(trimmed, trimmed[:1]) == ""
+}
+
+func isBlockQuote(line string) bool {
+	return indentation(line) < 4 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func isListItem(line string) bool {
+	_, ok := parseListMarker(line)
+	return ok && !isThematicBreak(line)
+}
+
+func isHTMLBlock(line string) bool {
+	if indentation(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	if strings.HasPrefix(trimmed, "<!") {
+		return true
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(trimmed, "<"), "/")
+	if len(name) == len(trimmed) || name == "" || !isASCIILetter(name[0]) {
+		return false
+	}
+	// Only tags start HTML blocks, not autolinks such as <https://example.com>.
+	end := 1
+	for end < len(name) && (isASCIILetter(name[end]) || isASCIIDigit(name[end])) {
+		end++
+	}
+	return end == len(name) || strings.IndexByte(" />", name[end]) >= 0
+}
@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTML_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"atx headings", "# Title #\n### Sub", "<h1>Title</h1>\n<h3>Sub</h3>\n"},
+		{"setext headings", "Title\n=====\nSub\n---", "<h1>Title</h1>\n<h2>Sub</h2>\n"},
+		{"thematic break", "a\n\n* * *", "<p>a</p>\n<hr>\n"},
+		{"block quote", "> quoted\nlazy\n\nafter", "<blockquote>\n<p>quoted\nlazy</p>\n</blockquote>\n<p>after</p>\n"},
+		{"tight bullet list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{"loose ordered list", "3. one\n\n4. two", "<ol start=\"3\">\n<li><p>one</p></li>\n<li><p>two</p></li>\n</ol>\n"},
+		{"nested list", "- a\n  - b\n- c", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul></li>\n<li>c</li>\n</ul>\n"},
+		{"task list", "- [ ] todo\n- [x] done", "<ul>\n<li><input type=\"checkbox\" disabled> todo</li>\n<li><input type=\"checkbox\" checked disabled> done</li>\n</ul>\n"},
+		{"list interrupts paragraph", "intro\n- item", "<p>intro</p>\n<ul>\n<li>item</li>\n</ul>\n"},
+		{"indented code", "    x := 1\n\n    y", "<pre><code>x := 1\n\ny\n</code></pre>\n"},
+		{"fenced code without language", "```\n<b>\n```", "<pre class=\"highlight language-plaintext\"><code>&lt;b&gt;\n</code></pre>\n"},
+		{"html block", "<div>\n*raw*\n</div>", "<div>\n*raw*\n</div>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.in); got != tt.want {
+				t.Errorf("ToHTML(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToHTML_Inline(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"emphasis", "*a* _b_ **c** __d__", "<em>a</em> <em>b</em> <strong>c</strong> <strong>d</strong>"},
+		{"nested emphasis", "***a*** and *x **y** z*", "<strong><em>a</em></strong> and <em>x <strong>y</strong> z</em>"},
+		{"intraword underscore", "snake_case_name", "snake_case_name"},
+		{"unmatched delimiters", "2 * 3 * 4 and a*", "2 * 3 * 4 and a*"},
+		{"strikethrough", "~~gone~~", "<del>gone</del>"},
+		{"code span", "use `a < b` and `` `x` ``", "use <code>a &lt; b</code> and <code>`x`</code>"},
+		{"emphasis inside code", "`*not*`", "<code>*not*</code>"},
+		{"link", `[the *site*](https://example.com "Title")`, `<a href="https://example.com" title="Title">the <em>site</em></a>`},
+		{"link with parentheses", "[w](https://en.wikipedia.org/wiki/Go_(language))", `<a href="https://en.wikipedia.org/wiki/Go_(language)">w</a>`},
+		{"image", "![a *cat*](/blobs/abc)", `<img src="/blobs/abc" alt="a cat">`},
+		{"autolinks", "<https://example.com> <me@example.com>", `<a href="https://example.com">https://example.com</a> <a href="mailto:me@example.com">me@example.com</a>`},
+		{"escapes", `\*not\* 1 < 2 & 3`, "*not* 1 &lt; 2 &amp; 3"},
+		{"entities", "&copy; &#169; &nope;", "&copy; &#169; &amp;nope;"},
+		{"raw html", `a <span class="x">b</span>`, `a <span class="x">b</span>`},
+		{"hard breaks", "a  \nb\\\nc", "a<br>\nb<br>\nc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := "<p>" + tt.want + "</p>\n"
+			if got := ToHTML(tt.in); got != want {
+				t.Errorf("ToHTML(%q) = %q; want %q", tt.in, got, want)
+			}
+		})
+	}
+}
+
+func TestToHTML_HighlightsFencedCode(t *testing.T) {
+	got := ToHTML("```go\nfunc main() {}\n```")
+
+	if !strings.Contains(got, `class="highlight language-go"`) || !strings.Contains(got, `<span class="hl-kw">func</span>`) {
+		t.Errorf("Expected highlighted Go code, got %q", got)
+	}
+}
@@ -0,0 +1,42 @@
+package blobrepo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// BlobStore defines the interface for content-addressed binary storage.
+// A blob is identified by the hex-encoded SHA-256 hash of its data, so storing
+// the same data twice keeps a single copy.
+type BlobStore interface {
+	// Put stores data and returns its hash. Storing data that already exists is a no-op.
+	Put(data []byte) (string, error)
+	// PutReader streams up to limit bytes from r into the store and returns the hash and size
+	// of the data. It returns ErrBlobTooLarge, storing nothing, if r holds more than limit bytes.
+	PutReader(r io.Reader, limit int64) (string, int64, error)
+	Get(hash string) ([]byte, error)
+	// Open returns a reader over the data of a blob. The caller must close it.
+	Open(hash string) (io.ReadSeekCloser, error)
+	Exists(hash string) (bool, error)
+	Delete(hash string) error
+}
+
+// HashOf returns the hash that identifies data in a BlobStore.
+func HashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateHash reports whether hash has the form of a blob hash.
+func ValidateHash(hash string) error {
+	if len(hash) != sha256.Size*2 {
+		return ErrInvalidHash
+	}
+	for _, r := range hash {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return ErrInvalidHash
+		}
+	}
+	return nil
+}
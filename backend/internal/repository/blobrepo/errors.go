@@ -0,0 +1,14 @@
+package blobrepo
+
+import "errors"
+
+var (
+	// ErrBlobNotFound is returned when a blob is not found.
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrInvalidHash is returned when a blob hash is malformed.
+	ErrInvalidHash = errors.New("invalid blob hash")
+	// ErrBlobTooLarge is returned when streamed data exceeds the allowed size.
+	ErrBlobTooLarge = errors.New("blob exceeds the maximum size")
+	// ErrImageNotFound is returned when no metadata is stored for an image.
+	ErrImageNotFound = errors.New("image not found")
+)
@@ -0,0 +1,155 @@
+package blobrepo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileSystemBlobStore is a BlobStore that keeps each blob in its own file under a root directory.
+// Blobs are spread over subdirectories named after the first two characters of their hash.
+type FileSystemBlobStore struct {
+	root string
+}
+
+// NewFileSystemBlobStore creates a FileSystemBlobStore rooted at root, creating the directory if needed.
+func NewFileSystemBlobStore(root string) (*FileSystemBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FileSystemBlobStore{root: root}, nil
+}
+
+// Put stores data and returns its hash.
+func (s *FileSystemBlobStore) Put(data []byte) (string, error) {
+	hash := HashOf(data)
+	path := s.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	// Write to a temporary file and rename it so readers never see a partial blob.
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store blob: %w", err)
+	}
+	return hash, nil
+}
+
+// PutReader streams data from r into the store and returns its hash and size.
+// The data is hashed while it is written, so it is never held in memory as a whole.
+func (s *FileSystemBlobStore) PutReader(r io.Reader, limit int64) (string, int64, error) {
+	tmp, err := os.CreateTemp(s.root, "upload.tmp-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, limit+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to write blob: %w", err)
+	}
+	if size > limit {
+		return "", 0, ErrBlobTooLarge
+	}
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	path := s.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, size, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", 0, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, fmt.Errorf("failed to store blob: %w", err)
+	}
+	return hash, size, nil
+}
+
+// Get retrieves the data of a blob by its hash.
+func (s *FileSystemBlobStore) Get(hash string) ([]byte, error) {
+	if err := ValidateHash(hash); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// Open returns a reader over the data of a blob.
+func (s *FileSystemBlobStore) Open(hash string) (io.ReadSeekCloser, error) {
+	if err := ValidateHash(hash); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return f, nil
+}
+
+// Exists reports whether a blob is stored.
+func (s *FileSystemBlobStore) Exists(hash string) (bool, error) {
+	if err := ValidateHash(hash); err != nil {
+		return false, err
+	}
+	_, err := os.Stat(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat blob: %w", err)
+	}
+	return true, nil
+}
+
+// Delete removes a blob by its hash.
+func (s *FileSystemBlobStore) Delete(hash string) error {
+	if err := ValidateHash(hash); err != nil {
+		return err
+	}
+	err := os.Remove(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrBlobNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSystemBlobStore) path(hash string) string {
+	return filepath.Join(s.root, hash[:2], hash)
+}
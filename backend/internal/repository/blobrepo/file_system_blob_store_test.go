@@ -0,0 +1,157 @@
+package blobrepo_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"noteapp/internal/repository/blobrepo"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSystemBlobStore_PutAndGet(t *testing.T) {
+	// Arrange
+	store, err := blobrepo.NewFileSystemBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+	data := []byte("image bytes")
+
+	// Act
+	hash, err := store.Put(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if hash != blobrepo.HashOf(data) {
+		t.Errorf("Expected hash %s but got %s", blobrepo.HashOf(data), hash)
+	}
+	got, err := store.Get(hash)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected data %q but got %q", data, got)
+	}
+}
+
+func TestFileSystemBlobStore_Put_DeduplicatesIdenticalData(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	store, _ := blobrepo.NewFileSystemBlobStore(root)
+	data := []byte("same bytes")
+
+	// Act
+	hash1, _ := store.Put(data)
+	hash2, _ := store.Put(append([]byte(nil), data...))
+
+	// Assert
+	if hash1 != hash2 {
+		t.Fatalf("Expected identical data to have the same hash but got %s and %s", hash1, hash2)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, hash1[:2]))
+	if err != nil {
+		t.Fatalf("Failed to read blob directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected 1 stored file but got %d", len(entries))
+	}
+}
+
+func TestFileSystemBlobStore_Get_NotFound(t *testing.T) {
+	// Arrange
+	store, _ := blobrepo.NewFileSystemBlobStore(t.TempDir())
+
+	// Act
+	_, err := store.Get(blobrepo.HashOf([]byte("missing")))
+
+	// Assert
+	if !errors.Is(err, blobrepo.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound but got %v", err)
+	}
+}
+
+func TestFileSystemBlobStore_Get_InvalidHash(t *testing.T) {
+	// Arrange
+	store, _ := blobrepo.NewFileSystemBlobStore(t.TempDir())
+
+	// Act
+	_, err := store.Get("../../etc/passwd")
+
+	// Assert
+	if !errors.Is(err, blobrepo.ErrInvalidHash) {
+		t.Errorf("Expected ErrInvalidHash but got %v", err)
+	}
+}
+
+func TestFileSystemBlobStore_Delete(t *testing.T) {
+	// Arrange
+	store, _ := blobrepo.NewFileSystemBlobStore(t.TempDir())
+	hash, _ := store.Put([]byte("to delete"))
+
+	// Act
+	err := store.Delete(hash)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	exists, _ := store.Exists(hash)
+	if exists {
+		t.Errorf("Expected blob to be deleted")
+	}
+	if err := store.Delete(hash); !errors.Is(err, blobrepo.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound on second delete but got %v", err)
+	}
+}
+
+func TestFileSystemBlobStore_PutReader(t *testing.T) {
+	// Arrange
+	store, _ := blobrepo.NewFileSystemBlobStore(t.TempDir())
+	data := []byte("streamed attachment")
+
+	// Act
+	hash, size, err := store.PutReader(bytes.NewReader(data), int64(len(data)))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if hash != blobrepo.HashOf(data) || size != int64(len(data)) {
+		t.Errorf("Expected hash %s and size %d but got %s and %d", blobrepo.HashOf(data), len(data), hash, size)
+	}
+	f, err := store.Open(hash)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	defer f.Close()
+	got, _ := io.ReadAll(f)
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected data %q but got %q", data, got)
+	}
+}
+
+func TestFileSystemBlobStore_PutReader_TooLarge(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	store, _ := blobrepo.NewFileSystemBlobStore(root)
+	data := []byte("too many bytes")
+
+	// Act
+	_, _, err := store.PutReader(bytes.NewReader(data), int64(len(data))-1)
+
+	// Assert
+	if !errors.Is(err, blobrepo.ErrBlobTooLarge) {
+		t.Fatalf("Expected ErrBlobTooLarge but got %v", err)
+	}
+	exists, _ := store.Exists(blobrepo.HashOf(data))
+	if exists {
+		t.Errorf("Expected nothing to be stored")
+	}
+	entries, _ := os.ReadDir(root)
+	if len(entries) != 0 {
+		t.Errorf("Expected no leftover files but got %d", len(entries))
+	}
+}
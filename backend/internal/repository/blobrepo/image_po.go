@@ -0,0 +1,13 @@
+package blobrepo
+
+// ImagePO represents the persistent metadata of a stored image. Thumbnails maps the
+// longest-edge length of each thumbnail to the hash of its blob.
+type ImagePO struct {
+	Hash       string
+	MimeType   string
+	Size       int
+	Width      int
+	Height     int
+	Format     string
+	Thumbnails map[int]string
+}
@@ -0,0 +1,9 @@
+package blobrepo
+
+// ImageRepository defines the interface for the persistence of image metadata, so that
+// images are decoded and their thumbnails generated only once.
+type ImageRepository interface {
+	Save(image *ImagePO) error
+	FindByHash(hash string) (*ImagePO, error)
+	Delete(hash string) error
+}
@@ -0,0 +1,107 @@
+package blobrepo
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// InMemoryBlobStore is an in-memory implementation of BlobStore.
+type InMemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewInMemoryBlobStore creates a new InMemoryBlobStore.
+func NewInMemoryBlobStore() *InMemoryBlobStore {
+	return &InMemoryBlobStore{
+		blobs: make(map[string][]byte),
+	}
+}
+
+// Put stores data and returns its hash.
+func (s *InMemoryBlobStore) Put(data []byte) (string, error) {
+	hash := HashOf(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blobs[hash]; !ok {
+		s.blobs[hash] = append([]byte(nil), data...)
+	}
+	return hash, nil
+}
+
+// PutReader reads data from r into the store and returns its hash and size.
+func (s *InMemoryBlobStore) PutReader(r io.Reader, limit int64) (string, int64, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return "", 0, err
+	}
+	if int64(len(data)) > limit {
+		return "", 0, ErrBlobTooLarge
+	}
+	hash, err := s.Put(data)
+	return hash, int64(len(data)), err
+}
+
+// Get retrieves the data of a blob by its hash.
+func (s *InMemoryBlobStore) Get(hash string) ([]byte, error) {
+	if err := ValidateHash(hash); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blobs[hash]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Open returns a reader over the data of a blob.
+func (s *InMemoryBlobStore) Open(hash string) (io.ReadSeekCloser, error) {
+	data, err := s.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+// Exists reports whether a blob is stored.
+func (s *InMemoryBlobStore) Exists(hash string) (bool, error) {
+	if err := ValidateHash(hash); err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.blobs[hash]
+	return ok, nil
+}
+
+// Delete removes a blob by its hash.
+func (s *InMemoryBlobStore) Delete(hash string) error {
+	if err := ValidateHash(hash); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blobs[hash]; !ok {
+		return ErrBlobNotFound
+	}
+	delete(s.blobs, hash)
+	return nil
+}
+
+// nopCloser adds a no-op Close method to an in-memory reader.
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,60 @@
+package blobrepo
+
+import "sync"
+
+// InMemoryImageRepository is an in-memory implementation of ImageRepository.
+type InMemoryImageRepository struct {
+	mu     sync.RWMutex
+	images map[string]*ImagePO
+}
+
+// NewInMemoryImageRepository creates a new InMemoryImageRepository.
+func NewInMemoryImageRepository() *InMemoryImageRepository {
+	return &InMemoryImageRepository{
+		images: make(map[string]*ImagePO),
+	}
+}
+
+// Save saves the metadata of an image, replacing any metadata stored for the same hash.
+func (r *InMemoryImageRepository) Save(image *ImagePO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.images[image.Hash] = copyImagePO(image)
+	return nil
+}
+
+// FindByHash retrieves the metadata of an image by the hash of its blob.
+func (r *InMemoryImageRepository) FindByHash(hash string) (*ImagePO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	image, ok := r.images[hash]
+	if !ok {
+		return nil, ErrImageNotFound
+	}
+	return copyImagePO(image), nil
+}
+
+// Delete removes the metadata of an image.
+func (r *InMemoryImageRepository) Delete(hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.images[hash]; !ok {
+		return ErrImageNotFound
+	}
+	delete(r.images, hash)
+	return nil
+}
+
+func copyImagePO(image *ImagePO) *ImagePO {
+	c := *image
+	if image.Thumbnails != nil {
+		c.Thumbnails = make(map[int]string, len(image.Thumbnails))
+		for size, hash := range image.Thumbnails {
+			c.Thumbnails[size] = hash
+		}
+	}
+	return &c
+}
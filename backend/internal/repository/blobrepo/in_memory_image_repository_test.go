@@ -0,0 +1,35 @@
+package blobrepo_test
+
+import (
+	"errors"
+	"noteapp/internal/repository/blobrepo"
+	"testing"
+)
+
+func TestInMemoryImageRepository_SaveFindAndDelete(t *testing.T) {
+	// Arrange
+	repo := blobrepo.NewInMemoryImageRepository()
+	image := &blobrepo.ImagePO{Hash: "h1", MimeType: "image/png", Width: 640, Height: 480, Format: "png", Thumbnails: map[int]string{128: "t1"}}
+
+	// Act
+	err := repo.Save(image)
+	image.Thumbnails[128] = "changed"
+	found, findErr := repo.FindByHash("h1")
+
+	// Assert
+	if err != nil || findErr != nil {
+		t.Fatalf("Expected no error but got %v and %v", err, findErr)
+	}
+	if found.Width != 640 || found.Height != 480 || found.Thumbnails[128] != "t1" {
+		t.Errorf("Expected the saved metadata unaffected by later changes, got %+v", found)
+	}
+	if err := repo.Delete("h1"); err != nil {
+		t.Fatalf("Expected no error deleting but got %v", err)
+	}
+	if _, err := repo.FindByHash("h1"); !errors.Is(err, blobrepo.ErrImageNotFound) {
+		t.Errorf("Expected ErrImageNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete("h1"); !errors.Is(err, blobrepo.ErrImageNotFound) {
+		t.Errorf("Expected ErrImageNotFound deleting twice, got %v", err)
+	}
+}
@@ -1,10 +1,28 @@
 package contentrepo
 
+import "time"
+
 // ContentPO represents the persistence model for a Content object.
 type ContentPO struct {
-	ID      string
-	NoteID  string
-	Data    string
-	Type    string
-	Version int
+	ID             string
+	NoteID         string
+	Data           string
+	Type           string
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastModifiedBy string
+	Language       string
+	Format         string
+	ImageWidth     int
+	ImageHeight    int
+	ImageFormat    string
+	Thumbnails     map[int]string
+	// Attachment fields are set on attachment contents only.
+	AttachmentFilename   string
+	AttachmentSize       int64
+	AttachmentMimeType   string
+	AttachmentUploaderID string
+	// LinkedNoteIDs are the notes the text of the content links to, indexed for backlinks.
+	LinkedNoteIDs []string
 }
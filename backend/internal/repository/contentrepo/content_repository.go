@@ -7,4 +7,17 @@ type ContentRepository interface {
 	GetAllByNoteID(noteID string) ([]*ContentPO, error)
 	Delete(id string) error
 	DeleteAllByNoteID(noteID string) error
+	// SaveAttachment saves a new attachment content unless the attachments of its uploader,
+	// including it, would then exceed quota bytes. The quota is checked and reserved atomically,
+	// so concurrent uploads cannot exceed it together.
+	SaveAttachment(c *ContentPO, quota int64) error
+	// FindAttachmentsByUploader returns the attachment contents uploaded by a user.
+	FindAttachmentsByUploader(uploaderID string) ([]*ContentPO, error)
+	// IsBlobReferenced reports whether any content stores the blob hash as its data or as a thumbnail.
+	IsBlobReferenced(hash string) (bool, error)
+	// FindByBlob returns the contents that store the blob hash as their data or as a thumbnail.
+	FindByBlob(hash string) ([]*ContentPO, error)
+	// FindLinkingTo returns the contents whose text links to a note, using an index of the
+	// LinkedNoteIDs of the saved contents.
+	FindLinkingTo(noteID string) ([]*ContentPO, error)
 }
@@ -7,4 +7,6 @@ var (
 	ErrContentNotFound = errors.New("content not found")
 	// ErrContentConflict is returned when a version conflict occurs.
 	ErrContentConflict = errors.New("content conflict")
+	// ErrStorageQuotaExceeded is returned when an attachment would exceed its uploader's quota.
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
 )
@@ -8,12 +8,15 @@ import (
 type InMemoryContentRepository struct {
 	mu       sync.RWMutex
 	contents map[string]*ContentPO
+	// backlinks indexes the IDs of the contents linking to each note, by note ID.
+	backlinks map[string]map[string]bool
 }
 
 // NewInMemoryContentRepository creates a new InMemoryContentRepository.
 func NewInMemoryContentRepository() *InMemoryContentRepository {
 	return &InMemoryContentRepository{
-		contents: make(map[string]*ContentPO),
+		contents:  make(map[string]*ContentPO),
+		backlinks: make(map[string]map[string]bool),
 	}
 }
 
@@ -27,11 +30,13 @@ func (r *InMemoryContentRepository) Save(c *ContentPO) error {
 			return ErrContentConflict
 		}
 		c.Version++
+		r.unindexLinks(existing)
 	} else {
 		c.Version = 0
 	}
 
 	r.contents[c.ID] = c
+	r.indexLinks(c)
 	return nil
 }
 
@@ -67,9 +72,11 @@ func (r *InMemoryContentRepository) Delete(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.contents[id]; !ok {
+	c, ok := r.contents[id]
+	if !ok {
 		return ErrContentNotFound
 	}
+	r.unindexLinks(c)
 	delete(r.contents, id)
 	return nil
 }
@@ -81,8 +88,122 @@ func (r *InMemoryContentRepository) DeleteAllByNoteID(noteID string) error {
 
 	for id, c := range r.contents {
 		if c.NoteID == noteID {
+			r.unindexLinks(c)
 			delete(r.contents, id)
 		}
 	}
 	return nil
 }
+
+// SaveAttachment saves a new attachment content if its uploader's attachments stay within quota bytes.
+func (r *InMemoryContentRepository) SaveAttachment(c *ContentPO, quota int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.contents[c.ID]; ok {
+		return ErrContentConflict
+	}
+	usage := c.AttachmentSize
+	for _, existing := range r.contents {
+		if existing.AttachmentFilename != "" && existing.AttachmentUploaderID == c.AttachmentUploaderID {
+			usage += existing.AttachmentSize
+		}
+	}
+	if usage > quota {
+		return ErrStorageQuotaExceeded
+	}
+
+	c.Version = 0
+	r.contents[c.ID] = c
+	r.indexLinks(c)
+	return nil
+}
+
+// FindAttachmentsByUploader returns the attachment contents uploaded by a user.
+func (r *InMemoryContentRepository) FindAttachmentsByUploader(uploaderID string) ([]*ContentPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*ContentPO
+	for _, c := range r.contents {
+		if c.AttachmentFilename != "" && c.AttachmentUploaderID == uploaderID {
+			copy := *c
+			results = append(results, &copy)
+		}
+	}
+	return results, nil
+}
+
+// IsBlobReferenced reports whether any content stores the blob hash as its data or as a thumbnail.
+func (r *InMemoryContentRepository) IsBlobReferenced(hash string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.contents {
+		if referencesBlob(c, hash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// referencesBlob reports whether a content stores the blob hash as its data or as a thumbnail.
+func referencesBlob(c *ContentPO, hash string) bool {
+	if c.Data == hash {
+		return true
+	}
+	for _, thumbnail := range c.Thumbnails {
+		if thumbnail == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByBlob returns the contents that store the blob hash as their data or as a thumbnail.
+func (r *InMemoryContentRepository) FindByBlob(hash string) ([]*ContentPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*ContentPO
+	for _, c := range r.contents {
+		if referencesBlob(c, hash) {
+			copy := *c
+			results = append(results, &copy)
+		}
+	}
+	return results, nil
+}
+
+// FindLinkingTo returns the contents that link to a note.
+func (r *InMemoryContentRepository) FindLinkingTo(noteID string) ([]*ContentPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*ContentPO
+	for id := range r.backlinks[noteID] {
+		copy := *r.contents[id]
+		results = append(results, &copy)
+	}
+	return results, nil
+}
+
+// indexLinks adds the links of a content to the backlink index. The caller must hold the write lock.
+func (r *InMemoryContentRepository) indexLinks(c *ContentPO) {
+	for _, noteID := range c.LinkedNoteIDs {
+		if r.backlinks[noteID] == nil {
+			r.backlinks[noteID] = make(map[string]bool)
+		}
+		r.backlinks[noteID][c.ID] = true
+	}
+}
+
+// unindexLinks removes the links of a content from the backlink index. The caller must hold the write lock.
+func (r *InMemoryContentRepository) unindexLinks(c *ContentPO) {
+	for _, noteID := range c.LinkedNoteIDs {
+		delete(r.backlinks[noteID], c.ID)
+		if len(r.backlinks[noteID]) == 0 {
+			delete(r.backlinks, noteID)
+		}
+	}
+}
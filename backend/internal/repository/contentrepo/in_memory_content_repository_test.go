@@ -1,6 +1,8 @@
 package contentrepo_test
 
 import (
+	"errors"
+	"fmt"
 	"noteapp/internal/repository/contentrepo"
 	"sync"
 	"testing"
@@ -149,3 +151,66 @@ func TestInMemoryContentRepository_Delete(t *testing.T) {
 		t.Errorf("Expected error to be '%v', but got '%v'", contentrepo.ErrContentNotFound, err)
 	}
 }
+
+func TestInMemoryContentRepository_FindLinkingTo(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	repo.Save(&contentrepo.ContentPO{ID: "c1", NoteID: "n1", LinkedNoteIDs: []string{"n2", "n3"}})
+	repo.Save(&contentrepo.ContentPO{ID: "c2", NoteID: "n2", LinkedNoteIDs: []string{"n3"}})
+
+	linking, err := repo.FindLinkingTo("n3")
+	if err != nil {
+		t.Fatalf("FindLinkingTo returned an unexpected error: %v", err)
+	}
+	if len(linking) != 2 {
+		t.Fatalf("Expected 2 contents linking to n3, but got %d", len(linking))
+	}
+
+	repo.Save(&contentrepo.ContentPO{ID: "c1", NoteID: "n1", Version: 0, LinkedNoteIDs: []string{"n2"}})
+	linking, _ = repo.FindLinkingTo("n3")
+	if len(linking) != 1 || linking[0].ID != "c2" {
+		t.Errorf("Expected only c2 to link to n3 after c1 was edited, but got %v", linking)
+	}
+
+	repo.Delete("c2")
+	linking, _ = repo.FindLinkingTo("n3")
+	if len(linking) != 0 {
+		t.Errorf("Expected no contents linking to n3 after c2 was deleted, but got %d", len(linking))
+	}
+}
+
+func TestInMemoryContentRepository_SaveAttachment_ReservesQuotaAtomically(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	repo.Save(&contentrepo.ContentPO{ID: "other", AttachmentFilename: "other.pdf", AttachmentSize: 100, AttachmentUploaderID: "user-2"})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.SaveAttachment(&contentrepo.ContentPO{
+				ID:                   fmt.Sprintf("c%d", i),
+				AttachmentFilename:   "report.pdf",
+				AttachmentSize:       30,
+				AttachmentUploaderID: "user-1",
+			}, 100)
+		}(i)
+	}
+	wg.Wait()
+
+	saved, savedID := 0, ""
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			saved, savedID = saved+1, fmt.Sprintf("c%d", i)
+		case !errors.Is(err, contentrepo.ErrStorageQuotaExceeded):
+			t.Errorf("Expected ErrStorageQuotaExceeded, got %v", err)
+		}
+	}
+	if saved != 3 {
+		t.Errorf("Expected 3 attachments to fit in the quota, got %d", saved)
+	}
+	if err := repo.SaveAttachment(&contentrepo.ContentPO{ID: savedID, AttachmentFilename: "again.pdf", AttachmentUploaderID: "user-1"}, 100); !errors.Is(err, contentrepo.ErrContentConflict) {
+		t.Errorf("Expected ErrContentConflict for an existing content, got %v", err)
+	}
+}
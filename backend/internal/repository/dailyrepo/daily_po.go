@@ -0,0 +1,16 @@
+package dailyrepo
+
+// SettingsPO represents the persistent state of a user's daily note settings.
+type SettingsPO struct {
+	UserID     string
+	TimeZone   string
+	TemplateID string
+	Version    int
+}
+
+// EntryPO represents the persistent state of a daily note: the note a user keeps for a date.
+type EntryPO struct {
+	UserID string
+	Date   string
+	NoteID string
+}
@@ -0,0 +1,14 @@
+package dailyrepo
+
+// DailyNoteRepository defines the interface for daily note persistence.
+type DailyNoteRepository interface {
+	SaveSettings(s *SettingsPO) error
+	FindSettings(userID string) (*SettingsPO, error)
+	// SaveEntry records a new daily note, and fails with ErrEntryExists if the user already
+	// has one for the date.
+	SaveEntry(e *EntryPO) error
+	FindEntry(userID, date string) (*EntryPO, error)
+	// FindEntries retrieves the daily notes of a user, ordered by date.
+	FindEntries(userID string) ([]*EntryPO, error)
+	DeleteEntry(userID, date string) error
+}
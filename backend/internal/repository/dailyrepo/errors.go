@@ -0,0 +1,14 @@
+package dailyrepo
+
+import "errors"
+
+var (
+	// ErrSettingsNotFound is returned when a user has no daily note settings.
+	ErrSettingsNotFound = errors.New("daily note settings not found")
+	// ErrSettingsConflict is returned when a version conflict occurs.
+	ErrSettingsConflict = errors.New("daily note settings conflict")
+	// ErrEntryNotFound is returned when a user has no daily note for a date.
+	ErrEntryNotFound = errors.New("daily note not found")
+	// ErrEntryExists is returned when a user already has a daily note for a date.
+	ErrEntryExists = errors.New("daily note already exists")
+)
@@ -0,0 +1,114 @@
+package dailyrepo
+
+import (
+	"sort"
+	"sync"
+)
+
+// InMemoryDailyNoteRepository is an in-memory implementation of DailyNoteRepository.
+type InMemoryDailyNoteRepository struct {
+	mu       sync.RWMutex
+	settings map[string]*SettingsPO
+	// entries maps a user ID to the user's daily notes by date.
+	entries map[string]map[string]*EntryPO
+}
+
+// NewInMemoryDailyNoteRepository creates a new InMemoryDailyNoteRepository.
+func NewInMemoryDailyNoteRepository() *InMemoryDailyNoteRepository {
+	return &InMemoryDailyNoteRepository{
+		settings: make(map[string]*SettingsPO),
+		entries:  make(map[string]map[string]*EntryPO),
+	}
+}
+
+// SaveSettings saves a user's daily note settings to the repository.
+func (r *InMemoryDailyNoteRepository) SaveSettings(s *SettingsPO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.settings[s.UserID]; ok {
+		if existing.Version != s.Version {
+			return ErrSettingsConflict
+		}
+		s.Version++
+	} else {
+		s.Version = 0
+	}
+
+	stored := *s
+	r.settings[s.UserID] = &stored
+	return nil
+}
+
+// FindSettings retrieves a user's daily note settings.
+func (r *InMemoryDailyNoteRepository) FindSettings(userID string) (*SettingsPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.settings[userID]
+	if !ok {
+		return nil, ErrSettingsNotFound
+	}
+	found := *s
+	return &found, nil
+}
+
+// SaveEntry records a new daily note.
+func (r *InMemoryDailyNoteRepository) SaveEntry(e *EntryPO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byDate, ok := r.entries[e.UserID]
+	if !ok {
+		byDate = make(map[string]*EntryPO)
+		r.entries[e.UserID] = byDate
+	}
+	if _, ok := byDate[e.Date]; ok {
+		return ErrEntryExists
+	}
+	stored := *e
+	byDate[e.Date] = &stored
+	return nil
+}
+
+// FindEntry retrieves the daily note of a user for a date.
+func (r *InMemoryDailyNoteRepository) FindEntry(userID, date string) (*EntryPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.entries[userID][date]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	found := *e
+	return &found, nil
+}
+
+// FindEntries retrieves the daily notes of a user, ordered by date.
+func (r *InMemoryDailyNoteRepository) FindEntries(userID string) ([]*EntryPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*EntryPO
+	for _, e := range r.entries[userID] {
+		found := *e
+		results = append(results, &found)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date < results[j].Date
+	})
+	return results, nil
+}
+
+// DeleteEntry removes the daily note of a user for a date from the repository. The note
+// itself is left alone.
+func (r *InMemoryDailyNoteRepository) DeleteEntry(userID, date string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[userID][date]; !ok {
+		return ErrEntryNotFound
+	}
+	delete(r.entries[userID], date)
+	return nil
+}
@@ -0,0 +1,57 @@
+package dailyrepo_test
+
+import (
+	"noteapp/internal/repository/dailyrepo"
+	"testing"
+)
+
+func TestInMemoryDailyNoteRepository_Settings(t *testing.T) {
+	repo := dailyrepo.NewInMemoryDailyNoteRepository()
+	if _, err := repo.FindSettings("user-1"); err != dailyrepo.ErrSettingsNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", dailyrepo.ErrSettingsNotFound, err)
+	}
+
+	if err := repo.SaveSettings(&dailyrepo.SettingsPO{UserID: "user-1", TimeZone: "Asia/Tokyo"}); err != nil {
+		t.Fatalf("SaveSettings returned an unexpected error: %v", err)
+	}
+	first, _ := repo.FindSettings("user-1")
+	second, _ := repo.FindSettings("user-1")
+
+	if first.TimeZone != "Asia/Tokyo" || first.Version != 0 {
+		t.Errorf("Expected the settings stored at version 0, got %+v", first)
+	}
+	if err := repo.SaveSettings(first); err != nil {
+		t.Fatalf("SaveSettings returned an unexpected error: %v", err)
+	}
+	if err := repo.SaveSettings(second); err != dailyrepo.ErrSettingsConflict {
+		t.Errorf("Expected error to be '%v', but got '%v'", dailyrepo.ErrSettingsConflict, err)
+	}
+}
+
+func TestInMemoryDailyNoteRepository_Entries(t *testing.T) {
+	repo := dailyrepo.NewInMemoryDailyNoteRepository()
+	repo.SaveEntry(&dailyrepo.EntryPO{UserID: "user-1", Date: "2024-03-05", NoteID: "n2"})
+	repo.SaveEntry(&dailyrepo.EntryPO{UserID: "user-1", Date: "2024-03-04", NoteID: "n1"})
+	repo.SaveEntry(&dailyrepo.EntryPO{UserID: "user-2", Date: "2024-03-04", NoteID: "n3"})
+
+	if err := repo.SaveEntry(&dailyrepo.EntryPO{UserID: "user-1", Date: "2024-03-04", NoteID: "n4"}); err != dailyrepo.ErrEntryExists {
+		t.Errorf("Expected error to be '%v', but got '%v'", dailyrepo.ErrEntryExists, err)
+	}
+	entries, err := repo.FindEntries("user-1")
+	if err != nil {
+		t.Fatalf("FindEntries returned an unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].NoteID != "n1" || entries[1].NoteID != "n2" {
+		t.Errorf("Expected the user's entries ordered by date, got %+v", entries)
+	}
+
+	if err := repo.DeleteEntry("user-1", "2024-03-04"); err != nil {
+		t.Fatalf("DeleteEntry returned an unexpected error: %v", err)
+	}
+	if _, err := repo.FindEntry("user-1", "2024-03-04"); err != dailyrepo.ErrEntryNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", dailyrepo.ErrEntryNotFound, err)
+	}
+	if e, err := repo.FindEntry("user-2", "2024-03-04"); err != nil || e.NoteID != "n3" {
+		t.Errorf("Expected the other user's entry to be kept, got %+v, %v", e, err)
+	}
+}
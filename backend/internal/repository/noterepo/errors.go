@@ -9,4 +9,6 @@ var (
 	ErrNoteConflict = errors.New("note conflict")
 	// ErrNilNote is returned when a nil note is passed.
 	ErrNilNote = errors.New("note cannot be nil")
+	// ErrInvalidCursor is returned when a listing cursor cannot be decoded.
+	ErrInvalidCursor = errors.New("invalid cursor")
 )
@@ -0,0 +1,58 @@
+package noterepo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// similarity returns the normalized edit distance similarity of two strings, ignoring case.
+// It is 1 for equal strings and 0 for strings with nothing in common.
+func similarity(a, b string) float64 {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the minimum number of single-rune insertions, deletions and
+// substitutions needed to turn a into b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// bestSimilarity scores a query against a text and against each of its words or keyword
+// segments, so "concurency" matches both "concurrency" and "cs/os/concurrency".
+func bestSimilarity(query, text string) float64 {
+	best := similarity(query, text)
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || string(r) == "/"
+	})
+	for _, part := range parts {
+		if score := similarity(query, part); score > best {
+			best = score
+		}
+	}
+	return best
+}
@@ -1,6 +1,8 @@
 package noterepo
 
 import (
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -38,8 +40,72 @@ func (r *InMemoryNoteRepository) Save(note *NotePO) error {
 	return nil
 }
 
-// FindByID retrieves a note by its ID.
+// SaveAll saves several notes at once: either all of them are saved, or, when any of them is
+// nil or conflicts, none is.
+func (r *InMemoryNoteRepository) SaveAll(notes ...*NotePO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, note := range notes {
+		if note == nil {
+			return ErrNilNote
+		}
+		if existing, ok := r.notes[note.ID]; ok && existing.Version != note.Version {
+			return ErrNoteConflict
+		}
+	}
+	for _, note := range notes {
+		if _, ok := r.notes[note.ID]; ok {
+			note.Version++
+		} else {
+			note.Version = 0
+		}
+		r.notes[note.ID] = note
+	}
+	return nil
+}
+
+// SaveAllAndDelete saves some notes and deletes others at once. The deleted notes must still
+// be at the versions given; either all changes are made or, when any of them conflicts, none is.
+func (r *InMemoryNoteRepository) SaveAllAndDelete(notes []*NotePO, deleted []*NotePO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
+	for _, note := range deleted {
+		if note == nil {
+			return ErrNilNote
+		}
+		existing, ok := r.notes[note.ID]
+		if !ok {
+			return ErrNoteNotFound
+		}
+		if existing.Version != note.Version {
+			return ErrNoteConflict
+		}
+	}
+	for _, note := range notes {
+		if note == nil {
+			return ErrNilNote
+		}
+		if existing, ok := r.notes[note.ID]; ok && existing.Version != note.Version {
+			return ErrNoteConflict
+		}
+	}
+	for _, note := range deleted {
+		delete(r.notes, note.ID)
+	}
+	for _, note := range notes {
+		if _, ok := r.notes[note.ID]; ok {
+			note.Version++
+		} else {
+			note.Version = 0
+		}
+		r.notes[note.ID] = note
+	}
+	return nil
+}
+
+// FindByID retrieves a note by its ID.
 func (r *InMemoryNoteRepository) FindByID(id string) (*NotePO, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -48,25 +114,7 @@ func (r *InMemoryNoteRepository) FindByID(id string) (*NotePO, error) {
 		return nil, ErrNoteNotFound
 	}
 	// Return a copy to prevent race conditions in concurrent tests
-	newNote := &NotePO{
-		ID:            note.ID,
-		OwnerID:       note.OwnerID,
-		Title:         note.Title,
-		Version:       note.Version,
-		ContentIDs:    make([]string, len(note.ContentIDs)),
-		Keywords:      make(map[string][]string),
-		Collaborators: make(map[string]string),
-	}
-	copy(newNote.ContentIDs, note.ContentIDs)
-	for k, v := range note.Keywords {
-		newNote.Keywords[k] = append([]string{}, v...)
-	}
-
-	for k, v := range note.Collaborators {
-		newNote.Collaborators[k] = v
-	}
-	return newNote, nil
-
+	return copyNotePO(note), nil
 }
 
 // Delete removes a note from the repository.
@@ -116,3 +164,202 @@ func (r *InMemoryNoteRepository) GetAccessibleNotesByUserID(userID string) ([]*N
 	}
 	return accessibleNotes, nil
 }
+
+// FindByKeywordWithDescendantsForUser finds notes tagged by a user with a keyword
+// or with any hierarchical keyword below it (e.g. "cs/os/concurrency" for "cs/os").
+func (r *InMemoryNoteRepository) FindByKeywordWithDescendantsForUser(userID, keyword string) ([]*NotePO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var foundNotes []*NotePO
+	for _, note := range r.notes {
+		for _, k := range note.Keywords[userID] {
+			if k == keyword || strings.HasPrefix(k, keyword+"/") {
+				foundNotes = append(foundNotes, copyNotePO(note))
+				break
+			}
+		}
+	}
+	return foundNotes, nil
+}
+
+// FindTaggedByUser retrieves all notes on which a user has at least one keyword.
+func (r *InMemoryNoteRepository) FindTaggedByUser(userID string) ([]*NotePO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var foundNotes []*NotePO
+	for _, note := range r.notes {
+		if len(note.Keywords[userID]) > 0 {
+			foundNotes = append(foundNotes, copyNotePO(note))
+		}
+	}
+	return foundNotes, nil
+}
+
+// FindByFuzzyMatchForUser finds notes whose title or whose keywords applied by the user are
+// similar to query, tolerating typos. Titles are only matched on notes the user owns or
+// collaborates on. Each note is scored by its best match, and notes scoring below threshold
+// are omitted. Results are ordered by score, best first.
+func (r *InMemoryNoteRepository) FindByFuzzyMatchForUser(userID, query string, threshold float64) ([]*NoteMatchPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var matches []*NoteMatchPO
+	for _, note := range r.notes {
+		var best *NoteMatchPO
+		consider := func(field, text string) {
+			score := bestSimilarity(query, text)
+			if score >= threshold && (best == nil || score > best.Score) {
+				best = &NoteMatchPO{Score: score, MatchedField: field, MatchedText: text}
+			}
+		}
+		for _, k := range note.Keywords[userID] {
+			consider(MatchFieldKeyword, k)
+		}
+		_, isCollaborator := note.Collaborators[userID]
+		if note.OwnerID == userID || isCollaborator {
+			consider(MatchFieldTitle, note.Title)
+		}
+		if best != nil {
+			best.Note = copyNotePO(note)
+			matches = append(matches, best)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Note.ID < matches[j].Note.ID
+	})
+	return matches, nil
+}
+
+// ListAccessibleNotes returns a page of the notes a user owns or collaborates on,
+// filtered and sorted as described by the query.
+func (r *InMemoryNoteRepository) ListAccessibleNotes(query NoteListQuery) (*NotePagePO, error) {
+	var after *listCursor
+	if query.Cursor != "" {
+		c, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &c
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []listCursor
+	notes := make(map[string]*NotePO)
+	for _, note := range r.notes {
+		if !r.matchesListQuery(note, query) {
+			continue
+		}
+		entries = append(entries, listCursor{
+			Pinned: note.States[query.UserID].Pinned,
+			Key:    sortKey(note, query.SortBy),
+			ID:     note.ID,
+		})
+		notes[note.ID] = note
+	}
+
+	// Pinned notes come first whatever the sort order.
+	less := func(a, b listCursor) bool {
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if a.Key != b.Key {
+			return (a.Key < b.Key) != query.Descending
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
+
+	page := &NotePagePO{Notes: []*NotePO{}, Total: len(entries)}
+	start := 0
+	if after != nil {
+		start = sort.Search(len(entries), func(i int) bool {
+			return less(*after, entries[i])
+		})
+	}
+	end := len(entries)
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+		page.NextCursor = encodeCursor(entries[end-1])
+	}
+	for _, e := range entries[start:end] {
+		page.Notes = append(page.Notes, copyNotePO(notes[e.ID]))
+	}
+	return page, nil
+}
+
+func (r *InMemoryNoteRepository) matchesListQuery(note *NotePO, query NoteListQuery) bool {
+	permission, isCollaborator := note.Collaborators[query.UserID]
+	isOwner := note.OwnerID == query.UserID
+	if isOwner {
+		permission = "read-write"
+	}
+	state := note.States[query.UserID]
+	switch {
+	case !isOwner && !isCollaborator,
+		query.Ownership == OwnershipOwned && !isOwner,
+		query.Ownership == OwnershipShared && isOwner,
+		query.Permission != "" && permission != query.Permission,
+		query.Archived == ArchivedExclude && state.Archived,
+		query.Archived == ArchivedOnly && !state.Archived,
+		query.FavouritesOnly && !state.Favourite:
+		return false
+	}
+	if query.Keyword == "" {
+		return true
+	}
+	for _, k := range note.Keywords[query.UserID] {
+		if k == query.Keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKeyTimeLayout formats timestamps with a fixed width so that they sort as strings.
+const sortKeyTimeLayout = "20060102150405.000000000"
+
+// sortKey returns a key whose string order matches the requested sort order of notes.
+func sortKey(note *NotePO, sortBy string) string {
+	switch sortBy {
+	case SortByCreated:
+		return note.CreatedAt.UTC().Format(sortKeyTimeLayout)
+	case SortByModified:
+		return note.UpdatedAt.UTC().Format(sortKeyTimeLayout)
+	default:
+		return strings.ToLower(note.Title)
+	}
+}
+
+// copyNotePO returns a deep copy of a note so callers cannot mutate the stored state.
+func copyNotePO(note *NotePO) *NotePO {
+	newNote := &NotePO{
+		ID:             note.ID,
+		OwnerID:        note.OwnerID,
+		Title:          note.Title,
+		Version:        note.Version,
+		ContentIDs:     make([]string, len(note.ContentIDs)),
+		Keywords:       make(map[string][]string),
+		States:         make(map[string]NoteStatePO),
+		Collaborators:  make(map[string]string),
+		CreatedAt:      note.CreatedAt,
+		UpdatedAt:      note.UpdatedAt,
+		LastModifiedBy: note.LastModifiedBy,
+	}
+	copy(newNote.ContentIDs, note.ContentIDs)
+	for k, v := range note.Keywords {
+		newNote.Keywords[k] = append([]string{}, v...)
+	}
+	for k, v := range note.States {
+		newNote.States[k] = v
+	}
+	for k, v := range note.Collaborators {
+		newNote.Collaborators[k] = v
+	}
+	return newNote
+}
@@ -2,6 +2,7 @@ package noterepo
 
 import (
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -255,3 +256,354 @@ func TestInMemoryNoteRepository_Save_Conflict(t *testing.T) {
 		t.Errorf("Expected one of the saves to fail with a conflict error, but got err1: %v, err2: %v", err1, err2)
 	}
 }
+
+func TestInMemoryNoteRepository_FindByKeywordWithDescendantsForUser(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "note-1", Keywords: map[string][]string{"user-1": {"cs/os"}}})
+	repo.Save(&NotePO{ID: "note-2", Keywords: map[string][]string{"user-1": {"cs/os/concurrency"}}})
+	repo.Save(&NotePO{ID: "note-3", Keywords: map[string][]string{"user-1": {"cs/osx"}}})
+	repo.Save(&NotePO{ID: "note-4", Keywords: map[string][]string{"user-2": {"cs/os/concurrency"}}})
+
+	// Act
+	notes, err := repo.FindByKeywordWithDescendantsForUser("user-1", "cs/os")
+	if err != nil {
+		t.Fatalf("FindByKeywordWithDescendantsForUser() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	for _, note := range notes {
+		if note.ID != "note-1" && note.ID != "note-2" {
+			t.Errorf("Expected note-1 or note-2, got %s", note.ID)
+		}
+	}
+}
+
+func TestInMemoryNoteRepository_FindTaggedByUser(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "note-1", Keywords: map[string][]string{"user-1": {"go"}}})
+	repo.Save(&NotePO{ID: "note-2", Keywords: map[string][]string{"user-1": {}}})
+	repo.Save(&NotePO{ID: "note-3", Keywords: map[string][]string{"user-2": {"go"}}})
+
+	// Act
+	notes, err := repo.FindTaggedByUser("user-1")
+	if err != nil {
+		t.Fatalf("FindTaggedByUser() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if len(notes) != 1 {
+		t.Fatalf("Expected 1 note, got %d", len(notes))
+	}
+	if notes[0].ID != "note-1" {
+		t.Errorf("Expected note-1, got %s", notes[0].ID)
+	}
+}
+
+func TestInMemoryNoteRepository_FindByFuzzyMatchForUser(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "note-1", OwnerID: "user-1", Title: "Scheduling", Keywords: map[string][]string{"user-1": {"cs/os/concurrency"}}})
+	repo.Save(&NotePO{ID: "note-2", OwnerID: "user-1", Title: "On Concurrence"})
+	repo.Save(&NotePO{ID: "note-3", OwnerID: "user-2", Title: "Concurrency"})
+	repo.Save(&NotePO{ID: "note-4", OwnerID: "user-1", Title: "Networking", Keywords: map[string][]string{"user-1": {"sockets"}}})
+
+	// Act
+	matches, err := repo.FindByFuzzyMatchForUser("user-1", "concurency", 0.7)
+	if err != nil {
+		t.Fatalf("FindByFuzzyMatchForUser() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Note.ID != "note-1" || matches[0].MatchedField != MatchFieldKeyword || matches[0].MatchedText != "cs/os/concurrency" {
+		t.Errorf("Expected note-1 to match on its keyword first, got %+v", matches[0])
+	}
+	if matches[1].Note.ID != "note-2" || matches[1].MatchedField != MatchFieldTitle {
+		t.Errorf("Expected note-2 to match on its title second, got %+v", matches[1])
+	}
+	if matches[0].Score <= matches[1].Score {
+		t.Errorf("Expected matches ordered by score, got %f then %f", matches[0].Score, matches[1].Score)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"go", "go", 1},
+		{"Go", "gO", 1},
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+	}
+
+	for _, tc := range testCases {
+		if got := similarity(tc.a, tc.b); got != tc.expected {
+			t.Errorf("similarity(%q, %q): expected %f, got %f", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_Pagination(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "note-c", OwnerID: "user-1", Title: "Cherry"})
+	repo.Save(&NotePO{ID: "note-a", OwnerID: "user-1", Title: "apple"})
+	repo.Save(&NotePO{ID: "note-b", OwnerID: "user-1", Title: "Banana"})
+	repo.Save(&NotePO{ID: "note-x", OwnerID: "user-2", Title: "Apricot"})
+
+	// Act
+	first, err := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", SortBy: SortByTitle, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+	}
+	second, err := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", SortBy: SortByTitle, Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if first.Total != 3 || second.Total != 3 {
+		t.Errorf("Expected a total of 3, got %d and %d", first.Total, second.Total)
+	}
+	if len(first.Notes) != 2 || first.Notes[0].ID != "note-a" || first.Notes[1].ID != "note-b" {
+		t.Errorf("Expected the first page to be [note-a note-b], got %v", noteIDs(first.Notes))
+	}
+	if first.NextCursor == "" {
+		t.Errorf("Expected a cursor for the next page")
+	}
+	if len(second.Notes) != 1 || second.Notes[0].ID != "note-c" || second.NextCursor != "" {
+		t.Errorf("Expected the last page to be [note-c] without a cursor, got %v", noteIDs(second.Notes))
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_SortByTimestamps(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	repo.Save(&NotePO{ID: "note-1", OwnerID: "user-1", Title: "First", CreatedAt: start, UpdatedAt: start.Add(3 * time.Hour)})
+	repo.Save(&NotePO{ID: "note-2", OwnerID: "user-1", Title: "Second", CreatedAt: start.Add(time.Hour), UpdatedAt: start.Add(time.Hour)})
+	repo.Save(&NotePO{ID: "note-3", OwnerID: "user-1", Title: "Third", CreatedAt: start.Add(2 * time.Hour), UpdatedAt: start.Add(2 * time.Hour)})
+
+	// Act
+	byCreated, _ := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", SortBy: SortByCreated})
+	byModified, _ := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", SortBy: SortByModified, Descending: true})
+
+	// Assert
+	if ids := noteIDs(byCreated.Notes); len(ids) != 3 || ids[0] != "note-1" || ids[1] != "note-2" || ids[2] != "note-3" {
+		t.Errorf("Expected notes in creation order [note-1 note-2 note-3], got %v", ids)
+	}
+	if ids := noteIDs(byModified.Notes); len(ids) != 3 || ids[0] != "note-1" || ids[1] != "note-3" || ids[2] != "note-2" {
+		t.Errorf("Expected most recently modified first [note-1 note-3 note-2], got %v", ids)
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_Filters(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "owned", OwnerID: "user-1", Title: "Owned", Keywords: map[string][]string{"user-1": {"go"}}})
+	repo.Save(&NotePO{ID: "read", OwnerID: "user-2", Title: "Read", Collaborators: map[string]string{"user-1": "read"}})
+	repo.Save(&NotePO{ID: "write", OwnerID: "user-2", Title: "Write", Collaborators: map[string]string{"user-1": "read-write"}, Keywords: map[string][]string{"user-1": {"go"}}})
+
+	testCases := []struct {
+		name     string
+		query    NoteListQuery
+		expected []string
+	}{
+		{"owned", NoteListQuery{UserID: "user-1", Ownership: OwnershipOwned}, []string{"owned"}},
+		{"shared", NoteListQuery{UserID: "user-1", Ownership: OwnershipShared}, []string{"read", "write"}},
+		{"read-write", NoteListQuery{UserID: "user-1", Permission: "read-write"}, []string{"owned", "write"}},
+		{"shared read-write", NoteListQuery{UserID: "user-1", Ownership: OwnershipShared, Permission: "read-write"}, []string{"write"}},
+		{"keyword", NoteListQuery{UserID: "user-1", Keyword: "go"}, []string{"owned", "write"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			page, err := repo.ListAccessibleNotes(tc.query)
+			if err != nil {
+				t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+			}
+
+			// Assert
+			ids := noteIDs(page.Notes)
+			if len(ids) != len(tc.expected) || page.Total != len(tc.expected) {
+				t.Fatalf("Expected %v, got %v (total %d)", tc.expected, ids, page.Total)
+			}
+			for i := range ids {
+				if ids[i] != tc.expected[i] {
+					t.Errorf("Expected %v, got %v", tc.expected, ids)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_States(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "a", OwnerID: "user-1", Title: "A"})
+	repo.Save(&NotePO{ID: "b", OwnerID: "user-1", Title: "B", States: map[string]NoteStatePO{"user-1": {Archived: true}}})
+	repo.Save(&NotePO{ID: "c", OwnerID: "user-1", Title: "C", States: map[string]NoteStatePO{"user-1": {Pinned: true, Favourite: true}}})
+	repo.Save(&NotePO{ID: "d", OwnerID: "user-2", Title: "D", Collaborators: map[string]string{"user-1": "read"}, States: map[string]NoteStatePO{"user-1": {Pinned: true}, "user-2": {Archived: true}}})
+	repo.Save(&NotePO{ID: "e", OwnerID: "user-1", Title: "E", States: map[string]NoteStatePO{"user-2": {Pinned: true}}})
+
+	testCases := []struct {
+		name     string
+		query    NoteListQuery
+		expected []string
+	}{
+		{"pinned first, archived hidden", NoteListQuery{UserID: "user-1"}, []string{"c", "d", "a", "e"}},
+		{"pinned first in descending order", NoteListQuery{UserID: "user-1", Descending: true}, []string{"d", "c", "e", "a"}},
+		{"archived included", NoteListQuery{UserID: "user-1", Archived: ArchivedInclude}, []string{"c", "d", "a", "b", "e"}},
+		{"archived only", NoteListQuery{UserID: "user-1", Archived: ArchivedOnly}, []string{"b"}},
+		{"favourites", NoteListQuery{UserID: "user-1", FavouritesOnly: true}, []string{"c"}},
+		{"another user's states", NoteListQuery{UserID: "user-2", Archived: ArchivedInclude}, []string{"d"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			page, err := repo.ListAccessibleNotes(tc.query)
+			if err != nil {
+				t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+			}
+
+			// Assert
+			if ids := noteIDs(page.Notes); !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_PaginationAcrossPinnedNotes(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "a", OwnerID: "user-1", Title: "A"})
+	repo.Save(&NotePO{ID: "b", OwnerID: "user-1", Title: "B", States: map[string]NoteStatePO{"user-1": {Pinned: true}}})
+	repo.Save(&NotePO{ID: "c", OwnerID: "user-1", Title: "C"})
+
+	// Act
+	var ids []string
+	cursor := ""
+	for {
+		page, err := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", Cursor: cursor, Limit: 1})
+		if err != nil {
+			t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+		}
+		ids = append(ids, noteIDs(page.Notes)...)
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	// Assert
+	if expected := []string{"b", "a", "c"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestInMemoryNoteRepository_ListAccessibleNotes_InvalidCursor(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryNoteRepository()
+
+	// Act
+	_, err := repo.ListAccessibleNotes(NoteListQuery{UserID: "user-1", Cursor: "not a cursor"})
+
+	// Assert
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, but got %v", err)
+	}
+}
+
+func noteIDs(notes []*NotePO) []string {
+	ids := make([]string, 0, len(notes))
+	for _, note := range notes {
+		ids = append(ids, note.ID)
+	}
+	return ids
+}
+
+func TestInMemoryNoteRepository_SaveAll(t *testing.T) {
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "n1", Title: "One"})
+	repo.Save(&NotePO{ID: "n2", Title: "Two"})
+
+	err := repo.SaveAll(&NotePO{ID: "n1", Title: "One changed", Version: 0}, &NotePO{ID: "n2", Title: "Two changed", Version: 0})
+
+	if err != nil {
+		t.Fatalf("SaveAll returned an unexpected error: %v", err)
+	}
+	for _, id := range []string{"n1", "n2"} {
+		if note, _ := repo.FindByID(id); note.Version != 1 {
+			t.Errorf("Expected note %s to be at version 1, but got %d", id, note.Version)
+		}
+	}
+}
+
+func TestInMemoryNoteRepository_SaveAll_Conflict(t *testing.T) {
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "n1", Title: "One"})
+	repo.Save(&NotePO{ID: "n2", Title: "Two"})
+
+	err := repo.SaveAll(&NotePO{ID: "n1", Title: "One changed", Version: 0}, &NotePO{ID: "n2", Title: "Two changed", Version: 5})
+
+	if err != ErrNoteConflict {
+		t.Fatalf("Expected error to be '%v', but got '%v'", ErrNoteConflict, err)
+	}
+	if note, _ := repo.FindByID("n1"); note.Title != "One" || note.Version != 0 {
+		t.Errorf("Expected n1 to be left unchanged, but got %+v", note)
+	}
+}
+
+func TestInMemoryNoteRepository_SaveAllAndDelete(t *testing.T) {
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "n1", Title: "One"})
+	repo.Save(&NotePO{ID: "n2", Title: "Two"})
+	repo.Save(&NotePO{ID: "n3", Title: "Three"})
+
+	err := repo.SaveAllAndDelete([]*NotePO{{ID: "n1", Title: "One merged", Version: 0}}, []*NotePO{{ID: "n2", Version: 0}, {ID: "n3", Version: 0}})
+
+	if err != nil {
+		t.Fatalf("SaveAllAndDelete returned an unexpected error: %v", err)
+	}
+	if note, _ := repo.FindByID("n1"); note.Title != "One merged" || note.Version != 1 {
+		t.Errorf("Expected n1 to be saved at version 1, but got %+v", note)
+	}
+	for _, id := range []string{"n2", "n3"} {
+		if _, err := repo.FindByID(id); err != ErrNoteNotFound {
+			t.Errorf("Expected note %s to be deleted, but got '%v'", id, err)
+		}
+	}
+}
+
+func TestInMemoryNoteRepository_SaveAllAndDelete_Conflict(t *testing.T) {
+	repo := NewInMemoryNoteRepository()
+	repo.Save(&NotePO{ID: "n1", Title: "One"})
+	repo.Save(&NotePO{ID: "n2", Title: "Two"})
+
+	err := repo.SaveAllAndDelete([]*NotePO{{ID: "n1", Title: "One merged", Version: 0}}, []*NotePO{{ID: "n2", Version: 3}})
+
+	if err != ErrNoteConflict {
+		t.Fatalf("Expected error to be '%v', but got '%v'", ErrNoteConflict, err)
+	}
+	if note, _ := repo.FindByID("n1"); note.Title != "One" {
+		t.Errorf("Expected n1 to be left unchanged, but got %+v", note)
+	}
+	if _, err := repo.FindByID("n2"); err != nil {
+		t.Errorf("Expected n2 to be kept, but got '%v'", err)
+	}
+	if err := repo.SaveAllAndDelete(nil, []*NotePO{{ID: "missing"}}); err != ErrNoteNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrNoteNotFound, err)
+	}
+}
@@ -0,0 +1,83 @@
+package noterepo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Sort keys for listing notes.
+const (
+	SortByTitle    = "title"
+	SortByCreated  = "created"
+	SortByModified = "modified"
+)
+
+// Ownership filters for listing notes.
+const (
+	OwnershipAll    = ""
+	OwnershipOwned  = "owned"
+	OwnershipShared = "shared"
+)
+
+// Archive filters for listing notes.
+const (
+	ArchivedExclude = ""
+	ArchivedInclude = "include"
+	ArchivedOnly    = "only"
+)
+
+// NoteListQuery describes a page of the notes accessible to a user. The notes the user
+// pinned come first, each part sorted as requested.
+type NoteListQuery struct {
+	UserID string
+	// Ownership restricts the notes to those the user owns or those shared with them.
+	Ownership string
+	// Permission restricts the notes to those the user can access with exactly this
+	// permission. Owners have read-write permission on their notes.
+	Permission string
+	// Keyword restricts the notes to those the user tagged with this keyword.
+	Keyword string
+	// Archived decides whether the notes the user archived are left out, listed along with
+	// the others, or listed on their own.
+	Archived string
+	// FavouritesOnly restricts the notes to the user's favourites.
+	FavouritesOnly bool
+	SortBy         string
+	Descending     bool
+	// Cursor continues a listing after the last note of a previous page.
+	Cursor string
+	// Limit caps the number of notes in the page. Zero means no limit.
+	Limit int
+}
+
+// NotePagePO is a page of notes. Total counts all notes matching the query's filters,
+// and NextCursor is empty on the last page.
+type NotePagePO struct {
+	Notes      []*NotePO
+	Total      int
+	NextCursor string
+}
+
+// listCursor identifies the position of a note in a sorted listing.
+type listCursor struct {
+	Pinned bool   `json:"p,omitempty"`
+	Key    string `json:"k"`
+	ID     string `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
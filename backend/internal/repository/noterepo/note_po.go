@@ -1,5 +1,7 @@
 package noterepo
 
+import "time"
+
 // ContentPO represents the persistent state of a content block.
 type ContentPO struct {
 	ID   string
@@ -9,11 +11,37 @@ type ContentPO struct {
 
 // NotePO represents the persistent state of a note.
 type NotePO struct {
-	ID            string
-	OwnerID       string
-	Title         string
-	Version       int
-	ContentIDs    []string
-	Keywords      map[string][]string
-	Collaborators map[string]string
+	ID             string
+	OwnerID        string
+	Title          string
+	Version        int
+	ContentIDs     []string
+	Keywords       map[string][]string
+	States         map[string]NoteStatePO
+	Collaborators  map[string]string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastModifiedBy string
+}
+
+// NoteStatePO represents the persistent state of how a user keeps a note.
+type NoteStatePO struct {
+	Pinned    bool
+	Archived  bool
+	Favourite bool
+}
+
+// Fields a fuzzy search can match on.
+const (
+	MatchFieldKeyword = "keyword"
+	MatchFieldTitle   = "title"
+)
+
+// NoteMatchPO represents a note found by a fuzzy search together with the quality of its best match.
+// Score is a similarity between 0 and 1, where 1 is an exact match.
+type NoteMatchPO struct {
+	Note         *NotePO
+	Score        float64
+	MatchedField string
+	MatchedText  string
 }
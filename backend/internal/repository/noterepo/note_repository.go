@@ -3,8 +3,14 @@ package noterepo
 // NoteRepository defines the interface for note persistence.
 type NoteRepository interface {
 	Save(note *NotePO) error
+	SaveAll(notes ...*NotePO) error
+	SaveAllAndDelete(notes []*NotePO, deleted []*NotePO) error
 	FindByID(id string) (*NotePO, error)
 	Delete(id string) error
 	FindByKeywordForUser(userID, keyword string) ([]*NotePO, error)
 	GetAccessibleNotesByUserID(userID string) ([]*NotePO, error)
+	FindByKeywordWithDescendantsForUser(userID, keyword string) ([]*NotePO, error)
+	FindTaggedByUser(userID string) ([]*NotePO, error)
+	FindByFuzzyMatchForUser(userID, query string, threshold float64) ([]*NoteMatchPO, error)
+	ListAccessibleNotes(query NoteListQuery) (*NotePagePO, error)
 }
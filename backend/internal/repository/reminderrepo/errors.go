@@ -0,0 +1,10 @@
+package reminderrepo
+
+import "errors"
+
+var (
+	// ErrReminderNotFound is returned when a reminder is not found.
+	ErrReminderNotFound = errors.New("reminder not found")
+	// ErrReminderConflict is returned when a version conflict occurs.
+	ErrReminderConflict = errors.New("reminder conflict")
+)
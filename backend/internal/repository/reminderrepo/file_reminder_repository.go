@@ -0,0 +1,140 @@
+package reminderrepo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileReminderRepository is a ReminderRepository that keeps its reminders in memory and
+// writes them all to a JSON file on every change, so that reminders, and which of their
+// occurrences have fired, survive restarts.
+type FileReminderRepository struct {
+	path   string
+	memory *InMemoryReminderRepository
+}
+
+// NewFileReminderRepository creates a new FileReminderRepository backed by the file at path,
+// loading the reminders it already holds. The file is created on the first change.
+func NewFileReminderRepository(path string) (*FileReminderRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create reminder directory: %w", err)
+	}
+	r := &FileReminderRepository{path: path, memory: NewInMemoryReminderRepository()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reminders: %w", err)
+	}
+	var pos []*ReminderPO
+	if err := json.Unmarshal(data, &pos); err != nil {
+		return nil, fmt.Errorf("failed to decode reminders: %w", err)
+	}
+	for _, po := range pos {
+		r.memory.reminders[po.ID] = po
+	}
+	return r, nil
+}
+
+// Save saves a reminder to the repository. If the file cannot be written, the reminder is
+// left as it was.
+func (r *FileReminderRepository) Save(po *ReminderPO) error {
+	r.memory.mu.Lock()
+	defer r.memory.mu.Unlock()
+
+	previous, existed := r.memory.reminders[po.ID]
+	version := po.Version
+	if existed {
+		if previous.Version != po.Version {
+			return ErrReminderConflict
+		}
+		po.Version++
+	} else {
+		po.Version = 0
+	}
+
+	stored := *po
+	r.memory.reminders[po.ID] = &stored
+	if err := r.write(); err != nil {
+		if existed {
+			r.memory.reminders[po.ID] = previous
+		} else {
+			delete(r.memory.reminders, po.ID)
+		}
+		po.Version = version
+		return err
+	}
+	return nil
+}
+
+// FindByID retrieves a reminder by its ID.
+func (r *FileReminderRepository) FindByID(id string) (*ReminderPO, error) {
+	return r.memory.FindByID(id)
+}
+
+// FindByUser retrieves the reminders of a user, ordered by when they are next due, with the
+// reminders that have no occurrence left last.
+func (r *FileReminderRepository) FindByUser(userID string) ([]*ReminderPO, error) {
+	return r.memory.FindByUser(userID)
+}
+
+// FindDue retrieves the reminders with an occurrence due at or before a time, ordered by when
+// they are due.
+func (r *FileReminderRepository) FindDue(at time.Time) ([]*ReminderPO, error) {
+	return r.memory.FindDue(at)
+}
+
+// Delete removes a reminder from the repository. If the file cannot be written, the reminder
+// is kept.
+func (r *FileReminderRepository) Delete(id string) error {
+	r.memory.mu.Lock()
+	defer r.memory.mu.Unlock()
+
+	previous, ok := r.memory.reminders[id]
+	if !ok {
+		return ErrReminderNotFound
+	}
+	delete(r.memory.reminders, id)
+	if err := r.write(); err != nil {
+		r.memory.reminders[id] = previous
+		return err
+	}
+	return nil
+}
+
+// write replaces the file with all reminders. The caller must hold the lock of the memory.
+func (r *FileReminderRepository) write() error {
+	pos := make([]*ReminderPO, 0, len(r.memory.reminders))
+	for _, po := range r.memory.reminders {
+		pos = append(pos, po)
+	}
+	sortByNextAt(pos)
+	data, err := json.MarshalIndent(pos, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reminders: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create reminder file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write reminders: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("failed to store reminders: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package reminderrepo_test
+
+import (
+	"noteapp/internal/repository/reminderrepo"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileReminderRepository_SurvivesReopening(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "data", "reminders.json")
+	repo, err := reminderrepo.NewFileReminderRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileReminderRepository returned an unexpected error: %v", err)
+	}
+	at := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	repo.Save(&reminderrepo.ReminderPO{ID: "r1", UserID: "user-1", NextAt: at})
+	repo.Save(&reminderrepo.ReminderPO{ID: "r2", UserID: "user-1", NextAt: at})
+	fired, _ := repo.FindByID("r1")
+	fired.Occurrence = 1
+	fired.NextAt = time.Time{}
+	fired.LastFiredAt = at
+	repo.Save(fired)
+	repo.Delete("r2")
+
+	// Act
+	reopened, err := reminderrepo.NewFileReminderRepository(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewFileReminderRepository returned an unexpected error: %v", err)
+	}
+	found, err := reopened.FindByID("r1")
+	if err != nil {
+		t.Fatalf("FindByID returned an unexpected error: %v", err)
+	}
+	if found.Version != 1 || found.Occurrence != 1 || !found.NextAt.IsZero() || !found.LastFiredAt.Equal(at) {
+		t.Errorf("Expected the fired state of the reminder to be kept, got %+v", found)
+	}
+	if _, err := reopened.FindByID("r2"); err != reminderrepo.ErrReminderNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", reminderrepo.ErrReminderNotFound, err)
+	}
+	if due, _ := reopened.FindDue(at); len(due) != 0 {
+		t.Errorf("Expected no reminder due after reopening, got %d", len(due))
+	}
+}
+
+func TestFileReminderRepository_KeepsStateWhenWriteFails(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "reminders")
+	repo, _ := reminderrepo.NewFileReminderRepository(filepath.Join(dir, "reminders.json"))
+	repo.Save(&reminderrepo.ReminderPO{ID: "r1", UserID: "user-1"})
+	os.RemoveAll(dir)
+	po := &reminderrepo.ReminderPO{ID: "r1", UserID: "user-1", Message: "changed"}
+
+	// Act
+	err := repo.Save(po)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Expected Save to fail without its directory")
+	}
+	found, _ := repo.FindByID("r1")
+	if found.Message != "" || found.Version != 0 || po.Version != 0 {
+		t.Errorf("Expected the reminder left as it was, got %+v and %+v", found, po)
+	}
+}
@@ -0,0 +1,110 @@
+package reminderrepo
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryReminderRepository is an in-memory implementation of ReminderRepository.
+type InMemoryReminderRepository struct {
+	mu        sync.RWMutex
+	reminders map[string]*ReminderPO
+}
+
+// NewInMemoryReminderRepository creates a new InMemoryReminderRepository.
+func NewInMemoryReminderRepository() *InMemoryReminderRepository {
+	return &InMemoryReminderRepository{
+		reminders: make(map[string]*ReminderPO),
+	}
+}
+
+// Save saves a reminder to the repository.
+func (r *InMemoryReminderRepository) Save(po *ReminderPO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.reminders[po.ID]; ok {
+		if existing.Version != po.Version {
+			return ErrReminderConflict
+		}
+		po.Version++
+	} else {
+		po.Version = 0
+	}
+
+	stored := *po
+	r.reminders[po.ID] = &stored
+	return nil
+}
+
+// FindByID retrieves a reminder by its ID.
+func (r *InMemoryReminderRepository) FindByID(id string) (*ReminderPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	po, ok := r.reminders[id]
+	if !ok {
+		return nil, ErrReminderNotFound
+	}
+	found := *po
+	return &found, nil
+}
+
+// FindByUser retrieves the reminders of a user, ordered by when they are next due, with the
+// reminders that have no occurrence left last.
+func (r *InMemoryReminderRepository) FindByUser(userID string) ([]*ReminderPO, error) {
+	return r.find(func(po *ReminderPO) bool {
+		return po.UserID == userID
+	}), nil
+}
+
+// FindDue retrieves the reminders with an occurrence due at or before a time, ordered by when
+// they are due.
+func (r *InMemoryReminderRepository) FindDue(at time.Time) ([]*ReminderPO, error) {
+	return r.find(func(po *ReminderPO) bool {
+		return !po.NextAt.IsZero() && !po.NextAt.After(at)
+	}), nil
+}
+
+// Delete removes a reminder from the repository.
+func (r *InMemoryReminderRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reminders[id]; !ok {
+		return ErrReminderNotFound
+	}
+	delete(r.reminders, id)
+	return nil
+}
+
+func (r *InMemoryReminderRepository) find(match func(po *ReminderPO) bool) []*ReminderPO {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*ReminderPO
+	for _, po := range r.reminders {
+		if match(po) {
+			found := *po
+			results = append(results, &found)
+		}
+	}
+	sortByNextAt(results)
+	return results
+}
+
+// sortByNextAt orders reminders by when they are next due, with the reminders that have no
+// occurrence left last, and by ID when they are due at the same time.
+func sortByNextAt(reminders []*ReminderPO) {
+	sort.Slice(reminders, func(i, j int) bool {
+		a, b := reminders[i], reminders[j]
+		if a.NextAt.IsZero() != b.NextAt.IsZero() {
+			return b.NextAt.IsZero()
+		}
+		if !a.NextAt.Equal(b.NextAt) {
+			return a.NextAt.Before(b.NextAt)
+		}
+		return a.ID < b.ID
+	})
+}
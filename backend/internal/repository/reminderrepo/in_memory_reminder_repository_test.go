@@ -0,0 +1,63 @@
+package reminderrepo_test
+
+import (
+	"noteapp/internal/repository/reminderrepo"
+	"testing"
+	"time"
+)
+
+func TestInMemoryReminderRepository_SaveAndFind(t *testing.T) {
+	repo := reminderrepo.NewInMemoryReminderRepository()
+	at := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	repo.Save(&reminderrepo.ReminderPO{ID: "later", UserID: "user-1", NextAt: at.Add(time.Hour)})
+	repo.Save(&reminderrepo.ReminderPO{ID: "done", UserID: "user-1"})
+	repo.Save(&reminderrepo.ReminderPO{ID: "sooner", UserID: "user-1", NextAt: at})
+	repo.Save(&reminderrepo.ReminderPO{ID: "other", UserID: "user-2", NextAt: at.Add(-time.Hour)})
+
+	reminders, err := repo.FindByUser("user-1")
+	if err != nil {
+		t.Fatalf("FindByUser returned an unexpected error: %v", err)
+	}
+	if ids := reminderIDs(reminders); ids != "sooner,later,done" {
+		t.Errorf("Expected the user's reminders by next due time, got %s", ids)
+	}
+
+	due, err := repo.FindDue(at)
+	if err != nil {
+		t.Fatalf("FindDue returned an unexpected error: %v", err)
+	}
+	if ids := reminderIDs(due); ids != "other,sooner" {
+		t.Errorf("Expected the reminders due by %v, got %s", at, ids)
+	}
+}
+
+func TestInMemoryReminderRepository_OptimisticLocking(t *testing.T) {
+	repo := reminderrepo.NewInMemoryReminderRepository()
+	repo.Save(&reminderrepo.ReminderPO{ID: "r1", UserID: "user-1"})
+	first, _ := repo.FindByID("r1")
+	second, _ := repo.FindByID("r1")
+
+	if err := repo.Save(first); err != nil {
+		t.Fatalf("Save returned an unexpected error: %v", err)
+	}
+	if err := repo.Save(second); err != reminderrepo.ErrReminderConflict {
+		t.Errorf("Expected error to be '%v', but got '%v'", reminderrepo.ErrReminderConflict, err)
+	}
+	if err := repo.Delete("r1"); err != nil {
+		t.Fatalf("Delete returned an unexpected error: %v", err)
+	}
+	if _, err := repo.FindByID("r1"); err != reminderrepo.ErrReminderNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", reminderrepo.ErrReminderNotFound, err)
+	}
+}
+
+func reminderIDs(reminders []*reminderrepo.ReminderPO) string {
+	ids := ""
+	for i, r := range reminders {
+		if i > 0 {
+			ids += ","
+		}
+		ids += r.ID
+	}
+	return ids
+}
@@ -0,0 +1,21 @@
+package reminderrepo
+
+import "time"
+
+// ReminderPO represents the persistent state of a reminder, including which of its
+// occurrences have fired.
+type ReminderPO struct {
+	ID          string
+	NoteID      string
+	UserID      string
+	DueAt       time.Time
+	TimeZone    string
+	Frequency   string
+	Interval    int
+	Until       time.Time
+	Message     string
+	Occurrence  int
+	NextAt      time.Time
+	LastFiredAt time.Time
+	Version     int
+}
@@ -0,0 +1,16 @@
+package reminderrepo
+
+import "time"
+
+// ReminderRepository defines the interface for reminder persistence.
+type ReminderRepository interface {
+	Save(r *ReminderPO) error
+	FindByID(id string) (*ReminderPO, error)
+	// FindByUser retrieves the reminders of a user, ordered by when they are next due, with
+	// the reminders that have no occurrence left last.
+	FindByUser(userID string) ([]*ReminderPO, error)
+	// FindDue retrieves the reminders with an occurrence due at or before a time, ordered by
+	// when they are due.
+	FindDue(at time.Time) ([]*ReminderPO, error)
+	Delete(id string) error
+}
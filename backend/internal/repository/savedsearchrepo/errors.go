@@ -0,0 +1,10 @@
+package savedsearchrepo
+
+import "errors"
+
+var (
+	// ErrSavedSearchNotFound is returned when a saved search is not found.
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	// ErrSavedSearchConflict is returned when a version conflict occurs.
+	ErrSavedSearchConflict = errors.New("saved search conflict")
+)
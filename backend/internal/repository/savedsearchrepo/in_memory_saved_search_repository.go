@@ -0,0 +1,84 @@
+package savedsearchrepo
+
+import (
+	"sort"
+	"sync"
+)
+
+// InMemorySavedSearchRepository is an in-memory implementation of SavedSearchRepository.
+type InMemorySavedSearchRepository struct {
+	mu       sync.RWMutex
+	searches map[string]*SavedSearchPO
+}
+
+// NewInMemorySavedSearchRepository creates a new InMemorySavedSearchRepository.
+func NewInMemorySavedSearchRepository() *InMemorySavedSearchRepository {
+	return &InMemorySavedSearchRepository{
+		searches: make(map[string]*SavedSearchPO),
+	}
+}
+
+// Save saves a saved search to the repository.
+func (r *InMemorySavedSearchRepository) Save(s *SavedSearchPO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.searches[s.ID]; ok {
+		if existing.Version != s.Version {
+			return ErrSavedSearchConflict
+		}
+		s.Version++
+	} else {
+		s.Version = 0
+	}
+
+	stored := *s
+	r.searches[s.ID] = &stored
+	return nil
+}
+
+// FindByID retrieves a saved search by its ID.
+func (r *InMemorySavedSearchRepository) FindByID(id string) (*SavedSearchPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.searches[id]
+	if !ok {
+		return nil, ErrSavedSearchNotFound
+	}
+	found := *s
+	return &found, nil
+}
+
+// FindByOwnerID retrieves all saved searches of a user, ordered by name.
+func (r *InMemorySavedSearchRepository) FindByOwnerID(ownerID string) ([]*SavedSearchPO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*SavedSearchPO
+	for _, s := range r.searches {
+		if s.OwnerID == ownerID {
+			found := *s
+			results = append(results, &found)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// Delete removes a saved search from the repository.
+func (r *InMemorySavedSearchRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.searches[id]; !ok {
+		return ErrSavedSearchNotFound
+	}
+	delete(r.searches, id)
+	return nil
+}
@@ -0,0 +1,71 @@
+package savedsearchrepo_test
+
+import (
+	"noteapp/internal/repository/savedsearchrepo"
+	"testing"
+)
+
+func TestInMemorySavedSearchRepository_SaveAndFindByID(t *testing.T) {
+	repo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	s := &savedsearchrepo.SavedSearchPO{ID: "s1", OwnerID: "user-1", Name: "Go", Query: "go", Sort: "title_asc"}
+
+	if err := repo.Save(s); err != nil {
+		t.Fatalf("Save returned an unexpected error: %v", err)
+	}
+
+	found, err := repo.FindByID("s1")
+	if err != nil {
+		t.Fatalf("FindByID returned an unexpected error: %v", err)
+	}
+	if found.Name != "Go" || found.Query != "go" || found.Version != 0 {
+		t.Errorf("Expected the saved search to be stored at version 0, got %+v", found)
+	}
+}
+
+func TestInMemorySavedSearchRepository_Save_Conflict(t *testing.T) {
+	repo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	repo.Save(&savedsearchrepo.SavedSearchPO{ID: "s1", OwnerID: "user-1", Name: "Go"})
+
+	first, _ := repo.FindByID("s1")
+	second, _ := repo.FindByID("s1")
+	if err := repo.Save(first); err != nil {
+		t.Fatalf("Save returned an unexpected error: %v", err)
+	}
+
+	if err := repo.Save(second); err != savedsearchrepo.ErrSavedSearchConflict {
+		t.Errorf("Expected error to be '%v', but got '%v'", savedsearchrepo.ErrSavedSearchConflict, err)
+	}
+}
+
+func TestInMemorySavedSearchRepository_FindByOwnerID(t *testing.T) {
+	repo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	repo.Save(&savedsearchrepo.SavedSearchPO{ID: "s1", OwnerID: "user-1", Name: "Work"})
+	repo.Save(&savedsearchrepo.SavedSearchPO{ID: "s2", OwnerID: "user-1", Name: "OS"})
+	repo.Save(&savedsearchrepo.SavedSearchPO{ID: "s3", OwnerID: "user-2", Name: "Other"})
+
+	searches, err := repo.FindByOwnerID("user-1")
+	if err != nil {
+		t.Fatalf("FindByOwnerID returned an unexpected error: %v", err)
+	}
+	if len(searches) != 2 {
+		t.Fatalf("Expected 2 saved searches, got %d", len(searches))
+	}
+	if searches[0].Name != "OS" || searches[1].Name != "Work" {
+		t.Errorf("Expected saved searches ordered by name, got '%s', '%s'", searches[0].Name, searches[1].Name)
+	}
+}
+
+func TestInMemorySavedSearchRepository_Delete(t *testing.T) {
+	repo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	repo.Save(&savedsearchrepo.SavedSearchPO{ID: "s1", OwnerID: "user-1", Name: "Go"})
+
+	if err := repo.Delete("s1"); err != nil {
+		t.Fatalf("Delete returned an unexpected error: %v", err)
+	}
+	if _, err := repo.FindByID("s1"); err != savedsearchrepo.ErrSavedSearchNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", savedsearchrepo.ErrSavedSearchNotFound, err)
+	}
+	if err := repo.Delete("s1"); err != savedsearchrepo.ErrSavedSearchNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", savedsearchrepo.ErrSavedSearchNotFound, err)
+	}
+}
@@ -0,0 +1,11 @@
+package savedsearchrepo
+
+// SavedSearchPO represents the persistent state of a saved search.
+type SavedSearchPO struct {
+	ID      string
+	OwnerID string
+	Name    string
+	Query   string
+	Sort    string
+	Version int
+}
@@ -0,0 +1,9 @@
+package savedsearchrepo
+
+// SavedSearchRepository defines the interface for saved search persistence.
+type SavedSearchRepository interface {
+	Save(s *SavedSearchPO) error
+	FindByID(id string) (*SavedSearchPO, error)
+	FindByOwnerID(ownerID string) ([]*SavedSearchPO, error)
+	Delete(id string) error
+}
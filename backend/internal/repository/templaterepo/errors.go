@@ -0,0 +1,10 @@
+package templaterepo
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound is returned when a template is not found.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrTemplateConflict is returned when a version conflict occurs.
+	ErrTemplateConflict = errors.New("template conflict")
+)
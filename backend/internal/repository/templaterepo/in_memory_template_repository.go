@@ -0,0 +1,83 @@
+package templaterepo
+
+import (
+	"sort"
+	"sync"
+)
+
+// InMemoryTemplateRepository is an in-memory implementation of TemplateRepository.
+type InMemoryTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[string]*TemplatePO
+}
+
+// NewInMemoryTemplateRepository creates a new InMemoryTemplateRepository.
+func NewInMemoryTemplateRepository() *InMemoryTemplateRepository {
+	return &InMemoryTemplateRepository{
+		templates: make(map[string]*TemplatePO),
+	}
+}
+
+// Save saves a template to the repository.
+func (r *InMemoryTemplateRepository) Save(t *TemplatePO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.templates[t.ID]; ok {
+		if existing.Version != t.Version {
+			return ErrTemplateConflict
+		}
+		t.Version++
+	} else {
+		t.Version = 0
+	}
+
+	r.templates[t.ID] = copyTemplatePO(t)
+	return nil
+}
+
+// FindByID retrieves a template by its ID.
+func (r *InMemoryTemplateRepository) FindByID(id string) (*TemplatePO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[id]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return copyTemplatePO(t), nil
+}
+
+// FindAll retrieves all templates, ordered by name.
+func (r *InMemoryTemplateRepository) FindAll() ([]*TemplatePO, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*TemplatePO, 0, len(r.templates))
+	for _, t := range r.templates {
+		results = append(results, copyTemplatePO(t))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// Delete removes a template from the repository.
+func (r *InMemoryTemplateRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[id]; !ok {
+		return ErrTemplateNotFound
+	}
+	delete(r.templates, id)
+	return nil
+}
+
+func copyTemplatePO(t *TemplatePO) *TemplatePO {
+	copied := *t
+	copied.Blocks = append([]BlockPO{}, t.Blocks...)
+	return &copied
+}
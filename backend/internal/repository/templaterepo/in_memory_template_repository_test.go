@@ -0,0 +1,62 @@
+package templaterepo_test
+
+import (
+	"noteapp/internal/repository/templaterepo"
+	"testing"
+)
+
+func TestInMemoryTemplateRepository_SaveAndFindByID(t *testing.T) {
+	repo := templaterepo.NewInMemoryTemplateRepository()
+	tmpl := &templaterepo.TemplatePO{ID: "t1", OwnerID: "user-1", Name: "Meeting", TitlePattern: "Meeting {{date}}", Blocks: []templaterepo.BlockPO{{Type: "text", Data: "Agenda"}}}
+
+	if err := repo.Save(tmpl); err != nil {
+		t.Fatalf("Save returned an unexpected error: %v", err)
+	}
+	tmpl.Blocks[0].Data = "Changed after saving"
+
+	found, err := repo.FindByID("t1")
+	if err != nil {
+		t.Fatalf("FindByID returned an unexpected error: %v", err)
+	}
+	if found.Name != "Meeting" || found.Version != 0 || found.Blocks[0].Data != "Agenda" {
+		t.Errorf("Expected a copy of the template stored at version 0, got %+v", found)
+	}
+}
+
+func TestInMemoryTemplateRepository_Save_Conflict(t *testing.T) {
+	repo := templaterepo.NewInMemoryTemplateRepository()
+	repo.Save(&templaterepo.TemplatePO{ID: "t1", OwnerID: "user-1", Name: "Meeting"})
+	first, _ := repo.FindByID("t1")
+	second, _ := repo.FindByID("t1")
+
+	if err := repo.Save(first); err != nil {
+		t.Fatalf("Save returned an unexpected error: %v", err)
+	}
+	if err := repo.Save(second); err != templaterepo.ErrTemplateConflict {
+		t.Errorf("Expected error to be '%v', but got '%v'", templaterepo.ErrTemplateConflict, err)
+	}
+}
+
+func TestInMemoryTemplateRepository_FindAllAndDelete(t *testing.T) {
+	repo := templaterepo.NewInMemoryTemplateRepository()
+	repo.Save(&templaterepo.TemplatePO{ID: "t1", OwnerID: "user-1", Name: "Meeting"})
+	repo.Save(&templaterepo.TemplatePO{ID: "t2", OwnerID: "user-2", Name: "Incident"})
+
+	templates, err := repo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned an unexpected error: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "Incident" || templates[1].Name != "Meeting" {
+		t.Errorf("Expected both templates ordered by name, got %+v", templates)
+	}
+
+	if err := repo.Delete("t1"); err != nil {
+		t.Fatalf("Delete returned an unexpected error: %v", err)
+	}
+	if _, err := repo.FindByID("t1"); err != templaterepo.ErrTemplateNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", templaterepo.ErrTemplateNotFound, err)
+	}
+	if err := repo.Delete("t1"); err != templaterepo.ErrTemplateNotFound {
+		t.Errorf("Expected error to be '%v', but got '%v'", templaterepo.ErrTemplateNotFound, err)
+	}
+}
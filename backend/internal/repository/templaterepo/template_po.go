@@ -0,0 +1,19 @@
+package templaterepo
+
+// TemplatePO represents the persistent state of a note template.
+type TemplatePO struct {
+	ID           string
+	OwnerID      string
+	Name         string
+	TitlePattern string
+	Blocks       []BlockPO
+	Version      int
+}
+
+// BlockPO represents the persistent state of a content block of a template.
+type BlockPO struct {
+	Type     string
+	Data     string
+	Language string
+	Format   string
+}
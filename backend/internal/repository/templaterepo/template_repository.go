@@ -0,0 +1,9 @@
+package templaterepo
+
+// TemplateRepository defines the interface for template persistence.
+type TemplateRepository interface {
+	Save(t *TemplatePO) error
+	FindByID(id string) (*TemplatePO, error)
+	FindAll() ([]*TemplatePO, error)
+	Delete(id string) error
+}
@@ -0,0 +1,216 @@
+// Package sanitize cleans untrusted HTML against an allow-list of elements, attributes and
+// URL schemes, so that rendered notes can be shown to the users they are shared with
+// without letting their authors run scripts in the readers' browsers.
+package sanitize
+
+import (
+	"html"
+	"strings"
+)
+
+// allowedAttributes maps each allowed element to the attributes it may keep.
+var allowedAttributes = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"dd":         nil,
+	"del":        nil,
+	"details":    nil,
+	"div":        {"class"},
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"figcaption": nil,
+	"figure":     nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"input":      {"type", "checked"},
+	"kbd":        nil,
+	"li":         nil,
+	"mark":       nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        {"class"},
+	"s":          nil,
+	"span":       {"class"},
+	"strong":     nil,
+	"sub":        nil,
+	"summary":    nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align", "colspan", "rowspan"},
+	"th":         {"align", "colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// voidElements have no content and no end tag.
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true, "input": true}
+
+// droppedElements are removed together with their content, rather than just unwrapped.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true,
+	"template": true, "textarea": true, "title": true, "xmp": true, "svg": true, "math": true,
+}
+
+// urlAttributes are the attributes whose values are URLs and must use an allowed scheme.
+var urlAttributes = map[string]bool{"href": true, "src": true}
+
+// allowedSchemes are the URL schemes links and images may use. URLs without a scheme are
+// relative and always allowed.
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// HTML returns the allowed subset of the HTML fragment s. Disallowed elements are unwrapped,
+// keeping their text, except for scripts, styles and embedded documents, which are removed
+// with their content. Disallowed attributes, event handlers and URLs with other schemes than
+// http, https and mailto are removed. Links get rel="nofollow noopener noreferrer", and open
+// elements are closed at the end of the fragment.
+func HTML(s string) string {
+	t := &tokenizer{src: s}
+	var b strings.Builder
+	var open []string
+
+	for {
+		tok, ok := t.next()
+		if !ok {
+			break
+		}
+		switch tok.kind {
+		case textToken:
+			b.WriteString(html.EscapeString(html.UnescapeString(tok.data)))
+		case startTagToken:
+			if droppedElements[tok.data] {
+				if !tok.selfClosing {
+					t.skipRawText(tok.data)
+				}
+				continue
+			}
+			allowed, ok := allowedAttributes[tok.data]
+			if !ok {
+				continue
+			}
+			if tok.data == "input" && !isCheckbox(tok.attrs) {
+				continue
+			}
+			writeStartTag(&b, tok.data, filterAttributes(tok.data, tok.attrs, allowed))
+			if !voidElements[tok.data] {
+				open = append(open, tok.data)
+			}
+		case endTagToken:
+			i := lastIndex(open, tok.data)
+			if i < 0 {
+				continue
+			}
+			for j := len(open) - 1; j >= i; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			open = open[:i]
+		}
+	}
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("</" + open[j] + ">")
+	}
+	return b.String()
+}
+
+// IsSafeURL reports whether u is relative or uses one of the allowed URL schemes.
+func IsSafeURL(u string) bool {
+	// Browsers ignore control characters and whitespace inside schemes, as in "java\tscript:".
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(u))
+
+	colon := strings.IndexByte(cleaned, ':')
+	if colon < 0 {
+		return true
+	}
+	if delim := strings.IndexAny(cleaned, "/?#"); delim >= 0 && delim < colon {
+		return true
+	}
+	return allowedSchemes[strings.ToLower(cleaned[:colon])]
+}
+
+func filterAttributes(tag string, attrs []attribute, allowed []string) []attribute {
+	var kept []attribute
+	for _, attr := range attrs {
+		if !contains(allowed, attr.name) || hasAttribute(kept, attr.name) {
+			continue
+		}
+		if urlAttributes[attr.name] && !IsSafeURL(attr.value) {
+			continue
+		}
+		kept = append(kept, attr)
+	}
+	if tag == "a" {
+		kept = append(kept, attribute{name: "rel", value: "nofollow noopener noreferrer"})
+	}
+	if tag == "input" {
+		kept = append(kept, attribute{name: "disabled", value: ""})
+	}
+	return kept
+}
+
+func writeStartTag(b *strings.Builder, tag string, attrs []attribute) {
+	b.WriteString("<" + tag)
+	for _, attr := range attrs {
+		b.WriteString(" " + attr.name)
+		if attr.value != "" || attr.name != "checked" && attr.name != "disabled" {
+			b.WriteString(`="` + html.EscapeString(html.UnescapeString(attr.value)) + `"`)
+		}
+	}
+	b.WriteString(">")
+}
+
+// isCheckbox reports whether an input element is a checkbox, the only input allowed in
+// rendered notes, where it shows the state of task list items.
+func isCheckbox(attrs []attribute) bool {
+	for _, attr := range attrs {
+		if attr.name == "type" {
+			return strings.EqualFold(attr.value, "checkbox")
+		}
+	}
+	return false
+}
+
+func hasAttribute(attrs []attribute, name string) bool {
+	for _, attr := range attrs {
+		if attr.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func lastIndex(names []string, name string) int {
+	for i := len(names) - 1; i >= 0; i-- {
+		if names[i] == name {
+			return i
+		}
+	}
+	return -1
+}
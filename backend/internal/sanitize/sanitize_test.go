@@ -0,0 +1,59 @@
+package sanitize
+
+import "testing"
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"allowed markup", `<p>Hello <strong>world</strong></p>`, `<p>Hello <strong>world</strong></p>`},
+		{"script removed with content", `<p>a</p><script>alert(1)</script><p>b</p>`, `<p>a</p><p>b</p>`},
+		{"script end tag case", `<SCRIPT>alert(1)</ScRiPt>ok`, `ok`},
+		{"unknown element unwrapped", `<marquee>hi</marquee>`, `hi`},
+		{"event handler dropped", `<img src="a.png" onerror="alert(1)">`, `<img src="a.png">`},
+		{"javascript link dropped", `<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow noopener noreferrer">x</a>`},
+		{"obfuscated scheme dropped", `<a href="java&#x09;script:alert(1)">x</a>`, `<a rel="nofollow noopener noreferrer">x</a>`},
+		{"data image dropped", `<img src="data:text/html;base64,AAAA">`, `<img>`},
+		{"safe link kept", `<a href="https://example.com/?a=1&amp;b=2" target="_blank">x</a>`, `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener noreferrer">x</a>`},
+		{"relative link kept", `<a href="/notes/1">x</a>`, `<a href="/notes/1" rel="nofollow noopener noreferrer">x</a>`},
+		{"unclosed elements closed", `<ul><li>one`, `<ul><li>one</li></ul>`},
+		{"stray end tag dropped", `a</div>b`, `ab`},
+		{"comment removed", `a<!-- <script>x</script> -->b`, `ab`},
+		{"text escaped", `1 < 2 & "x"`, `1 &lt; 2 &amp; &#34;x&#34;`},
+		{"checkbox kept disabled", `<input type="checkbox" checked>`, `<input type="checkbox" checked disabled>`},
+		{"other inputs dropped", `<input type="text" value="x">`, ``},
+		{"attribute quotes escaped", `<img alt='a"onerror="x'>`, `<img alt="a&#34;onerror=&#34;x">`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTML(tt.in); got != tt.want {
+				t.Errorf("HTML(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSafeURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com", true},
+		{"mailto:someone@example.com", true},
+		{"notes/1", true},
+		{"/blobs/abc", true},
+		{"#heading", true},
+		{"?q=a:b", true},
+		{"javascript:alert(1)", false},
+		{" JavaScript:alert(1)", false},
+		{"vbscript:x", false},
+		{"data:text/html,x", false},
+	}
+	for _, tt := range tests {
+		if got := IsSafeURL(tt.url); got != tt.want {
+			t.Errorf("IsSafeURL(%q) = %v; want %v", tt.url, got, tt.want)
+		}
+	}
+}
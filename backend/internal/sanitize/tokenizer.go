@@ -0,0 +1,181 @@
+package sanitize
+
+import "strings"
+
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	startTagToken
+	endTagToken
+)
+
+// token is a piece of an HTML fragment. The data of tags is their lower-case element name.
+type token struct {
+	kind        tokenKind
+	data        string
+	attrs       []attribute
+	selfClosing bool
+}
+
+type attribute struct {
+	name  string
+	value string
+}
+
+// tokenizer splits an HTML fragment into text and tags. Comments, doctypes and processing
+// instructions are skipped, and a "<" that does not start a tag is text.
+type tokenizer struct {
+	src string
+	pos int
+}
+
+// next returns the next token, or false at the end of the fragment.
+func (t *tokenizer) next() (token, bool) {
+	for t.pos < len(t.src) {
+		start := t.pos
+		if t.src[start] != '<' {
+			end := strings.IndexByte(t.src[start:], '<')
+			if end < 0 {
+				end = len(t.src) - start
+			}
+			if end == 0 {
+				end = 1
+			}
+			t.pos = start + end
+			return token{kind: textToken, data: t.src[start:t.pos]}, true
+		}
+
+		rest := t.src[start:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			t.skipPast(start+4, "-->")
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			t.skipPast(start+2, ">")
+		case strings.HasPrefix(rest, "</") && len(rest) > 2 && isLetter(rest[2]):
+			t.pos = start + 2
+			name := t.readName()
+			t.skipPast(t.pos, ">")
+			return token{kind: endTagToken, data: name}, true
+		case len(rest) > 1 && isLetter(rest[1]):
+			t.pos = start + 1
+			return t.readStartTag(), true
+		default:
+			t.pos = start + 1
+			return token{kind: textToken, data: "<"}, true
+		}
+	}
+	return token{}, false
+}
+
+// skipRawText skips the content of a raw text element such as a script, up to and including its end tag.
+func (t *tokenizer) skipRawText(name string) {
+	lower := strings.ToLower(t.src[t.pos:])
+	end := strings.Index(lower, "</"+name)
+	if end < 0 {
+		t.pos = len(t.src)
+		return
+	}
+	t.skipPast(t.pos+end, ">")
+}
+
+func (t *tokenizer) skipPast(from int, delim string) {
+	end := strings.Index(t.src[from:], delim)
+	if end < 0 {
+		t.pos = len(t.src)
+		return
+	}
+	t.pos = from + end + len(delim)
+}
+
+func (t *tokenizer) readStartTag() token {
+	tok := token{kind: startTagToken, data: t.readName()}
+	for {
+		t.skipSpace()
+		if t.pos >= len(t.src) {
+			return tok
+		}
+		switch t.src[t.pos] {
+		case '>':
+			t.pos++
+			return tok
+		case '/':
+			t.pos++
+			if t.pos < len(t.src) && t.src[t.pos] == '>' {
+				t.pos++
+				tok.selfClosing = true
+				return tok
+			}
+			continue
+		}
+
+		name := t.readAttributeName()
+		if name == "" {
+			t.pos++
+			continue
+		}
+		attr := attribute{name: name}
+		t.skipSpace()
+		if t.pos < len(t.src) && t.src[t.pos] == '=' {
+			t.pos++
+			t.skipSpace()
+			attr.value = t.readAttributeValue()
+		}
+		tok.attrs = append(tok.attrs, attr)
+	}
+}
+
+func (t *tokenizer) readName() string {
+	start := t.pos
+	for t.pos < len(t.src) && !isSpace(t.src[t.pos]) && t.src[t.pos] != '/' && t.src[t.pos] != '>' {
+		t.pos++
+	}
+	return strings.ToLower(t.src[start:t.pos])
+}
+
+func (t *tokenizer) readAttributeName() string {
+	start := t.pos
+	for t.pos < len(t.src) {
+		c := t.src[t.pos]
+		if isSpace(c) || c == '/' || c == '>' || c == '=' {
+			break
+		}
+		t.pos++
+	}
+	return strings.ToLower(t.src[start:t.pos])
+}
+
+func (t *tokenizer) readAttributeValue() string {
+	if t.pos >= len(t.src) {
+		return ""
+	}
+	if quote := t.src[t.pos]; quote == '"' || quote == '\'' {
+		start := t.pos + 1
+		end := strings.IndexByte(t.src[start:], quote)
+		if end < 0 {
+			t.pos = len(t.src)
+			return t.src[start:]
+		}
+		t.pos = start + end + 1
+		return t.src[start : start+end]
+	}
+	start := t.pos
+	for t.pos < len(t.src) && !isSpace(t.src[t.pos]) && t.src[t.pos] != '>' {
+		t.pos++
+	}
+	return t.src[start:t.pos]
+}
+
+func (t *tokenizer) skipSpace() {
+	for t.pos < len(t.src) && isSpace(t.src[t.pos]) {
+		t.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,107 @@
+// Package scheduler fires due reminders in the background and delivers them to notifiers.
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"noteapp/internal/usecase/reminderuc"
+)
+
+// Notifier delivers a fired reminder to its user.
+type Notifier interface {
+	Notify(reminder *reminderuc.FiredReminderDTO) error
+}
+
+// NotifierFunc adapts a function to a Notifier.
+type NotifierFunc func(reminder *reminderuc.FiredReminderDTO) error
+
+// Notify calls f.
+func (f NotifierFunc) Notify(reminder *reminderuc.FiredReminderDTO) error {
+	return f(reminder)
+}
+
+// Scheduler checks for due reminders at a fixed interval and delivers each reminder that fires
+// to every notifier. Which reminders are due is decided by the clock of the reminder usecase,
+// so tests can drive the scheduler with Tick and a fake clock instead of starting it.
+//
+// A reminder is recorded as fired before it is delivered, so a reminder whose delivery fails
+// or is cut short by a restart is not delivered again.
+type Scheduler struct {
+	reminders *reminderuc.ReminderUsecase
+	notifiers []Notifier
+	interval  time.Duration
+
+	tickMu sync.Mutex
+	mu     sync.Mutex
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewScheduler creates a new Scheduler that checks for due reminders every interval.
+func NewScheduler(reminders *reminderuc.ReminderUsecase, interval time.Duration, notifiers ...Notifier) *Scheduler {
+	return &Scheduler{reminders: reminders, notifiers: notifiers, interval: interval}
+}
+
+// Tick fires the reminders that are due and delivers them, returning the reminders it fired.
+// Delivery errors are logged and do not stop the other deliveries.
+func (s *Scheduler) Tick() []*reminderuc.FiredReminderDTO {
+	s.tickMu.Lock()
+	defer s.tickMu.Unlock()
+
+	fired, err := s.reminders.FireDueReminders()
+	if err != nil {
+		fmt.Printf("Warning: Could not fire all due reminders: %v\n", err)
+	}
+	for _, reminder := range fired {
+		for _, notifier := range s.notifiers {
+			if err := notifier.Notify(reminder); err != nil {
+				fmt.Printf("Warning: Could not deliver reminder %s to user %s: %v\n", reminder.ReminderID, reminder.UserID, err)
+			}
+		}
+	}
+	return fired
+}
+
+// Start checks for due reminders right away and then every interval, until Stop is called.
+// Starting a scheduler that is already running does nothing.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(s.stop, s.done)
+}
+
+// Stop stops a running scheduler and waits for the check in progress, if any, to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+	s.done = nil
+}
+
+func (s *Scheduler) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package scheduler_test
+
+import (
+	"errors"
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/reminderrepo"
+	"noteapp/internal/scheduler"
+	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/reminderuc"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a Notifier that keeps the reminders it is given.
+type recorder struct {
+	mu        sync.Mutex
+	reminders []*reminderuc.FiredReminderDTO
+}
+
+func (r *recorder) Notify(reminder *reminderuc.FiredReminderDTO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reminders = append(r.reminders, reminder)
+	return nil
+}
+
+func (r *recorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reminders)
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	// Arrange
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	nuc := noteuc.NewNoteUsecase(noteRepo)
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC))
+	ruc := reminderuc.NewReminderUsecaseWithClock(reminderrepo.NewInMemoryReminderRepository(), noteRepo, c)
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	ruc.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:00", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "daily"}})
+
+	delivered := &recorder{}
+	failing := scheduler.NotifierFunc(func(*reminderuc.FiredReminderDTO) error {
+		return errors.New("offline")
+	})
+	s := scheduler.NewScheduler(ruc, time.Minute, failing, delivered)
+
+	// Act
+	before := s.Tick()
+	c.Advance(time.Hour)
+	due := s.Tick()
+	again := s.Tick()
+	c.Advance(24 * time.Hour)
+	next := s.Tick()
+
+	// Assert
+	if len(before) != 0 || len(again) != 0 {
+		t.Errorf("Expected nothing to fire before the reminder is due or twice, got %d and %d", len(before), len(again))
+	}
+	if len(due) != 1 || len(next) != 1 || next[0].DueAt.Day() != 5 {
+		t.Errorf("Expected the reminder to fire on each day, got %+v and %+v", due, next)
+	}
+	if delivered.count() != 2 {
+		t.Errorf("Expected each reminder delivered despite the failing notifier, got %d", delivered.count())
+	}
+}
+
+func TestScheduler_DoesNotFireAgainAfterRestart(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "reminders.json")
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	nuc := noteuc.NewNoteUsecase(noteRepo)
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC))
+	noteID, _ := nuc.CreateNote("", "Taxes", "user-1")
+
+	start := func() (*scheduler.Scheduler, *reminderuc.ReminderUsecase, *recorder) {
+		repo, err := reminderrepo.NewFileReminderRepository(path)
+		if err != nil {
+			t.Fatalf("failed to open reminders: %v", err)
+		}
+		ruc := reminderuc.NewReminderUsecaseWithClock(repo, noteRepo, c)
+		delivered := &recorder{}
+		return scheduler.NewScheduler(ruc, time.Minute, delivered), ruc, delivered
+	}
+	s, ruc, _ := start()
+	ruc.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:00"})
+	c.Advance(time.Hour)
+	if fired := s.Tick(); len(fired) != 1 {
+		t.Fatalf("Expected the reminder to fire before the restart, got %d", len(fired))
+	}
+
+	// Act
+	restarted, _, delivered := start()
+	restarted.Tick()
+
+	// Assert
+	if delivered.count() != 0 {
+		t.Errorf("Expected the reminder not to fire again after a restart, got %d deliveries", delivered.count())
+	}
+}
+
+func TestScheduler_StartAndStop(t *testing.T) {
+	// Arrange
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	nuc := noteuc.NewNoteUsecase(noteRepo)
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC))
+	ruc := reminderuc.NewReminderUsecaseWithClock(reminderrepo.NewInMemoryReminderRepository(), noteRepo, c)
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	ruc.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T09:00"})
+	delivered := &recorder{}
+	s := scheduler.NewScheduler(ruc, time.Millisecond, delivered)
+
+	// Act
+	s.Start()
+	s.Start()
+	c.Advance(time.Hour)
+	deadline := time.Now().Add(2 * time.Second)
+	for delivered.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.Stop()
+	s.Stop()
+
+	// Assert
+	if delivered.count() != 1 {
+		t.Errorf("Expected the running scheduler to deliver the reminder once, got %d", delivered.count())
+	}
+}
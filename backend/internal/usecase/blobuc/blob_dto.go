@@ -0,0 +1,18 @@
+package blobuc
+
+// BlobDTO describes a stored blob.
+type BlobDTO struct {
+	Hash     string `json:"hash"`
+	MimeType string `json:"mime_type"`
+	Size     int    `json:"size"`
+}
+
+// ImageDTO describes a stored image. Thumbnails maps the longest-edge length of each
+// thumbnail to the hash of its blob.
+type ImageDTO struct {
+	BlobDTO
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	Format     string         `json:"format"`
+	Thumbnails map[int]string `json:"thumbnails,omitempty"`
+}
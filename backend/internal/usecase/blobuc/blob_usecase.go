@@ -0,0 +1,337 @@
+package blobuc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"noteapp/internal/repository/blobrepo"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MaxImageSize is the largest image, in bytes, that can be uploaded.
+const MaxImageSize = 10 << 20
+
+// MaxImagePixels is the largest number of pixels an image may declare. A small file can
+// declare huge dimensions, so images are checked against it before they are decoded.
+const MaxImagePixels = 40_000_000
+
+// MaxAttachmentSize is the largest attachment, in bytes, that can be uploaded.
+const MaxAttachmentSize = 25 << 20
+
+// sniffLen is the number of leading bytes used to detect the media type of streamed data.
+const sniffLen = 512
+
+// DefaultThumbnailSizes are the longest-edge lengths, in pixels, of the thumbnails generated for each image.
+var DefaultThumbnailSizes = []int{128, 512}
+
+// imageMimeTypes lists the media types accepted for image uploads.
+var imageMimeTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// BlobUsecase handles the business logic for binary data such as images.
+type BlobUsecase struct {
+	store          blobrepo.BlobStore
+	images         blobrepo.ImageRepository
+	thumbnailSizes []int
+	// references is held for reading while contents referencing blobs are created, and for
+	// writing while a blob is checked to be unreferenced and deleted.
+	references sync.RWMutex
+}
+
+// NewBlobUsecase creates a new BlobUsecase that generates thumbnails of the default sizes.
+func NewBlobUsecase(store blobrepo.BlobStore) *BlobUsecase {
+	return NewBlobUsecaseWithThumbnailSizes(store, DefaultThumbnailSizes)
+}
+
+// NewBlobUsecaseWithThumbnailSizes creates a new BlobUsecase that generates a thumbnail for each of sizes.
+// Sizes that are not positive are ignored. The metadata of images is kept in memory.
+func NewBlobUsecaseWithThumbnailSizes(store blobrepo.BlobStore, sizes []int) *BlobUsecase {
+	return NewBlobUsecaseWithImageRepository(store, blobrepo.NewInMemoryImageRepository(), sizes)
+}
+
+// NewBlobUsecaseWithImageRepository creates a new BlobUsecase that keeps the metadata of images
+// in images and generates a thumbnail for each of sizes. Sizes that are not positive are ignored.
+func NewBlobUsecaseWithImageRepository(store blobrepo.BlobStore, images blobrepo.ImageRepository, sizes []int) *BlobUsecase {
+	var thumbnailSizes []int
+	for _, size := range sizes {
+		if size > 0 {
+			thumbnailSizes = append(thumbnailSizes, size)
+		}
+	}
+	sort.Ints(thumbnailSizes)
+	return &BlobUsecase{store: store, images: images, thumbnailSizes: thumbnailSizes}
+}
+
+// StoreImage stores an image after checking its size and sniffing its media type, and
+// generates its thumbnails. Uploading an image that is already stored returns the existing blob.
+func (uc *BlobUsecase) StoreImage(data []byte) (*ImageDTO, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyBlob
+	}
+	if len(data) > MaxImageSize {
+		return nil, ErrBlobTooLarge
+	}
+	mimeType := http.DetectContentType(data)
+	if !imageMimeTypes[mimeType] {
+		return nil, ErrUnsupportedMediaType
+	}
+	if image, err := uc.findImage(blobrepo.HashOf(data)); err == nil {
+		return image, nil
+	}
+
+	// Decode before storing so that corrupt images are rejected.
+	image, err := uc.describeImage(data, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := uc.store.Put(data)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	image.Hash = hash
+	if err := uc.images.Save(toImagePO(image)); err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return image, nil
+}
+
+// GetImage retrieves the metadata of a stored image, as recorded when it was uploaded. Images
+// without recorded metadata, such as those stored before a restart, are described once.
+func (uc *BlobUsecase) GetImage(hash string) (*ImageDTO, error) {
+	image, err := uc.findImage(hash)
+	if err == nil {
+		return image, nil
+	}
+	if !errors.Is(err, blobrepo.ErrImageNotFound) {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	data, err := uc.store.Get(hash)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	mimeType := http.DetectContentType(data)
+	if !imageMimeTypes[mimeType] {
+		return nil, ErrUnsupportedMediaType
+	}
+	image, err = uc.describeImage(data, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	image.Hash = hash
+	if err := uc.images.Save(toImagePO(image)); err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return image, nil
+}
+
+// findImage looks up the recorded metadata of an image, if its blob is still stored.
+func (uc *BlobUsecase) findImage(hash string) (*ImageDTO, error) {
+	if err := blobrepo.ValidateHash(hash); err != nil {
+		return nil, err
+	}
+	po, err := uc.images.FindByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := uc.store.Exists(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, blobrepo.ErrImageNotFound
+	}
+	return toImageDTO(po), nil
+}
+
+// StoreAttachment streams an attachment of any media type into the store. The media type is
+// sniffed from the leading bytes of the data. Attachments larger than limit, or than
+// MaxAttachmentSize, are rejected with ErrBlobTooLarge without being stored.
+func (uc *BlobUsecase) StoreAttachment(r io.Reader, limit int64) (*BlobDTO, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, ErrEmptyBlob
+	}
+	mimeType := http.DetectContentType(head)
+
+	hash, size, err := uc.store.PutReader(br, min(limit, MaxAttachmentSize))
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return &BlobDTO{Hash: hash, MimeType: mimeType, Size: int(size)}, nil
+}
+
+// OpenBlob returns a reader over the data of a blob. The caller must close it.
+func (uc *BlobUsecase) OpenBlob(hash string) (io.ReadSeekCloser, error) {
+	f, err := uc.store.Open(hash)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return f, nil
+}
+
+// StatBlob describes a stored blob without reading all of its data.
+func (uc *BlobUsecase) StatBlob(hash string) (*BlobDTO, error) {
+	blob, f, err := uc.GetBlob(hash)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	return blob, nil
+}
+
+// HoldBlobs keeps DeleteUnreferencedBlob from deleting any blob until the returned function is
+// called. Callers hold it from looking up or storing a blob until the content that references
+// it is saved, and must not delete blobs meanwhile.
+func (uc *BlobUsecase) HoldBlobs() func() {
+	uc.references.RLock()
+	return uc.references.RUnlock
+}
+
+// DeleteUnreferencedBlob removes a blob, along with its image metadata, unless referenced
+// reports that a content still references it. It reports whether the blob was removed. No
+// content can be created for the blob by holders of HoldBlobs in the meantime.
+func (uc *BlobUsecase) DeleteUnreferencedBlob(hash string, referenced func(hash string) (bool, error)) (bool, error) {
+	uc.references.Lock()
+	defer uc.references.Unlock()
+
+	isReferenced, err := referenced(hash)
+	if err != nil || isReferenced {
+		return false, err
+	}
+	if err := uc.DeleteBlob(hash); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteBlob removes a blob, along with its image metadata. Callers must make sure no
+// content references it any more.
+func (uc *BlobUsecase) DeleteBlob(hash string) error {
+	if err := uc.store.Delete(hash); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if err := uc.images.Delete(hash); err != nil && !errors.Is(err, blobrepo.ErrImageNotFound) {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// GetBlob describes a stored blob and returns a reader over its data, positioned at its start,
+// so that the data can be streamed instead of read into memory. The caller must close the reader.
+func (uc *BlobUsecase) GetBlob(hash string) (*BlobDTO, io.ReadSeekCloser, error) {
+	f, err := uc.OpenBlob(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	size, err := f.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return &BlobDTO{Hash: hash, MimeType: http.DetectContentType(head[:n]), Size: int(size)}, f, nil
+}
+
+// describeImage reads the dimensions of an image and stores its thumbnails. Each thumbnail
+// is stored as a blob of its own; sizes at least as large as the image map to the image itself.
+// Formats that cannot be decoded, such as WebP, are described by their format only.
+func (uc *BlobUsecase) describeImage(data []byte, mimeType string) (*ImageDTO, error) {
+	format := strings.TrimPrefix(mimeType, "image/")
+	image := &ImageDTO{
+		BlobDTO: BlobDTO{Hash: blobrepo.HashOf(data), MimeType: mimeType, Size: len(data)},
+		Format:  format,
+	}
+	if !decodableImageFormats[format] {
+		return image, nil
+	}
+
+	config, err := decodeImageConfig(data, format)
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return nil, ErrInvalidImage
+	}
+	img, err := decodeImage(data, format)
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	image.Width = img.Bounds().Dx()
+	image.Height = img.Bounds().Dy()
+
+	image.Thumbnails = make(map[int]string, len(uc.thumbnailSizes))
+	for _, size := range uc.thumbnailSizes {
+		if size >= image.Width && size >= image.Height {
+			image.Thumbnails[size] = image.Hash
+			continue
+		}
+		width, height := thumbnailBounds(image.Width, image.Height, size)
+		thumbnail, err := encodeThumbnail(scaleDown(img, width, height), format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		hash, err := uc.store.Put(thumbnail)
+		if err != nil {
+			return nil, uc.mapRepositoryError(err)
+		}
+		image.Thumbnails[size] = hash
+	}
+	return image, nil
+}
+
+func toImagePO(image *ImageDTO) *blobrepo.ImagePO {
+	return &blobrepo.ImagePO{
+		Hash:       image.Hash,
+		MimeType:   image.MimeType,
+		Size:       image.Size,
+		Width:      image.Width,
+		Height:     image.Height,
+		Format:     image.Format,
+		Thumbnails: image.Thumbnails,
+	}
+}
+
+func toImageDTO(po *blobrepo.ImagePO) *ImageDTO {
+	return &ImageDTO{
+		BlobDTO:    BlobDTO{Hash: po.Hash, MimeType: po.MimeType, Size: po.Size},
+		Width:      po.Width,
+		Height:     po.Height,
+		Format:     po.Format,
+		Thumbnails: po.Thumbnails,
+	}
+}
+
+func (uc *BlobUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, blobrepo.ErrBlobNotFound):
+		return ErrBlobNotFound
+	case errors.Is(err, blobrepo.ErrInvalidHash):
+		return ErrInvalidHash
+	case errors.Is(err, blobrepo.ErrBlobTooLarge):
+		return ErrBlobTooLarge
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
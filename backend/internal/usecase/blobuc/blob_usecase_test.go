@@ -0,0 +1,405 @@
+package blobuc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"noteapp/internal/repository/blobrepo"
+	"noteapp/internal/usecase/blobuc"
+	"testing"
+	"time"
+)
+
+// pngHeader is the signature that identifies PNG data.
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// newTestImage returns a width x height image filled with a gradient.
+func newTestImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, newTestImage(width, height)); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// encodePNGHeader returns the start of a PNG image that declares width x height pixels,
+// without any pixel data.
+func encodePNGHeader(width, height uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	data := []byte(pngHeader)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(ihdr)-4))
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, newTestImage(width, height), nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBlobUsecase_StoreImage(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	data := encodePNG(t, 40, 30)
+
+	// Act
+	blob, err := uc.StoreImage(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if blob.MimeType != "image/png" {
+		t.Errorf("Expected mime type image/png but got %s", blob.MimeType)
+	}
+	if blob.Size != len(data) {
+		t.Errorf("Expected size %d but got %d", len(data), blob.Size)
+	}
+	if blob.Width != 40 || blob.Height != 30 || blob.Format != "png" {
+		t.Errorf("Expected a 40x30 png but got a %dx%d %s", blob.Width, blob.Height, blob.Format)
+	}
+	stored, f, err := uc.GetBlob(blob.Hash)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	defer f.Close()
+	got, _ := io.ReadAll(f)
+	if stored.Size != len(data) || stored.MimeType != "image/png" {
+		t.Errorf("Expected the stored blob described as a %d-byte png but got %+v", len(data), stored)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected stored data to match the upload")
+	}
+}
+
+// countingStore is a BlobStore that counts the blobs read and written.
+type countingStore struct {
+	blobrepo.BlobStore
+	gets, puts int
+}
+
+func (s *countingStore) Get(hash string) ([]byte, error) {
+	s.gets++
+	return s.BlobStore.Get(hash)
+}
+
+func (s *countingStore) Put(data []byte) (string, error) {
+	s.puts++
+	return s.BlobStore.Put(data)
+}
+
+func TestBlobUsecase_GetImage_UsesMetadataRecordedAtUpload(t *testing.T) {
+	// Arrange
+	store := &countingStore{BlobStore: blobrepo.NewInMemoryBlobStore()}
+	uc := blobuc.NewBlobUsecaseWithThumbnailSizes(store, []int{50})
+	stored, err := uc.StoreImage(encodePNG(t, 200, 100))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	store.gets, store.puts = 0, 0
+
+	// Act
+	first, err := uc.GetImage(stored.Hash)
+	second, _ := uc.GetImage(stored.Hash)
+	again, _ := uc.StoreImage(encodePNG(t, 200, 100))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if store.gets != 0 || store.puts != 0 {
+		t.Errorf("Expected the image not to be read or its thumbnails stored again, got %d reads and %d writes", store.gets, store.puts)
+	}
+	for _, image := range []*blobuc.ImageDTO{first, second, again} {
+		if image.Width != 200 || image.Height != 100 || image.Thumbnails[50] != stored.Thumbnails[50] {
+			t.Errorf("Expected the metadata recorded at upload, got %+v", image)
+		}
+	}
+}
+
+func TestBlobUsecase_GetImage_DescribesImagesWithoutMetadataOnce(t *testing.T) {
+	// Arrange
+	store := &countingStore{BlobStore: blobrepo.NewInMemoryBlobStore()}
+	stored, _ := blobuc.NewBlobUsecase(store).StoreImage(encodePNG(t, 200, 100))
+	uc := blobuc.NewBlobUsecase(store)
+	store.gets = 0
+
+	// Act
+	first, err := uc.GetImage(stored.Hash)
+	second, _ := uc.GetImage(stored.Hash)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if store.gets != 1 {
+		t.Errorf("Expected the image to be read once, got %d reads", store.gets)
+	}
+	if first.Width != 200 || second.Width != 200 || second.Thumbnails[128] != stored.Thumbnails[128] {
+		t.Errorf("Expected the described metadata, got %+v and %+v", first, second)
+	}
+}
+
+func TestBlobUsecase_DeleteBlob_ForgetsImageMetadata(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	stored, _ := uc.StoreImage(encodePNG(t, 4, 4))
+
+	// Act
+	err := uc.DeleteBlob(stored.Hash)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if _, err := uc.GetImage(stored.Hash); !errors.Is(err, blobuc.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound after delete, got %v", err)
+	}
+}
+
+func TestBlobUsecase_DeleteUnreferencedBlob(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	kept, _ := uc.StoreImage(encodePNG(t, 4, 4))
+	unused, _ := uc.StoreImage(encodePNG(t, 5, 5))
+	referenced := func(hash string) (bool, error) { return hash == kept.Hash, nil }
+
+	// Act
+	keptDeleted, keptErr := uc.DeleteUnreferencedBlob(kept.Hash, referenced)
+	unusedDeleted, unusedErr := uc.DeleteUnreferencedBlob(unused.Hash, referenced)
+
+	// Assert
+	if keptErr != nil || keptDeleted {
+		t.Errorf("Expected the referenced blob to be kept, got %v, %v", keptDeleted, keptErr)
+	}
+	if _, err := uc.GetImage(kept.Hash); err != nil {
+		t.Errorf("Expected the referenced image to remain, got %v", err)
+	}
+	if unusedErr != nil || !unusedDeleted {
+		t.Errorf("Expected the unreferenced blob to be deleted, got %v, %v", unusedDeleted, unusedErr)
+	}
+	if _, err := uc.GetImage(unused.Hash); !errors.Is(err, blobuc.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound after delete, got %v", err)
+	}
+}
+
+func TestBlobUsecase_HoldBlobs_DefersDeletion(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	stored, _ := uc.StoreImage(encodePNG(t, 4, 4))
+	release := uc.HoldBlobs()
+	referencedNow := false
+	done := make(chan bool)
+
+	// Act
+	go func() {
+		deleted, _ := uc.DeleteUnreferencedBlob(stored.Hash, func(string) (bool, error) { return referencedNow, nil })
+		done <- deleted
+	}()
+	select {
+	case <-done:
+		t.Fatal("Expected the deletion to wait for the hold to be released")
+	case <-time.After(50 * time.Millisecond):
+	}
+	// A content referencing the blob is saved before the hold is released.
+	referencedNow = true
+	release()
+
+	// Assert
+	if deleted := <-done; deleted {
+		t.Errorf("Expected the blob referenced while it was held to be kept")
+	}
+	if _, err := uc.GetImage(stored.Hash); err != nil {
+		t.Errorf("Expected the image to remain, got %v", err)
+	}
+}
+
+func TestBlobUsecase_StoreImage_Deduplicates(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	data := encodePNG(t, 4, 4)
+
+	// Act
+	first, _ := uc.StoreImage(data)
+	second, _ := uc.StoreImage(data)
+
+	// Assert
+	if first.Hash != second.Hash {
+		t.Errorf("Expected the same hash for identical uploads but got %s and %s", first.Hash, second.Hash)
+	}
+}
+
+func TestBlobUsecase_StoreImage_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, blobuc.ErrEmptyBlob},
+		{"not an image", []byte("just some text"), blobuc.ErrUnsupportedMediaType},
+		{"too large", append([]byte(pngHeader), make([]byte, blobuc.MaxImageSize)...), blobuc.ErrBlobTooLarge},
+		{"corrupt image", []byte(pngHeader + "not really pixels"), blobuc.ErrInvalidImage},
+		{"too many pixels", encodePNGHeader(50000, 50000), blobuc.ErrInvalidImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+
+			// Act
+			_, err := uc.StoreImage(tt.data)
+
+			// Assert
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBlobUsecase_StoreImage_GeneratesThumbnails(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecaseWithThumbnailSizes(blobrepo.NewInMemoryBlobStore(), []int{50, 400})
+
+	// Act
+	img, err := uc.StoreImage(encodePNG(t, 200, 100))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if len(img.Thumbnails) != 2 {
+		t.Fatalf("Expected 2 thumbnails but got %d", len(img.Thumbnails))
+	}
+	thumbnail, err := uc.GetImage(img.Thumbnails[50])
+	if err != nil {
+		t.Fatalf("Expected thumbnail to be stored but got %v", err)
+	}
+	if thumbnail.Width != 50 || thumbnail.Height != 25 {
+		t.Errorf("Expected a 50x25 thumbnail but got %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+	if img.Thumbnails[400] != img.Hash {
+		t.Errorf("Expected a size larger than the image to map to the image itself")
+	}
+}
+
+func TestBlobUsecase_StoreImage_JPEGThumbnailStaysJPEG(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecaseWithThumbnailSizes(blobrepo.NewInMemoryBlobStore(), []int{16})
+
+	// Act
+	img, err := uc.StoreImage(encodeJPEG(t, 64, 64))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	thumbnail, err := uc.GetImage(img.Thumbnails[16])
+	if err != nil {
+		t.Fatalf("Expected thumbnail to be stored but got %v", err)
+	}
+	if thumbnail.Format != "jpeg" {
+		t.Errorf("Expected a jpeg thumbnail but got %s", thumbnail.Format)
+	}
+	if thumbnail.Width != 16 || thumbnail.Height != 16 {
+		t.Errorf("Expected a 16x16 thumbnail but got %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+}
+
+func TestBlobUsecase_GetBlob_Errors(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+
+	// Act
+	_, _, errInvalid := uc.GetBlob("not-a-hash")
+	_, _, errMissing := uc.GetBlob(blobrepo.HashOf([]byte("missing")))
+
+	// Assert
+	if !errors.Is(errInvalid, blobuc.ErrInvalidHash) {
+		t.Errorf("Expected ErrInvalidHash but got %v", errInvalid)
+	}
+	if !errors.Is(errMissing, blobuc.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound but got %v", errMissing)
+	}
+}
+
+func TestBlobUsecase_StoreAttachment(t *testing.T) {
+	// Arrange
+	uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+	data := []byte("%PDF-1.7 a small document")
+
+	// Act
+	blob, err := uc.StoreAttachment(bytes.NewReader(data), blobuc.MaxAttachmentSize)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if blob.MimeType != "application/pdf" {
+		t.Errorf("Expected mime type application/pdf but got %s", blob.MimeType)
+	}
+	if blob.Size != len(data) {
+		t.Errorf("Expected size %d but got %d", len(data), blob.Size)
+	}
+	f, err := uc.OpenBlob(blob.Hash)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	defer f.Close()
+	got, _ := io.ReadAll(f)
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected stored data to match the upload")
+	}
+}
+
+func TestBlobUsecase_StoreAttachment_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		limit int64
+		want  error
+	}{
+		{"empty", nil, blobuc.MaxAttachmentSize, blobuc.ErrEmptyBlob},
+		{"too large", make([]byte, blobuc.MaxAttachmentSize+1), blobuc.MaxAttachmentSize + 10, blobuc.ErrBlobTooLarge},
+		{"over the limit", []byte("%PDF-1.7 quarterly report"), 10, blobuc.ErrBlobTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			uc := blobuc.NewBlobUsecase(blobrepo.NewInMemoryBlobStore())
+
+			// Act
+			_, err := uc.StoreAttachment(bytes.NewReader(tt.data), tt.limit)
+
+			// Assert
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v but got %v", tt.want, err)
+			}
+		})
+	}
+}
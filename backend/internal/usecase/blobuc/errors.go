@@ -0,0 +1,21 @@
+package blobuc
+
+import "errors"
+
+// ErrInvalidHash is returned when a malformed blob hash is provided.
+var ErrInvalidHash = errors.New("invalid blob hash")
+
+// ErrBlobNotFound is returned when a blob is not found.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrEmptyBlob is returned when an upload contains no data.
+var ErrEmptyBlob = errors.New("blob cannot be empty")
+
+// ErrBlobTooLarge is returned when an upload exceeds the size limit.
+var ErrBlobTooLarge = errors.New("blob exceeds the maximum size")
+
+// ErrUnsupportedMediaType is returned when an upload is not of an accepted media type.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// ErrInvalidImage is returned when image data cannot be decoded.
+var ErrInvalidImage = errors.New("invalid image data")
@@ -0,0 +1,110 @@
+package blobuc
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// thumbnailJPEGQuality is the quality used to encode thumbnails of JPEG images.
+const thumbnailJPEGQuality = 85
+
+// decodableImageFormats lists the formats whose dimensions can be read and thumbnails generated.
+var decodableImageFormats = map[string]bool{
+	"png":  true,
+	"jpeg": true,
+	"gif":  true,
+}
+
+// decodeImageConfig reads the dimensions PNG, JPEG and GIF data declare without decoding its pixels.
+func decodeImageConfig(data []byte, format string) (image.Config, error) {
+	switch format {
+	case "png":
+		return png.DecodeConfig(bytes.NewReader(data))
+	case "jpeg":
+		return jpeg.DecodeConfig(bytes.NewReader(data))
+	case "gif":
+		return gif.DecodeConfig(bytes.NewReader(data))
+	default:
+		return image.Config{}, image.ErrFormat
+	}
+}
+
+// decodeImage decodes PNG, JPEG and GIF data. For animated GIFs only the first frame is used.
+func decodeImage(data []byte, format string) (image.Image, error) {
+	switch format {
+	case "png":
+		return png.Decode(bytes.NewReader(data))
+	case "jpeg":
+		return jpeg.Decode(bytes.NewReader(data))
+	case "gif":
+		return gif.Decode(bytes.NewReader(data))
+	default:
+		return nil, image.ErrFormat
+	}
+}
+
+// encodeThumbnail encodes a thumbnail as JPEG for JPEG sources and as PNG otherwise,
+// so photos stay small and images with transparency keep it.
+func encodeThumbnail(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnailBounds returns the dimensions of an image scaled so that its longer edge is maxEdge.
+func thumbnailBounds(width, height, maxEdge int) (int, int) {
+	if width >= height {
+		return maxEdge, max(1, height*maxEdge/width)
+	}
+	return max(1, width*maxEdge/height), maxEdge
+}
+
+// scaleDown resizes src to width x height by averaging the source pixels covered by each
+// destination pixel, which avoids the aliasing of nearest-neighbour sampling.
+func scaleDown(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcRGBA := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(srcRGBA, srcRGBA.Bounds(), src, bounds.Min, draw.Src)
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max(y0+1, (y+1)*srcH/height)
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max(x0+1, (x+1)*srcW/width)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := srcRGBA.Pix[sy*srcRGBA.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -1,10 +1,72 @@
 package contentuc
 
+import "time"
+
 // ContentDTO represents the data transfer object for a Content.
 type ContentDTO struct {
-	ID      string `json:"id"`
-	NoteID  string `json:"noteId"`
-	Data    string `json:"data"`
-	Type    string `json:"type"`
-	Version int    `json:"version"`
+	ID             string    `json:"id"`
+	NoteID         string    `json:"noteId"`
+	Data           string    `json:"data"`
+	Type           string    `json:"type"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	LastModifiedBy string    `json:"lastModifiedBy"`
+	// Language is the programming language of a code content.
+	Language string `json:"language,omitempty"`
+	// Format is the markup of a text content, "markdown" or empty for plain text, or the
+	// encoding of an image content.
+	Format string `json:"format,omitempty"`
+	// Width, Height and Thumbnails describe the image of an image content.
+	Width      int            `json:"width,omitempty"`
+	Height     int            `json:"height,omitempty"`
+	Thumbnails map[int]string `json:"thumbnails,omitempty"`
+	// Filename, Size and MimeType describe the file of an attachment content.
+	Filename string `json:"filename,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// ImageMetadataDTO describes the image of a new image content.
+type ImageMetadataDTO struct {
+	Width      int
+	Height     int
+	Format     string
+	Thumbnails map[int]string
+}
+
+// AttachmentMetadataDTO describes the file of a new attachment content.
+type AttachmentMetadataDTO struct {
+	Filename string
+	Size     int64
+	MimeType string
+}
+
+// TableColumnDTO represents a column of a table content.
+type TableColumnDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TableRowDTO represents a row of a table content. Cells maps column IDs to cell values.
+type TableRowDTO struct {
+	ID    string            `json:"id"`
+	Cells map[string]string `json:"cells,omitempty"`
+}
+
+// TableCellDTO represents a cell of a table content. Version is the version of the content
+// in which the cell was last changed.
+type TableCellDTO struct {
+	RowID    string `json:"rowId"`
+	ColumnID string `json:"columnId"`
+	Value    string `json:"value"`
+	Version  int    `json:"version"`
+}
+
+// ChecklistItemDTO represents an item of a checklist content.
+type ChecklistItemDTO struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	Checked  bool   `json:"checked"`
+	Assignee string `json:"assignee,omitempty"`
 }
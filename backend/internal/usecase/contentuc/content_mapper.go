@@ -15,27 +15,107 @@ func NewContentMapper() *ContentMapper {
 
 // ToPO converts a domain.Content to a repository.ContentPO.
 func (m *ContentMapper) ToPO(c *content.Content) *contentrepo.ContentPO {
-	return &contentrepo.ContentPO{
-		ID:      c.ID,
-		NoteID:  c.NoteID,
-		Data:    c.Data,
-		Type:    string(c.Type),
-		Version: c.Version,
+	po := &contentrepo.ContentPO{
+		ID:             c.ID,
+		NoteID:         c.NoteID,
+		Data:           c.Data,
+		Type:           string(c.Type),
+		Version:        c.Version,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+		LastModifiedBy: c.LastModifiedBy,
+		Language:       c.Language,
+		Format:         c.Format,
+		LinkedNoteIDs:  c.LinkedNoteIDs(),
 	}
+	if c.Image != nil {
+		po.ImageWidth = c.Image.Width
+		po.ImageHeight = c.Image.Height
+		po.ImageFormat = c.Image.Format
+		po.Thumbnails = copyThumbnails(c.Image.Thumbnails)
+	}
+	if c.Attachment != nil {
+		po.AttachmentFilename = c.Attachment.Filename
+		po.AttachmentSize = c.Attachment.Size
+		po.AttachmentMimeType = c.Attachment.MimeType
+		po.AttachmentUploaderID = c.Attachment.UploaderID
+	}
+	return po
 }
 
 // ToDomain converts a repository.ContentPO to a domain.Content.
 func (m *ContentMapper) ToDomain(po *contentrepo.ContentPO) *content.Content {
-	return content.NewContent(po.ID, po.NoteID, po.Data, content.ContentType(po.Type), po.Version)
+	c := content.NewContent(po.ID, po.NoteID, po.Data, content.ContentType(po.Type), po.Version)
+	c.CreatedAt = po.CreatedAt
+	c.UpdatedAt = po.UpdatedAt
+	c.LastModifiedBy = po.LastModifiedBy
+	c.Language = po.Language
+	c.Format = po.Format
+	if po.ImageFormat != "" {
+		c.Image = &content.ImageMetadata{
+			Width:      po.ImageWidth,
+			Height:     po.ImageHeight,
+			Format:     po.ImageFormat,
+			Thumbnails: copyThumbnails(po.Thumbnails),
+		}
+	}
+	if po.AttachmentFilename != "" {
+		c.Attachment = &content.AttachmentMetadata{
+			Filename:   po.AttachmentFilename,
+			Size:       po.AttachmentSize,
+			MimeType:   po.AttachmentMimeType,
+			UploaderID: po.AttachmentUploaderID,
+		}
+	}
+	return c
 }
 
 // ToDTO converts a domain.Content to a ContentDTO.
 func (m *ContentMapper) ToDTO(c *content.Content) *ContentDTO {
-	return &ContentDTO{
-		ID:      c.ID,
-		NoteID:  c.NoteID,
-		Data:    c.Data,
-		Type:    string(c.Type),
-		Version: c.Version,
+	dto := &ContentDTO{
+		ID:             c.ID,
+		NoteID:         c.NoteID,
+		Data:           c.Data,
+		Type:           string(c.Type),
+		Version:        c.Version,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+		LastModifiedBy: c.LastModifiedBy,
+		Language:       c.Language,
+		Format:         c.Format,
+	}
+	if c.Image != nil {
+		dto.Width = c.Image.Width
+		dto.Height = c.Image.Height
+		dto.Format = c.Image.Format
+		dto.Thumbnails = copyThumbnails(c.Image.Thumbnails)
+	}
+	if c.Attachment != nil {
+		dto.Filename = c.Attachment.Filename
+		dto.Size = c.Attachment.Size
+		dto.MimeType = c.Attachment.MimeType
+	}
+	return dto
+}
+
+func copyThumbnails(thumbnails map[int]string) map[int]string {
+	if thumbnails == nil {
+		return nil
+	}
+	copied := make(map[int]string, len(thumbnails))
+	for size, hash := range thumbnails {
+		copied[size] = hash
+	}
+	return copied
+}
+
+func toTableRowDTO(row content.TableRow) *TableRowDTO {
+	dto := &TableRowDTO{ID: row.ID}
+	if len(row.Cells) > 0 {
+		dto.Cells = make(map[string]string, len(row.Cells))
+		for columnID, cell := range row.Cells {
+			dto.Cells[columnID] = cell.Value
+		}
 	}
+	return dto
 }
@@ -0,0 +1,62 @@
+package contentuc
+
+import (
+	"html"
+	"noteapp/internal/domain/content"
+	"strings"
+)
+
+// renderPlainText renders plain text as escaped paragraphs, separated by blank lines, that
+// keep their line breaks.
+func renderPlainText(text string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// renderChecklist renders checklist items as a list of disabled checkboxes, like Markdown task lists.
+func renderChecklist(items []content.ChecklistItem) string {
+	var b strings.Builder
+	b.WriteString("<ul>\n")
+	for _, item := range items {
+		b.WriteString(`<li><input type="checkbox"`)
+		if item.Checked {
+			b.WriteString(" checked")
+		}
+		b.WriteString(" disabled> ")
+		b.WriteString(html.EscapeString(item.Text))
+		if item.Assignee != "" {
+			b.WriteString(" <em>@" + html.EscapeString(item.Assignee) + "</em>")
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ul>\n")
+	return b.String()
+}
+
+// renderTable renders a table with a header row of column names.
+func renderTable(table content.Table) string {
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, column := range table.Columns {
+		b.WriteString("<th>" + html.EscapeString(column.Name) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range table.Rows {
+		b.WriteString("<tr>")
+		for _, column := range table.Columns {
+			b.WriteString("<td>" + html.EscapeString(row.Cells[column.ID].Value) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
@@ -8,4 +8,15 @@ const (
 	TextContentType ContentType = "text"
 	// ImageContentType represents an image content block.
 	ImageContentType ContentType = "image"
+	// AttachmentContentType represents a file attachment content block.
+	AttachmentContentType ContentType = "attachment"
+	// ChecklistContentType represents a checklist content block.
+	ChecklistContentType ContentType = "checklist"
+	// CodeContentType represents a source code content block.
+	CodeContentType ContentType = "code"
+	// TableContentType represents a table content block.
+	TableContentType ContentType = "table"
 )
+
+// MarkdownFormat is the format of text contents written in Markdown.
+const MarkdownFormat = "markdown"
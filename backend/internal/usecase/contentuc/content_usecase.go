@@ -3,28 +3,102 @@ package contentuc
 import (
 	"errors"
 	"fmt"
+	"noteapp/internal/clock"
 	"noteapp/internal/domain/content"
+	"noteapp/internal/highlight"
+	"noteapp/internal/markdown"
 	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/sanitize"
+	"path"
+	"strings"
 )
 
+// maxCellUpdateAttempts bounds how often a table cell update is retried when other changes
+// of the table are saved concurrently.
+const maxCellUpdateAttempts = 3
+
+// maxMoveAttempts bounds how often moving a content along with the rest of its note is retried
+// when other changes of the content are saved concurrently.
+const maxMoveAttempts = 3
+
+// DefaultStorageQuota is the total size, in bytes, of the attachments each user may upload.
+const DefaultStorageQuota int64 = 100 << 20
+
 // ContentUsecase handles the business logic for content.
 type ContentUsecase struct {
-	repo   contentrepo.ContentRepository
-	mapper *ContentMapper
+	repo         contentrepo.ContentRepository
+	mapper       *ContentMapper
+	clock        clock.Clock
+	storageQuota int64
 }
 
-// NewContentUsecase creates a new ContentUsecase.
+// NewContentUsecase creates a new ContentUsecase that uses the system clock.
 func NewContentUsecase(repo contentrepo.ContentRepository) *ContentUsecase {
-	return &ContentUsecase{repo: repo, mapper: NewContentMapper()}
+	return NewContentUsecaseWithClock(repo, clock.NewSystemClock())
+}
+
+// NewContentUsecaseWithClock creates a new ContentUsecase that reads the time from c.
+func NewContentUsecaseWithClock(repo contentrepo.ContentRepository, c clock.Clock) *ContentUsecase {
+	return NewContentUsecaseWithStorageQuota(repo, c, DefaultStorageQuota)
+}
+
+// NewContentUsecaseWithStorageQuota creates a new ContentUsecase that reads the time from c and
+// limits the attachments of each user to quota bytes.
+func NewContentUsecaseWithStorageQuota(repo contentrepo.ContentRepository, c clock.Clock, quota int64) *ContentUsecase {
+	return &ContentUsecase{repo: repo, mapper: NewContentMapper(), clock: c, storageQuota: quota}
 }
 
-// CreateContent creates a new content.
-func (uc *ContentUsecase) CreateContent(noteID, contentID, data string, contentType ContentType) (string, error) {
+// CreateContent creates a new content authored by authorID.
+func (uc *ContentUsecase) CreateContent(noteID, authorID, contentID, data string, contentType ContentType) (string, error) {
 	domainContentType, err := mapToDomainContentType(contentType)
 	if err != nil {
 		return "", err
 	}
+	switch domainContentType {
+	case content.ChecklistContentType:
+		if data, err = content.NormalizeChecklist(data); err != nil {
+			return "", uc.mapDomainError(err)
+		}
+	case content.TableContentType:
+		if data, err = content.NormalizeTable(data); err != nil {
+			return "", uc.mapDomainError(err)
+		}
+	}
 	c := content.NewContent(contentID, noteID, data, domainContentType, 0)
+	c.MarkCreated(authorID, uc.clock.Now())
+	po := uc.mapper.ToPO(c)
+	if err := uc.repo.Save(po); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return c.ID, nil
+}
+
+// CreateTextContent creates a new text content in the given format, authored by authorID.
+// An empty format is plain text.
+func (uc *ContentUsecase) CreateTextContent(noteID, authorID, contentID, data, format string) (string, error) {
+	format, err := normalizeFormat(format)
+	if err != nil {
+		return "", err
+	}
+	c := content.NewContent(contentID, noteID, data, content.TextContentType, 0)
+	c.Format = format
+	c.MarkCreated(authorID, uc.clock.Now())
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return c.ID, nil
+}
+
+// CreateCodeContent creates a new code content in the given programming language, authored by authorID.
+// An empty language is plain text.
+func (uc *ContentUsecase) CreateCodeContent(noteID, authorID, contentID, data, language string) (string, error) {
+	language, err := normalizeLanguage(language)
+	if err != nil {
+		return "", err
+	}
+	c := content.NewContent(contentID, noteID, data, content.CodeContentType, 0)
+	c.Language = language
+	c.MarkCreated(authorID, uc.clock.Now())
 	po := uc.mapper.ToPO(c)
 	if err := uc.repo.Save(po); err != nil {
 		return "", uc.mapRepositoryError(err)
@@ -32,6 +106,76 @@ func (uc *ContentUsecase) CreateContent(noteID, contentID, data string, contentT
 	return c.ID, nil
 }
 
+// CreateImageContent creates a new image content authored by authorID, referencing the image blob by its hash.
+func (uc *ContentUsecase) CreateImageContent(noteID, authorID, contentID, hash string, image ImageMetadataDTO) (string, error) {
+	c := content.NewContent(contentID, noteID, hash, content.ImageContentType, 0)
+	c.Image = &content.ImageMetadata{
+		Width:      image.Width,
+		Height:     image.Height,
+		Format:     image.Format,
+		Thumbnails: copyThumbnails(image.Thumbnails),
+	}
+	c.MarkCreated(authorID, uc.clock.Now())
+	po := uc.mapper.ToPO(c)
+	if err := uc.repo.Save(po); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return c.ID, nil
+}
+
+// CreateAttachmentContent creates a new attachment content uploaded by uploaderID, referencing the
+// file blob by its hash. The file counts against the uploader's storage quota even when the same
+// file is attached elsewhere; the quota is reserved atomically with saving the content.
+func (uc *ContentUsecase) CreateAttachmentContent(noteID, uploaderID, contentID, hash string, attachment AttachmentMetadataDTO) (string, error) {
+	if noteID == "" || uploaderID == "" {
+		return "", ErrInvalidID
+	}
+	filename := path.Base(strings.ReplaceAll(strings.TrimSpace(attachment.Filename), "\\", "/"))
+	if filename == "." || filename == "/" {
+		return "", ErrEmptyFilename
+	}
+
+	c := content.NewContent(contentID, noteID, hash, content.AttachmentContentType, 0)
+	c.Attachment = &content.AttachmentMetadata{
+		Filename:   filename,
+		Size:       attachment.Size,
+		MimeType:   attachment.MimeType,
+		UploaderID: uploaderID,
+	}
+	c.MarkCreated(uploaderID, uc.clock.Now())
+	po := uc.mapper.ToPO(c)
+	if err := uc.repo.SaveAttachment(po, uc.storageQuota); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return c.ID, nil
+}
+
+// StorageUsage returns the total size, in bytes, of the attachments uploaded by a user.
+func (uc *ContentUsecase) StorageUsage(userID string) (int64, error) {
+	pos, err := uc.repo.FindAttachmentsByUploader(userID)
+	if err != nil {
+		return 0, uc.mapRepositoryError(err)
+	}
+	var usage int64
+	for _, po := range pos {
+		usage += po.AttachmentSize
+	}
+	return usage, nil
+}
+
+// RemainingStorage returns how many bytes of attachments a user may still upload. Uploads can
+// be limited to it before they are stored; CreateAttachmentContent enforces the quota.
+func (uc *ContentUsecase) RemainingStorage(userID string) (int64, error) {
+	if userID == "" {
+		return 0, ErrInvalidID
+	}
+	usage, err := uc.StorageUsage(userID)
+	if err != nil {
+		return 0, err
+	}
+	return max(uc.storageQuota-usage, 0), nil
+}
+
 // GetContentByID retrieves a content by its ID.
 func (uc *ContentUsecase) GetContentByID(id string) (*ContentDTO, error) {
 	if id == "" {
@@ -45,8 +189,8 @@ func (uc *ContentUsecase) GetContentByID(id string) (*ContentDTO, error) {
 	return uc.mapper.ToDTO(c), nil
 }
 
-// UpdateContent updates a content.
-func (uc *ContentUsecase) UpdateContent(id, data string, version int) error {
+// UpdateContent updates a content on behalf of editorID.
+func (uc *ContentUsecase) UpdateContent(id, editorID, data string, version int) error {
 	po, err := uc.repo.GetByID(id)
 	if err != nil {
 		return uc.mapRepositoryError(err)
@@ -57,9 +201,28 @@ func (uc *ContentUsecase) UpdateContent(id, data string, version int) error {
 	}
 
 	c := uc.mapper.ToDomain(po)
+	if !c.IsEditable() {
+		return ErrContentNotEditable
+	}
 
 	// For now, we only support updating the data.
-	c.Data = data
+	switch c.Type {
+	case content.ChecklistContentType:
+		if data, err = content.NormalizeChecklist(data); err != nil {
+			return uc.mapDomainError(err)
+		}
+		c.Data = data
+	case content.TableContentType:
+		if data, err = content.NormalizeTable(data); err != nil {
+			return uc.mapDomainError(err)
+		}
+		if err := c.ReplaceTable(data); err != nil {
+			return uc.mapDomainError(err)
+		}
+	default:
+		c.Data = data
+	}
+	c.MarkModified(editorID, uc.clock.Now())
 
 	po = uc.mapper.ToPO(c)
 	if err := uc.repo.Save(po); err != nil {
@@ -68,6 +231,372 @@ func (uc *ContentUsecase) UpdateContent(id, data string, version int) error {
 	return nil
 }
 
+// UpdateTextContent updates the text and the format of a text content on behalf of editorID.
+func (uc *ContentUsecase) UpdateTextContent(id, editorID, data, format string, version int) error {
+	format, err := normalizeFormat(format)
+	if err != nil {
+		return err
+	}
+	po, err := uc.repo.GetByID(id)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return ErrConflict
+	}
+
+	c := uc.mapper.ToDomain(po)
+	if c.Type != content.TextContentType {
+		return ErrUnsupportedContentType
+	}
+	c.Data = data
+	c.Format = format
+	c.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// UpdateCodeContent updates the code and the programming language of a code content on behalf of editorID.
+func (uc *ContentUsecase) UpdateCodeContent(id, editorID, data, language string, version int) error {
+	language, err := normalizeLanguage(language)
+	if err != nil {
+		return err
+	}
+	po, err := uc.repo.GetByID(id)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return ErrConflict
+	}
+
+	c := uc.mapper.ToDomain(po)
+	if c.Type != content.CodeContentType {
+		return ErrUnsupportedContentType
+	}
+	c.Data = data
+	c.Language = language
+	c.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// RenderContent renders a text, code or checklist content as HTML that is safe to show to
+// other users. Markdown is rendered and sanitized, plain text is escaped, and code contents
+// are syntax-highlighted in their language.
+func (uc *ContentUsecase) RenderContent(id string) (string, error) {
+	po, err := uc.repo.GetByID(id)
+	if err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	c := uc.mapper.ToDomain(po)
+	switch c.Type {
+	case content.TextContentType:
+		if c.Format == content.MarkdownFormat {
+			return sanitize.HTML(markdown.ToHTML(c.Data)), nil
+		}
+		return renderPlainText(c.Data), nil
+	case content.CodeContentType:
+		return highlight.HTML(c.Data, c.Language), nil
+	case content.ChecklistContentType:
+		items, err := c.ChecklistItems()
+		if err != nil {
+			return "", uc.mapDomainError(err)
+		}
+		return renderChecklist(items), nil
+	case content.TableContentType:
+		table, err := c.Table()
+		if err != nil {
+			return "", uc.mapDomainError(err)
+		}
+		return renderTable(table), nil
+	default:
+		return "", ErrRenderNotSupported
+	}
+}
+
+// AddChecklistItem adds an unchecked item to a checklist at index, or appends it when index is -1.
+func (uc *ContentUsecase) AddChecklistItem(contentID, editorID, text, assignee string, index, version int) (*ChecklistItemDTO, error) {
+	return uc.updateChecklist(contentID, editorID, version, func(c *content.Content) (content.ChecklistItem, error) {
+		return c.AddChecklistItem(text, assignee, index)
+	})
+}
+
+// ToggleChecklistItem flips the checked state of a checklist item.
+func (uc *ContentUsecase) ToggleChecklistItem(contentID, editorID, itemID string, version int) (*ChecklistItemDTO, error) {
+	return uc.updateChecklist(contentID, editorID, version, func(c *content.Content) (content.ChecklistItem, error) {
+		return c.ToggleChecklistItem(itemID)
+	})
+}
+
+// MoveChecklistItem moves a checklist item to index.
+func (uc *ContentUsecase) MoveChecklistItem(contentID, editorID, itemID string, index, version int) (*ChecklistItemDTO, error) {
+	return uc.updateChecklist(contentID, editorID, version, func(c *content.Content) (content.ChecklistItem, error) {
+		return c.MoveChecklistItem(itemID, index)
+	})
+}
+
+// RemoveChecklistItem removes a checklist item.
+func (uc *ContentUsecase) RemoveChecklistItem(contentID, editorID, itemID string, version int) (*ChecklistItemDTO, error) {
+	return uc.updateChecklist(contentID, editorID, version, func(c *content.Content) (content.ChecklistItem, error) {
+		return c.RemoveChecklistItem(itemID)
+	})
+}
+
+// updateChecklist applies an item-level change to a checklist and saves it as a new version.
+func (uc *ContentUsecase) updateChecklist(contentID, editorID string, version int, change func(*content.Content) (content.ChecklistItem, error)) (*ChecklistItemDTO, error) {
+	po, err := uc.repo.GetByID(contentID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return nil, ErrConflict
+	}
+
+	c := uc.mapper.ToDomain(po)
+	item, err := change(c)
+	if err != nil {
+		return nil, uc.mapDomainError(err)
+	}
+	c.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return &ChecklistItemDTO{ID: item.ID, Text: item.Text, Checked: item.Checked, Assignee: item.Assignee}, nil
+}
+
+// UpdateTableCell sets the value of a table cell on behalf of editorID. baseVersion is the
+// version of the content the edit is based on: the update only conflicts when the same cell
+// was changed after it, so users editing different cells do not block each other. The
+// returned cell carries the new version of the content.
+func (uc *ContentUsecase) UpdateTableCell(contentID, editorID, rowID, columnID, value string, baseVersion int) (*TableCellDTO, error) {
+	for attempt := 0; ; attempt++ {
+		po, err := uc.repo.GetByID(contentID)
+		if err != nil {
+			return nil, uc.mapRepositoryError(err)
+		}
+		if baseVersion > po.Version {
+			return nil, ErrConflict
+		}
+
+		c := uc.mapper.ToDomain(po)
+		cell, err := c.UpdateTableCell(rowID, columnID, value, baseVersion)
+		if err != nil {
+			return nil, uc.mapDomainError(err)
+		}
+		c.MarkModified(editorID, uc.clock.Now())
+
+		err = uc.repo.Save(uc.mapper.ToPO(c))
+		if errors.Is(err, contentrepo.ErrContentConflict) && attempt < maxCellUpdateAttempts-1 {
+			// Another change was saved since the content was read; apply the edit to it instead.
+			continue
+		}
+		if err != nil {
+			return nil, uc.mapRepositoryError(err)
+		}
+		return &TableCellDTO{RowID: rowID, ColumnID: columnID, Value: cell.Value, Version: cell.Version}, nil
+	}
+}
+
+// InsertTableRow inserts a row into a table at index, or appends it when index is -1. Values
+// maps column IDs to the initial values of the row's cells.
+func (uc *ContentUsecase) InsertTableRow(contentID, editorID string, index int, values map[string]string, version int) (*TableRowDTO, error) {
+	var row content.TableRow
+	err := uc.updateTable(contentID, editorID, version, func(c *content.Content) (err error) {
+		row, err = c.InsertTableRow(index, values)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTableRowDTO(row), nil
+}
+
+// DeleteTableRow removes a row from a table.
+func (uc *ContentUsecase) DeleteTableRow(contentID, editorID, rowID string, version int) (*TableRowDTO, error) {
+	var row content.TableRow
+	err := uc.updateTable(contentID, editorID, version, func(c *content.Content) (err error) {
+		row, err = c.DeleteTableRow(rowID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTableRowDTO(row), nil
+}
+
+// InsertTableColumn inserts an empty column into a table at index, or appends it when index is -1.
+func (uc *ContentUsecase) InsertTableColumn(contentID, editorID, name string, index, version int) (*TableColumnDTO, error) {
+	var column content.TableColumn
+	err := uc.updateTable(contentID, editorID, version, func(c *content.Content) (err error) {
+		column, err = c.InsertTableColumn(name, index)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TableColumnDTO{ID: column.ID, Name: column.Name}, nil
+}
+
+// DeleteTableColumn removes a column, with its cells, from a table.
+func (uc *ContentUsecase) DeleteTableColumn(contentID, editorID, columnID string, version int) (*TableColumnDTO, error) {
+	var column content.TableColumn
+	err := uc.updateTable(contentID, editorID, version, func(c *content.Content) (err error) {
+		column, err = c.DeleteTableColumn(columnID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TableColumnDTO{ID: column.ID, Name: column.Name}, nil
+}
+
+// updateTable applies a change to the rows or columns of a table and saves it as a new version.
+// Unlike cell updates, these changes require the current version of the content.
+func (uc *ContentUsecase) updateTable(contentID, editorID string, version int, change func(*content.Content) error) error {
+	po, err := uc.repo.GetByID(contentID)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return ErrConflict
+	}
+
+	c := uc.mapper.ToDomain(po)
+	if err := change(c); err != nil {
+		return uc.mapDomainError(err)
+	}
+	c.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// MoveContent records on behalf of editorID that a content now belongs to the note noteID.
+func (uc *ContentUsecase) MoveContent(id, noteID, editorID string, version int) error {
+	if id == "" || noteID == "" {
+		return ErrInvalidID
+	}
+	po, err := uc.repo.GetByID(id)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return ErrConflict
+	}
+
+	c := uc.mapper.ToDomain(po)
+	c.NoteID = noteID
+	c.MarkModified(editorID, uc.clock.Now())
+	if err := uc.repo.Save(uc.mapper.ToPO(c)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// MoveContents moves several contents into the note noteID on behalf of editorID, whatever
+// their versions, as when whole notes are split or merged. Contents that no longer exist are
+// skipped. Either all contents are moved or, when one cannot be, the ones already moved are put
+// back in their notes.
+func (uc *ContentUsecase) MoveContents(ids []string, noteID, editorID string) error {
+	if noteID == "" {
+		return ErrInvalidID
+	}
+	previousNoteIDs := make(map[string]string, len(ids))
+	for _, id := range ids {
+		previousNoteID, err := uc.moveContent(id, noteID, editorID)
+		if errors.Is(err, ErrContentNotFound) {
+			continue
+		}
+		if err != nil {
+			for movedID, movedFrom := range previousNoteIDs {
+				uc.moveContent(movedID, movedFrom, editorID)
+			}
+			return err
+		}
+		previousNoteIDs[id] = previousNoteID
+	}
+	return nil
+}
+
+// moveContent moves a content into the note noteID at its current version, and returns the
+// note it was in.
+func (uc *ContentUsecase) moveContent(id, noteID, editorID string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		po, err := uc.repo.GetByID(id)
+		if err != nil {
+			return "", uc.mapRepositoryError(err)
+		}
+
+		c := uc.mapper.ToDomain(po)
+		previousNoteID := c.NoteID
+		c.NoteID = noteID
+		c.MarkModified(editorID, uc.clock.Now())
+		err = uc.repo.Save(uc.mapper.ToPO(c))
+		if errors.Is(err, contentrepo.ErrContentConflict) && attempt < maxMoveAttempts-1 {
+			continue
+		}
+		if err != nil {
+			return "", uc.mapRepositoryError(err)
+		}
+		return previousNoteID, nil
+	}
+}
+
+// CopyContent copies a content into the note noteID on behalf of authorID and returns the ID
+// of the copy. A copied attachment shares the file of the original, but counts against the
+// storage quota of the author of the copy.
+func (uc *ContentUsecase) CopyContent(id, noteID, authorID string) (string, error) {
+	if id == "" || noteID == "" {
+		return "", ErrInvalidID
+	}
+	po, err := uc.repo.GetByID(id)
+	if err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+
+	c := uc.mapper.ToDomain(po).Copy(noteID)
+	c.MarkCreated(authorID, uc.clock.Now())
+	if c.Attachment != nil {
+		// The copy counts against the author's quota, reserved as the copy is saved.
+		c.Attachment.UploaderID = authorID
+		err = uc.repo.SaveAttachment(uc.mapper.ToPO(c), uc.storageQuota)
+	} else {
+		err = uc.repo.Save(uc.mapper.ToPO(c))
+	}
+	if err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return c.ID, nil
+}
+
+// CopyContents copies several contents into the note noteID on behalf of authorID, as
+// CopyContent does, and returns the IDs of the copies in the same order. Either all contents
+// are copied or, when one cannot be, none is.
+func (uc *ContentUsecase) CopyContents(ids []string, noteID, authorID string) ([]string, error) {
+	copyIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		copyID, err := uc.CopyContent(id, noteID, authorID)
+		if err != nil {
+			for _, copied := range copyIDs {
+				uc.repo.Delete(copied)
+			}
+			return nil, err
+		}
+		copyIDs = append(copyIDs, copyID)
+	}
+	return copyIDs, nil
+}
+
 // DeleteContent deletes a content.
 func (uc *ContentUsecase) DeleteContent(id string, version int) error {
 	po, err := uc.repo.GetByID(id)
@@ -85,6 +614,41 @@ func (uc *ContentUsecase) DeleteContent(id string, version int) error {
 	return nil
 }
 
+// GetContentsByNoteID retrieves all contents of a note, in no particular order.
+func (uc *ContentUsecase) GetContentsByNoteID(noteID string) ([]*ContentDTO, error) {
+	pos, err := uc.repo.GetAllByNoteID(noteID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	dtos := make([]*ContentDTO, 0, len(pos))
+	for _, po := range pos {
+		dtos = append(dtos, uc.mapper.ToDTO(uc.mapper.ToDomain(po)))
+	}
+	return dtos, nil
+}
+
+// IsBlobReferenced reports whether any content still references a blob, as its data or as a thumbnail.
+func (uc *ContentUsecase) IsBlobReferenced(hash string) (bool, error) {
+	referenced, err := uc.repo.IsBlobReferenced(hash)
+	if err != nil {
+		return false, uc.mapRepositoryError(err)
+	}
+	return referenced, nil
+}
+
+// GetContentsByBlob retrieves the contents that reference a blob, as their data or as a thumbnail.
+func (uc *ContentUsecase) GetContentsByBlob(hash string) ([]*ContentDTO, error) {
+	pos, err := uc.repo.FindByBlob(hash)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	dtos := make([]*ContentDTO, 0, len(pos))
+	for _, po := range pos {
+		dtos = append(dtos, uc.mapper.ToDTO(uc.mapper.ToDomain(po)))
+	}
+	return dtos, nil
+}
+
 // DeleteAllContentsByNoteID deletes all content associated with a given note ID.
 func (uc *ContentUsecase) DeleteAllContentsByNoteID(noteID string) error {
 	if err := uc.repo.DeleteAllByNoteID(noteID); err != nil {
@@ -99,17 +663,78 @@ func (uc *ContentUsecase) mapRepositoryError(err error) error {
 		return ErrContentNotFound
 	case errors.Is(err, contentrepo.ErrContentConflict):
 		return ErrConflict
+	case errors.Is(err, contentrepo.ErrStorageQuotaExceeded):
+		return ErrStorageQuotaExceeded
 	default:
 		return fmt.Errorf("an unexpected repository error occurred: %w", err)
 	}
 }
 
+func (uc *ContentUsecase) mapDomainError(err error) error {
+	switch {
+	case errors.Is(err, content.ErrNotAChecklist):
+		return ErrNotAChecklist
+	case errors.Is(err, content.ErrInvalidChecklist):
+		return ErrInvalidChecklist
+	case errors.Is(err, content.ErrEmptyChecklistItem):
+		return ErrEmptyChecklistItem
+	case errors.Is(err, content.ErrChecklistItemNotFound):
+		return ErrChecklistItemNotFound
+	case errors.Is(err, content.ErrNotATable):
+		return ErrNotATable
+	case errors.Is(err, content.ErrInvalidTable):
+		return ErrInvalidTable
+	case errors.Is(err, content.ErrTableRowNotFound):
+		return ErrTableRowNotFound
+	case errors.Is(err, content.ErrTableColumnNotFound):
+		return ErrTableColumnNotFound
+	case errors.Is(err, content.ErrTableCellConflict):
+		return ErrConflict
+	case errors.Is(err, content.ErrIndexOutOfBounds):
+		return ErrIndexOutOfBounds
+	default:
+		return fmt.Errorf("an unexpected domain error occurred: %w", err)
+	}
+}
+
+// normalizeLanguage resolves a programming language to its canonical name. An empty language is plain text.
+func normalizeLanguage(language string) (string, error) {
+	if strings.TrimSpace(language) == "" {
+		return "plaintext", nil
+	}
+	name, ok := highlight.Normalize(language)
+	if !ok {
+		return "", ErrUnsupportedLanguage
+	}
+	return name, nil
+}
+
+// normalizeFormat resolves a text format to its canonical name. Plain text has no format.
+func normalizeFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "plain":
+		return "", nil
+	case MarkdownFormat, "md":
+		return content.MarkdownFormat, nil
+	default:
+		return "", ErrUnsupportedTextFormat
+	}
+}
+
 func mapToDomainContentType(ct ContentType) (content.ContentType, error) {
 	switch ct {
 	case TextContentType:
 		return content.TextContentType, nil
 	case ImageContentType:
 		return content.ImageContentType, nil
+	case AttachmentContentType:
+		return content.AttachmentContentType, nil
+	case ChecklistContentType:
+		return content.ChecklistContentType, nil
+	case CodeContentType:
+		return content.CodeContentType, nil
+	case TableContentType:
+		return content.TableContentType, nil
 	default:
 		return "", ErrUnsupportedContentType
 	}
@@ -1,9 +1,13 @@
 package contentuc_test
 
 import (
+	"noteapp/internal/clock"
 	"noteapp/internal/repository/contentrepo"
 	"noteapp/internal/usecase/contentuc"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestContentUsecase_CreateContent_WithInjectedID(t *testing.T) {
@@ -14,7 +18,7 @@ func TestContentUsecase_CreateContent_WithInjectedID(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	returnedID, err := usecase.CreateContent(noteID, contentID, data, contentType)
+	returnedID, err := usecase.CreateContent(noteID, "user-1", contentID, data, contentType)
 	if err != nil {
 		t.Fatalf("CreateContent() returned an unexpected error: %v", err)
 	}
@@ -48,7 +52,7 @@ func TestContentUsecase_CreateContent_WithGeneratedID(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	returnedID, err := usecase.CreateContent(noteID, "", data, contentType)
+	returnedID, err := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 	if err != nil {
 		t.Fatalf("CreateContent() returned an unexpected error: %v", err)
 	}
@@ -82,12 +86,406 @@ func TestContentUsecase_CreateContent_UnsupportedType(t *testing.T) {
 	data := "Test content"
 	contentType := "unsupported"
 
-	_, err := usecase.CreateContent(noteID, "", data, contentuc.ContentType(contentType))
+	_, err := usecase.CreateContent(noteID, "user-1", "", data, contentuc.ContentType(contentType))
 	if err != contentuc.ErrUnsupportedContentType {
 		t.Errorf("Expected error to be '%v', but got '%v'", contentuc.ErrUnsupportedContentType, err)
 	}
 }
 
+func TestContentUsecase_CreateImageContent(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	image := contentuc.ImageMetadataDTO{
+		Width:      640,
+		Height:     480,
+		Format:     "png",
+		Thumbnails: map[int]string{128: "thumb-hash"},
+	}
+
+	id, err := usecase.CreateImageContent("n1", "user-1", "", "image-hash", image)
+	if err != nil {
+		t.Fatalf("CreateImageContent() returned an unexpected error: %v", err)
+	}
+
+	dto, err := usecase.GetContentByID(id)
+	if err != nil {
+		t.Fatalf("GetContentByID() returned an unexpected error: %v", err)
+	}
+	if dto.Type != string(contentuc.ImageContentType) || dto.Data != "image-hash" {
+		t.Errorf("Expected an image content with data 'image-hash', got %s content '%s'", dto.Type, dto.Data)
+	}
+	if dto.Width != 640 || dto.Height != 480 || dto.Format != "png" {
+		t.Errorf("Expected a 640x480 png, got %dx%d %s", dto.Width, dto.Height, dto.Format)
+	}
+	if dto.Thumbnails[128] != "thumb-hash" {
+		t.Errorf("Expected thumbnail 'thumb-hash', got '%s'", dto.Thumbnails[128])
+	}
+}
+
+func TestContentUsecase_CreateAttachmentContent(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecaseWithStorageQuota(repo, clock.NewSystemClock(), 100)
+	attachment := contentuc.AttachmentMetadataDTO{Filename: "../logs/server.log", Size: 60, MimeType: "text/plain; charset=utf-8"}
+
+	id, err := usecase.CreateAttachmentContent("n1", "user-1", "", "file-hash", attachment)
+	if err != nil {
+		t.Fatalf("CreateAttachmentContent() returned an unexpected error: %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Filename != "server.log" {
+		t.Errorf("Expected the filename without its directory, got '%s'", dto.Filename)
+	}
+	if dto.Size != 60 || dto.Data != "file-hash" {
+		t.Errorf("Expected a 60 byte attachment of 'file-hash', got %d bytes of '%s'", dto.Size, dto.Data)
+	}
+	usage, _ := usecase.StorageUsage("user-1")
+	if usage != 60 {
+		t.Errorf("Expected storage usage of 60, got %d", usage)
+	}
+	if remaining, _ := usecase.RemainingStorage("user-1"); remaining != 40 {
+		t.Errorf("Expected 40 bytes of storage remaining, got %d", remaining)
+	}
+
+	// A second attachment of the same file still counts against the quota.
+	_, err = usecase.CreateAttachmentContent("n1", "user-1", "", "file-hash", attachment)
+	if err != contentuc.ErrStorageQuotaExceeded {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", err)
+	}
+	if _, err := usecase.CreateAttachmentContent("n1", "user-2", "", "file-hash", attachment); err != nil {
+		t.Errorf("Expected another user's quota to be separate, got %v", err)
+	}
+}
+
+func TestContentUsecase_CreateAttachmentContent_RequiresUploader(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+
+	_, err := usecase.CreateAttachmentContent("n1", "", "", "file-hash", contentuc.AttachmentMetadataDTO{Filename: "report.pdf", Size: 1})
+
+	if err != contentuc.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID, got %v", err)
+	}
+	if _, err := usecase.RemainingStorage(""); err != contentuc.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID for the storage of no user, got %v", err)
+	}
+}
+
+func TestContentUsecase_CreateAttachmentContent_EmptyFilename(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+
+	_, err := usecase.CreateAttachmentContent("n1", "user-1", "", "file-hash", contentuc.AttachmentMetadataDTO{Filename: "  ", Size: 1})
+
+	if err != contentuc.ErrEmptyFilename {
+		t.Errorf("Expected ErrEmptyFilename, got %v", err)
+	}
+}
+
+func TestContentUsecase_IsBlobReferenced(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	usecase.CreateImageContent("n1", "user-1", "", "image-hash", contentuc.ImageMetadataDTO{
+		Format:     "png",
+		Thumbnails: map[int]string{128: "thumb-hash"},
+	})
+
+	for _, hash := range []string{"image-hash", "thumb-hash"} {
+		if referenced, _ := usecase.IsBlobReferenced(hash); !referenced {
+			t.Errorf("Expected '%s' to be referenced", hash)
+		}
+	}
+	if referenced, _ := usecase.IsBlobReferenced("other-hash"); referenced {
+		t.Errorf("Expected 'other-hash' not to be referenced")
+	}
+}
+
+func TestContentUsecase_GetContentsByBlob(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	imageID, _ := usecase.CreateImageContent("n1", "user-1", "", "image-hash", contentuc.ImageMetadataDTO{
+		Format:     "png",
+		Thumbnails: map[int]string{128: "thumb-hash"},
+	})
+	usecase.CreateTextContent("n2", "user-1", "", "image-hash", "")
+
+	contents, err := usecase.GetContentsByBlob("thumb-hash")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(contents) != 1 || contents[0].ID != imageID || contents[0].NoteID != "n1" {
+		t.Errorf("Expected the image content referencing the thumbnail, got %+v", contents)
+	}
+	if contents, _ := usecase.GetContentsByBlob("other-hash"); len(contents) != 0 {
+		t.Errorf("Expected no contents for 'other-hash', got %+v", contents)
+	}
+}
+
+func TestContentUsecase_ChecklistItems(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	id, err := usecase.CreateContent("n1", "user-1", "", "", contentuc.ChecklistContentType)
+	if err != nil {
+		t.Fatalf("CreateContent() returned an unexpected error: %v", err)
+	}
+
+	item, err := usecase.AddChecklistItem(id, "user-1", "write tests", "user-2", -1, 0)
+	if err != nil {
+		t.Fatalf("AddChecklistItem() returned an unexpected error: %v", err)
+	}
+	toggled, err := usecase.ToggleChecklistItem(id, "user-2", item.ID, 1)
+	if err != nil {
+		t.Fatalf("ToggleChecklistItem() returned an unexpected error: %v", err)
+	}
+	if !toggled.Checked {
+		t.Errorf("Expected the item to be checked")
+	}
+	if _, err := usecase.RemoveChecklistItem(id, "user-1", item.ID, 1); err != contentuc.ErrConflict {
+		t.Errorf("Expected ErrConflict for a stale version, got %v", err)
+	}
+	if _, err := usecase.RemoveChecklistItem(id, "user-1", item.ID, 2); err != nil {
+		t.Fatalf("RemoveChecklistItem() returned an unexpected error: %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Data != "[]" || dto.Version != 3 || dto.LastModifiedBy != "user-1" {
+		t.Errorf("Expected an empty checklist at version 3 modified by user-1, got '%s' at version %d by %s", dto.Data, dto.Version, dto.LastModifiedBy)
+	}
+}
+
+func TestContentUsecase_Checklist_InvalidData(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+
+	_, createErr := usecase.CreateContent("n1", "user-1", "", "not json", contentuc.ChecklistContentType)
+	id, _ := usecase.CreateContent("n1", "user-1", "", "[]", contentuc.ChecklistContentType)
+	updateErr := usecase.UpdateContent(id, "user-1", `[{"text":""}]`, 0)
+
+	if createErr != contentuc.ErrInvalidChecklist {
+		t.Errorf("Expected ErrInvalidChecklist, got %v", createErr)
+	}
+	if updateErr != contentuc.ErrEmptyChecklistItem {
+		t.Errorf("Expected ErrEmptyChecklistItem, got %v", updateErr)
+	}
+}
+
+func TestContentUsecase_CodeContent(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+
+	id, err := usecase.CreateCodeContent("n1", "user-1", "", "x = 1", "py")
+	if err != nil {
+		t.Fatalf("CreateCodeContent() returned an unexpected error: %v", err)
+	}
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Type != string(contentuc.CodeContentType) || dto.Language != "python" {
+		t.Errorf("Expected python code, got %s in '%s'", dto.Type, dto.Language)
+	}
+
+	if err := usecase.UpdateCodeContent(id, "user-1", "SELECT 1", "SQL", 0); err != nil {
+		t.Fatalf("UpdateCodeContent() returned an unexpected error: %v", err)
+	}
+	rendered, err := usecase.RenderContent(id)
+	if err != nil {
+		t.Fatalf("RenderContent() returned an unexpected error: %v", err)
+	}
+	if !strings.Contains(rendered, `language-sql`) || !strings.Contains(rendered, `<span class="hl-kw">SELECT</span>`) {
+		t.Errorf("Expected highlighted SQL, got %s", rendered)
+	}
+}
+
+func TestContentUsecase_CodeContent_Errors(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	textID, _ := usecase.CreateContent("n1", "user-1", "", "plain", contentuc.TextContentType)
+
+	if _, err := usecase.CreateCodeContent("n1", "user-1", "", "x", "cobol"); err != contentuc.ErrUnsupportedLanguage {
+		t.Errorf("Expected ErrUnsupportedLanguage, got %v", err)
+	}
+	if err := usecase.UpdateCodeContent(textID, "user-1", "x", "go", 0); err != contentuc.ErrUnsupportedContentType {
+		t.Errorf("Expected ErrUnsupportedContentType, got %v", err)
+	}
+}
+
+func TestContentUsecase_RenderContent_NotSupported(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	imageID, _ := usecase.CreateImageContent("n1", "user-1", "", strings.Repeat("a", 64), contentuc.ImageMetadataDTO{Format: "png"})
+
+	if _, err := usecase.RenderContent(imageID); err != contentuc.ErrRenderNotSupported {
+		t.Errorf("Expected ErrRenderNotSupported, got %v", err)
+	}
+}
+
+func TestContentUsecase_MarkdownContent(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+
+	id, err := usecase.CreateTextContent("n1", "user-1", "", "# Hi\n\n<script>alert(1)</script>\n\n[x](javascript:alert(1))", "MD")
+	if err != nil {
+		t.Fatalf("CreateTextContent() returned an unexpected error: %v", err)
+	}
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Type != string(contentuc.TextContentType) || dto.Format != contentuc.MarkdownFormat {
+		t.Errorf("Expected markdown text, got %s in '%s'", dto.Type, dto.Format)
+	}
+
+	rendered, err := usecase.RenderContent(id)
+	if err != nil {
+		t.Fatalf("RenderContent() returned an unexpected error: %v", err)
+	}
+	expected := "<h1>Hi</h1>\n\n<p><a rel=\"nofollow noopener noreferrer\">x</a></p>\n"
+	if rendered != expected {
+		t.Errorf("Expected sanitized HTML %q, got %q", expected, rendered)
+	}
+}
+
+func TestContentUsecase_UpdateTextContent_SwitchesToPlainText(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	id, _ := usecase.CreateTextContent("n1", "user-1", "", "**bold**", contentuc.MarkdownFormat)
+
+	if err := usecase.UpdateTextContent(id, "user-1", "**<b>not</b>**\nbold", "plain", 0); err != nil {
+		t.Fatalf("UpdateTextContent() returned an unexpected error: %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Format != "" {
+		t.Errorf("Expected plain text to have no format, got '%s'", dto.Format)
+	}
+	rendered, _ := usecase.RenderContent(id)
+	if expected := "<p>**&lt;b&gt;not&lt;/b&gt;**<br>\nbold</p>\n"; rendered != expected {
+		t.Errorf("Expected escaped text %q, got %q", expected, rendered)
+	}
+}
+
+func TestContentUsecase_TextContent_Errors(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	codeID, _ := usecase.CreateCodeContent("n1", "user-1", "", "x", "go")
+
+	if _, err := usecase.CreateTextContent("n1", "user-1", "", "x", "rst"); err != contentuc.ErrUnsupportedTextFormat {
+		t.Errorf("Expected ErrUnsupportedTextFormat, got %v", err)
+	}
+	if err := usecase.UpdateTextContent(codeID, "user-1", "x", "markdown", 0); err != contentuc.ErrUnsupportedContentType {
+		t.Errorf("Expected ErrUnsupportedContentType, got %v", err)
+	}
+}
+
+func TestContentUsecase_RenderContent_Checklist(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	id, _ := usecase.CreateContent("n1", "user-1", "", `[{"text":"<ship>","checked":true,"assignee":"bob"}]`, contentuc.ChecklistContentType)
+
+	rendered, err := usecase.RenderContent(id)
+	if err != nil {
+		t.Fatalf("RenderContent() returned an unexpected error: %v", err)
+	}
+	expected := "<ul>\n<li><input type=\"checkbox\" checked disabled> &lt;ship&gt; <em>@bob</em></li>\n</ul>\n"
+	if rendered != expected {
+		t.Errorf("Expected %q, got %q", expected, rendered)
+	}
+}
+
+const tableData = `{"columns":[{"id":"plan","name":"Plan"},{"id":"price","name":"Price"}],"rows":[{"id":"r1","cells":{"plan":{"value":"Basic"}}}]}`
+
+func TestContentUsecase_UpdateTableCell_ConflictsOnlyOnSameCell(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	id, err := usecase.CreateContent("n1", "user-1", "", tableData, contentuc.TableContentType)
+	if err != nil {
+		t.Fatalf("CreateContent() returned an unexpected error: %v", err)
+	}
+
+	// Two users edit different cells of version 0.
+	first, err := usecase.UpdateTableCell(id, "user-1", "r1", "plan", "Pro", 0)
+	if err != nil {
+		t.Fatalf("UpdateTableCell() returned an unexpected error: %v", err)
+	}
+	second, err := usecase.UpdateTableCell(id, "user-2", "r1", "price", "10", 0)
+	if err != nil {
+		t.Fatalf("Expected an edit of another cell to succeed, got %v", err)
+	}
+	if first.Version != 1 || second.Version != 2 {
+		t.Errorf("Expected the cells at versions 1 and 2, got %d and %d", first.Version, second.Version)
+	}
+
+	// A third user edits a cell that changed after the version they saw.
+	if _, err := usecase.UpdateTableCell(id, "user-3", "r1", "plan", "Team", 0); err != contentuc.ErrConflict {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+	if _, err := usecase.UpdateTableCell(id, "user-3", "r1", "plan", "Team", 5); err != contentuc.ErrConflict {
+		t.Errorf("Expected ErrConflict for a future version, got %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Version != 2 || dto.LastModifiedBy != "user-2" {
+		t.Errorf("Expected version 2 by user-2, got version %d by %s", dto.Version, dto.LastModifiedBy)
+	}
+	rendered, _ := usecase.RenderContent(id)
+	if !strings.Contains(rendered, "<th>Plan</th><th>Price</th>") || !strings.Contains(rendered, "<td>Pro</td><td>10</td>") {
+		t.Errorf("Expected the rendered table to contain both edits, got %s", rendered)
+	}
+}
+
+// racingContentRepository saves a concurrent change right before the first save it is asked to make.
+type racingContentRepository struct {
+	contentrepo.ContentRepository
+	race func()
+}
+
+func (r *racingContentRepository) Save(po *contentrepo.ContentPO) error {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.ContentRepository.Save(po)
+}
+
+func TestContentUsecase_UpdateTableCell_RetriesConcurrentSave(t *testing.T) {
+	repo := &racingContentRepository{ContentRepository: contentrepo.NewInMemoryContentRepository()}
+	usecase := contentuc.NewContentUsecase(repo)
+	id, _ := usecase.CreateContent("n1", "user-1", "", tableData, contentuc.TableContentType)
+	repo.race = func() {
+		if _, err := usecase.UpdateTableCell(id, "user-2", "r1", "price", "10", 0); err != nil {
+			t.Fatalf("Concurrent UpdateTableCell() returned an unexpected error: %v", err)
+		}
+	}
+
+	cell, err := usecase.UpdateTableCell(id, "user-1", "r1", "plan", "Pro", 0)
+
+	if err != nil {
+		t.Fatalf("Expected the edit to be retried, got %v", err)
+	}
+	if cell.Version != 2 {
+		t.Errorf("Expected the retried edit at version 2, got %d", cell.Version)
+	}
+	rendered, _ := usecase.RenderContent(id)
+	if !strings.Contains(rendered, "<td>Pro</td><td>10</td>") {
+		t.Errorf("Expected both edits to be kept, got %s", rendered)
+	}
+}
+
+func TestContentUsecase_TableRowsAndColumns(t *testing.T) {
+	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
+	id, _ := usecase.CreateContent("n1", "user-1", "", tableData, contentuc.TableContentType)
+
+	column, err := usecase.InsertTableColumn(id, "user-1", "Seats", -1, 0)
+	if err != nil {
+		t.Fatalf("InsertTableColumn() returned an unexpected error: %v", err)
+	}
+	row, err := usecase.InsertTableRow(id, "user-1", -1, map[string]string{column.ID: "5"}, 1)
+	if err != nil {
+		t.Fatalf("InsertTableRow() returned an unexpected error: %v", err)
+	}
+	if row.Cells[column.ID] != "5" {
+		t.Errorf("Expected the new row to have 5 seats, got %+v", row.Cells)
+	}
+	// Structural changes require the current version.
+	if _, err := usecase.DeleteTableRow(id, "user-1", "r1", 1); err != contentuc.ErrConflict {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+	if _, err := usecase.DeleteTableColumn(id, "user-1", "missing", 2); err != contentuc.ErrTableColumnNotFound {
+		t.Errorf("Expected ErrTableColumnNotFound, got %v", err)
+	}
+	if _, err := usecase.DeleteTableRow(id, "user-1", "r1", 2); err != nil {
+		t.Fatalf("DeleteTableRow() returned an unexpected error: %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID(id)
+	if dto.Version != 3 || strings.Contains(dto.Data, "Basic") {
+		t.Errorf("Expected version 3 without the deleted row, got version %d: %s", dto.Version, dto.Data)
+	}
+}
+
 func TestContentUsecase_GetContentByID(t *testing.T) {
 	repo := contentrepo.NewInMemoryContentRepository()
 	usecase := contentuc.NewContentUsecase(repo)
@@ -95,7 +493,7 @@ func TestContentUsecase_GetContentByID(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	id, _ := usecase.CreateContent(noteID, "", data, contentType)
+	id, _ := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 
 	t.Run("should get content by id", func(t *testing.T) {
 		dto, err := usecase.GetContentByID(id)
@@ -132,10 +530,10 @@ func TestContentUsecase_UpdateContent(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	id, _ := usecase.CreateContent(noteID, "", data, contentType)
+	id, _ := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 
 	updatedData := "Updated data"
-	err := usecase.UpdateContent(id, updatedData, 0)
+	err := usecase.UpdateContent(id, "user-1", updatedData, 0)
 	if err != nil {
 		t.Fatalf("UpdateContent() returned an unexpected error: %v", err)
 	}
@@ -155,7 +553,7 @@ func TestContentUsecase_UpdateContent(t *testing.T) {
 func TestContentUsecase_UpdateContent_NotFound(t *testing.T) {
 	usecase := contentuc.NewContentUsecase(contentrepo.NewInMemoryContentRepository())
 
-	err := usecase.UpdateContent("non-existent-id", "updated data", 0)
+	err := usecase.UpdateContent("non-existent-id", "user-1", "updated data", 0)
 	if err != contentuc.ErrContentNotFound {
 		t.Errorf("Expected error to be '%v', but got '%v'", contentuc.ErrContentNotFound, err)
 	}
@@ -168,15 +566,27 @@ func TestContentUsecase_UpdateContent_Conflict(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	id, _ := usecase.CreateContent(noteID, "", data, contentType)
+	id, _ := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 
 	// Attempt to update with an incorrect version
-	err := usecase.UpdateContent(id, "updated data", 99)
+	err := usecase.UpdateContent(id, "user-1", "updated data", 99)
 	if err != contentuc.ErrConflict {
 		t.Errorf("Expected error to be '%v', but got '%v'", contentuc.ErrConflict, err)
 	}
 }
 
+func TestContentUsecase_UpdateContent_ImageNotEditable(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	contentID, _ := usecase.CreateContent("n1", "user-1", "", "image-hash", contentuc.ImageContentType)
+
+	err := usecase.UpdateContent(contentID, "user-1", "other-hash", 0)
+
+	if err != contentuc.ErrContentNotEditable {
+		t.Errorf("Expected ErrContentNotEditable, got %v", err)
+	}
+}
+
 func TestContentUsecase_DeleteContent(t *testing.T) {
 	repo := contentrepo.NewInMemoryContentRepository()
 	usecase := contentuc.NewContentUsecase(repo)
@@ -184,7 +594,7 @@ func TestContentUsecase_DeleteContent(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	id, _ := usecase.CreateContent(noteID, "", data, contentType)
+	id, _ := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 
 	err := usecase.DeleteContent(id, 0)
 	if err != nil {
@@ -213,7 +623,7 @@ func TestContentUsecase_DeleteContent_Conflict(t *testing.T) {
 	data := "Test content"
 	contentType := contentuc.TextContentType
 
-	id, _ := usecase.CreateContent(noteID, "", data, contentType)
+	id, _ := usecase.CreateContent(noteID, "user-1", "", data, contentType)
 
 	err := usecase.DeleteContent(id, 99)
 	if err != contentuc.ErrConflict {
@@ -227,9 +637,9 @@ func TestContentUsecase_DeleteAllContentsByNoteID(t *testing.T) {
 	uc := contentuc.NewContentUsecase(repo)
 	noteID1 := "note-1"
 	noteID2 := "note-2"
-	uc.CreateContent(noteID1, "content-1", "Data 1", contentuc.TextContentType)
-	uc.CreateContent(noteID1, "content-2", "Data 2", contentuc.TextContentType)
-	uc.CreateContent(noteID2, "content-3", "Data 3", contentuc.TextContentType)
+	uc.CreateContent(noteID1, "user-1", "content-1", "Data 1", contentuc.TextContentType)
+	uc.CreateContent(noteID1, "user-1", "content-2", "Data 2", contentuc.TextContentType)
+	uc.CreateContent(noteID2, "user-1", "content-3", "Data 3", contentuc.TextContentType)
 
 	// Act
 	err := uc.DeleteAllContentsByNoteID(noteID1)
@@ -249,3 +659,160 @@ func TestContentUsecase_DeleteAllContentsByNoteID(t *testing.T) {
 		t.Errorf("Expected 1 content for noteID2, got %d", len(contents2))
 	}
 }
+
+func TestContentUsecase_TracksTimestampsAndEditor(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(start)
+	usecase := contentuc.NewContentUsecaseWithClock(contentrepo.NewInMemoryContentRepository(), fakeClock)
+	id, _ := usecase.CreateContent("n1", "user-1", "", "data", contentuc.TextContentType)
+
+	// Act
+	fakeClock.Advance(time.Minute)
+	err := usecase.UpdateContent(id, "user-2", "updated data", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateContent() returned an unexpected error: %v", err)
+	}
+	dto, _ := usecase.GetContentByID(id)
+	if !dto.CreatedAt.Equal(start) {
+		t.Errorf("Expected CreatedAt to be %v, but got %v", start, dto.CreatedAt)
+	}
+	if !dto.UpdatedAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected UpdatedAt to be %v, but got %v", start.Add(time.Minute), dto.UpdatedAt)
+	}
+	if dto.LastModifiedBy != "user-2" {
+		t.Errorf("Expected LastModifiedBy to be 'user-2', but got '%s'", dto.LastModifiedBy)
+	}
+}
+
+func TestContentUsecase_MoveContent(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	usecase.CreateContent("n1", "user-1", "c1", "Moving text", contentuc.TextContentType)
+
+	if err := usecase.MoveContent("c1", "n2", "user-2", 1); err != contentuc.ErrConflict {
+		t.Errorf("Expected ErrConflict for a stale version, got %v", err)
+	}
+	if err := usecase.MoveContent("c1", "n2", "user-2", 0); err != nil {
+		t.Fatalf("MoveContent() returned an unexpected error: %v", err)
+	}
+
+	dto, _ := usecase.GetContentByID("c1")
+	if dto.NoteID != "n2" || dto.Version != 1 || dto.LastModifiedBy != "user-2" {
+		t.Errorf("Expected the content in n2 at version 1 moved by user-2, got %+v", dto)
+	}
+	if contents, _ := usecase.GetContentsByNoteID("n1"); len(contents) != 0 {
+		t.Errorf("Expected no contents left in n1, got %d", len(contents))
+	}
+}
+
+func TestContentUsecase_CopyContent(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	usecase.CreateCodeContent("n1", "user-1", "c1", "fmt.Println()", "go")
+
+	copyID, err := usecase.CopyContent("c1", "n2", "user-2")
+	if err != nil {
+		t.Fatalf("CopyContent() returned an unexpected error: %v", err)
+	}
+
+	copied, _ := usecase.GetContentByID(copyID)
+	if copyID == "c1" || copied.NoteID != "n2" || copied.Version != 0 {
+		t.Errorf("Expected a new version 0 content in n2, got %+v", copied)
+	}
+	if copied.Data != "fmt.Println()" || copied.Language != "go" || copied.LastModifiedBy != "user-2" {
+		t.Errorf("Expected a copy of the Go code by user-2, got %+v", copied)
+	}
+	if _, err := usecase.CopyContent("missing", "n2", "user-2"); err != contentuc.ErrContentNotFound {
+		t.Errorf("Expected ErrContentNotFound, got %v", err)
+	}
+}
+
+func TestContentUsecase_CopyContent_AttachmentCountsAgainstCopier(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecaseWithStorageQuota(repo, clock.NewSystemClock(), 100)
+	attachment := contentuc.AttachmentMetadataDTO{Filename: "report.pdf", Size: 60, MimeType: "application/pdf"}
+	id, _ := usecase.CreateAttachmentContent("n1", "user-1", "", "file-hash", attachment)
+
+	if _, err := usecase.CopyContent(id, "n2", "user-2"); err != nil {
+		t.Fatalf("CopyContent() returned an unexpected error: %v", err)
+	}
+	if usage, _ := usecase.StorageUsage("user-2"); usage != 60 {
+		t.Errorf("Expected the copy to count against user-2, got a usage of %d", usage)
+	}
+	if _, err := usecase.CopyContent(id, "n2", "user-1"); err != contentuc.ErrStorageQuotaExceeded {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", err)
+	}
+}
+
+func TestContentUsecase_CopyContent_ConcurrentAttachmentCopiesStayWithinQuota(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecaseWithStorageQuota(repo, clock.NewSystemClock(), 100)
+	attachment := contentuc.AttachmentMetadataDTO{Filename: "report.pdf", Size: 30, MimeType: "application/pdf"}
+	id, _ := usecase.CreateAttachmentContent("n1", "user-1", "", "file-hash", attachment)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			usecase.CopyContent(id, "n2", "user-2")
+		}()
+	}
+	wg.Wait()
+
+	if usage, _ := usecase.StorageUsage("user-2"); usage != 90 {
+		t.Errorf("Expected exactly three copies to fit in the quota, got a usage of %d", usage)
+	}
+}
+
+func TestContentUsecase_CopyContents_AllOrNone(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecaseWithStorageQuota(repo, clock.NewSystemClock(), 100)
+	usecase.CreateContent("n1", "user-1", "c1", "Some text", contentuc.TextContentType)
+	attachment := contentuc.AttachmentMetadataDTO{Filename: "report.pdf", Size: 60, MimeType: "application/pdf"}
+	usecase.CreateAttachmentContent("n1", "user-1", "c2", "file-hash", attachment)
+
+	copyIDs, err := usecase.CopyContents([]string{"c1", "c2"}, "n2", "user-2")
+	if err != nil {
+		t.Fatalf("CopyContents() returned an unexpected error: %v", err)
+	}
+	contents, _ := usecase.GetContentsByNoteID("n2")
+	if len(copyIDs) != 2 || len(contents) != 2 {
+		t.Fatalf("Expected two copies in n2, got %v and %d contents", copyIDs, len(contents))
+	}
+	if first, _ := usecase.GetContentByID(copyIDs[0]); first.Data != "Some text" {
+		t.Errorf("Expected the copies in order, got %+v first", first)
+	}
+
+	if _, err := usecase.CopyContents([]string{"c1", "c2"}, "n3", "user-2"); err != contentuc.ErrStorageQuotaExceeded {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", err)
+	}
+	if contents, _ := usecase.GetContentsByNoteID("n3"); len(contents) != 0 {
+		t.Errorf("Expected no copies left in n3, got %d", len(contents))
+	}
+}
+
+func TestContentUsecase_MoveContents(t *testing.T) {
+	repo := contentrepo.NewInMemoryContentRepository()
+	usecase := contentuc.NewContentUsecase(repo)
+	usecase.CreateContent("n1", "user-1", "c1", "First", contentuc.TextContentType)
+	usecase.CreateContent("n2", "user-1", "c2", "Second", contentuc.TextContentType)
+	usecase.UpdateContent("c2", "user-1", "Second changed", 0)
+
+	if err := usecase.MoveContents([]string{"c1", "missing", "c2"}, "n3", "user-2"); err != nil {
+		t.Fatalf("MoveContents() returned an unexpected error: %v", err)
+	}
+
+	contents, _ := usecase.GetContentsByNoteID("n3")
+	if len(contents) != 2 {
+		t.Fatalf("Expected both contents in n3, got %d", len(contents))
+	}
+	for _, c := range contents {
+		if c.LastModifiedBy != "user-2" {
+			t.Errorf("Expected %s to be moved by user-2, got %+v", c.ID, c)
+		}
+	}
+}
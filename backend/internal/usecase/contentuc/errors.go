@@ -13,3 +13,48 @@ var ErrContentNotFound = errors.New("content not found")
 
 // ErrConflict is returned when a version conflict occurs.
 var ErrConflict = errors.New("conflict")
+
+// ErrContentNotEditable is returned when updating a content whose type cannot be edited.
+var ErrContentNotEditable = errors.New("content cannot be edited")
+
+// ErrEmptyFilename is returned when an attachment has no filename.
+var ErrEmptyFilename = errors.New("attachment filename cannot be empty")
+
+// ErrStorageQuotaExceeded is returned when an attachment would exceed the uploader's storage quota.
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// ErrNotAChecklist is returned when a checklist operation targets another content type.
+var ErrNotAChecklist = errors.New("content is not a checklist")
+
+// ErrInvalidChecklist is returned when checklist data is not a valid list of items.
+var ErrInvalidChecklist = errors.New("invalid checklist data")
+
+// ErrEmptyChecklistItem is returned when a checklist item has no text.
+var ErrEmptyChecklistItem = errors.New("checklist item text cannot be empty")
+
+// ErrChecklistItemNotFound is returned when a checklist item is not found.
+var ErrChecklistItemNotFound = errors.New("checklist item not found")
+
+// ErrIndexOutOfBounds is returned when a checklist item is placed outside the list.
+var ErrIndexOutOfBounds = errors.New("index out of bounds")
+
+// ErrNotATable is returned when a table operation targets another content type.
+var ErrNotATable = errors.New("content is not a table")
+
+// ErrInvalidTable is returned when table data is not a valid table.
+var ErrInvalidTable = errors.New("invalid table data")
+
+// ErrTableRowNotFound is returned when a table row is not found.
+var ErrTableRowNotFound = errors.New("table row not found")
+
+// ErrTableColumnNotFound is returned when a table column is not found.
+var ErrTableColumnNotFound = errors.New("table column not found")
+
+// ErrUnsupportedLanguage is returned when a code content uses an unknown programming language.
+var ErrUnsupportedLanguage = errors.New("unsupported programming language")
+
+// ErrRenderNotSupported is returned when rendering a content type that has no HTML rendering.
+var ErrRenderNotSupported = errors.New("content type cannot be rendered")
+
+// ErrUnsupportedTextFormat is returned when a text content uses an unknown format.
+var ErrUnsupportedTextFormat = errors.New("unsupported text format")
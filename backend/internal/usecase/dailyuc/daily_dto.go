@@ -0,0 +1,27 @@
+package dailyuc
+
+import "time"
+
+// SettingsDTO represents a user's daily note settings.
+type SettingsDTO struct {
+	UserID     string `json:"user_id"`
+	TimeZone   string `json:"time_zone"`
+	TemplateID string `json:"template_id"`
+	Version    int    `json:"version"`
+}
+
+// DayDTO describes the daily note a user asks for: its date, the keyword it is tagged with,
+// and the template and time it is created from if it does not exist yet.
+type DayDTO struct {
+	Date       string    `json:"date"`
+	Keyword    string    `json:"keyword"`
+	TemplateID string    `json:"template_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DailyNoteDTO represents the daily note of a user for a date.
+type DailyNoteDTO struct {
+	Date   string `json:"date"`
+	NoteID string `json:"note_id"`
+	Title  string `json:"title"`
+}
@@ -0,0 +1,260 @@
+package dailyuc
+
+import (
+	"errors"
+	"fmt"
+
+	"noteapp/internal/clock"
+	"noteapp/internal/domain/daily"
+	"noteapp/internal/repository/dailyrepo"
+	"noteapp/internal/repository/noterepo"
+)
+
+// DailyUsecase keeps track of the daily notes of each user: one note per date, in the user's
+// time zone. It records which note belongs to which date; creating the notes is left to the
+// caller, which can draft them from the template in the user's settings.
+type DailyUsecase struct {
+	repo     dailyrepo.DailyNoteRepository
+	noteRepo noterepo.NoteRepository
+	clock    clock.Clock
+}
+
+// NewDailyUsecase creates a new DailyUsecase.
+func NewDailyUsecase(repo dailyrepo.DailyNoteRepository, noteRepo noterepo.NoteRepository) *DailyUsecase {
+	return NewDailyUsecaseWithClock(repo, noteRepo, clock.NewSystemClock())
+}
+
+// NewDailyUsecaseWithClock creates a new DailyUsecase that reads the current date from c.
+func NewDailyUsecaseWithClock(repo dailyrepo.DailyNoteRepository, noteRepo noterepo.NoteRepository, c clock.Clock) *DailyUsecase {
+	return &DailyUsecase{repo: repo, noteRepo: noteRepo, clock: c}
+}
+
+// GetSettings retrieves a user's daily note settings, or the defaults at version 0 if the
+// user has not saved any.
+func (uc *DailyUsecase) GetSettings(userID string) (*SettingsDTO, error) {
+	s, err := uc.getSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toSettingsDTO(s), nil
+}
+
+// UpdateSettings changes a user's time zone and the template new daily notes are created from.
+func (uc *DailyUsecase) UpdateSettings(userID, timeZone, templateID string, version int) error {
+	current, err := uc.getSettings(userID)
+	if err != nil {
+		return err
+	}
+	if current.Version != version {
+		return ErrConflict
+	}
+	s, err := daily.NewSettings(userID, timeZone, templateID, version)
+	if err != nil {
+		return mapDomainError(err)
+	}
+
+	po := &dailyrepo.SettingsPO{UserID: s.UserID, TimeZone: s.TimeZone, TemplateID: s.TemplateID, Version: s.Version}
+	if err := uc.repo.SaveSettings(po); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// ResolveDay describes the daily note of a user for a date, which may be "today" for the
+// current date in the user's time zone.
+func (uc *DailyUsecase) ResolveDay(userID, date string) (*DayDTO, error) {
+	s, err := uc.getSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	resolved, err := s.ResolveDate(date, now)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+	createdAt, err := s.CreationTime(resolved, now)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+	return &DayDTO{
+		Date:       resolved,
+		Keyword:    daily.Keyword(resolved),
+		TemplateID: s.TemplateID,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// FindDailyNote retrieves the daily note of a user for a date. A daily note whose note has
+// been deleted is forgotten, so that the date gets a new one.
+func (uc *DailyUsecase) FindDailyNote(userID, date string) (*DailyNoteDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	if err := daily.CheckDate(date); err != nil {
+		return nil, mapDomainError(err)
+	}
+	po, err := uc.repo.FindEntry(userID, date)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	dto, err := uc.toDailyNoteDTO(po)
+	if err != nil {
+		return nil, err
+	}
+	if dto == nil {
+		if err := uc.repo.DeleteEntry(userID, date); err != nil && !errors.Is(err, dailyrepo.ErrEntryNotFound) {
+			return nil, uc.mapRepositoryError(err)
+		}
+		return nil, ErrDailyNoteNotFound
+	}
+	return dto, nil
+}
+
+// RecordDailyNote makes a note the daily note of a user for a date. It fails with
+// ErrDailyNoteExists if another request recorded one first.
+func (uc *DailyUsecase) RecordDailyNote(userID, date, noteID string) error {
+	if userID == "" || noteID == "" {
+		return ErrInvalidID
+	}
+	if err := daily.CheckDate(date); err != nil {
+		return mapDomainError(err)
+	}
+	if err := uc.repo.SaveEntry(&dailyrepo.EntryPO{UserID: userID, Date: date, NoteID: noteID}); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// ListDailyNotes retrieves the daily notes of a user from one date to another, both
+// inclusive and both optional, ordered by date.
+func (uc *DailyUsecase) ListDailyNotes(userID, from, to string) ([]*DailyNoteDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	for _, date := range []string{from, to} {
+		if date == "" {
+			continue
+		}
+		if err := daily.CheckDate(date); err != nil {
+			return nil, mapDomainError(err)
+		}
+	}
+
+	return uc.findDailyNotes(userID, func(date string) bool {
+		return (from == "" || date >= from) && (to == "" || date <= to)
+	}, false)
+}
+
+// PreviousDailyNote retrieves the latest daily note of a user before a date, which may be
+// "today".
+func (uc *DailyUsecase) PreviousDailyNote(userID, date string) (*DailyNoteDTO, error) {
+	day, err := uc.ResolveDay(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	return uc.adjacentDailyNote(userID, func(d string) bool { return d < day.Date }, true)
+}
+
+// NextDailyNote retrieves the earliest daily note of a user after a date, which may be
+// "today".
+func (uc *DailyUsecase) NextDailyNote(userID, date string) (*DailyNoteDTO, error) {
+	day, err := uc.ResolveDay(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	return uc.adjacentDailyNote(userID, func(d string) bool { return d > day.Date }, false)
+}
+
+func (uc *DailyUsecase) adjacentDailyNote(userID string, match func(date string) bool, latest bool) (*DailyNoteDTO, error) {
+	dtos, err := uc.findDailyNotes(userID, match, latest)
+	if err != nil {
+		return nil, err
+	}
+	if len(dtos) == 0 {
+		return nil, ErrDailyNoteNotFound
+	}
+	return dtos[0], nil
+}
+
+// findDailyNotes returns the daily notes of a user on the dates that match, skipping notes
+// that have been deleted, ordered by date or in reverse.
+func (uc *DailyUsecase) findDailyNotes(userID string, match func(date string) bool, reverse bool) ([]*DailyNoteDTO, error) {
+	pos, err := uc.repo.FindEntries(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if reverse {
+		for i, j := 0, len(pos)-1; i < j; i, j = i+1, j-1 {
+			pos[i], pos[j] = pos[j], pos[i]
+		}
+	}
+
+	dtos := []*DailyNoteDTO{}
+	for _, po := range pos {
+		if !match(po.Date) {
+			continue
+		}
+		dto, err := uc.toDailyNoteDTO(po)
+		if err != nil {
+			return nil, err
+		}
+		if dto != nil {
+			dtos = append(dtos, dto)
+		}
+	}
+	return dtos, nil
+}
+
+// toDailyNoteDTO returns the daily note an entry records, or nil if its note has been deleted.
+func (uc *DailyUsecase) toDailyNoteDTO(po *dailyrepo.EntryPO) (*DailyNoteDTO, error) {
+	notePO, err := uc.noteRepo.FindByID(po.NoteID)
+	if errors.Is(err, noterepo.ErrNoteNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+	return &DailyNoteDTO{Date: po.Date, NoteID: po.NoteID, Title: notePO.Title}, nil
+}
+
+func (uc *DailyUsecase) getSettings(userID string) (*daily.Settings, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	po, err := uc.repo.FindSettings(userID)
+	if errors.Is(err, dailyrepo.ErrSettingsNotFound) {
+		return daily.NewSettings(userID, "", "", 0)
+	}
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return &daily.Settings{UserID: po.UserID, TimeZone: po.TimeZone, TemplateID: po.TemplateID, Version: po.Version}, nil
+}
+
+func toSettingsDTO(s *daily.Settings) *SettingsDTO {
+	return &SettingsDTO{UserID: s.UserID, TimeZone: s.TimeZone, TemplateID: s.TemplateID, Version: s.Version}
+}
+
+func (uc *DailyUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, dailyrepo.ErrEntryNotFound):
+		return ErrDailyNoteNotFound
+	case errors.Is(err, dailyrepo.ErrEntryExists):
+		return ErrDailyNoteExists
+	case errors.Is(err, dailyrepo.ErrSettingsConflict):
+		return ErrConflict
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
+
+func mapDomainError(err error) error {
+	switch {
+	case errors.Is(err, daily.ErrInvalidDate):
+		return ErrInvalidDate
+	case errors.Is(err, daily.ErrInvalidTimeZone):
+		return ErrInvalidTimeZone
+	default:
+		return fmt.Errorf("an unexpected domain error occurred: %w", err)
+	}
+}
@@ -0,0 +1,149 @@
+package dailyuc_test
+
+import (
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/dailyrepo"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/usecase/dailyuc"
+	"noteapp/internal/usecase/noteuc"
+	"testing"
+	"time"
+)
+
+func setUpDailyUsecase() (*dailyuc.DailyUsecase, *noteuc.NoteUsecase, *clock.FakeClock) {
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 20, 15, 0, 0, time.UTC))
+	return dailyuc.NewDailyUsecaseWithClock(dailyrepo.NewInMemoryDailyNoteRepository(), noteRepo, c), noteuc.NewNoteUsecase(noteRepo), c
+}
+
+func TestDailyUsecase_Settings(t *testing.T) {
+	usecase, _, _ := setUpDailyUsecase()
+
+	settings, err := usecase.GetSettings("user-1")
+	if err != nil {
+		t.Fatalf("GetSettings returned an unexpected error: %v", err)
+	}
+	if settings.TimeZone != "UTC" || settings.TemplateID != "" || settings.Version != 0 {
+		t.Errorf("Expected the default settings at version 0, got %+v", settings)
+	}
+
+	if err := usecase.UpdateSettings("user-1", "Mars/Olympus_Mons", "", 0); err != dailyuc.ErrInvalidTimeZone {
+		t.Errorf("Expected error '%v', got '%v'", dailyuc.ErrInvalidTimeZone, err)
+	}
+	if err := usecase.UpdateSettings("user-1", "Asia/Tokyo", "template-1", 0); err != nil {
+		t.Fatalf("UpdateSettings returned an unexpected error: %v", err)
+	}
+	if err := usecase.UpdateSettings("user-1", "UTC", "", 0); err != nil {
+		t.Fatalf("UpdateSettings returned an unexpected error: %v", err)
+	}
+	if err := usecase.UpdateSettings("user-1", "UTC", "", 0); err != dailyuc.ErrConflict {
+		t.Errorf("Expected error '%v', got '%v'", dailyuc.ErrConflict, err)
+	}
+	if settings, _ := usecase.GetSettings("user-1"); settings.TimeZone != "UTC" || settings.Version != 1 {
+		t.Errorf("Expected the updated settings at version 1, got %+v", settings)
+	}
+}
+
+func TestDailyUsecase_ResolveDay(t *testing.T) {
+	// Arrange
+	usecase, _, c := setUpDailyUsecase()
+	usecase.UpdateSettings("user-1", "Asia/Tokyo", "template-1", 0)
+
+	// Act
+	day, err := usecase.ResolveDay("user-1", "today")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ResolveDay returned an unexpected error: %v", err)
+	}
+	if day.Date != "2024-03-05" || day.Keyword != "daily/2024-03-05" || day.TemplateID != "template-1" {
+		t.Errorf("Expected tomorrow's date in Tokyo with the user's template, got %+v", day)
+	}
+	if got := day.CreatedAt.Format("2006-01-02 15:04"); got != "2024-03-05 05:15" {
+		t.Errorf("Expected creation at '2024-03-05 05:15', got '%s'", got)
+	}
+
+	c.Advance(-12 * time.Hour)
+	if day, _ := usecase.ResolveDay("user-1", "today"); day.Date != "2024-03-04" {
+		t.Errorf("Expected the date to follow the clock, got %+v", day)
+	}
+	if _, err := usecase.ResolveDay("user-1", "2024-13-01"); err != dailyuc.ErrInvalidDate {
+		t.Errorf("Expected error '%v', got '%v'", dailyuc.ErrInvalidDate, err)
+	}
+}
+
+func TestDailyUsecase_RecordAndFindDailyNote(t *testing.T) {
+	usecase, nuc, _ := setUpDailyUsecase()
+	noteID, _ := nuc.CreateNote("", "2024-03-04", "user-1")
+
+	if _, err := usecase.FindDailyNote("user-1", "2024-03-04"); err != dailyuc.ErrDailyNoteNotFound {
+		t.Errorf("Expected error '%v', got '%v'", dailyuc.ErrDailyNoteNotFound, err)
+	}
+	if err := usecase.RecordDailyNote("user-1", "2024-03-04", noteID); err != nil {
+		t.Fatalf("RecordDailyNote returned an unexpected error: %v", err)
+	}
+	if err := usecase.RecordDailyNote("user-1", "2024-03-04", "other-note"); err != dailyuc.ErrDailyNoteExists {
+		t.Errorf("Expected error '%v', got '%v'", dailyuc.ErrDailyNoteExists, err)
+	}
+	found, err := usecase.FindDailyNote("user-1", "2024-03-04")
+	if err != nil {
+		t.Fatalf("FindDailyNote returned an unexpected error: %v", err)
+	}
+	if found.NoteID != noteID || found.Title != "2024-03-04" {
+		t.Errorf("Expected the recorded note, got %+v", found)
+	}
+
+	nuc.DeleteNote(noteID, 0)
+	if _, err := usecase.FindDailyNote("user-1", "2024-03-04"); err != dailyuc.ErrDailyNoteNotFound {
+		t.Errorf("Expected error '%v' once the note is deleted, got '%v'", dailyuc.ErrDailyNoteNotFound, err)
+	}
+	if err := usecase.RecordDailyNote("user-1", "2024-03-04", "new-note"); err != nil {
+		t.Errorf("Expected the date to take a new daily note, got '%v'", err)
+	}
+}
+
+func TestDailyUsecase_ListAndNavigateDailyNotes(t *testing.T) {
+	usecase, nuc, _ := setUpDailyUsecase()
+	dates := []string{"2024-03-01", "2024-03-02", "2024-03-04", "2024-03-07"}
+	ids := map[string]string{}
+	for _, date := range dates {
+		ids[date], _ = nuc.CreateNote("", date, "user-1")
+		usecase.RecordDailyNote("user-1", date, ids[date])
+	}
+	nuc.DeleteNote(ids["2024-03-02"], 0)
+
+	notes, err := usecase.ListDailyNotes("user-1", "2024-03-02", "")
+	if err != nil {
+		t.Fatalf("ListDailyNotes returned an unexpected error: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Date != "2024-03-04" || notes[1].Date != "2024-03-07" {
+		t.Errorf("Expected the daily notes from 2024-03-02 without the deleted one, got %+v", notes)
+	}
+
+	tests := []struct {
+		name     string
+		navigate func(userID, date string) (*dailyuc.DailyNoteDTO, error)
+		date     string
+		want     string
+		wantErr  error
+	}{
+		{"previous skips deleted notes", usecase.PreviousDailyNote, "2024-03-04", "2024-03-01", nil},
+		{"previous of today", usecase.PreviousDailyNote, "today", "2024-03-01", nil},
+		{"next of today", usecase.NextDailyNote, "today", "2024-03-07", nil},
+		{"next from a date without a note", usecase.NextDailyNote, "2024-03-05", "2024-03-07", nil},
+		{"nothing after the last note", usecase.NextDailyNote, "2024-03-07", "", dailyuc.ErrDailyNoteNotFound},
+		{"nothing before the first note", usecase.PreviousDailyNote, "2024-03-01", "", dailyuc.ErrDailyNoteNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.navigate("user-1", tt.date)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && (got.Date != tt.want || got.NoteID != ids[tt.want]) {
+				t.Errorf("Expected the daily note of %s, got %+v", tt.want, got)
+			}
+		})
+	}
+}
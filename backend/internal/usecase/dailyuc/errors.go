@@ -0,0 +1,21 @@
+package dailyuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrInvalidDate is returned when a date is neither "today" nor formatted as YYYY-MM-DD.
+var ErrInvalidDate = errors.New("date must be 'today' or formatted as YYYY-MM-DD")
+
+// ErrInvalidTimeZone is returned when a time zone is not a known IANA time zone name.
+var ErrInvalidTimeZone = errors.New("unknown time zone")
+
+// ErrDailyNoteNotFound is returned when a user has no daily note for a date.
+var ErrDailyNoteNotFound = errors.New("daily note not found")
+
+// ErrDailyNoteExists is returned when a daily note is recorded for a date that already has one.
+var ErrDailyNoteExists = errors.New("daily note already exists")
+
+// ErrConflict is returned when a version conflict occurs.
+var ErrConflict = errors.New("conflict")
@@ -0,0 +1,18 @@
+package discoveryuc
+
+// KeywordSuggestionDTO represents a keyword proposed for a note from its contents.
+// Existing is true when the user already uses the keyword on other notes.
+type KeywordSuggestionDTO struct {
+	Keyword  string  `json:"keyword"`
+	Score    float64 `json:"score"`
+	Existing bool    `json:"existing"`
+}
+
+// RelatedNoteDTO represents a note related to another note, with the combined similarity
+// score and the keywords the user has applied to both notes.
+type RelatedNoteDTO struct {
+	NoteID         string   `json:"note_id"`
+	Title          string   `json:"title"`
+	Score          float64  `json:"score"`
+	SharedKeywords []string `json:"shared_keywords"`
+}
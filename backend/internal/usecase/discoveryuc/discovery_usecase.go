@@ -0,0 +1,337 @@
+package discoveryuc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"noteapp/internal/domain/content"
+	domainnote "noteapp/internal/domain/note"
+	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/noterepo"
+)
+
+// DefaultSuggestionLimit is the number of keyword suggestions returned when no limit is given.
+const DefaultSuggestionLimit = 5
+
+// DefaultRelatedNotesLimit is the number of related notes returned when no limit is given.
+const DefaultRelatedNotesLimit = 10
+
+// keywordWeight and textWeight balance the user's own keywords against text similarity
+// when ranking related notes.
+const (
+	keywordWeight = 0.5
+	textWeight    = 0.5
+)
+
+// existingKeywordBoost multiplies the score of keywords the user already uses elsewhere,
+// so suggestions steer towards a consistent personal vocabulary.
+const existingKeywordBoost = 2.0
+
+// DiscoveryUsecase helps users rediscover and organize notes by analysing their contents.
+type DiscoveryUsecase struct {
+	noteRepo    noterepo.NoteRepository
+	contentRepo contentrepo.ContentRepository
+}
+
+// NewDiscoveryUsecase creates a new DiscoveryUsecase.
+func NewDiscoveryUsecase(noteRepo noterepo.NoteRepository, contentRepo contentrepo.ContentRepository) *DiscoveryUsecase {
+	return &DiscoveryUsecase{noteRepo: noteRepo, contentRepo: contentRepo}
+}
+
+// SuggestKeywordsForNote proposes keywords for a note by scoring its text with TF-IDF
+// against all notes accessible to the user. Keywords the user already uses on other
+// notes are favoured, and keywords already on the note are never suggested.
+// A non-positive limit falls back to DefaultSuggestionLimit.
+func (uc *DiscoveryUsecase) SuggestKeywordsForNote(noteID, userID string, limit int) ([]*KeywordSuggestionDTO, error) {
+	if noteID == "" || userID == "" {
+		return nil, ErrInvalidID
+	}
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+
+	target, err := uc.getAccessibleNote(noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, documents, err := uc.accessibleDocuments(userID)
+	if err != nil {
+		return nil, err
+	}
+	targetFrequencies, ok := documents[target.ID]
+	if !ok {
+		return []*KeywordSuggestionDTO{}, nil
+	}
+	scores := newCorpusFromDocuments(documents).tfidf(targetFrequencies)
+
+	// Terms already expressed by a keyword on the note, or by a suggested existing
+	// keyword, are not suggested again as raw terms.
+	alreadyTagged := make(map[string]bool)
+	coveredTerms := make(map[string]bool)
+	for _, keyword := range target.Keywords[userID] {
+		alreadyTagged[keyword] = true
+		for _, term := range leafTerms(keyword) {
+			coveredTerms[term] = true
+		}
+	}
+
+	existingKeywords, err := uc.userKeywords(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make(map[string]*KeywordSuggestionDTO)
+	for _, keyword := range existingKeywords {
+		if alreadyTagged[keyword] {
+			continue
+		}
+		score, terms := scoreKeyword(keyword, scores)
+		if score == 0 {
+			continue
+		}
+		suggestions[keyword] = &KeywordSuggestionDTO{Keyword: keyword, Score: score * existingKeywordBoost, Existing: true}
+		for _, term := range terms {
+			coveredTerms[term] = true
+		}
+	}
+	for term, score := range scores {
+		if coveredTerms[term] || suggestions[term] != nil {
+			continue
+		}
+		suggestions[term] = &KeywordSuggestionDTO{Keyword: term, Score: score}
+	}
+
+	return rankSuggestions(suggestions, limit), nil
+}
+
+// FindRelatedNotes ranks the user's other accessible notes by their similarity to a note.
+// Similarity combines the overlap of the user's keywords, where a hierarchical keyword
+// also counts its ancestors, with the cosine similarity of the notes' TF-IDF vectors.
+// Notes with no similarity are omitted. A non-positive limit falls back to DefaultRelatedNotesLimit.
+func (uc *DiscoveryUsecase) FindRelatedNotes(noteID, userID string, limit int) ([]*RelatedNoteDTO, error) {
+	if noteID == "" || userID == "" {
+		return nil, ErrInvalidID
+	}
+	if limit <= 0 {
+		limit = DefaultRelatedNotesLimit
+	}
+
+	target, err := uc.getAccessibleNote(noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessible, documents, err := uc.accessibleDocuments(userID)
+	if err != nil {
+		return nil, err
+	}
+	c := newCorpusFromDocuments(documents)
+	targetVector := c.tfidf(documents[target.ID])
+	targetKeywords := expandKeywords(target.Keywords[userID])
+
+	related := []*RelatedNoteDTO{}
+	for _, notePO := range accessible {
+		if notePO.ID == target.ID {
+			continue
+		}
+		keywords := expandKeywords(notePO.Keywords[userID])
+		score := keywordWeight*jaccardSimilarity(targetKeywords, keywords) +
+			textWeight*cosineSimilarity(targetVector, c.tfidf(documents[notePO.ID]))
+		if score == 0 {
+			continue
+		}
+		related = append(related, &RelatedNoteDTO{
+			NoteID:         notePO.ID,
+			Title:          notePO.Title,
+			Score:          score,
+			SharedKeywords: sharedKeywords(target.Keywords[userID], notePO.Keywords[userID]),
+		})
+	}
+
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].NoteID < related[j].NoteID
+	})
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
+
+// expandKeywords returns the set of keywords together with all of their ancestor paths,
+// so that "cs/os/concurrency" and "cs/os/scheduling" partially overlap.
+func expandKeywords(keywords []string) map[string]bool {
+	expanded := make(map[string]bool)
+	for _, keyword := range keywords {
+		segments := strings.Split(keyword, domainnote.KeywordPathSeparator)
+		for i := range segments {
+			expanded[strings.Join(segments[:i+1], domainnote.KeywordPathSeparator)] = true
+		}
+	}
+	return expanded
+}
+
+// sharedKeywords returns the sorted keywords present in both lists.
+func sharedKeywords(a, b []string) []string {
+	inA := make(map[string]bool)
+	for _, keyword := range a {
+		inA[keyword] = true
+	}
+	shared := []string{}
+	seen := make(map[string]bool)
+	for _, keyword := range b {
+		if inA[keyword] && !seen[keyword] {
+			seen[keyword] = true
+			shared = append(shared, keyword)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// scoreKeyword scores an existing keyword against a note's term scores using the leaf
+// segment of the keyword. Every term of the leaf must occur in the note.
+func scoreKeyword(keyword string, scores map[string]float64) (float64, []string) {
+	terms := leafTerms(keyword)
+	if len(terms) == 0 {
+		return 0, nil
+	}
+
+	total := 0.0
+	for _, term := range terms {
+		score, ok := scores[term]
+		if !ok {
+			return 0, nil
+		}
+		total += score
+	}
+	return total / float64(len(terms)), terms
+}
+
+// leafTerms tokenizes the last segment of a possibly hierarchical keyword.
+func leafTerms(keyword string) []string {
+	segments := strings.Split(keyword, domainnote.KeywordPathSeparator)
+	return tokenize(segments[len(segments)-1])
+}
+
+func rankSuggestions(suggestions map[string]*KeywordSuggestionDTO, limit int) []*KeywordSuggestionDTO {
+	ranked := make([]*KeywordSuggestionDTO, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		ranked = append(ranked, suggestion)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Keyword < ranked[j].Keyword
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// userKeywords returns the distinct keywords a user has applied to any note.
+func (uc *DiscoveryUsecase) userKeywords(userID string) ([]string, error) {
+	notePOs, err := uc.noteRepo.FindTaggedByUser(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	seen := make(map[string]bool)
+	var keywords []string
+	for _, notePO := range notePOs {
+		for _, keyword := range notePO.Keywords[userID] {
+			if !seen[keyword] {
+				seen[keyword] = true
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+	sort.Strings(keywords)
+	return keywords, nil
+}
+
+// accessibleDocuments loads the notes accessible to a user along with the term frequencies
+// of each note, keyed by note ID.
+func (uc *DiscoveryUsecase) accessibleDocuments(userID string) ([]*noterepo.NotePO, map[string]map[string]int, error) {
+	accessible, err := uc.noteRepo.GetAccessibleNotesByUserID(userID)
+	if err != nil {
+		return nil, nil, uc.mapRepositoryError(err)
+	}
+
+	documents := make(map[string]map[string]int, len(accessible))
+	for _, notePO := range accessible {
+		frequencies, err := uc.noteTermFrequencies(notePO)
+		if err != nil {
+			return nil, nil, err
+		}
+		documents[notePO.ID] = frequencies
+	}
+	return accessible, documents, nil
+}
+
+// newCorpusFromDocuments builds a corpus from term frequencies keyed by note ID.
+func newCorpusFromDocuments(documents map[string]map[string]int) *corpus {
+	frequencies := make([]map[string]int, 0, len(documents))
+	for _, document := range documents {
+		frequencies = append(frequencies, document)
+	}
+	return newCorpus(frequencies)
+}
+
+// noteTermFrequencies counts the terms in a note's title and text contents.
+func (uc *DiscoveryUsecase) noteTermFrequencies(notePO *noterepo.NotePO) (map[string]int, error) {
+	text, err := uc.noteText(notePO)
+	if err != nil {
+		return nil, err
+	}
+	return termFrequencies(tokenize(text)), nil
+}
+
+// noteText concatenates a note's title with the data of its text contents.
+func (uc *DiscoveryUsecase) noteText(notePO *noterepo.NotePO) (string, error) {
+	contentPOs, err := uc.contentRepo.GetAllByNoteID(notePO.ID)
+	if err != nil {
+		return "", fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(notePO.Title)
+	for _, contentPO := range contentPOs {
+		if contentPO.Type != string(content.TextContentType) {
+			continue
+		}
+		builder.WriteString("\n")
+		builder.WriteString(contentPO.Data)
+	}
+	return builder.String(), nil
+}
+
+// getAccessibleNote loads a note and checks that the user owns it or is a collaborator.
+func (uc *DiscoveryUsecase) getAccessibleNote(noteID, userID string) (*noterepo.NotePO, error) {
+	notePO, err := uc.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if notePO.OwnerID != userID {
+		if _, ok := notePO.Collaborators[userID]; !ok {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return notePO, nil
+}
+
+func (uc *DiscoveryUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, noterepo.ErrNoteNotFound):
+		return ErrNoteNotFound
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
@@ -0,0 +1,152 @@
+package discoveryuc_test
+
+import (
+	"errors"
+	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/usecase/discoveryuc"
+	"testing"
+)
+
+func setUpDiscovery() (*noterepo.InMemoryNoteRepository, *contentrepo.InMemoryContentRepository, *discoveryuc.DiscoveryUsecase) {
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	contentRepo := contentrepo.NewInMemoryContentRepository()
+	return noteRepo, contentRepo, discoveryuc.NewDiscoveryUsecase(noteRepo, contentRepo)
+}
+
+func saveNote(noteRepo *noterepo.InMemoryNoteRepository, contentRepo *contentrepo.InMemoryContentRepository, note *noterepo.NotePO, texts ...string) {
+	for i, text := range texts {
+		contentID := note.ID + "-c" + string(rune('0'+i))
+		contentRepo.Save(&contentrepo.ContentPO{ID: contentID, NoteID: note.ID, Data: text, Type: "text"})
+		note.ContentIDs = append(note.ContentIDs, contentID)
+	}
+	noteRepo.Save(note)
+}
+
+func TestDiscoveryUsecase_SuggestKeywordsForNote(t *testing.T) {
+	// Arrange
+	noteRepo, contentRepo, uc := setUpDiscovery()
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Locks"},
+		"A mutex protects shared memory. Every mutex must be unlocked, a mutex is cheap.", "Deadlock happens.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n2", OwnerID: "user-1", Title: "Networks", Keywords: map[string][]string{"user-1": {"cs/os/deadlock"}}},
+		"Sockets and shared memory.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n3", OwnerID: "user-1", Title: "Memory"},
+		"Shared memory pages.")
+
+	// Act
+	suggestions, err := uc.SuggestKeywordsForNote("n1", "user-1", 3)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywordsForNote() returned an unexpected error: %v", err)
+	}
+	if len(suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %d", len(suggestions))
+	}
+	if suggestions[0].Keyword != "mutex" {
+		t.Errorf("Expected 'mutex' to rank first, got '%s'", suggestions[0].Keyword)
+	}
+	// "deadlock" occurs once, like "locks", but the user's existing keyword is favoured.
+	if suggestions[1].Keyword != "cs/os/deadlock" || !suggestions[1].Existing {
+		t.Errorf("Expected the existing keyword 'cs/os/deadlock' to rank second, got %+v", suggestions[1])
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.Keyword == "deadlock" || suggestion.Keyword == "shared" {
+			t.Errorf("Did not expect '%s' to be suggested", suggestion.Keyword)
+		}
+	}
+}
+
+func TestDiscoveryUsecase_SuggestKeywordsForNote_SkipsKeywordsOnNote(t *testing.T) {
+	// Arrange
+	noteRepo, contentRepo, uc := setUpDiscovery()
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Mutex", Keywords: map[string][]string{"user-1": {"mutex"}}},
+		"mutex mutex semaphore")
+
+	// Act
+	suggestions, err := uc.SuggestKeywordsForNote("n1", "user-1", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywordsForNote() returned an unexpected error: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Keyword != "semaphore" {
+		t.Errorf("Expected only 'semaphore' to be suggested, got %+v", suggestions)
+	}
+}
+
+func TestDiscoveryUsecase_SuggestKeywordsForNote_PermissionDenied(t *testing.T) {
+	// Arrange
+	noteRepo, contentRepo, uc := setUpDiscovery()
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Private"})
+
+	// Act
+	_, err := uc.SuggestKeywordsForNote("n1", "user-2", 0)
+
+	// Assert
+	if !errors.Is(err, discoveryuc.ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", discoveryuc.ErrPermissionDenied, err)
+	}
+}
+
+func TestDiscoveryUsecase_SuggestKeywordsForNote_NoteNotFound(t *testing.T) {
+	// Arrange
+	_, _, uc := setUpDiscovery()
+
+	// Act
+	_, err := uc.SuggestKeywordsForNote("missing", "user-1", 0)
+
+	// Assert
+	if !errors.Is(err, discoveryuc.ErrNoteNotFound) {
+		t.Errorf("Expected error to be '%v', but got '%v'", discoveryuc.ErrNoteNotFound, err)
+	}
+}
+
+func TestDiscoveryUsecase_FindRelatedNotes(t *testing.T) {
+	// Arrange
+	noteRepo, contentRepo, uc := setUpDiscovery()
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "project", OwnerID: "user-1", Title: "ProjectX", Keywords: map[string][]string{"user-1": {"cs/os/concurrency"}}},
+		"Worker pool with a mutex around the job queue.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "os-notes", OwnerID: "user-2", Title: "OS lecture", Collaborators: map[string]string{"user-1": "read"}, Keywords: map[string][]string{"user-1": {"cs/os/concurrency"}}},
+		"Mutex and semaphore semantics.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "scheduling", OwnerID: "user-1", Title: "Scheduling", Keywords: map[string][]string{"user-1": {"cs/os/scheduling"}}},
+		"Round robin.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "groceries", OwnerID: "user-1", Title: "Groceries"},
+		"Milk and eggs.")
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "hidden", OwnerID: "user-2", Title: "Mutex", Keywords: map[string][]string{"user-2": {"cs/os/concurrency"}}},
+		"Mutex mutex mutex.")
+
+	// Act
+	related, err := uc.FindRelatedNotes("project", "user-1", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("FindRelatedNotes() returned an unexpected error: %v", err)
+	}
+	if len(related) != 2 {
+		t.Fatalf("Expected 2 related notes, got %d: %+v", len(related), related)
+	}
+	if related[0].NoteID != "os-notes" {
+		t.Errorf("Expected 'os-notes' to rank first, got '%s'", related[0].NoteID)
+	}
+	if len(related[0].SharedKeywords) != 1 || related[0].SharedKeywords[0] != "cs/os/concurrency" {
+		t.Errorf("Expected shared keyword 'cs/os/concurrency', got %v", related[0].SharedKeywords)
+	}
+	if related[1].NoteID != "scheduling" {
+		t.Errorf("Expected 'scheduling' to rank second through its sibling keyword, got '%s'", related[1].NoteID)
+	}
+}
+
+func TestDiscoveryUsecase_FindRelatedNotes_PermissionDenied(t *testing.T) {
+	// Arrange
+	noteRepo, contentRepo, uc := setUpDiscovery()
+	saveNote(noteRepo, contentRepo, &noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Private"})
+
+	// Act
+	_, err := uc.FindRelatedNotes("n1", "user-2", 0)
+
+	// Assert
+	if !errors.Is(err, discoveryuc.ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", discoveryuc.ErrPermissionDenied, err)
+	}
+}
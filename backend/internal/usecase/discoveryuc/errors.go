@@ -0,0 +1,12 @@
+package discoveryuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrNoteNotFound is returned when a note is not found.
+var ErrNoteNotFound = errors.New("note not found")
+
+// ErrPermissionDenied is returned when a user cannot access a note.
+var ErrPermissionDenied = errors.New("permission denied")
@@ -0,0 +1,128 @@
+package discoveryuc
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// minTermLength is the minimum number of runes a token needs to be treated as a term.
+const minTermLength = 3
+
+// stopWords are common English words that carry no topical meaning.
+var stopWords = map[string]bool{
+	"about": true, "after": true, "all": true, "also": true, "and": true, "any": true, "are": true,
+	"because": true, "been": true, "before": true, "being": true, "between": true, "both": true,
+	"but": true, "can": true, "could": true, "did": true, "does": true, "doing": true, "each": true,
+	"for": true, "from": true, "had": true, "has": true, "have": true, "her": true, "here": true,
+	"him": true, "his": true, "how": true, "into": true, "its": true, "just": true, "more": true,
+	"most": true, "not": true, "now": true, "only": true, "other": true, "our": true, "out": true,
+	"over": true, "she": true, "should": true, "some": true, "such": true, "than": true, "that": true,
+	"the": true, "their": true, "them": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "those": true, "through": true, "too": true, "under": true, "until": true,
+	"use": true, "very": true, "was": true, "were": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "who": true, "why": true, "will": true, "with": true, "would": true,
+	"you": true, "your": true,
+}
+
+// tokenize splits text into lower-cased terms, dropping stop words, numbers and very short tokens.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	for _, field := range fields {
+		if len([]rune(field)) < minTermLength || stopWords[field] || isNumber(field) {
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// termFrequencies counts how often each term occurs in a document.
+func termFrequencies(terms []string) map[string]int {
+	frequencies := make(map[string]int)
+	for _, term := range terms {
+		frequencies[term]++
+	}
+	return frequencies
+}
+
+// corpus holds the document frequency of every term across a set of documents.
+type corpus struct {
+	documents         int
+	documentFrequency map[string]int
+}
+
+func newCorpus(documents []map[string]int) *corpus {
+	c := &corpus{documents: len(documents), documentFrequency: make(map[string]int)}
+	for _, frequencies := range documents {
+		for term := range frequencies {
+			c.documentFrequency[term]++
+		}
+	}
+	return c
+}
+
+// idf returns the smoothed inverse document frequency of a term.
+func (c *corpus) idf(term string) float64 {
+	return math.Log(float64(1+c.documents)/float64(1+c.documentFrequency[term])) + 1
+}
+
+// tfidf scores every term of a document against the corpus.
+func (c *corpus) tfidf(frequencies map[string]int) map[string]float64 {
+	total := 0
+	for _, count := range frequencies {
+		total += count
+	}
+
+	scores := make(map[string]float64, len(frequencies))
+	if total == 0 {
+		return scores
+	}
+	for term, count := range frequencies {
+		scores[term] = float64(count) / float64(total) * c.idf(term)
+	}
+	return scores
+}
+
+// cosineSimilarity returns the cosine of the angle between two sparse term vectors.
+func cosineSimilarity(a, b map[string]float64) float64 {
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for term, weight := range a {
+		normA += weight * weight
+		dot += weight * b[term]
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// jaccardSimilarity returns the size of the intersection of two sets over the size of their union.
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for item := range a {
+		if b[item] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
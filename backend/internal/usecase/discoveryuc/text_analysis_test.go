@@ -0,0 +1,53 @@
+package discoveryuc
+
+import "testing"
+
+func TestTokenize(t *testing.T) {
+	terms := tokenize("The Mutex, the SEMAPHORE and 42 go-routines!")
+
+	expected := []string{"mutex", "semaphore", "routines"}
+	if len(terms) != len(expected) {
+		t.Fatalf("Expected %d terms, got %d: %v", len(expected), len(terms), terms)
+	}
+	for i, term := range expected {
+		if terms[i] != term {
+			t.Errorf("Expected term %d to be '%s', got '%s'", i, term, terms[i])
+		}
+	}
+}
+
+func TestCorpus_TFIDF_FavoursRareTerms(t *testing.T) {
+	doc1 := termFrequencies([]string{"mutex", "lock", "lock"})
+	doc2 := termFrequencies([]string{"lock", "network"})
+	doc3 := termFrequencies([]string{"lock", "socket"})
+	c := newCorpus([]map[string]int{doc1, doc2, doc3})
+
+	scores := c.tfidf(doc1)
+
+	if scores["mutex"] <= scores["lock"]/2 {
+		t.Errorf("Expected rare term 'mutex' to be weighted above common term 'lock', got %v", scores)
+	}
+	if c.idf("mutex") <= c.idf("lock") {
+		t.Errorf("Expected idf of 'mutex' (%f) to exceed idf of 'lock' (%f)", c.idf("mutex"), c.idf("lock"))
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	a := map[string]float64{"mutex": 1, "lock": 1}
+
+	if got := cosineSimilarity(a, a); got < 0.999 {
+		t.Errorf("Expected identical vectors to have similarity 1, got %f", got)
+	}
+	if got := cosineSimilarity(a, map[string]float64{"socket": 1}); got != 0 {
+		t.Errorf("Expected disjoint vectors to have similarity 0, got %f", got)
+	}
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	a := map[string]bool{"cs": true, "cs/os": true}
+	b := map[string]bool{"cs": true, "cs/networks": true}
+
+	if got := jaccardSimilarity(a, b); got < 0.333 || got > 0.334 {
+		t.Errorf("Expected similarity 1/3, got %f", got)
+	}
+}
@@ -0,0 +1,51 @@
+package linkuc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatDOT writes a note graph in the GraphViz DOT language. Notes are drawn as boxes and
+// keywords as ellipses; keyword co-occurrence is drawn without arrowheads.
+func FormatDOT(graph *GraphDTO) string {
+	var b strings.Builder
+	b.WriteString("digraph notes {\n")
+
+	nodeIDs := make([]string, 0, len(graph.Graph.Nodes))
+	for id := range graph.Graph.Nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Strings(nodeIDs)
+	for _, id := range nodeIDs {
+		node := graph.Graph.Nodes[id]
+		shape := "box"
+		if node.Metadata.Type == KeywordNodeType {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(id), dotQuote(node.Label), shape)
+	}
+
+	for _, edge := range graph.Graph.Edges {
+		attrs := []string{"label=" + dotQuote(edge.Relation)}
+		switch edge.Relation {
+		case TaggedWithRelation:
+			attrs = append(attrs, "style=dashed")
+		case CoOccursWithRelation:
+			attrs = append(attrs, "dir=none")
+		}
+		if edge.Metadata.Weight > 1 {
+			attrs = append(attrs, fmt.Sprintf("weight=%d", edge.Metadata.Weight), fmt.Sprintf("penwidth=%d", edge.Metadata.Weight))
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.Source), dotQuote(edge.Target), strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote quotes a string as a DOT ID.
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
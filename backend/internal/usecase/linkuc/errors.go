@@ -0,0 +1,18 @@
+package linkuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrNoteNotFound is returned when a note is not found.
+var ErrNoteNotFound = errors.New("note not found")
+
+// ErrPermissionDenied is returned when a user cannot access a note.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrInvalidKeyword is returned when a graph is filtered by an invalid keyword.
+var ErrInvalidKeyword = errors.New("invalid keyword")
+
+// ErrInvalidDepth is returned when a graph is requested with a negative depth.
+var ErrInvalidDepth = errors.New("depth must not be negative")
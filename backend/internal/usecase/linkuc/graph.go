@@ -0,0 +1,256 @@
+package linkuc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"noteapp/internal/domain/note"
+	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/noterepo"
+)
+
+// Types of the nodes of a note graph.
+const (
+	NoteNodeType    = "note"
+	KeywordNodeType = "keyword"
+)
+
+// Relations of the edges of a note graph.
+const (
+	LinksToRelation      = "links_to"
+	TaggedWithRelation   = "tagged_with"
+	CoOccursWithRelation = "co_occurs_with"
+)
+
+// GraphType is the type of the graphs exported by GetGraph.
+const GraphType = "noteapp.notes"
+
+// DefaultGraphDepth is the depth used when a graph starts from a note without a depth.
+const DefaultGraphDepth = 1
+
+// GetGraph exports the notes accessible to a user as a graph. Notes are connected to the
+// notes they link to and to the keywords the user tagged them with, and keywords used on
+// the same notes are connected to each other. When the query starts from a note, notes are
+// one step apart if one links to the other or if they share a keyword.
+func (uc *LinkUsecase) GetGraph(userID string, query GraphQuery) (*GraphDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	var keyword note.Keyword
+	if query.Keyword != "" {
+		k, err := note.NewKeyword(query.Keyword)
+		if err != nil {
+			return nil, ErrInvalidKeyword
+		}
+		keyword = k
+	}
+	if query.Depth < 0 {
+		return nil, ErrInvalidDepth
+	}
+	if query.Depth == 0 {
+		query.Depth = DefaultGraphDepth
+	}
+	if query.StartNoteID != "" {
+		if _, err := uc.getAccessibleNote(query.StartNoteID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	notePOs, err := uc.noteRepo.GetAccessibleNotesByUserID(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	notes := make(map[string]*noterepo.NotePO, len(notePOs))
+	for _, notePO := range notePOs {
+		if query.Keyword == "" || notePO.ID == query.StartNoteID || hasKeyword(notePO.Keywords[userID], keyword) {
+			notes[notePO.ID] = notePO
+		}
+	}
+
+	links, err := uc.linksBetween(notes)
+	if err != nil {
+		return nil, err
+	}
+	if query.StartNoteID != "" {
+		reached := neighbourhood(query.StartNoteID, query.Depth, notes, links, userID)
+		for id := range notes {
+			if !reached[id] {
+				delete(notes, id)
+			}
+		}
+	}
+	return buildGraph(notes, links, userID), nil
+}
+
+// linksBetween returns, for each note, the number of its contents linking to each other note.
+func (uc *LinkUsecase) linksBetween(notes map[string]*noterepo.NotePO) (map[string]map[string]int, error) {
+	links := make(map[string]map[string]int)
+	for _, notePO := range notes {
+		for _, contentID := range notePO.ContentIDs {
+			contentPO, err := uc.contentRepo.GetByID(contentID)
+			if errors.Is(err, contentrepo.ErrContentNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+			}
+			for _, targetID := range contentPO.LinkedNoteIDs {
+				if targetID == notePO.ID || notes[targetID] == nil {
+					continue
+				}
+				if links[notePO.ID] == nil {
+					links[notePO.ID] = make(map[string]int)
+				}
+				links[notePO.ID][targetID]++
+			}
+		}
+	}
+	return links, nil
+}
+
+// neighbourhood returns the notes within depth steps of a note, following links in both
+// directions and shared keywords.
+func neighbourhood(startID string, depth int, notes map[string]*noterepo.NotePO, links map[string]map[string]int, userID string) map[string]bool {
+	adjacent := make(map[string]map[string]bool)
+	connect := func(a, b string) {
+		if adjacent[a] == nil {
+			adjacent[a] = make(map[string]bool)
+		}
+		if adjacent[b] == nil {
+			adjacent[b] = make(map[string]bool)
+		}
+		adjacent[a][b] = true
+		adjacent[b][a] = true
+	}
+	for sourceID, targets := range links {
+		for targetID := range targets {
+			connect(sourceID, targetID)
+		}
+	}
+	tagged := make(map[string][]string)
+	for _, notePO := range notes {
+		for _, k := range notePO.Keywords[userID] {
+			for _, otherID := range tagged[k] {
+				connect(notePO.ID, otherID)
+			}
+			tagged[k] = append(tagged[k], notePO.ID)
+		}
+	}
+
+	reached := map[string]bool{startID: true}
+	frontier := []string{startID}
+	for step := 0; step < depth && len(frontier) > 0; step++ {
+		var next []string
+		for _, id := range frontier {
+			for neighbourID := range adjacent[id] {
+				if !reached[neighbourID] {
+					reached[neighbourID] = true
+					next = append(next, neighbourID)
+				}
+			}
+		}
+		frontier = next
+	}
+	return reached
+}
+
+func buildGraph(notes map[string]*noterepo.NotePO, links map[string]map[string]int, userID string) *GraphDTO {
+	graph := GraphBodyDTO{
+		Directed: true,
+		Type:     GraphType,
+		Nodes:    make(map[string]*GraphNodeDTO),
+		Edges:    []*GraphEdgeDTO{},
+	}
+
+	coOccurrences := make(map[[2]string]int)
+	for _, notePO := range notes {
+		nodeID := noteNodeID(notePO.ID)
+		graph.Nodes[nodeID] = &GraphNodeDTO{
+			Label:    notePO.Title,
+			Metadata: GraphNodeMetadataDTO{Type: NoteNodeType, NoteID: notePO.ID},
+		}
+
+		for targetID, weight := range links[notePO.ID] {
+			if notes[targetID] == nil {
+				continue
+			}
+			graph.Edges = append(graph.Edges, &GraphEdgeDTO{
+				Source:   nodeID,
+				Target:   noteNodeID(targetID),
+				Relation: LinksToRelation,
+				Directed: true,
+				Metadata: GraphEdgeMetadataDTO{Weight: weight},
+			})
+		}
+
+		keywords := uniqueSorted(notePO.Keywords[userID])
+		for i, k := range keywords {
+			keywordID := keywordNodeID(k)
+			graph.Nodes[keywordID] = &GraphNodeDTO{
+				Label:    k,
+				Metadata: GraphNodeMetadataDTO{Type: KeywordNodeType, Keyword: k},
+			}
+			graph.Edges = append(graph.Edges, &GraphEdgeDTO{
+				Source:   nodeID,
+				Target:   keywordID,
+				Relation: TaggedWithRelation,
+				Directed: true,
+				Metadata: GraphEdgeMetadataDTO{Weight: 1},
+			})
+			for _, other := range keywords[i+1:] {
+				coOccurrences[[2]string{k, other}]++
+			}
+		}
+	}
+	for pair, weight := range coOccurrences {
+		graph.Edges = append(graph.Edges, &GraphEdgeDTO{
+			Source:   keywordNodeID(pair[0]),
+			Target:   keywordNodeID(pair[1]),
+			Relation: CoOccursWithRelation,
+			Metadata: GraphEdgeMetadataDTO{Weight: weight},
+		})
+	}
+
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.Relation != b.Relation {
+			return a.Relation < b.Relation
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+	return &GraphDTO{Graph: graph}
+}
+
+func hasKeyword(keywords []string, query note.Keyword) bool {
+	for _, k := range keywords {
+		if keyword, err := note.NewKeyword(k); err == nil && keyword.Matches(query, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+func noteNodeID(noteID string) string {
+	return NoteNodeType + ":" + noteID
+}
+
+func keywordNodeID(keyword string) string {
+	return KeywordNodeType + ":" + keyword
+}
@@ -0,0 +1,182 @@
+package linkuc_test
+
+import (
+	"errors"
+	"noteapp/internal/usecase/linkuc"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// tag applies a keyword to a note as its owner.
+func (f *linkFixture) tag(t *testing.T, noteID, keyword string) {
+	t.Helper()
+	note, _ := f.notes.GetNoteByID(noteID)
+	if err := f.notes.TagNote(noteID, note.OwnerID, keyword, note.Version); err != nil {
+		t.Fatalf("Failed to tag note: %v", err)
+	}
+}
+
+func nodeIDs(graph *linkuc.GraphDTO) []string {
+	ids := []string{}
+	for id := range graph.Graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func edgeStrings(graph *linkuc.GraphDTO) []string {
+	edges := []string{}
+	for _, edge := range graph.Graph.Edges {
+		edges = append(edges, edge.Source+" "+edge.Relation+" "+edge.Target)
+	}
+	return edges
+}
+
+func TestLinkUsecase_GetGraph(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	f.notes.CreateNote("a", "Alpha", "user-1")
+	f.notes.CreateNote("b", "Beta", "user-1")
+	f.notes.CreateNote("private", "Private", "user-2")
+	f.addText(t, "a", "[[b]] and [[private]]")
+	f.addText(t, "a", "[[b]] again")
+	f.tag(t, "a", "go")
+	f.tag(t, "a", "cs/os")
+	f.tag(t, "b", "go")
+
+	// Act
+	graph, err := f.links.GetGraph("user-1", linkuc.GraphQuery{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetGraph() returned an unexpected error: %v", err)
+	}
+	wantNodes := []string{"keyword:cs/os", "keyword:go", "note:a", "note:b"}
+	if got := nodeIDs(graph); !reflect.DeepEqual(got, wantNodes) {
+		t.Errorf("Expected nodes %v, got %v", wantNodes, got)
+	}
+	wantEdges := []string{
+		"keyword:cs/os co_occurs_with keyword:go",
+		"note:a links_to note:b",
+		"note:a tagged_with keyword:cs/os",
+		"note:a tagged_with keyword:go",
+		"note:b tagged_with keyword:go",
+	}
+	if got := edgeStrings(graph); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("Expected edges %v, got %v", wantEdges, got)
+	}
+	if link := graph.Graph.Edges[1]; link.Metadata.Weight != 2 || !link.Directed {
+		t.Errorf("Expected a directed link of weight 2, got %+v", link)
+	}
+	if coOccurrence := graph.Graph.Edges[0]; coOccurrence.Directed {
+		t.Errorf("Expected keyword co-occurrence to be undirected")
+	}
+	if node := graph.Graph.Nodes["note:a"]; node.Label != "Alpha" || node.Metadata.Type != linkuc.NoteNodeType {
+		t.Errorf("Expected the note node to be labelled with its title, got %+v", node)
+	}
+}
+
+func TestLinkUsecase_GetGraph_KeywordFilter(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	f.notes.CreateNote("a", "Alpha", "user-1")
+	f.notes.CreateNote("b", "Beta", "user-1")
+	f.notes.CreateNote("c", "Gamma", "user-1")
+	f.tag(t, "a", "cs")
+	f.tag(t, "b", "cs/os")
+	f.tag(t, "c", "cooking")
+
+	// Act
+	graph, err := f.links.GetGraph("user-1", linkuc.GraphQuery{Keyword: "cs"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetGraph() returned an unexpected error: %v", err)
+	}
+	want := []string{"keyword:cs", "keyword:cs/os", "note:a", "note:b"}
+	if got := nodeIDs(graph); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the notes under cs and their keywords %v, got %v", want, got)
+	}
+}
+
+func TestLinkUsecase_GetGraph_Depth(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		f.notes.CreateNote(id, strings.ToUpper(id), "user-1")
+	}
+	f.addText(t, "a", "[[b]]")
+	f.addText(t, "c", "[[b]]")
+	f.tag(t, "c", "shared")
+	f.tag(t, "d", "shared")
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{1, []string{"note:a", "note:b"}},
+		{2, []string{"keyword:shared", "note:a", "note:b", "note:c"}},
+		{3, []string{"keyword:shared", "note:a", "note:b", "note:c", "note:d"}},
+	}
+	for _, tt := range tests {
+		// Act
+		graph, err := f.links.GetGraph("user-1", linkuc.GraphQuery{StartNoteID: "a", Depth: tt.depth})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetGraph() returned an unexpected error: %v", err)
+		}
+		if got := nodeIDs(graph); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("depth %d: expected nodes %v, got %v", tt.depth, tt.want, got)
+		}
+	}
+}
+
+func TestLinkUsecase_GetGraph_Errors(t *testing.T) {
+	f := setUpLinks()
+	f.notes.CreateNote("private", "Private", "user-2")
+
+	tests := []struct {
+		name  string
+		query linkuc.GraphQuery
+		want  error
+	}{
+		{"invalid keyword", linkuc.GraphQuery{Keyword: "cs//os"}, linkuc.ErrInvalidKeyword},
+		{"negative depth", linkuc.GraphQuery{Depth: -1}, linkuc.ErrInvalidDepth},
+		{"missing start note", linkuc.GraphQuery{StartNoteID: "missing"}, linkuc.ErrNoteNotFound},
+		{"inaccessible start note", linkuc.GraphQuery{StartNoteID: "private"}, linkuc.ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.links.GetGraph("user-1", tt.query); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFormatDOT(t *testing.T) {
+	graph := &linkuc.GraphDTO{Graph: linkuc.GraphBodyDTO{
+		Nodes: map[string]*linkuc.GraphNodeDTO{
+			"note:a":     {Label: `Say "hi"`, Metadata: linkuc.GraphNodeMetadataDTO{Type: linkuc.NoteNodeType}},
+			"keyword:go": {Label: "go", Metadata: linkuc.GraphNodeMetadataDTO{Type: linkuc.KeywordNodeType}},
+		},
+		Edges: []*linkuc.GraphEdgeDTO{
+			{Source: "note:a", Target: "keyword:go", Relation: linkuc.TaggedWithRelation, Metadata: linkuc.GraphEdgeMetadataDTO{Weight: 1}},
+		},
+	}}
+
+	got := linkuc.FormatDOT(graph)
+
+	want := "digraph notes {\n" +
+		"  \"keyword:go\" [label=\"go\", shape=ellipse];\n" +
+		"  \"note:a\" [label=\"Say \\\"hi\\\"\", shape=box];\n" +
+		"  \"note:a\" -> \"keyword:go\" [label=\"tagged_with\", style=dashed];\n" +
+		"}\n"
+	if got != want {
+		t.Errorf("FormatDOT() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package linkuc
+
+// LinkDTO represents a note at the other end of wiki-style links from or to a note.
+// ContentIDs are the contents containing the links. Broken is true when the linked note
+// does not exist, for example because it was deleted; its title is then unknown.
+type LinkDTO struct {
+	NoteID     string   `json:"note_id"`
+	Title      string   `json:"title,omitempty"`
+	ContentIDs []string `json:"content_ids"`
+	Broken     bool     `json:"broken,omitempty"`
+}
+
+// GraphQuery selects the part of a user's note graph to export. Keyword keeps only the notes
+// the user tagged with the keyword or with a keyword below it. StartNoteID keeps only the
+// notes within Depth steps of a note; a zero Depth falls back to DefaultGraphDepth.
+type GraphQuery struct {
+	Keyword     string
+	StartNoteID string
+	Depth       int
+}
+
+// GraphDTO is a note graph in the JSON Graph Format (https://jsongraphformat.info).
+type GraphDTO struct {
+	Graph GraphBodyDTO `json:"graph"`
+}
+
+// GraphBodyDTO holds the nodes of a graph, keyed by node ID, and its edges.
+type GraphBodyDTO struct {
+	Directed bool                     `json:"directed"`
+	Type     string                   `json:"type"`
+	Nodes    map[string]*GraphNodeDTO `json:"nodes"`
+	Edges    []*GraphEdgeDTO          `json:"edges"`
+}
+
+// GraphNodeDTO is a note or a keyword in a note graph.
+type GraphNodeDTO struct {
+	Label    string               `json:"label"`
+	Metadata GraphNodeMetadataDTO `json:"metadata"`
+}
+
+// GraphNodeMetadataDTO tells what a node stands for.
+type GraphNodeMetadataDTO struct {
+	Type    string `json:"type"`
+	NoteID  string `json:"note_id,omitempty"`
+	Keyword string `json:"keyword,omitempty"`
+}
+
+// GraphEdgeDTO connects two nodes of a note graph. Directed is false for keyword
+// co-occurrence, which has no direction.
+type GraphEdgeDTO struct {
+	Source   string               `json:"source"`
+	Target   string               `json:"target"`
+	Relation string               `json:"relation"`
+	Directed bool                 `json:"directed"`
+	Metadata GraphEdgeMetadataDTO `json:"metadata"`
+}
+
+// GraphEdgeMetadataDTO holds the weight of an edge: the number of contents holding a link,
+// or the number of notes two keywords share.
+type GraphEdgeMetadataDTO struct {
+	Weight int `json:"weight"`
+}
@@ -0,0 +1,157 @@
+package linkuc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/noterepo"
+)
+
+// LinkUsecase navigates the wiki-style links between notes, [[note-id]], written in their
+// text contents, and exports the graph they form together with keywords.
+type LinkUsecase struct {
+	noteRepo    noterepo.NoteRepository
+	contentRepo contentrepo.ContentRepository
+}
+
+// NewLinkUsecase creates a new LinkUsecase.
+func NewLinkUsecase(noteRepo noterepo.NoteRepository, contentRepo contentrepo.ContentRepository) *LinkUsecase {
+	return &LinkUsecase{noteRepo: noteRepo, contentRepo: contentRepo}
+}
+
+// GetBacklinks returns the notes accessible to the user that link to a note, ordered by title.
+// Links from a note to itself are left out.
+func (uc *LinkUsecase) GetBacklinks(noteID, userID string) ([]*LinkDTO, error) {
+	if noteID == "" || userID == "" {
+		return nil, ErrInvalidID
+	}
+	if _, err := uc.getAccessibleNote(noteID, userID); err != nil {
+		return nil, err
+	}
+
+	contentPOs, err := uc.contentRepo.FindLinkingTo(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+
+	links := make(map[string]*LinkDTO)
+	for _, contentPO := range contentPOs {
+		if contentPO.NoteID == noteID {
+			continue
+		}
+		link, ok := links[contentPO.NoteID]
+		if !ok {
+			source, err := uc.getAccessibleNote(contentPO.NoteID, userID)
+			if errors.Is(err, ErrNoteNotFound) || errors.Is(err, ErrPermissionDenied) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			link = &LinkDTO{NoteID: source.ID, Title: source.Title}
+			links[source.ID] = link
+		}
+		link.ContentIDs = append(link.ContentIDs, contentPO.ID)
+	}
+
+	result := make([]*LinkDTO, 0, len(links))
+	for _, link := range links {
+		sort.Strings(link.ContentIDs)
+		result = append(result, link)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Title != result[j].Title {
+			return result[i].Title < result[j].Title
+		}
+		return result[i].NoteID < result[j].NoteID
+	})
+	return result, nil
+}
+
+// GetOutlinks returns the notes a note links to, in the order the links appear in its contents.
+// Linked notes the user cannot access are left out, and links to notes that do not exist are
+// reported as broken.
+func (uc *LinkUsecase) GetOutlinks(noteID, userID string) ([]*LinkDTO, error) {
+	if noteID == "" || userID == "" {
+		return nil, ErrInvalidID
+	}
+	notePO, err := uc.getAccessibleNote(noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*LinkDTO
+	links := make(map[string]*LinkDTO)
+	for _, contentID := range notePO.ContentIDs {
+		contentPO, err := uc.contentRepo.GetByID(contentID)
+		if errors.Is(err, contentrepo.ErrContentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+		}
+
+		for _, targetID := range contentPO.LinkedNoteIDs {
+			if targetID == noteID {
+				continue
+			}
+			link, ok := links[targetID]
+			if !ok {
+				link, err = uc.outlink(targetID, userID)
+				if err != nil {
+					return nil, err
+				}
+				links[targetID] = link
+				if link != nil {
+					result = append(result, link)
+				}
+			}
+			if link != nil {
+				link.ContentIDs = append(link.ContentIDs, contentPO.ID)
+			}
+		}
+	}
+	if result == nil {
+		result = []*LinkDTO{}
+	}
+	return result, nil
+}
+
+// outlink describes a linked note, or returns nil when the user cannot access it.
+func (uc *LinkUsecase) outlink(targetID, userID string) (*LinkDTO, error) {
+	target, err := uc.getAccessibleNote(targetID, userID)
+	switch {
+	case errors.Is(err, ErrNoteNotFound):
+		return &LinkDTO{NoteID: targetID, Broken: true}, nil
+	case errors.Is(err, ErrPermissionDenied):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return &LinkDTO{NoteID: target.ID, Title: target.Title}, nil
+}
+
+// getAccessibleNote loads a note and checks that the user owns it or is a collaborator.
+func (uc *LinkUsecase) getAccessibleNote(noteID, userID string) (*noterepo.NotePO, error) {
+	notePO, err := uc.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if notePO.OwnerID != userID {
+		if _, ok := notePO.Collaborators[userID]; !ok {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return notePO, nil
+}
+
+func (uc *LinkUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, noterepo.ErrNoteNotFound):
+		return ErrNoteNotFound
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
@@ -0,0 +1,143 @@
+package linkuc_test
+
+import (
+	"errors"
+	"noteapp/internal/repository/contentrepo"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/usecase/contentuc"
+	"noteapp/internal/usecase/linkuc"
+	"noteapp/internal/usecase/noteuc"
+	"reflect"
+	"testing"
+)
+
+type linkFixture struct {
+	notes    *noteuc.NoteUsecase
+	contents *contentuc.ContentUsecase
+	links    *linkuc.LinkUsecase
+}
+
+func setUpLinks() *linkFixture {
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	contentRepo := contentrepo.NewInMemoryContentRepository()
+	return &linkFixture{
+		notes:    noteuc.NewNoteUsecase(noteRepo),
+		contents: contentuc.NewContentUsecase(contentRepo),
+		links:    linkuc.NewLinkUsecase(noteRepo, contentRepo),
+	}
+}
+
+// addText adds a text content to a note and returns its ID.
+func (f *linkFixture) addText(t *testing.T, noteID, text string) string {
+	t.Helper()
+	note, err := f.notes.GetNoteByID(noteID)
+	if err != nil {
+		t.Fatalf("Failed to get note: %v", err)
+	}
+	contentID, err := f.contents.CreateContent(noteID, note.OwnerID, "", text, contentuc.TextContentType)
+	if err != nil {
+		t.Fatalf("Failed to create content: %v", err)
+	}
+	if err := f.notes.AddContent(noteID, note.OwnerID, contentID, -1, note.Version); err != nil {
+		t.Fatalf("Failed to add content: %v", err)
+	}
+	return contentID
+}
+
+func noteIDs(links []*linkuc.LinkDTO) []string {
+	ids := []string{}
+	for _, link := range links {
+		ids = append(ids, link.NoteID)
+	}
+	return ids
+}
+
+func TestLinkUsecase_GetBacklinks(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	f.notes.CreateNote("target", "Target", "user-1")
+	f.notes.CreateNote("b", "Beta", "user-1")
+	f.notes.CreateNote("a", "Alpha", "user-1")
+	f.notes.CreateNote("private", "Private", "user-2")
+	f.addText(t, "b", "See [[target]].")
+	f.addText(t, "b", "Again [[target|the target]].")
+	f.addText(t, "a", "Also [[target]].")
+	f.addText(t, "private", "Secretly [[target]].")
+	f.addText(t, "target", "Self [[target]].")
+
+	// Act
+	links, err := f.links.GetBacklinks("target", "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetBacklinks() returned an unexpected error: %v", err)
+	}
+	if got := noteIDs(links); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("Expected backlinks from the accessible notes [a b] by title, got %v", got)
+	}
+	if len(links[1].ContentIDs) != 2 || links[1].Title != "Beta" {
+		t.Errorf("Expected both contents of Beta, got %+v", links[1])
+	}
+}
+
+func TestLinkUsecase_GetBacklinks_FollowsEdits(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	f.notes.CreateNote("target", "Target", "user-1")
+	f.notes.CreateNote("source", "Source", "user-1")
+	contentID := f.addText(t, "source", "See [[target]].")
+
+	// Act
+	f.contents.UpdateContent(contentID, "user-1", "No more links.", 0)
+	links, _ := f.links.GetBacklinks("target", "user-1")
+
+	// Assert
+	if len(links) != 0 {
+		t.Errorf("Expected the edit to remove the backlink, got %v", noteIDs(links))
+	}
+}
+
+func TestLinkUsecase_GetOutlinks(t *testing.T) {
+	// Arrange
+	f := setUpLinks()
+	f.notes.CreateNote("source", "Source", "user-1")
+	f.notes.CreateNote("x", "X", "user-1")
+	f.notes.CreateNote("y", "Y", "user-1")
+	f.notes.CreateNote("private", "Private", "user-2")
+	f.notes.CreateNote("deleted", "Deleted", "user-1")
+	f.addText(t, "source", "[[y]] then [[x]] and [[private]]")
+	f.addText(t, "source", "[[deleted]] [[y]] [[source]]")
+	f.notes.DeleteNote("deleted", 0)
+
+	// Act
+	links, err := f.links.GetOutlinks("source", "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetOutlinks() returned an unexpected error: %v", err)
+	}
+	if got := noteIDs(links); !reflect.DeepEqual(got, []string{"y", "x", "deleted"}) {
+		t.Fatalf("Expected outlinks [y x deleted] in order of appearance, got %v", got)
+	}
+	if len(links[0].ContentIDs) != 2 || links[0].Title != "Y" {
+		t.Errorf("Expected Y to be linked from both contents, got %+v", links[0])
+	}
+	if !links[2].Broken || links[2].Title != "" {
+		t.Errorf("Expected the link to the deleted note to be broken, got %+v", links[2])
+	}
+}
+
+func TestLinkUsecase_Errors(t *testing.T) {
+	f := setUpLinks()
+	f.notes.CreateNote("n1", "Mine", "user-1")
+
+	if _, err := f.links.GetBacklinks("n1", "user-2"); !errors.Is(err, linkuc.ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := f.links.GetOutlinks("missing", "user-1"); !errors.Is(err, linkuc.ErrNoteNotFound) {
+		t.Errorf("Expected ErrNoteNotFound, got %v", err)
+	}
+	if _, err := f.links.GetOutlinks("n1", ""); !errors.Is(err, linkuc.ErrInvalidID) {
+		t.Errorf("Expected ErrInvalidID, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package noteuc
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// keywordEntry tracks how often and how recently a user applied a keyword.
+type keywordEntry struct {
+	keyword  string
+	count    int
+	lastUsed uint64
+}
+
+// userKeywordIndex is a per-user index of keywords kept sorted for prefix lookups.
+type userKeywordIndex struct {
+	entries []*keywordEntry
+}
+
+func (idx *userKeywordIndex) find(keyword string) (int, bool) {
+	i := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].keyword >= keyword })
+	return i, i < len(idx.entries) && idx.entries[i].keyword == keyword
+}
+
+// keywordIndex keeps a sorted keyword index per user for autocomplete.
+// A user's index is loaded lazily from the repository on first use and then
+// maintained incrementally as the user tags and untags notes.
+type keywordIndex struct {
+	// changes is held shared by each keyword change from before it is saved until it is
+	// recorded, and exclusively while an index is loaded, so that every change is either in
+	// the keywords an index is loaded from or recorded after the index is loaded.
+	changes sync.RWMutex
+	mu      sync.Mutex
+	users   map[string]*userKeywordIndex
+	clock   uint64
+}
+
+func newKeywordIndex() *keywordIndex {
+	return &keywordIndex{users: make(map[string]*userKeywordIndex)}
+}
+
+// isLoaded reports whether the user's index has already been built.
+func (ki *keywordIndex) isLoaded(userID string) bool {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	_, ok := ki.users[userID]
+	return ok
+}
+
+// change starts a change of keywords in the repository, to be recorded with add or remove
+// before calling the returned function to end it.
+func (ki *keywordIndex) change() func() {
+	ki.changes.RLock()
+	return ki.changes.RUnlock
+}
+
+// ensureLoaded builds the user's index from the keywords returned by read, unless it is
+// already loaded. No keyword change runs while the keywords are read.
+func (ki *keywordIndex) ensureLoaded(userID string, read func() ([]string, error)) error {
+	if ki.isLoaded(userID) {
+		return nil
+	}
+	ki.changes.Lock()
+	defer ki.changes.Unlock()
+	if ki.isLoaded(userID) {
+		return nil
+	}
+	keywords, err := read()
+	if err != nil {
+		return err
+	}
+	ki.load(userID, keywords)
+	return nil
+}
+
+// load builds the user's index from the keywords on the notes they have tagged.
+// It is a no-op if the index is already loaded.
+func (ki *keywordIndex) load(userID string, keywords []string) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	if _, ok := ki.users[userID]; ok {
+		return
+	}
+	idx := &userKeywordIndex{}
+	ki.users[userID] = idx
+	for _, keyword := range keywords {
+		ki.addLocked(idx, keyword, 0)
+	}
+}
+
+// add records a use of a keyword by a user. It is ignored until the user's index is loaded.
+func (ki *keywordIndex) add(userID, keyword string) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	idx, ok := ki.users[userID]
+	if !ok {
+		return
+	}
+	ki.clock++
+	ki.addLocked(idx, keyword, ki.clock)
+}
+
+func (ki *keywordIndex) addLocked(idx *userKeywordIndex, keyword string, lastUsed uint64) {
+	i, found := idx.find(keyword)
+	if found {
+		idx.entries[i].count++
+		if lastUsed > idx.entries[i].lastUsed {
+			idx.entries[i].lastUsed = lastUsed
+		}
+		return
+	}
+	entry := &keywordEntry{keyword: keyword, count: 1, lastUsed: lastUsed}
+	idx.entries = append(idx.entries, nil)
+	copy(idx.entries[i+1:], idx.entries[i:])
+	idx.entries[i] = entry
+}
+
+// remove records that a keyword was removed from one of the user's notes.
+func (ki *keywordIndex) remove(userID, keyword string) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	idx, ok := ki.users[userID]
+	if !ok {
+		return
+	}
+	i, found := idx.find(keyword)
+	if !found {
+		return
+	}
+	idx.entries[i].count--
+	if idx.entries[i].count <= 0 {
+		idx.entries = append(idx.entries[:i], idx.entries[i+1:]...)
+	}
+}
+
+// suggest returns up to limit keywords starting with prefix, most used first,
+// breaking ties by the most recently used and then alphabetically.
+func (ki *keywordIndex) suggest(userID, prefix string, limit int) []*KeywordSuggestionDTO {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	idx, ok := ki.users[userID]
+	if !ok {
+		return []*KeywordSuggestionDTO{}
+	}
+
+	start, _ := idx.find(prefix)
+	var matches []*keywordEntry
+	for i := start; i < len(idx.entries) && strings.HasPrefix(idx.entries[i].keyword, prefix); i++ {
+		matches = append(matches, idx.entries[i])
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].count != matches[j].count {
+			return matches[i].count > matches[j].count
+		}
+		return matches[i].lastUsed > matches[j].lastUsed
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	suggestions := make([]*KeywordSuggestionDTO, 0, len(matches))
+	for _, entry := range matches {
+		suggestions = append(suggestions, &KeywordSuggestionDTO{Keyword: entry.keyword, Count: entry.count})
+	}
+	return suggestions
+}
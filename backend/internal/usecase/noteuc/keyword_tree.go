@@ -0,0 +1,55 @@
+package noteuc
+
+import (
+	"sort"
+	"strings"
+
+	domainnote "noteapp/internal/domain/note"
+	"noteapp/internal/repository/noterepo"
+)
+
+// buildKeywordTree builds a user's keyword tree from the notes the user has tagged.
+// A note is counted once per node even if it carries several keywords below that node.
+func buildKeywordTree(userID string, notePOs []*noterepo.NotePO) []*KeywordNodeDTO {
+	nodes := make(map[string]*KeywordNodeDTO)
+	exactNotes := make(map[string]map[string]bool)
+	subtreeNotes := make(map[string]map[string]bool)
+
+	for _, notePO := range notePOs {
+		for _, keyword := range notePO.Keywords[userID] {
+			segments := strings.Split(keyword, domainnote.KeywordPathSeparator)
+			for i := range segments {
+				path := strings.Join(segments[:i+1], domainnote.KeywordPathSeparator)
+				if _, ok := nodes[path]; !ok {
+					nodes[path] = &KeywordNodeDTO{Name: segments[i], Path: path, Children: []*KeywordNodeDTO{}}
+					exactNotes[path] = make(map[string]bool)
+					subtreeNotes[path] = make(map[string]bool)
+				}
+				subtreeNotes[path][notePO.ID] = true
+			}
+			exactNotes[keyword][notePO.ID] = true
+		}
+	}
+
+	roots := []*KeywordNodeDTO{}
+	for path, node := range nodes {
+		node.Count = len(exactNotes[path])
+		node.TotalCount = len(subtreeNotes[path])
+		if i := strings.LastIndex(path, domainnote.KeywordPathSeparator); i >= 0 {
+			parent := nodes[path[:i]]
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+
+	sortKeywordNodes(roots)
+	return roots
+}
+
+func sortKeywordNodes(nodes []*KeywordNodeDTO) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		sortKeywordNodes(node.Children)
+	}
+}
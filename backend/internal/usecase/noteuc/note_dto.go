@@ -1,5 +1,7 @@
 package noteuc
 
+import "time"
+
 // Permission represents the permission level for a collaborator.
 type Permission string
 
@@ -12,11 +14,86 @@ const (
 
 // NoteDTO represents a note.
 type NoteDTO struct {
-	ID            string                `json:"id"`
-	Title         string                `json:"title"`
-	OwnerID       string                `json:"owner_id"`
-	Version       int                   `json:"version"`
-	ContentIDs    []string              `json:"content_ids"`
-	Keywords      map[string][]string   `json:"keywords"`
-	Collaborators map[string]Permission `json:"collaborators"`
+	ID             string                  `json:"id"`
+	Title          string                  `json:"title"`
+	OwnerID        string                  `json:"owner_id"`
+	Version        int                     `json:"version"`
+	ContentIDs     []string                `json:"content_ids"`
+	Keywords       map[string][]string     `json:"keywords"`
+	States         map[string]NoteStateDTO `json:"states"`
+	Collaborators  map[string]Permission   `json:"collaborators"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+	LastModifiedBy string                  `json:"last_modified_by"`
+}
+
+// KeywordNodeDTO represents a node in a user's hierarchical keyword tree.
+// Count is the number of notes tagged with exactly this path, while TotalCount
+// also includes notes tagged with any keyword below it.
+type KeywordNodeDTO struct {
+	Name       string            `json:"name"`
+	Path       string            `json:"path"`
+	Count      int               `json:"count"`
+	TotalCount int               `json:"total_count"`
+	Children   []*KeywordNodeDTO `json:"children"`
+}
+
+// KeywordSuggestionDTO represents an autocomplete suggestion for one of a user's keywords.
+type KeywordSuggestionDTO struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// NoteMatchDTO represents a note found by a fuzzy search. Score is a similarity between 0
+// and 1, and MatchedField and MatchedText tell which keyword or title matched best.
+type NoteMatchDTO struct {
+	Note         *NoteDTO `json:"note"`
+	Score        float64  `json:"score"`
+	MatchedField string   `json:"matched_field"`
+	MatchedText  string   `json:"matched_text"`
+}
+
+// MergeSourceDTO identifies a note to merge into another, at the version the merge is based on.
+type MergeSourceDTO struct {
+	NoteID  string
+	Version int
+}
+
+// NoteStateDTO represents how a user keeps a note: pinned, archived or as a favourite.
+type NoteStateDTO struct {
+	Pinned    bool `json:"pinned"`
+	Archived  bool `json:"archived"`
+	Favourite bool `json:"favourite"`
+}
+
+// NoteStateUpdateDTO describes a change to how a user keeps a note. Flags left nil keep
+// their current value.
+type NoteStateUpdateDTO struct {
+	Pinned    *bool
+	Archived  *bool
+	Favourite *bool
+}
+
+// ListNotesOptions controls the filtering, sorting and pagination of a note listing.
+// Ownership is "all", "owned" or "shared"; Permission is "read" or "read-write";
+// Archived is "exclude", "include" or "only"; SortBy is "title", "created" or "modified";
+// Order is "asc" or "desc". Pinned notes are listed first.
+type ListNotesOptions struct {
+	Ownership      string
+	Permission     string
+	Keyword        string
+	Archived       string
+	FavouritesOnly bool
+	SortBy         string
+	Order          string
+	Cursor         string
+	Limit          int
+}
+
+// NotePageDTO represents a page of notes. Total counts all notes matching the filters,
+// and NextCursor is empty on the last page.
+type NotePageDTO struct {
+	Notes      []*NoteDTO `json:"notes"`
+	Total      int        `json:"total"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
@@ -22,6 +22,11 @@ func (m *NoteMapper) ToPO(note *domainnote.Note) *noterepo.NotePO {
 		}
 	}
 
+	statePOs := make(map[string]noterepo.NoteStatePO)
+	for userID, state := range note.States() {
+		statePOs[userID] = noterepo.NoteStatePO{Pinned: state.Pinned, Archived: state.Archived, Favourite: state.Favourite}
+	}
+
 	collaboratorPOs := make(map[string]string)
 	for userID, permission := range note.Collaborators {
 		collaboratorPOs[userID] = string(permission)
@@ -31,13 +36,17 @@ func (m *NoteMapper) ToPO(note *domainnote.Note) *noterepo.NotePO {
 	copy(contentIDs, note.ContentIDs)
 
 	return &noterepo.NotePO{
-		ID:            note.ID,
-		OwnerID:       note.OwnerID,
-		Title:         note.Title,
-		Version:       note.Version,
-		ContentIDs:    contentIDs,
-		Keywords:      keywordPOs,
-		Collaborators: collaboratorPOs,
+		ID:             note.ID,
+		OwnerID:        note.OwnerID,
+		Title:          note.Title,
+		Version:        note.Version,
+		ContentIDs:     contentIDs,
+		Keywords:       keywordPOs,
+		States:         statePOs,
+		Collaborators:  collaboratorPOs,
+		CreatedAt:      note.CreatedAt,
+		UpdatedAt:      note.UpdatedAt,
+		LastModifiedBy: note.LastModifiedBy,
 	}
 }
 
@@ -57,6 +66,12 @@ func (m *NoteMapper) ToDomain(po *noterepo.NotePO) *domainnote.Note {
 	for userID, permission := range po.Collaborators {
 		note.AddCollaborator(note.OwnerID, userID, domainnote.Permission(permission))
 	}
+	for userID, state := range po.States {
+		note.SetState(userID, domainnote.State{Pinned: state.Pinned, Archived: state.Archived, Favourite: state.Favourite})
+	}
+	note.CreatedAt = po.CreatedAt
+	note.UpdatedAt = po.UpdatedAt
+	note.LastModifiedBy = po.LastModifiedBy
 	return note
 }
 
@@ -68,6 +83,11 @@ func (m *NoteMapper) toNoteDTO(note *domainnote.Note) *NoteDTO {
 		}
 	}
 
+	states := make(map[string]NoteStateDTO)
+	for userID, state := range note.States() {
+		states[userID] = toNoteStateDTO(state)
+	}
+
 	collaborators := make(map[string]Permission)
 	for userID, permission := range note.Collaborators {
 		collaborators[userID] = Permission(permission)
@@ -77,12 +97,20 @@ func (m *NoteMapper) toNoteDTO(note *domainnote.Note) *NoteDTO {
 	copy(contentIDs, note.ContentIDs)
 
 	return &NoteDTO{
-		ID:            note.ID,
-		Title:         note.Title,
-		OwnerID:       note.OwnerID,
-		Version:       note.Version,
-		ContentIDs:    contentIDs,
-		Keywords:      keywords,
-		Collaborators: collaborators,
+		ID:             note.ID,
+		Title:          note.Title,
+		OwnerID:        note.OwnerID,
+		Version:        note.Version,
+		ContentIDs:     contentIDs,
+		Keywords:       keywords,
+		States:         states,
+		Collaborators:  collaborators,
+		CreatedAt:      note.CreatedAt,
+		UpdatedAt:      note.UpdatedAt,
+		LastModifiedBy: note.LastModifiedBy,
 	}
 }
+
+func toNoteStateDTO(state domainnote.State) NoteStateDTO {
+	return NoteStateDTO{Pinned: state.Pinned, Archived: state.Archived, Favourite: state.Favourite}
+}
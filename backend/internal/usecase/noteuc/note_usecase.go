@@ -3,6 +3,7 @@ package noteuc
 import (
 	"errors"
 	"fmt"
+	"noteapp/internal/clock"
 	"noteapp/internal/domain/note"
 	"noteapp/internal/repository/noterepo"
 )
@@ -25,6 +26,9 @@ var ErrContentNotFound = errors.New("content not found")
 // ErrEmptyKeyword is returned when a keyword is empty.
 var ErrEmptyKeyword = errors.New("keyword cannot be empty")
 
+// ErrInvalidKeywordPath is returned when a hierarchical keyword contains an empty segment.
+var ErrInvalidKeywordPath = errors.New("keyword path cannot contain empty segments")
+
 // ErrUserNotFound is returned when a user is not found.
 var ErrUserNotFound = errors.New("user not found")
 
@@ -43,6 +47,32 @@ var ErrIndexOutOfBounds = errors.New("index out of bounds")
 // ErrConflict is returned when a version conflict occurs.
 var ErrConflict = errors.New("conflict")
 
+// ErrInvalidCursor is returned when a note listing cursor is malformed.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrUnsupportedSortField is returned when notes are listed by an unknown sort field or order.
+var ErrUnsupportedSortField = errors.New("unsupported sort field")
+
+// ErrUnsupportedOwnershipFilter is returned when notes are filtered by an unknown ownership.
+var ErrUnsupportedOwnershipFilter = errors.New("unsupported ownership filter")
+
+// ErrUnsupportedArchivedFilter is returned when archived notes are filtered by an unknown mode.
+var ErrUnsupportedArchivedFilter = errors.New("unsupported archived filter")
+
+// ErrInvalidThreshold is returned when a fuzzy search threshold lies outside (0, 1].
+var ErrInvalidThreshold = errors.New("threshold must be between 0 and 1")
+
+// ErrInvalidContentOrder is returned when a new order of contents is not a permutation of the
+// note's contents.
+var ErrInvalidContentOrder = errors.New("content order must list each content of the note exactly once")
+
+// ErrSameNote is returned when a content is moved to the note it is already in.
+var ErrSameNote = errors.New("content is already in the target note")
+
+// ErrInvalidMergeSources is returned when the notes merged into a note are missing, repeated or
+// include the note itself.
+var ErrInvalidMergeSources = errors.New("notes to merge must be distinct from each other and from the target note")
+
 type ContentType string
 
 const (
@@ -53,14 +83,49 @@ const (
 )
 
 // NoteUsecase handles the business logic for notes.
+// Changes to the title or to the list of contents are recorded as modifications of the
+// note, while keywords and sharing are metadata and leave the timestamps untouched.
 type NoteUsecase struct {
-	repo   noterepo.NoteRepository
-	mapper *NoteMapper
+	repo     noterepo.NoteRepository
+	mapper   *NoteMapper
+	keywords *keywordIndex
+	clock    clock.Clock
 }
 
-// NewNoteUsecase creates a new NoteUsecase.
+// DefaultKeywordSuggestionLimit is the number of suggestions returned when no limit is given.
+const DefaultKeywordSuggestionLimit = 10
+
+// MaxKeywordSuggestionLimit caps the number of suggestions returned by SuggestKeywords.
+const MaxKeywordSuggestionLimit = 50
+
+// DefaultNotePageLimit is the number of notes in a page when no limit is given.
+const DefaultNotePageLimit = 50
+
+// MaxNotePageLimit caps the number of notes in a page returned by ListAccessibleNotes.
+const MaxNotePageLimit = 100
+
+// DuplicateTitleSuffix is appended to the title of a duplicated note when no title is given.
+const DuplicateTitleSuffix = " (copy)"
+
+// SplitTitleSuffix is appended to the title of a note split off another when no title is given.
+const SplitTitleSuffix = " (continued)"
+
+// DefaultFuzzyThreshold is the minimum similarity of a fuzzy match when no threshold is given.
+// It tolerates roughly one typo in a ten-letter word.
+const DefaultFuzzyThreshold = 0.75
+
+// maxMoveBackAttempts bounds how often undoing the move of a content is retried when either
+// note changes in the meantime.
+const maxMoveBackAttempts = 3
+
+// NewNoteUsecase creates a new NoteUsecase that uses the system clock.
 func NewNoteUsecase(repo noterepo.NoteRepository) *NoteUsecase {
-	return &NoteUsecase{repo: repo, mapper: NewNoteMapper()}
+	return NewNoteUsecaseWithClock(repo, clock.NewSystemClock())
+}
+
+// NewNoteUsecaseWithClock creates a new NoteUsecase that reads the time from c.
+func NewNoteUsecaseWithClock(repo noterepo.NoteRepository, c clock.Clock) *NoteUsecase {
+	return &NoteUsecase{repo: repo, mapper: NewNoteMapper(), keywords: newKeywordIndex(), clock: c}
 }
 
 // CreateNote creates a new note.
@@ -69,6 +134,7 @@ func (uc *NoteUsecase) CreateNote(id, title, ownerID string) (string, error) {
 	if err != nil {
 		return "", uc.mapDomainError(err)
 	}
+	n.MarkCreated(ownerID, uc.clock.Now())
 
 	notePO := uc.mapper.ToPO(n)
 	if err := uc.repo.Save(notePO); err != nil {
@@ -92,9 +158,35 @@ func (uc *NoteUsecase) GetNoteByID(id string) (*NoteDTO, error) {
 	return uc.mapper.toNoteDTO(n), nil
 }
 
+// CheckCanView returns ErrPermissionDenied unless a user can view a note.
+func (uc *NoteUsecase) CheckCanView(noteID, userID string) error {
+	return uc.checkAccess(noteID, userID, (*note.Note).CanView)
+}
+
+// CheckCanEdit returns ErrPermissionDenied unless a user can change a note.
+func (uc *NoteUsecase) CheckCanEdit(noteID, userID string) error {
+	return uc.checkAccess(noteID, userID, (*note.Note).CanEdit)
+}
+
+func (uc *NoteUsecase) checkAccess(noteID, userID string, allowed func(*note.Note, string) bool) error {
+	if noteID == "" || userID == "" {
+		return ErrInvalidID
+	}
+	notePO, err := uc.repo.FindByID(noteID)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	if !allowed(uc.mapper.ToDomain(notePO), userID) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
 // DeleteNote deletes a note by its ID.
 func (uc *NoteUsecase) DeleteNote(id string, version int) error {
-	_, err := uc.getNotePOAndCheckVersion(id, version)
+	defer uc.keywords.change()()
+
+	notePO, err := uc.getNotePOAndCheckVersion(id, version)
 	if err != nil {
 		return err
 	}
@@ -103,10 +195,17 @@ func (uc *NoteUsecase) DeleteNote(id string, version int) error {
 		return uc.mapRepositoryError(err)
 	}
 
+	for userID, keywords := range notePO.Keywords {
+		for _, keyword := range keywords {
+			uc.keywords.remove(userID, keyword)
+		}
+	}
+
 	return nil
 }
 
-func (uc *NoteUsecase) AddContent(noteID, contentID string, index, version int) error {
+// AddContent inserts a content into a note on behalf of editorID.
+func (uc *NoteUsecase) AddContent(noteID, editorID, contentID string, index, version int) error {
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
 		return err
@@ -117,6 +216,7 @@ func (uc *NoteUsecase) AddContent(noteID, contentID string, index, version int)
 	if err := n.AddContentID(contentID, index); err != nil {
 		return uc.mapDomainError(err)
 	}
+	n.MarkModified(editorID, uc.clock.Now())
 
 	updatedNotePO := uc.mapper.ToPO(n)
 	if err := uc.repo.Save(updatedNotePO); err != nil {
@@ -126,8 +226,79 @@ func (uc *NoteUsecase) AddContent(noteID, contentID string, index, version int)
 	return nil
 }
 
-// ChangeTitle updates the title of a note.
-func (uc *NoteUsecase) ChangeTitle(noteID, newTitle string, version int) error {
+// AddContents inserts several contents into a note on behalf of editorID, in order, starting at
+// index. If index is -1, the contents are appended.
+func (uc *NoteUsecase) AddContents(noteID, editorID string, contentIDs []string, index, version int) error {
+	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
+	if err != nil {
+		return err
+	}
+
+	n := uc.mapper.ToDomain(notePO)
+	for i, contentID := range contentIDs {
+		at := index
+		if index != -1 {
+			at = index + i
+		}
+		if err := n.AddContentID(contentID, at); err != nil {
+			return uc.mapDomainError(err)
+		}
+	}
+	n.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(n)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// DuplicateNote creates a new note owned by userID from a note the user can view, and returns
+// the ID of the new note along with the contents of the original, for the caller to copy and
+// add with AddContents. The new note takes the given title, or the original's followed by
+// DuplicateTitleSuffix. The user's keywords on the original are carried over if keepKeywords
+// is set; collaborators never are.
+func (uc *NoteUsecase) DuplicateNote(sourceID, userID, title string, keepKeywords bool) (string, []string, error) {
+	if sourceID == "" || userID == "" {
+		return "", nil, ErrInvalidID
+	}
+	defer uc.keywords.change()()
+
+	sourcePO, err := uc.repo.FindByID(sourceID)
+	if err != nil {
+		return "", nil, uc.mapRepositoryError(err)
+	}
+	source := uc.mapper.ToDomain(sourcePO)
+	if !source.CanView(userID) {
+		return "", nil, ErrPermissionDenied
+	}
+	if title == "" {
+		title = source.Title + DuplicateTitleSuffix
+	}
+
+	n, err := note.NewNote("", title, userID)
+	if err != nil {
+		return "", nil, uc.mapDomainError(err)
+	}
+	var keywords []note.Keyword
+	if keepKeywords {
+		keywords = source.UserKeywords(userID)
+	}
+	for _, keyword := range keywords {
+		n.AddKeyword(userID, keyword)
+	}
+	n.MarkCreated(userID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(n)); err != nil {
+		return "", nil, uc.mapRepositoryError(err)
+	}
+	for _, keyword := range keywords {
+		uc.keywords.add(userID, keyword.String())
+	}
+	return n.ID, source.ContentIDs, nil
+}
+
+// ChangeTitle updates the title of a note on behalf of editorID.
+func (uc *NoteUsecase) ChangeTitle(noteID, editorID, newTitle string, version int) error {
 	if noteID == "" {
 		return ErrInvalidID
 	}
@@ -140,6 +311,7 @@ func (uc *NoteUsecase) ChangeTitle(noteID, newTitle string, version int) error {
 	if err := n.ChangeTitle(newTitle); err != nil {
 		return uc.mapDomainError(err)
 	}
+	n.MarkModified(editorID, uc.clock.Now())
 
 	updatedNotePO := uc.mapper.ToPO(n)
 	if err := uc.repo.Save(updatedNotePO); err != nil {
@@ -149,7 +321,8 @@ func (uc *NoteUsecase) ChangeTitle(noteID, newTitle string, version int) error {
 	return nil
 }
 
-func (uc *NoteUsecase) RemoveContent(noteID, contentID string, version int) error {
+// RemoveContent removes a content from a note on behalf of editorID.
+func (uc *NoteUsecase) RemoveContent(noteID, editorID, contentID string, version int) error {
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
 		return err
@@ -159,6 +332,7 @@ func (uc *NoteUsecase) RemoveContent(noteID, contentID string, version int) erro
 	if err := n.RemoveContentID(contentID); err != nil {
 		return uc.mapDomainError(err)
 	}
+	n.MarkModified(editorID, uc.clock.Now())
 
 	updatedNotePO := uc.mapper.ToPO(n)
 	if err := uc.repo.Save(updatedNotePO); err != nil {
@@ -168,8 +342,282 @@ func (uc *NoteUsecase) RemoveContent(noteID, contentID string, version int) erro
 	return nil
 }
 
+// MoveContentWithinNote moves a content of a note to index on behalf of editorID, and returns
+// the new order of the note's contents.
+func (uc *NoteUsecase) MoveContentWithinNote(noteID, editorID, contentID string, index, version int) ([]string, error) {
+	return uc.reorderContents(noteID, editorID, version, func(n *note.Note) error {
+		return n.MoveContentID(contentID, index)
+	})
+}
+
+// ReorderContents replaces the order of a note's contents on behalf of editorID. The order must
+// list each content of the note exactly once.
+func (uc *NoteUsecase) ReorderContents(noteID, editorID string, order []string, version int) ([]string, error) {
+	return uc.reorderContents(noteID, editorID, version, func(n *note.Note) error {
+		return n.ReorderContentIDs(order)
+	})
+}
+
+func (uc *NoteUsecase) reorderContents(noteID, editorID string, version int, reorder func(*note.Note) error) ([]string, error) {
+	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	n := uc.mapper.ToDomain(notePO)
+	if !n.CanEdit(editorID) {
+		return nil, ErrPermissionDenied
+	}
+	if err := reorder(n); err != nil {
+		return nil, uc.mapDomainError(err)
+	}
+	n.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(n)); err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return n.ContentIDs, nil
+}
+
+// MoveContent moves a content from one note to another on behalf of editorID, inserting it
+// at index in the target note. The editor must be able to edit both notes, and both notes
+// are saved together, so the content is never in both notes or in neither.
+func (uc *NoteUsecase) MoveContent(contentID, sourceNoteID, targetNoteID, editorID string, index, sourceVersion, targetVersion int) error {
+	if sourceNoteID == targetNoteID {
+		return ErrSameNote
+	}
+	sourcePO, err := uc.getNotePOAndCheckVersion(sourceNoteID, sourceVersion)
+	if err != nil {
+		return err
+	}
+	targetPO, err := uc.getNotePOAndCheckVersion(targetNoteID, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	source := uc.mapper.ToDomain(sourcePO)
+	target := uc.mapper.ToDomain(targetPO)
+	if !source.CanEdit(editorID) || !target.CanEdit(editorID) {
+		return ErrPermissionDenied
+	}
+	if err := source.RemoveContentID(contentID); err != nil {
+		return uc.mapDomainError(err)
+	}
+	if err := target.AddContentID(contentID, index); err != nil {
+		return uc.mapDomainError(err)
+	}
+	now := uc.clock.Now()
+	source.MarkModified(editorID, now)
+	target.MarkModified(editorID, now)
+
+	if err := uc.repo.SaveAll(uc.mapper.ToPO(source), uc.mapper.ToPO(target)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// MoveContentBack undoes MoveContent when the content itself could not be moved, putting it
+// back at index in the note it came from, or at its end if the note has since shrunk. Unlike
+// MoveContent, it applies to the current versions of both notes, retrying when they change.
+func (uc *NoteUsecase) MoveContentBack(contentID, sourceNoteID, targetNoteID, editorID string, index int) error {
+	for attempt := 0; ; attempt++ {
+		sourcePO, err := uc.repo.FindByID(sourceNoteID)
+		if err != nil {
+			return uc.mapRepositoryError(err)
+		}
+		targetPO, err := uc.repo.FindByID(targetNoteID)
+		if err != nil {
+			return uc.mapRepositoryError(err)
+		}
+		at := index
+		if at > len(sourcePO.ContentIDs) {
+			at = -1
+		}
+		err = uc.MoveContent(contentID, targetNoteID, sourceNoteID, editorID, at, targetPO.Version, sourcePO.Version)
+		if errors.Is(err, ErrConflict) && attempt < maxMoveBackAttempts-1 {
+			continue
+		}
+		return err
+	}
+}
+
+// SplitNote moves the contents of a note from index onwards into a new note on behalf of
+// editorID, and returns the ID of the new note along with the contents moved, for the caller to
+// move with the content usecase. The new note takes the given title, or the original's followed
+// by SplitTitleSuffix, and the owner, collaborators and keywords of the original, so everyone
+// keeps access to the moved contents. Both notes are saved together.
+func (uc *NoteUsecase) SplitNote(noteID, editorID string, index int, title string, version int) (string, []string, error) {
+	defer uc.keywords.change()()
+
+	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
+	if err != nil {
+		return "", nil, err
+	}
+
+	source := uc.mapper.ToDomain(notePO)
+	if !source.CanEdit(editorID) {
+		return "", nil, ErrPermissionDenied
+	}
+	if title == "" {
+		title = source.Title + SplitTitleSuffix
+	}
+	split, err := note.NewNote("", title, source.OwnerID)
+	if err != nil {
+		return "", nil, uc.mapDomainError(err)
+	}
+	moved, err := source.SplitContentIDs(index)
+	if err != nil {
+		return "", nil, uc.mapDomainError(err)
+	}
+	split.ContentIDs = moved
+	for collaboratorID, permission := range source.Collaborators {
+		split.Collaborators[collaboratorID] = permission
+	}
+	keywords := source.Keywords()
+	for userID, userKeywords := range keywords {
+		for _, keyword := range userKeywords {
+			split.AddKeyword(userID, keyword)
+		}
+	}
+	now := uc.clock.Now()
+	source.MarkModified(editorID, now)
+	split.MarkCreated(editorID, now)
+
+	if err := uc.repo.SaveAll(uc.mapper.ToPO(source), uc.mapper.ToPO(split)); err != nil {
+		return "", nil, uc.mapRepositoryError(err)
+	}
+	for userID, userKeywords := range keywords {
+		for _, keyword := range userKeywords {
+			uc.keywords.add(userID, keyword.String())
+		}
+	}
+	return split.ID, moved, nil
+}
+
+// MergeNotes appends the contents of other notes to a note on behalf of editorID and deletes
+// them, and returns the new order of the note's contents. Each user's keywords on the merged
+// notes are added to the note, while its title and collaborators are kept. The editor must be
+// able to edit every note, and the note is saved and the others deleted together.
+func (uc *NoteUsecase) MergeNotes(noteID, editorID string, version int, sources []MergeSourceDTO) ([]string, error) {
+	defer uc.keywords.change()()
+
+	target, deleted, err := uc.loadMerge(noteID, editorID, version, sources)
+	if err != nil {
+		return nil, err
+	}
+	added := make(map[string][]note.Keyword)
+	for _, sourcePO := range deleted {
+		for userID, keywords := range target.Merge(uc.mapper.ToDomain(sourcePO)) {
+			added[userID] = append(added[userID], keywords...)
+		}
+	}
+	target.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.SaveAllAndDelete([]*noterepo.NotePO{uc.mapper.ToPO(target)}, deleted); err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	for userID, keywords := range added {
+		for _, keyword := range keywords {
+			uc.keywords.add(userID, keyword.String())
+		}
+	}
+	for _, sourcePO := range deleted {
+		for userID, keywords := range sourcePO.Keywords {
+			for _, keyword := range keywords {
+				uc.keywords.remove(userID, keyword)
+			}
+		}
+	}
+	return target.ContentIDs, nil
+}
+
+// CheckMerge checks that editorID can merge the given notes into a note at the given versions,
+// and returns the contents of each note to merge by its ID, for the caller to move with the
+// content usecase before merging the notes.
+func (uc *NoteUsecase) CheckMerge(noteID, editorID string, version int, sources []MergeSourceDTO) (map[string][]string, error) {
+	_, merged, err := uc.loadMerge(noteID, editorID, version, sources)
+	if err != nil {
+		return nil, err
+	}
+	contentIDs := make(map[string][]string, len(merged))
+	for _, sourcePO := range merged {
+		contentIDs[sourcePO.ID] = sourcePO.ContentIDs
+	}
+	return contentIDs, nil
+}
+
+// loadMerge loads a note and the notes to merge into it at the given versions, checking that
+// editorID can edit all of them.
+func (uc *NoteUsecase) loadMerge(noteID, editorID string, version int, sources []MergeSourceDTO) (*note.Note, []*noterepo.NotePO, error) {
+	if len(sources) == 0 {
+		return nil, nil, ErrInvalidMergeSources
+	}
+	seen := map[string]bool{noteID: true}
+	for _, source := range sources {
+		if seen[source.NoteID] {
+			return nil, nil, ErrInvalidMergeSources
+		}
+		seen[source.NoteID] = true
+	}
+
+	targetPO, err := uc.getNotePOAndCheckVersion(noteID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := uc.mapper.ToDomain(targetPO)
+	if !target.CanEdit(editorID) {
+		return nil, nil, ErrPermissionDenied
+	}
+	merged := make([]*noterepo.NotePO, 0, len(sources))
+	for _, source := range sources {
+		sourcePO, err := uc.getNotePOAndCheckVersion(source.NoteID, source.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !uc.mapper.ToDomain(sourcePO).CanEdit(editorID) {
+			return nil, nil, ErrPermissionDenied
+		}
+		merged = append(merged, sourcePO)
+	}
+	return target, merged, nil
+}
+
+// AddContentCopy inserts copyID, a copy of a content of another note, into a note on behalf
+// of editorID. The editor must be able to view the note the original is in and to edit the
+// note the copy is added to.
+func (uc *NoteUsecase) AddContentCopy(sourceNoteID, sourceContentID, targetNoteID, editorID, copyID string, index, targetVersion int) error {
+	sourcePO, err := uc.repo.FindByID(sourceNoteID)
+	if err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	targetPO, err := uc.getNotePOAndCheckVersion(targetNoteID, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	source := uc.mapper.ToDomain(sourcePO)
+	target := uc.mapper.ToDomain(targetPO)
+	if !source.CanView(editorID) || !target.CanEdit(editorID) {
+		return ErrPermissionDenied
+	}
+	if !containsString(source.ContentIDs, sourceContentID) {
+		return ErrContentNotFound
+	}
+	if err := target.AddContentID(copyID, index); err != nil {
+		return uc.mapDomainError(err)
+	}
+	target.MarkModified(editorID, uc.clock.Now())
+
+	if err := uc.repo.Save(uc.mapper.ToPO(target)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
 // TagNote adds a keyword to a note for a specific user.
 func (uc *NoteUsecase) TagNote(noteID, userID, keywordStr string, version int) error {
+	defer uc.keywords.change()()
+
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
 		return err
@@ -187,12 +635,15 @@ func (uc *NoteUsecase) TagNote(noteID, userID, keywordStr string, version int) e
 	if err := uc.repo.Save(updatedNotePO); err != nil {
 		return uc.mapRepositoryError(err)
 	}
+	uc.keywords.add(userID, keyword.String())
 
 	return nil
 }
 
 // UntagNote removes a keyword from a note for a specific user.
 func (uc *NoteUsecase) UntagNote(noteID, userID, keywordStr string, version int) error {
+	defer uc.keywords.change()()
+
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
 		return err
@@ -212,6 +663,7 @@ func (uc *NoteUsecase) UntagNote(noteID, userID, keywordStr string, version int)
 	if err := uc.repo.Save(updatedNotePO); err != nil {
 		return uc.mapRepositoryError(err)
 	}
+	uc.keywords.remove(userID, keyword.String())
 
 	return nil
 }
@@ -236,6 +688,110 @@ func (uc *NoteUsecase) FindNotesByKeyword(userID, keyword string) ([]*NoteDTO, e
 	return noteDTOs, nil
 }
 
+// FindNotesByKeywordWithDescendants finds notes tagged by a user with a keyword or with
+// any hierarchical keyword below it, so searching "cs/os" also finds "cs/os/concurrency".
+func (uc *NoteUsecase) FindNotesByKeywordWithDescendants(userID, keyword string) ([]*NoteDTO, error) {
+	if userID == "" || keyword == "" {
+		return []*NoteDTO{}, nil
+	}
+
+	if _, err := note.NewKeyword(keyword); err != nil {
+		return nil, uc.mapDomainError(err)
+	}
+
+	notePOs, err := uc.repo.FindByKeywordWithDescendantsForUser(userID, keyword)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	var noteDTOs []*NoteDTO
+	for _, notePO := range notePOs {
+		n := uc.mapper.ToDomain(notePO)
+		noteDTOs = append(noteDTOs, uc.mapper.toNoteDTO(n))
+	}
+
+	return noteDTOs, nil
+}
+
+// FindNotesByFuzzyMatch finds notes whose title or whose keywords applied by the user are
+// similar to query, so typos such as "concurency" still find "concurrency". Matches are
+// ordered by similarity, best first. A zero threshold falls back to DefaultFuzzyThreshold.
+func (uc *NoteUsecase) FindNotesByFuzzyMatch(userID, query string, threshold float64) ([]*NoteMatchDTO, error) {
+	if threshold == 0 {
+		threshold = DefaultFuzzyThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, ErrInvalidThreshold
+	}
+	if userID == "" || query == "" {
+		return []*NoteMatchDTO{}, nil
+	}
+
+	matchPOs, err := uc.repo.FindByFuzzyMatchForUser(userID, query, threshold)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	matchDTOs := []*NoteMatchDTO{}
+	for _, matchPO := range matchPOs {
+		n := uc.mapper.ToDomain(matchPO.Note)
+		matchDTOs = append(matchDTOs, &NoteMatchDTO{
+			Note:         uc.mapper.toNoteDTO(n),
+			Score:        matchPO.Score,
+			MatchedField: matchPO.MatchedField,
+			MatchedText:  matchPO.MatchedText,
+		})
+	}
+
+	return matchDTOs, nil
+}
+
+// GetKeywordTree returns the hierarchy of a user's keywords with the number of notes under each node.
+func (uc *NoteUsecase) GetKeywordTree(userID string) ([]*KeywordNodeDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+
+	notePOs, err := uc.repo.FindTaggedByUser(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	return buildKeywordTree(userID, notePOs), nil
+}
+
+// SuggestKeywords returns up to limit of the user's keywords that start with prefix,
+// ranked by how many notes use them and then by how recently they were applied.
+// A non-positive limit falls back to DefaultKeywordSuggestionLimit.
+func (uc *NoteUsecase) SuggestKeywords(userID, prefix string, limit int) ([]*KeywordSuggestionDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	if limit <= 0 {
+		limit = DefaultKeywordSuggestionLimit
+	}
+	if limit > MaxKeywordSuggestionLimit {
+		limit = MaxKeywordSuggestionLimit
+	}
+
+	err := uc.keywords.ensureLoaded(userID, func() ([]string, error) {
+		notePOs, err := uc.repo.FindTaggedByUser(userID)
+		if err != nil {
+			return nil, uc.mapRepositoryError(err)
+		}
+		var keywords []string
+		for _, notePO := range notePOs {
+			keywords = append(keywords, notePO.Keywords[userID]...)
+		}
+		return keywords, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.keywords.suggest(userID, prefix, limit), nil
+}
+
 // ShareNote shares a note with another user.
 func (uc *NoteUsecase) ShareNote(noteID, ownerID, collaboratorID, permission string, version int) error {
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
@@ -262,24 +818,118 @@ func (uc *NoteUsecase) ShareNote(noteID, ownerID, collaboratorID, permission str
 	return nil
 }
 
-// GetAccessibleNotesForUser retrieves all notes that a user can access (owned or shared).
-func (uc *NoteUsecase) GetAccessibleNotesForUser(userID string) ([]*NoteDTO, error) {
-	notePOs, err := uc.repo.GetAccessibleNotesByUserID(userID)
+// UpdateNoteState changes how a user who can view a note keeps it, and returns the user's
+// new state of the note. Like keywords, the state only affects the user's own lists.
+func (uc *NoteUsecase) UpdateNoteState(noteID, userID string, update NoteStateUpdateDTO, version int) (*NoteStateDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
+		return nil, err
+	}
+	n := uc.mapper.ToDomain(notePO)
+
+	state := n.State(userID)
+	if update.Pinned != nil {
+		state.Pinned = *update.Pinned
+	}
+	if update.Archived != nil {
+		state.Archived = *update.Archived
+	}
+	if update.Favourite != nil {
+		state.Favourite = *update.Favourite
+	}
+	if err := n.SetState(userID, state); err != nil {
+		return nil, uc.mapDomainError(err)
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(n)); err != nil {
 		return nil, uc.mapRepositoryError(err)
 	}
+	dto := toNoteStateDTO(state)
+	return &dto, nil
+}
 
-	var noteDTOs []*NoteDTO
-	for _, notePO := range notePOs {
-		n := uc.mapper.ToDomain(notePO)
-		noteDTOs = append(noteDTOs, uc.mapper.toNoteDTO(n))
+// ListAccessibleNotes returns a page of the notes a user owns or collaborates on, filtered and
+// sorted as requested. Notes are sorted by title unless another sort field is given. A
+// non-positive limit falls back to DefaultNotePageLimit; larger limits are capped at
+// MaxNotePageLimit.
+func (uc *NoteUsecase) ListAccessibleNotes(userID string, opts ListNotesOptions) (*NotePageDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultNotePageLimit
+	}
+	if limit > MaxNotePageLimit {
+		limit = MaxNotePageLimit
 	}
 
-	return noteDTOs, nil
+	query := noterepo.NoteListQuery{
+		UserID:  userID,
+		Keyword: opts.Keyword,
+		Cursor:  opts.Cursor,
+		Limit:   limit,
+	}
+	switch opts.SortBy {
+	case "", noterepo.SortByTitle:
+		query.SortBy = noterepo.SortByTitle
+	case noterepo.SortByCreated, noterepo.SortByModified:
+		query.SortBy = opts.SortBy
+	default:
+		return nil, ErrUnsupportedSortField
+	}
+	switch opts.Order {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, ErrUnsupportedSortField
+	}
+	switch opts.Ownership {
+	case "", "all":
+		query.Ownership = noterepo.OwnershipAll
+	case noterepo.OwnershipOwned, noterepo.OwnershipShared:
+		query.Ownership = opts.Ownership
+	default:
+		return nil, ErrUnsupportedOwnershipFilter
+	}
+	if opts.Permission != "" {
+		permission, err := mapToDomainPermissionType(opts.Permission)
+		if err != nil {
+			return nil, err
+		}
+		query.Permission = string(permission)
+	}
+	switch opts.Archived {
+	case "", "exclude":
+		query.Archived = noterepo.ArchivedExclude
+	case noterepo.ArchivedInclude, noterepo.ArchivedOnly:
+		query.Archived = opts.Archived
+	default:
+		return nil, ErrUnsupportedArchivedFilter
+	}
+	query.FavouritesOnly = opts.FavouritesOnly
+
+	pagePO, err := uc.repo.ListAccessibleNotes(query)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	page := &NotePageDTO{Notes: []*NoteDTO{}, Total: pagePO.Total, NextCursor: pagePO.NextCursor}
+	for _, notePO := range pagePO.Notes {
+		n := uc.mapper.ToDomain(notePO)
+		page.Notes = append(page.Notes, uc.mapper.toNoteDTO(n))
+	}
+	return page, nil
 }
 
 // RevokeAccess revokes a collaborator's access to a note.
 func (uc *NoteUsecase) RevokeAccess(noteID, ownerID, collaboratorID string, version int) error {
+	defer uc.keywords.change()()
+
 	notePO, err := uc.getNotePOAndCheckVersion(noteID, version)
 	if err != nil {
 		return err
@@ -295,6 +945,10 @@ func (uc *NoteUsecase) RevokeAccess(noteID, ownerID, collaboratorID string, vers
 	if err := uc.repo.Save(updatedNotePO); err != nil {
 		return uc.mapRepositoryError(err)
 	}
+	// Revoking access also drops the collaborator's private keywords on the note.
+	for _, keyword := range notePO.Keywords[collaboratorID] {
+		uc.keywords.remove(collaboratorID, keyword)
+	}
 
 	return nil
 }
@@ -307,6 +961,8 @@ func (uc *NoteUsecase) mapRepositoryError(err error) error {
 		return ErrNilNote
 	case errors.Is(err, noterepo.ErrNoteConflict):
 		return ErrConflict
+	case errors.Is(err, noterepo.ErrInvalidCursor):
+		return ErrInvalidCursor
 	default:
 		return fmt.Errorf("an unexpected repository error occurred: %w", err)
 	}
@@ -320,6 +976,8 @@ func (uc *NoteUsecase) mapDomainError(err error) error {
 		return ErrContentNotFound
 	case errors.Is(err, note.ErrEmptyKeyword):
 		return ErrEmptyKeyword
+	case errors.Is(err, note.ErrInvalidKeywordPath):
+		return ErrInvalidKeywordPath
 	case errors.Is(err, note.ErrUserNotFound):
 		return ErrUserNotFound
 	case errors.Is(err, note.ErrKeywordNotFound):
@@ -328,6 +986,8 @@ func (uc *NoteUsecase) mapDomainError(err error) error {
 		return ErrPermissionDenied
 	case errors.Is(err, note.ErrIndexOutOfBounds):
 		return ErrIndexOutOfBounds
+	case errors.Is(err, note.ErrInvalidContentOrder):
+		return ErrInvalidContentOrder
 	default:
 		return fmt.Errorf("an unexpected domain error occurred: %w", err)
 	}
@@ -356,3 +1016,12 @@ func (uc *NoteUsecase) getNotePOAndCheckVersion(noteID string, version int) (*no
 
 	return notePO, nil
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
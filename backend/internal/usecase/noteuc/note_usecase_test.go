@@ -2,17 +2,27 @@ package noteuc
 
 import (
 	"errors"
+	"fmt"
+	"noteapp/internal/clock"
 	"noteapp/internal/repository/noterepo"
+	"reflect"
 	"testing"
+	"time"
 )
 
 // mockNoteRepository is a mock implementation of the NoteRepository for testing error cases.
 type mockNoteRepository struct {
-	SaveFunc                       func(note *noterepo.NotePO) error
-	FindByIDFunc                   func(id string) (*noterepo.NotePO, error)
-	DeleteFunc                     func(id string) error
-	FindByKeywordForUserFunc       func(userID, keyword string) ([]*noterepo.NotePO, error)
-	GetAccessibleNotesByUserIDFunc func(userID string) ([]*noterepo.NotePO, error)
+	SaveFunc                                func(note *noterepo.NotePO) error
+	SaveAllFunc                             func(notes ...*noterepo.NotePO) error
+	SaveAllAndDeleteFunc                    func(notes []*noterepo.NotePO, deleted []*noterepo.NotePO) error
+	FindByIDFunc                            func(id string) (*noterepo.NotePO, error)
+	DeleteFunc                              func(id string) error
+	FindByKeywordForUserFunc                func(userID, keyword string) ([]*noterepo.NotePO, error)
+	GetAccessibleNotesByUserIDFunc          func(userID string) ([]*noterepo.NotePO, error)
+	FindByKeywordWithDescendantsForUserFunc func(userID, keyword string) ([]*noterepo.NotePO, error)
+	FindTaggedByUserFunc                    func(userID string) ([]*noterepo.NotePO, error)
+	FindByFuzzyMatchForUserFunc             func(userID, query string, threshold float64) ([]*noterepo.NoteMatchPO, error)
+	ListAccessibleNotesFunc                 func(query noterepo.NoteListQuery) (*noterepo.NotePagePO, error)
 }
 
 func (m *mockNoteRepository) Save(note *noterepo.NotePO) error {
@@ -21,6 +31,18 @@ func (m *mockNoteRepository) Save(note *noterepo.NotePO) error {
 	}
 	return nil
 }
+func (m *mockNoteRepository) SaveAll(notes ...*noterepo.NotePO) error {
+	if m.SaveAllFunc != nil {
+		return m.SaveAllFunc(notes...)
+	}
+	return nil
+}
+func (m *mockNoteRepository) SaveAllAndDelete(notes []*noterepo.NotePO, deleted []*noterepo.NotePO) error {
+	if m.SaveAllAndDeleteFunc != nil {
+		return m.SaveAllAndDeleteFunc(notes, deleted)
+	}
+	return nil
+}
 func (m *mockNoteRepository) FindByID(id string) (*noterepo.NotePO, error) {
 	if m.FindByIDFunc != nil {
 		return m.FindByIDFunc(id)
@@ -45,6 +67,30 @@ func (m *mockNoteRepository) GetAccessibleNotesByUserID(userID string) ([]*noter
 	}
 	return nil, nil
 }
+func (m *mockNoteRepository) FindByKeywordWithDescendantsForUser(userID, keyword string) ([]*noterepo.NotePO, error) {
+	if m.FindByKeywordWithDescendantsForUserFunc != nil {
+		return m.FindByKeywordWithDescendantsForUserFunc(userID, keyword)
+	}
+	return nil, nil
+}
+func (m *mockNoteRepository) FindTaggedByUser(userID string) ([]*noterepo.NotePO, error) {
+	if m.FindTaggedByUserFunc != nil {
+		return m.FindTaggedByUserFunc(userID)
+	}
+	return nil, nil
+}
+func (m *mockNoteRepository) FindByFuzzyMatchForUser(userID, query string, threshold float64) ([]*noterepo.NoteMatchPO, error) {
+	if m.FindByFuzzyMatchForUserFunc != nil {
+		return m.FindByFuzzyMatchForUserFunc(userID, query, threshold)
+	}
+	return nil, nil
+}
+func (m *mockNoteRepository) ListAccessibleNotes(query noterepo.NoteListQuery) (*noterepo.NotePagePO, error) {
+	if m.ListAccessibleNotesFunc != nil {
+		return m.ListAccessibleNotesFunc(query)
+	}
+	return &noterepo.NotePagePO{}, nil
+}
 
 func setUpRepositoryAndUsecase() (*noterepo.InMemoryNoteRepository, *NoteUsecase) {
 	repo := noterepo.NewInMemoryNoteRepository()
@@ -63,8 +109,8 @@ func setUpRepositoryAndUsecaseWithNoteAndContents() (*noterepo.InMemoryNoteRepos
 	noteID, _ := noteUsecase.CreateNote("", "Test Title", "owner-1")
 	contentID := "content-1"
 	contentID1 := "content-2"
-	noteUsecase.AddContent(noteID, contentID, -1, 0)
-	noteUsecase.AddContent(noteID, contentID1, -1, 1)
+	noteUsecase.AddContent(noteID, "owner-1", contentID, -1, 0)
+	noteUsecase.AddContent(noteID, "owner-1", contentID1, -1, 1)
 	return repo, noteUsecase, noteID, contentID, contentID1
 }
 
@@ -235,6 +281,34 @@ func TestNoteUsecase_GetNoteByID_InvalidID(t *testing.T) {
 	}
 }
 
+func TestNoteUsecase_CheckAccess(t *testing.T) {
+	_, noteUsecase, noteID := setUpRepositoryAndUsecaseWithNote()
+	noteUsecase.ShareNote(noteID, "owner-1", "reader-1", "read", 0)
+
+	tests := []struct {
+		name     string
+		check    func(noteID, userID string) error
+		noteID   string
+		userID   string
+		expected error
+	}{
+		{"owner can view", noteUsecase.CheckCanView, noteID, "owner-1", nil},
+		{"reader can view", noteUsecase.CheckCanView, noteID, "reader-1", nil},
+		{"stranger cannot view", noteUsecase.CheckCanView, noteID, "stranger", ErrPermissionDenied},
+		{"owner can edit", noteUsecase.CheckCanEdit, noteID, "owner-1", nil},
+		{"reader cannot edit", noteUsecase.CheckCanEdit, noteID, "reader-1", ErrPermissionDenied},
+		{"missing user", noteUsecase.CheckCanView, noteID, "", ErrInvalidID},
+		{"missing note", noteUsecase.CheckCanEdit, "missing", "owner-1", ErrNoteNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.check(tt.noteID, tt.userID); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected error '%v', got '%v'", tt.expected, err)
+			}
+		})
+	}
+}
+
 func TestNoteUsecase_DeleteNote_Success(t *testing.T) {
 	// Arrange
 	repo, noteUsecase, id := setUpRepositoryAndUsecaseWithNote()
@@ -327,7 +401,7 @@ func TestNoteUsecase_RemoveContent_Success(t *testing.T) {
 	_, noteUsecase, noteID, contentID1, contentID2 := setUpRepositoryAndUsecaseWithNoteAndContents()
 
 	// Act
-	err := noteUsecase.RemoveContent(noteID, contentID1, 2)
+	err := noteUsecase.RemoveContent(noteID, "owner-1", contentID1, 2)
 
 	// Assert
 	if err != nil {
@@ -350,7 +424,7 @@ func TestNoteUsecase_RemoveContent_NoteNotFound(t *testing.T) {
 	_, noteUsecase, _, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
 
 	// Act
-	err := noteUsecase.RemoveContent("non-existent-note-id", "content-id", 0)
+	err := noteUsecase.RemoveContent("non-existent-note-id", "owner-1", "content-id", 0)
 
 	// Assert
 	if err == nil {
@@ -366,7 +440,7 @@ func TestNoteUsecase_RemoveContent_ContentNotFound(t *testing.T) {
 	_, noteUsecase, noteID, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
 
 	// Act
-	err := noteUsecase.RemoveContent(noteID, "non-existent-content-id", 2)
+	err := noteUsecase.RemoveContent(noteID, "owner-1", "non-existent-content-id", 2)
 
 	// Assert
 	if err == nil {
@@ -382,7 +456,7 @@ func TestNoteUsecase_RemoveContent_Conflict(t *testing.T) {
 	_, noteUsecase, noteID, contentID, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
 
 	// Act
-	err := noteUsecase.RemoveContent(noteID, contentID, 99) // Incorrect version
+	err := noteUsecase.RemoveContent(noteID, "owner-1", contentID, 99) // Incorrect version
 
 	// Assert
 	if err == nil {
@@ -676,35 +750,153 @@ func TestNoteUsecase_ShareNote_Conflict(t *testing.T) {
 	}
 }
 
-func TestNoteUsecase_GetAccessibleNotesForUser(t *testing.T) {
+func TestNoteUsecase_ListAccessibleNotes(t *testing.T) {
 	// Arrange
-	_, noteUsecase, ownedNoteID, sharedNoteID, unrelatedNoteID := setUpRepositoryAndUsecaseWithTaggedNotes()
-	noteUsecase.ShareNote(sharedNoteID, "owner-2", "owner-1", "read", 4)
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	noteUsecase.CreateNote("", "Beta", "user-1")
+	noteUsecase.CreateNote("", "Alpha", "user-1")
+	sharedNote, _ := noteUsecase.CreateNote("", "Gamma", "user-2")
+	noteUsecase.ShareNote(sharedNote, "user-2", "user-1", "read", 0)
 
 	// Act
-	notes, err := noteUsecase.GetAccessibleNotesForUser("owner-1")
+	first, err := noteUsecase.ListAccessibleNotes("user-1", ListNotesOptions{SortBy: "title", Order: "desc", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+	}
+	second, err := noteUsecase.ListAccessibleNotes("user-1", ListNotesOptions{SortBy: "title", Order: "desc", Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+	}
+	shared, err := noteUsecase.ListAccessibleNotes("user-1", ListNotesOptions{Ownership: "shared", Permission: "read"})
+	if err != nil {
+		t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+	}
 
 	// Assert
-	if err != nil {
-		t.Fatalf("GetAccessibleNotesForUser() returned an unexpected error: %v", err)
+	if first.Total != 3 || len(first.Notes) != 2 || first.Notes[0].Title != "Gamma" || first.Notes[1].Title != "Beta" {
+		t.Errorf("Unexpected first page: total %d, %d notes", first.Total, len(first.Notes))
 	}
-	if len(notes) != 2 {
-		t.Fatalf("Expected 2 accessible notes, but got %d", len(notes))
+	if len(second.Notes) != 1 || second.Notes[0].Title != "Alpha" || second.NextCursor != "" {
+		t.Errorf("Expected the last page to contain only Alpha")
+	}
+	if shared.Total != 1 || shared.Notes[0].ID != sharedNote {
+		t.Errorf("Expected only the shared note, got total %d", shared.Total)
 	}
+}
 
-	// Check that the correct notes are returned
-	returnedIDs := make(map[string]bool)
-	for _, note := range notes {
-		returnedIDs[note.ID] = true
+func TestNoteUsecase_ListAccessibleNotes_LimitsPageSize(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	for i := 0; i < MaxNotePageLimit+1; i++ {
+		noteUsecase.CreateNote("", fmt.Sprintf("Note %03d", i), "user-1")
 	}
-	if !returnedIDs[ownedNoteID] {
-		t.Errorf("Expected to find owned note with ID %s", ownedNoteID)
+
+	testCases := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{"no limit", 0, DefaultNotePageLimit},
+		{"limit above the maximum", MaxNotePageLimit + 1, MaxNotePageLimit},
 	}
-	if !returnedIDs[sharedNoteID] {
-		t.Errorf("Expected to find shared note with ID %s", sharedNoteID)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			page, err := noteUsecase.ListAccessibleNotes("user-1", ListNotesOptions{Limit: tc.limit})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ListAccessibleNotes() returned an unexpected error: %v", err)
+			}
+			if len(page.Notes) != tc.expected || page.NextCursor == "" {
+				t.Errorf("Expected a page of %d notes with a next cursor, got %d notes", tc.expected, len(page.Notes))
+			}
+		})
 	}
-	if returnedIDs[unrelatedNoteID] {
-		t.Errorf("Did not expect to find unrelated note with ID %s", unrelatedNoteID)
+}
+
+func TestNoteUsecase_ListAccessibleNotes_InvalidOptions(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+
+	testCases := []struct {
+		name        string
+		opts        ListNotesOptions
+		expectedErr error
+	}{
+		{"unsupported sort field", ListNotesOptions{SortBy: "size"}, ErrUnsupportedSortField},
+		{"unsupported order", ListNotesOptions{Order: "sideways"}, ErrUnsupportedSortField},
+		{"unsupported ownership", ListNotesOptions{Ownership: "borrowed"}, ErrUnsupportedOwnershipFilter},
+		{"unsupported permission", ListNotesOptions{Permission: "admin"}, ErrUnsupportedPermissionType},
+		{"unsupported archived filter", ListNotesOptions{Archived: "hidden"}, ErrUnsupportedArchivedFilter},
+		{"invalid cursor", ListNotesOptions{Cursor: "%%%"}, ErrInvalidCursor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := noteUsecase.ListAccessibleNotes("user-1", tc.opts)
+
+			// Assert
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNoteUsecase_UpdateNoteState(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	sharedNoteID, _ := noteUsecase.CreateNote("", "Shared", "owner-1")
+	noteUsecase.ShareNote(sharedNoteID, "owner-1", "collaborator-1", "read", 0)
+	yes, no := true, false
+
+	// Act
+	state, err := noteUsecase.UpdateNoteState(sharedNoteID, "collaborator-1", NoteStateUpdateDTO{Pinned: &yes, Favourite: &yes}, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateNoteState() returned an unexpected error: %v", err)
+	}
+	if *state != (NoteStateDTO{Pinned: true, Favourite: true}) {
+		t.Errorf("Expected the note to be pinned and favourite, got %+v", state)
+	}
+	state, _ = noteUsecase.UpdateNoteState(sharedNoteID, "collaborator-1", NoteStateUpdateDTO{Pinned: &no}, 2)
+	if *state != (NoteStateDTO{Favourite: true}) {
+		t.Errorf("Expected only the pin to change, got %+v", state)
+	}
+	note, _ := noteUsecase.GetNoteByID(sharedNoteID)
+	if len(note.States) != 1 || note.States["collaborator-1"] != *state {
+		t.Errorf("Expected the collaborator's state only, got %+v", note.States)
+	}
+}
+
+func TestNoteUsecase_UpdateNoteState_Errors(t *testing.T) {
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	sharedNoteID, _ := noteUsecase.CreateNote("", "Shared", "owner-1")
+	noteUsecase.ShareNote(sharedNoteID, "owner-1", "collaborator-1", "read", 0)
+	yes := true
+
+	testCases := []struct {
+		name        string
+		userID      string
+		version     int
+		expectedErr error
+	}{
+		{"user without access", "stranger", 1, ErrPermissionDenied},
+		{"stale version", "collaborator-1", 0, ErrConflict},
+		{"missing user", "", 1, ErrInvalidID},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := noteUsecase.UpdateNoteState(sharedNoteID, tc.userID, NoteStateUpdateDTO{Pinned: &yes}, tc.version)
+
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, but got %v", tc.expectedErr, err)
+			}
+		})
 	}
 }
 
@@ -790,7 +982,7 @@ func TestNoteUsecase_AddContent_Success(t *testing.T) {
 	contentID := "new-content-id"
 
 	// Act
-	err := noteUsecase.AddContent(noteID, contentID, -1, 0)
+	err := noteUsecase.AddContent(noteID, "owner-1", contentID, -1, 0)
 
 	// Assert
 	if err != nil {
@@ -814,7 +1006,7 @@ func TestNoteUsecase_AddContent_NoteNotFound(t *testing.T) {
 	contentID := "new-content-id"
 
 	// Act
-	err := noteUsecase.AddContent("non-existent-id", contentID, -1, 0)
+	err := noteUsecase.AddContent("non-existent-id", "owner-1", contentID, -1, 0)
 
 	// Assert
 	if err == nil {
@@ -831,7 +1023,7 @@ func TestNoteUsecase_AddContent_Conflict(t *testing.T) {
 	contentID := "new-content-id"
 
 	// Act
-	err := noteUsecase.AddContent(noteID, contentID, -1, 99) // Incorrect version
+	err := noteUsecase.AddContent(noteID, "owner-1", contentID, -1, 99) // Incorrect version
 
 	// Assert
 	if err == nil {
@@ -848,7 +1040,7 @@ func TestNoteUsecase_AddContent_IndexOutOfBounds(t *testing.T) {
 	contentID := "new-content-id"
 
 	// Act
-	err := noteUsecase.AddContent(noteID, contentID, 99, 0) // Out of bounds index
+	err := noteUsecase.AddContent(noteID, "owner-1", contentID, 99, 0) // Out of bounds index
 
 	// Assert
 	if err == nil {
@@ -865,7 +1057,7 @@ func TestNoteUsecase_ChangeTitle_Success(t *testing.T) {
 	newTitle := "Updated Title"
 
 	// Act
-	err := noteUsecase.ChangeTitle(noteID, newTitle, 0)
+	err := noteUsecase.ChangeTitle(noteID, "owner-1", newTitle, 0)
 
 	// Assert
 	if err != nil {
@@ -888,7 +1080,7 @@ func TestNoteUsecase_ChangeTitle_NotFound(t *testing.T) {
 	_, noteUsecase, _ := setUpRepositoryAndUsecaseWithNote()
 
 	// Act
-	err := noteUsecase.ChangeTitle("non-existent-id", "New Title", 0)
+	err := noteUsecase.ChangeTitle("non-existent-id", "owner-1", "New Title", 0)
 
 	// Assert
 	if err == nil {
@@ -904,7 +1096,7 @@ func TestNoteUsecase_ChangeTitle_InvalidID(t *testing.T) {
 	_, noteUsecase, _ := setUpRepositoryAndUsecaseWithNote()
 
 	// Act
-	err := noteUsecase.ChangeTitle("", "New Title", 0) // Empty ID
+	err := noteUsecase.ChangeTitle("", "owner-1", "New Title", 0) // Empty ID
 
 	// Assert
 	if err == nil {
@@ -920,7 +1112,7 @@ func TestNoteUsecase_ChangeTitle_EmptyTitle(t *testing.T) {
 	_, noteUsecase, noteID := setUpRepositoryAndUsecaseWithNote()
 
 	// Act
-	err := noteUsecase.ChangeTitle(noteID, "", 0) // Empty title
+	err := noteUsecase.ChangeTitle(noteID, "owner-1", "", 0) // Empty title
 
 	// Assert
 	if err == nil {
@@ -936,7 +1128,7 @@ func TestNoteUsecase_ChangeTitle_Conflict(t *testing.T) {
 	_, noteUsecase, noteID := setUpRepositoryAndUsecaseWithNote()
 
 	// Act
-	err := noteUsecase.ChangeTitle(noteID, "New Title", 99) // Incorrect version
+	err := noteUsecase.ChangeTitle(noteID, "owner-1", "New Title", 99) // Incorrect version
 
 	// Assert
 	if err == nil {
@@ -946,3 +1138,666 @@ func TestNoteUsecase_ChangeTitle_Conflict(t *testing.T) {
 		t.Errorf("Expected error to be '%v', but got '%v'", ErrConflict, err)
 	}
 }
+
+func TestNoteUsecase_TagNote_InvalidKeywordPath(t *testing.T) {
+	// Arrange
+	_, noteUsecase, noteID := setUpRepositoryAndUsecaseWithNote()
+
+	// Act
+	err := noteUsecase.TagNote(noteID, "user-1", "cs//os", 0)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidKeywordPath) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrInvalidKeywordPath, err)
+	}
+}
+
+func TestNoteUsecase_FindNotesByKeywordWithDescendants(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	parentNote, _ := noteUsecase.CreateNote("", "OS", "user-1")
+	childNote, _ := noteUsecase.CreateNote("", "Concurrency", "user-1")
+	otherNote, _ := noteUsecase.CreateNote("", "Networks", "user-1")
+	noteUsecase.TagNote(parentNote, "user-1", "cs/os", 0)
+	noteUsecase.TagNote(childNote, "user-1", "cs/os/concurrency", 0)
+	noteUsecase.TagNote(otherNote, "user-1", "cs/networks", 0)
+
+	// Act
+	exact, err := noteUsecase.FindNotesByKeyword("user-1", "cs/os")
+	if err != nil {
+		t.Fatalf("FindNotesByKeyword() returned an unexpected error: %v", err)
+	}
+	withDescendants, err := noteUsecase.FindNotesByKeywordWithDescendants("user-1", "cs/os")
+	if err != nil {
+		t.Fatalf("FindNotesByKeywordWithDescendants() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if len(exact) != 1 {
+		t.Errorf("Expected 1 note for exact search, got %d", len(exact))
+	}
+	if len(withDescendants) != 2 {
+		t.Fatalf("Expected 2 notes with descendants, got %d", len(withDescendants))
+	}
+	for _, n := range withDescendants {
+		if n.ID == otherNote {
+			t.Errorf("Did not expect to find note with ID %s", otherNote)
+		}
+	}
+}
+
+func TestNoteUsecase_FindNotesByFuzzyMatch(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	taggedNote, _ := noteUsecase.CreateNote("", "Threads", "user-1")
+	titledNote, _ := noteUsecase.CreateNote("", "Concurrence", "user-1")
+	noteUsecase.CreateNote("", "Networks", "user-1")
+	noteUsecase.TagNote(taggedNote, "user-1", "cs/os/concurrency", 0)
+
+	// Act
+	matches, err := noteUsecase.FindNotesByFuzzyMatch("user-1", "concurency", 0)
+	if err != nil {
+		t.Fatalf("FindNotesByFuzzyMatch() returned an unexpected error: %v", err)
+	}
+	strict, err := noteUsecase.FindNotesByFuzzyMatch("user-1", "concurency", 0.95)
+	if err != nil {
+		t.Fatalf("FindNotesByFuzzyMatch() returned an unexpected error: %v", err)
+	}
+
+	// Assert
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Note.ID != taggedNote || matches[0].MatchedField != "keyword" {
+		t.Errorf("Expected the keyword match on %s first, got %+v", taggedNote, matches[0])
+	}
+	if matches[1].Note.ID != titledNote || matches[1].MatchedField != "title" {
+		t.Errorf("Expected the title match on %s second, got %+v", titledNote, matches[1])
+	}
+	if len(strict) != 0 {
+		t.Errorf("Expected no matches above a 0.95 threshold, got %d", len(strict))
+	}
+}
+
+func TestNoteUsecase_FindNotesByFuzzyMatch_InvalidThreshold(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+
+	// Act
+	_, err := noteUsecase.FindNotesByFuzzyMatch("user-1", "go", 1.5)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("Expected ErrInvalidThreshold, but got %v", err)
+	}
+}
+
+func TestNoteUsecase_GetKeywordTree(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	note1, _ := noteUsecase.CreateNote("", "Note 1", "user-1")
+	note2, _ := noteUsecase.CreateNote("", "Note 2", "user-1")
+	noteUsecase.TagNote(note1, "user-1", "cs/os", 0)
+	noteUsecase.TagNote(note1, "user-1", "cs/os/concurrency", 1)
+	noteUsecase.TagNote(note2, "user-1", "cs/networks", 0)
+	noteUsecase.TagNote(note2, "user-1", "work", 1)
+	noteUsecase.TagNote(note2, "user-2", "private", 2)
+
+	// Act
+	tree, err := noteUsecase.GetKeywordTree("user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetKeywordTree() returned an unexpected error: %v", err)
+	}
+	if len(tree) != 2 {
+		t.Fatalf("Expected 2 root keywords, got %d", len(tree))
+	}
+	cs := tree[0]
+	if cs.Path != "cs" || cs.Count != 0 || cs.TotalCount != 2 {
+		t.Errorf("Expected cs with count 0 and total 2, got %s with count %d and total %d", cs.Path, cs.Count, cs.TotalCount)
+	}
+	if len(cs.Children) != 2 {
+		t.Fatalf("Expected 2 children under cs, got %d", len(cs.Children))
+	}
+	os := cs.Children[1]
+	if os.Path != "cs/os" || os.Count != 1 || os.TotalCount != 1 {
+		t.Errorf("Expected cs/os with count 1 and total 1, got %s with count %d and total %d", os.Path, os.Count, os.TotalCount)
+	}
+	if len(os.Children) != 1 || os.Children[0].Path != "cs/os/concurrency" {
+		t.Errorf("Expected cs/os/concurrency under cs/os, got %v", os.Children)
+	}
+	if tree[1].Path != "work" {
+		t.Errorf("Expected second root to be 'work', got '%s'", tree[1].Path)
+	}
+}
+
+func TestNoteUsecase_SuggestKeywords_RankedByFrequencyAndRecency(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	note1, _ := noteUsecase.CreateNote("", "Note 1", "user-1")
+	note2, _ := noteUsecase.CreateNote("", "Note 2", "user-1")
+	noteUsecase.TagNote(note1, "user-1", "concurrency", 0)
+	noteUsecase.TagNote(note1, "user-1", "containers", 1)
+	noteUsecase.TagNote(note1, "user-1", "go", 2)
+	// Load the index before further tagging so the recency of later tags is tracked.
+	noteUsecase.SuggestKeywords("user-1", "", 0)
+	noteUsecase.TagNote(note2, "user-1", "concurrency", 0)
+	noteUsecase.TagNote(note2, "user-1", "consensus", 1)
+
+	// Act
+	suggestions, err := noteUsecase.SuggestKeywords("user-1", "con", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywords() returned an unexpected error: %v", err)
+	}
+	expected := []string{"concurrency", "consensus", "containers"}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %d", len(expected), len(suggestions))
+	}
+	for i, keyword := range expected {
+		if suggestions[i].Keyword != keyword {
+			t.Errorf("Expected suggestion %d to be '%s', got '%s'", i, keyword, suggestions[i].Keyword)
+		}
+	}
+	if suggestions[0].Count != 2 {
+		t.Errorf("Expected 'concurrency' to be used on 2 notes, got %d", suggestions[0].Count)
+	}
+}
+
+func TestNoteUsecase_SuggestKeywords_Limit(t *testing.T) {
+	// Arrange
+	_, noteUsecase, noteID := setUpRepositoryAndUsecaseWithNote()
+	noteUsecase.TagNote(noteID, "user-1", "go", 0)
+	noteUsecase.TagNote(noteID, "user-1", "gopher", 1)
+	noteUsecase.TagNote(noteID, "user-1", "gossip", 2)
+
+	// Act
+	suggestions, err := noteUsecase.SuggestKeywords("user-1", "go", 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywords() returned an unexpected error: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %d", len(suggestions))
+	}
+}
+
+func TestNoteUsecase_SuggestKeywords_UpdatedOnUntagAndDelete(t *testing.T) {
+	// Arrange
+	_, noteUsecase := setUpRepositoryAndUsecase()
+	note1, _ := noteUsecase.CreateNote("", "Note 1", "user-1")
+	note2, _ := noteUsecase.CreateNote("", "Note 2", "user-1")
+	noteUsecase.TagNote(note1, "user-1", "go", 0)
+	noteUsecase.TagNote(note2, "user-1", "golang", 0)
+	noteUsecase.SuggestKeywords("user-1", "go", 0)
+
+	// Act
+	noteUsecase.UntagNote(note1, "user-1", "go", 1)
+	noteUsecase.DeleteNote(note2, 1)
+	suggestions, err := noteUsecase.SuggestKeywords("user-1", "go", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywords() returned an unexpected error: %v", err)
+	}
+	if len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %d", len(suggestions))
+	}
+}
+
+// tagWhileLoadingRepository is a note repository that tags a note from another goroutine right
+// after the tagged notes of a user are read, to race the change with loading the keyword index.
+type tagWhileLoadingRepository struct {
+	noterepo.NoteRepository
+	tag  func()
+	done chan struct{}
+}
+
+func (r *tagWhileLoadingRepository) FindTaggedByUser(userID string) ([]*noterepo.NotePO, error) {
+	notePOs, err := r.NoteRepository.FindTaggedByUser(userID)
+	go func() {
+		r.tag()
+		close(r.done)
+	}()
+	// Give the change the chance to be saved and recorded before the index is loaded.
+	select {
+	case <-r.done:
+	case <-time.After(50 * time.Millisecond):
+	}
+	return notePOs, err
+}
+
+func TestNoteUsecase_SuggestKeywords_KeepsTagsSavedWhileLoading(t *testing.T) {
+	// Arrange
+	repo := &tagWhileLoadingRepository{NoteRepository: noterepo.NewInMemoryNoteRepository(), done: make(chan struct{})}
+	noteUsecase := NewNoteUsecase(repo)
+	noteID, _ := noteUsecase.CreateNote("", "Note 1", "user-1")
+	noteUsecase.TagNote(noteID, "user-1", "go", 0)
+	repo.tag = func() { noteUsecase.TagNote(noteID, "user-1", "gossip", 1) }
+
+	// Act
+	noteUsecase.SuggestKeywords("user-1", "go", 0)
+	<-repo.done
+	suggestions, err := noteUsecase.SuggestKeywords("user-1", "go", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SuggestKeywords() returned an unexpected error: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Errorf("Expected the keyword tagged while the index loaded to be suggested, got %d suggestions", len(suggestions))
+	}
+}
+
+func TestNoteUsecase_TracksTimestampsAndEditor(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(start)
+	noteUsecase := NewNoteUsecaseWithClock(noterepo.NewInMemoryNoteRepository(), fakeClock)
+	noteID, _ := noteUsecase.CreateNote("", "Test Title", "owner-1")
+	created, _ := noteUsecase.GetNoteByID(noteID)
+
+	// Act
+	fakeClock.Advance(time.Minute)
+	if err := noteUsecase.ChangeTitle(noteID, "user-2", "New Title", 0); err != nil {
+		t.Fatalf("ChangeTitle() returned an unexpected error: %v", err)
+	}
+	fakeClock.Advance(time.Minute)
+	if err := noteUsecase.TagNote(noteID, "user-3", "go", 1); err != nil {
+		t.Fatalf("TagNote() returned an unexpected error: %v", err)
+	}
+	modified, _ := noteUsecase.GetNoteByID(noteID)
+
+	// Assert
+	if !created.CreatedAt.Equal(start) || !created.UpdatedAt.Equal(start) || created.LastModifiedBy != "owner-1" {
+		t.Errorf("Expected the note to be created at %v by owner-1, got %+v", start, created)
+	}
+	if !modified.CreatedAt.Equal(start) {
+		t.Errorf("Expected CreatedAt to stay %v, but got %v", start, modified.CreatedAt)
+	}
+	// Tagging is private metadata and does not count as a modification.
+	if !modified.UpdatedAt.Equal(start.Add(time.Minute)) || modified.LastModifiedBy != "user-2" {
+		t.Errorf("Expected the title change by user-2 at %v to be the last modification, got %v by %s",
+			start.Add(time.Minute), modified.UpdatedAt, modified.LastModifiedBy)
+	}
+}
+
+func TestNoteUsecase_MoveContent_Success(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, sourceID, contentID, otherID := setUpRepositoryAndUsecaseWithNoteAndContents()
+	targetID, _ := noteUsecase.CreateNote("", "Target", "owner-1")
+	noteUsecase.AddContent(targetID, "owner-1", "target-content", -1, 0)
+
+	// Act
+	err := noteUsecase.MoveContent(contentID, sourceID, targetID, "owner-1", 0, 2, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("MoveContent() returned an unexpected error: %v", err)
+	}
+	source, _ := repo.FindByID(sourceID)
+	target, _ := repo.FindByID(targetID)
+	if !reflect.DeepEqual(source.ContentIDs, []string{otherID}) || source.Version != 3 {
+		t.Errorf("Expected the source to keep only '%s' at version 3, but got %v at version %d", otherID, source.ContentIDs, source.Version)
+	}
+	if !reflect.DeepEqual(target.ContentIDs, []string{contentID, "target-content"}) || target.Version != 2 {
+		t.Errorf("Expected the content at the start of the target at version 2, but got %v at version %d", target.ContentIDs, target.Version)
+	}
+}
+
+func TestNoteUsecase_MoveContent_Errors(t *testing.T) {
+	repo, noteUsecase, sourceID, contentID, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	targetID, _ := noteUsecase.CreateNote("", "Target", "owner-2")
+	noteUsecase.ShareNote(targetID, "owner-2", "owner-1", "read", 0)
+
+	tests := []struct {
+		name          string
+		contentID     string
+		targetID      string
+		index         int
+		targetVersion int
+		want          error
+	}{
+		{"same note", contentID, sourceID, -1, 2, ErrSameNote},
+		{"read-only target", contentID, targetID, -1, 1, ErrPermissionDenied},
+		{"stale target version", contentID, targetID, -1, 0, ErrConflict},
+		{"missing target", contentID, "missing", -1, 0, ErrNoteNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := noteUsecase.MoveContent(tt.contentID, sourceID, tt.targetID, "owner-1", tt.index, 2, tt.targetVersion); !errors.Is(err, tt.want) {
+				t.Errorf("Expected error to be '%v', but got '%v'", tt.want, err)
+			}
+		})
+	}
+
+	noteUsecase.ShareNote(targetID, "owner-2", "owner-1", "read-write", 1)
+	for _, tt := range []struct {
+		name      string
+		contentID string
+		index     int
+		want      error
+	}{
+		{"content not in source", "missing-content", -1, ErrContentNotFound},
+		{"index out of bounds", contentID, 5, ErrIndexOutOfBounds},
+	} {
+		if err := noteUsecase.MoveContent(tt.contentID, sourceID, targetID, "owner-1", tt.index, 2, 2); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected error to be '%v', but got '%v'", tt.name, tt.want, err)
+		}
+	}
+	if source, _ := repo.FindByID(sourceID); len(source.ContentIDs) != 2 || source.Version != 2 {
+		t.Errorf("Expected failed moves to leave the source unchanged, but got %v at version %d", source.ContentIDs, source.Version)
+	}
+}
+
+func TestNoteUsecase_MoveContentBack_UsesCurrentVersions(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, sourceID, contentID, otherID := setUpRepositoryAndUsecaseWithNoteAndContents()
+	targetID, _ := noteUsecase.CreateNote("", "Target", "owner-1")
+	noteUsecase.MoveContent(contentID, sourceID, targetID, "owner-1", -1, 2, 0)
+	noteUsecase.ChangeTitle(sourceID, "owner-1", "Renamed", 3)
+	noteUsecase.AddContent(targetID, "owner-1", "target-content", -1, 1)
+
+	// Act
+	err := noteUsecase.MoveContentBack(contentID, sourceID, targetID, "owner-1", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("MoveContentBack() returned an unexpected error: %v", err)
+	}
+	source, _ := repo.FindByID(sourceID)
+	target, _ := repo.FindByID(targetID)
+	if !reflect.DeepEqual(source.ContentIDs, []string{contentID, otherID}) || source.Version != 5 {
+		t.Errorf("Expected the content back at the start of the source at version 5, but got %v at version %d", source.ContentIDs, source.Version)
+	}
+	if !reflect.DeepEqual(target.ContentIDs, []string{"target-content"}) || target.Version != 3 {
+		t.Errorf("Expected the target to keep only its own content at version 3, but got %v at version %d", target.ContentIDs, target.Version)
+	}
+}
+
+func TestNoteUsecase_AddContentCopy(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, sourceID, contentID, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.ShareNote(sourceID, "owner-1", "user-1", "read", 2)
+	targetID, _ := noteUsecase.CreateNote("", "Target", "user-1")
+
+	// Act
+	err := noteUsecase.AddContentCopy(sourceID, contentID, targetID, "user-1", "copy-1", -1, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("AddContentCopy() returned an unexpected error: %v", err)
+	}
+	if target, _ := repo.FindByID(targetID); !reflect.DeepEqual(target.ContentIDs, []string{"copy-1"}) {
+		t.Errorf("Expected the copy to be added to the target, but got %v", target.ContentIDs)
+	}
+	if err := noteUsecase.AddContentCopy(sourceID, contentID, targetID, "user-2", "copy-2", -1, 1); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected a user who cannot view the source to be denied, but got '%v'", err)
+	}
+	if err := noteUsecase.AddContentCopy(sourceID, "missing-content", targetID, "user-1", "copy-3", -1, 1); !errors.Is(err, ErrContentNotFound) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrContentNotFound, err)
+	}
+}
+
+func TestNoteUsecase_MoveContentWithinNote(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, noteID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+
+	// Act
+	order, err := noteUsecase.MoveContentWithinNote(noteID, "owner-1", second, 0, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("MoveContentWithinNote() returned an unexpected error: %v", err)
+	}
+	want := []string{second, first}
+	notePO, _ := repo.FindByID(noteID)
+	if !reflect.DeepEqual(order, want) || !reflect.DeepEqual(notePO.ContentIDs, want) || notePO.Version != 3 {
+		t.Errorf("Expected order %v at version 3, but got %v and %v at version %d", want, order, notePO.ContentIDs, notePO.Version)
+	}
+}
+
+func TestNoteUsecase_ReorderContents_Errors(t *testing.T) {
+	_, noteUsecase, noteID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+
+	if _, err := noteUsecase.ReorderContents(noteID, "owner-1", []string{first}, 2); !errors.Is(err, ErrInvalidContentOrder) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrInvalidContentOrder, err)
+	}
+	if _, err := noteUsecase.ReorderContents(noteID, "someone-else", []string{second, first}, 2); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrPermissionDenied, err)
+	}
+	if _, err := noteUsecase.ReorderContents(noteID, "owner-1", []string{second, first}, 0); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrConflict, err)
+	}
+}
+
+func TestNoteUsecase_AddContents(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, noteID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+
+	// Act
+	err := noteUsecase.AddContents(noteID, "owner-1", []string{"content-3", "content-4"}, 1, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("AddContents() returned an unexpected error: %v", err)
+	}
+	want := []string{first, "content-3", "content-4", second}
+	if notePO, _ := repo.FindByID(noteID); !reflect.DeepEqual(notePO.ContentIDs, want) || notePO.Version != 3 {
+		t.Errorf("Expected %v at version 3, but got %v at version %d", want, notePO.ContentIDs, notePO.Version)
+	}
+	if err := noteUsecase.AddContents(noteID, "owner-1", []string{"content-5"}, -1, 2); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrConflict, err)
+	}
+}
+
+func TestNoteUsecase_DuplicateNote(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, sourceID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.ShareNote(sourceID, "owner-1", "user-1", "read", 2)
+	noteUsecase.TagNote(sourceID, "user-1", "go", 3)
+	noteUsecase.TagNote(sourceID, "owner-1", "private", 4)
+
+	// Act
+	duplicateID, contentIDs, err := noteUsecase.DuplicateNote(sourceID, "user-1", "", true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DuplicateNote() returned an unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(contentIDs, []string{first, second}) {
+		t.Errorf("Expected the contents of the original, but got %v", contentIDs)
+	}
+	duplicate, _ := repo.FindByID(duplicateID)
+	if duplicate.OwnerID != "user-1" || duplicate.Title != "Test Title"+DuplicateTitleSuffix || len(duplicate.ContentIDs) != 0 {
+		t.Errorf("Expected an empty copy owned by user-1, but got %+v", duplicate)
+	}
+	if len(duplicate.Collaborators) != 0 {
+		t.Errorf("Expected no collaborators, but got %v", duplicate.Collaborators)
+	}
+	if !reflect.DeepEqual(duplicate.Keywords, map[string][]string{"user-1": {"go"}}) {
+		t.Errorf("Expected only user-1's keywords, but got %v", duplicate.Keywords)
+	}
+	if suggestions, _ := noteUsecase.SuggestKeywords("user-1", "go", 0); len(suggestions) != 1 || suggestions[0].Count != 2 {
+		t.Errorf("Expected the keyword index to count both notes, but got %+v", suggestions)
+	}
+}
+
+func TestNoteUsecase_DuplicateNote_TitleAndKeywords(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, sourceID, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.TagNote(sourceID, "owner-1", "go", 2)
+
+	// Act
+	duplicateID, _, err := noteUsecase.DuplicateNote(sourceID, "owner-1", "Renamed", false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DuplicateNote() returned an unexpected error: %v", err)
+	}
+	if duplicate, _ := repo.FindByID(duplicateID); duplicate.Title != "Renamed" || len(duplicate.Keywords["owner-1"]) != 0 {
+		t.Errorf("Expected a copy titled Renamed without keywords, but got %+v", duplicate)
+	}
+	if _, _, err := noteUsecase.DuplicateNote(sourceID, "someone-else", "", false); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrPermissionDenied, err)
+	}
+	if _, _, err := noteUsecase.DuplicateNote("missing-note", "owner-1", "", false); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrNoteNotFound, err)
+	}
+}
+
+func TestNoteUsecase_SplitNote(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, noteID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.ShareNote(noteID, "owner-1", "user-1", "read-write", 2)
+	noteUsecase.TagNote(noteID, "user-1", "go", 3)
+
+	// Act
+	splitID, moved, err := noteUsecase.SplitNote(noteID, "user-1", 1, "", 4)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SplitNote() returned an unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(moved, []string{second}) {
+		t.Errorf("Expected %v to be split off, but got %v", []string{second}, moved)
+	}
+	source, _ := repo.FindByID(noteID)
+	if !reflect.DeepEqual(source.ContentIDs, []string{first}) || source.Version != 5 {
+		t.Errorf("Expected the original to keep %v at version 5, but got %v at version %d", []string{first}, source.ContentIDs, source.Version)
+	}
+	split, _ := repo.FindByID(splitID)
+	if split.OwnerID != "owner-1" || split.Title != "Test Title"+SplitTitleSuffix || !reflect.DeepEqual(split.ContentIDs, []string{second}) {
+		t.Errorf("Expected a note owned by owner-1 with the split off contents, but got %+v", split)
+	}
+	if split.Collaborators["user-1"] != "read-write" || !reflect.DeepEqual(split.Keywords["user-1"], []string{"go"}) {
+		t.Errorf("Expected the collaborators and keywords to be kept, but got %v and %v", split.Collaborators, split.Keywords)
+	}
+	if suggestions, _ := noteUsecase.SuggestKeywords("user-1", "go", 0); len(suggestions) != 1 || suggestions[0].Count != 2 {
+		t.Errorf("Expected the keyword index to count both notes, but got %+v", suggestions)
+	}
+}
+
+func TestNoteUsecase_SplitNote_Errors(t *testing.T) {
+	_, noteUsecase, noteID, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.ShareNote(noteID, "owner-1", "user-1", "read", 2)
+
+	if _, _, err := noteUsecase.SplitNote(noteID, "user-1", 1, "", 3); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrPermissionDenied, err)
+	}
+	if _, _, err := noteUsecase.SplitNote(noteID, "owner-1", 0, "", 3); !errors.Is(err, ErrIndexOutOfBounds) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrIndexOutOfBounds, err)
+	}
+	if _, _, err := noteUsecase.SplitNote(noteID, "owner-1", 1, "", 2); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrConflict, err)
+	}
+}
+
+func TestNoteUsecase_MergeNotes(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, targetID, first, second := setUpRepositoryAndUsecaseWithNoteAndContents()
+	noteUsecase.ShareNote(targetID, "owner-1", "user-1", "read", 2)
+	noteUsecase.TagNote(targetID, "user-1", "go", 3)
+	sourceID, _ := noteUsecase.CreateNote("", "Source", "owner-1")
+	noteUsecase.AddContent(sourceID, "owner-1", "content-3", -1, 0)
+	noteUsecase.ShareNote(sourceID, "owner-1", "user-2", "read", 1)
+	noteUsecase.TagNote(sourceID, "user-1", "go", 2)
+	noteUsecase.TagNote(sourceID, "user-1", "testing", 3)
+	noteUsecase.TagNote(sourceID, "user-2", "private", 4)
+
+	// Act
+	order, err := noteUsecase.MergeNotes(targetID, "owner-1", 4, []MergeSourceDTO{{NoteID: sourceID, Version: 5}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("MergeNotes() returned an unexpected error: %v", err)
+	}
+	want := []string{first, second, "content-3"}
+	target, _ := repo.FindByID(targetID)
+	if !reflect.DeepEqual(order, want) || !reflect.DeepEqual(target.ContentIDs, want) || target.Version != 5 {
+		t.Errorf("Expected %v at version 5, but got %v and %v at version %d", want, order, target.ContentIDs, target.Version)
+	}
+	if !reflect.DeepEqual(target.Keywords, map[string][]string{"user-1": {"go", "testing"}}) {
+		t.Errorf("Expected the keywords to be unioned for users who can view the note only, but got %v", target.Keywords)
+	}
+	if found, _ := noteUsecase.FindNotesByKeyword("user-2", "private"); len(found) != 0 {
+		t.Errorf("Expected user-2 not to find the note, which they cannot view, but got %v", found)
+	}
+	if suggestions, _ := noteUsecase.SuggestKeywords("user-2", "", 0); len(suggestions) != 0 {
+		t.Errorf("Expected no keywords left for user-2, but got %v", suggestions)
+	}
+	if !reflect.DeepEqual(target.Collaborators, map[string]string{"user-1": "read"}) {
+		t.Errorf("Expected the target's collaborators to be kept, but got %v", target.Collaborators)
+	}
+	if _, err := repo.FindByID(sourceID); !errors.Is(err, noterepo.ErrNoteNotFound) {
+		t.Errorf("Expected the merged note to be deleted, but got '%v'", err)
+	}
+	suggestions, _ := noteUsecase.SuggestKeywords("user-1", "", 0)
+	counts := map[string]int{}
+	for _, suggestion := range suggestions {
+		counts[suggestion.Keyword] = suggestion.Count
+	}
+	if !reflect.DeepEqual(counts, map[string]int{"go": 1, "testing": 1}) {
+		t.Errorf("Expected each keyword to be counted once, but got %v", counts)
+	}
+}
+
+func TestNoteUsecase_CheckMerge(t *testing.T) {
+	// Arrange
+	repo, noteUsecase, targetID, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	sourceID, _ := noteUsecase.CreateNote("", "Source", "owner-1")
+	noteUsecase.AddContent(sourceID, "owner-1", "content-3", -1, 0)
+	otherID, _ := noteUsecase.CreateNote("", "Other", "owner-2")
+
+	// Act
+	contentIDs, err := noteUsecase.CheckMerge(targetID, "owner-1", 2, []MergeSourceDTO{{NoteID: sourceID, Version: 1}})
+	_, deniedErr := noteUsecase.CheckMerge(targetID, "owner-1", 2, []MergeSourceDTO{{NoteID: otherID}})
+	_, staleErr := noteUsecase.CheckMerge(targetID, "owner-1", 1, []MergeSourceDTO{{NoteID: sourceID, Version: 1}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CheckMerge() returned an unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(contentIDs, map[string][]string{sourceID: {"content-3"}}) {
+		t.Errorf("Expected the contents of the note to merge, but got %v", contentIDs)
+	}
+	if !errors.Is(deniedErr, ErrPermissionDenied) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrPermissionDenied, deniedErr)
+	}
+	if !errors.Is(staleErr, ErrConflict) {
+		t.Errorf("Expected error to be '%v', but got '%v'", ErrConflict, staleErr)
+	}
+	if target, _ := repo.FindByID(targetID); target.Version != 2 {
+		t.Errorf("Expected the note to be left unchanged, but got version %d", target.Version)
+	}
+}
+
+func TestNoteUsecase_MergeNotes_Errors(t *testing.T) {
+	repo, noteUsecase, targetID, _, _ := setUpRepositoryAndUsecaseWithNoteAndContents()
+	sourceID, _ := noteUsecase.CreateNote("", "Source", "owner-2")
+
+	tests := []struct {
+		name    string
+		sources []MergeSourceDTO
+		wantErr error
+	}{
+		{"no notes", nil, ErrInvalidMergeSources},
+		{"note itself", []MergeSourceDTO{{NoteID: targetID, Version: 2}}, ErrInvalidMergeSources},
+		{"repeated note", []MergeSourceDTO{{NoteID: sourceID}, {NoteID: sourceID}}, ErrInvalidMergeSources},
+		{"stale note", []MergeSourceDTO{{NoteID: sourceID, Version: 1}}, ErrConflict},
+		{"note of someone else", []MergeSourceDTO{{NoteID: sourceID}}, ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := noteUsecase.MergeNotes(targetID, "owner-1", 2, tt.sources); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error to be '%v', but got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+	if _, err := repo.FindByID(sourceID); err != nil {
+		t.Errorf("Expected the note to be kept, but got '%v'", err)
+	}
+}
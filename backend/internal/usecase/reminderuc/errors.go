@@ -0,0 +1,33 @@
+package reminderuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrReminderNotFound is returned when a reminder is not found.
+var ErrReminderNotFound = errors.New("reminder not found")
+
+// ErrNoteNotFound is returned when the note of a reminder is not found.
+var ErrNoteNotFound = errors.New("note not found")
+
+// ErrPermissionDenied is returned when a user acts on a reminder they do not own, or sets
+// one on a note they cannot access.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrInvalidTime is returned when a due time is not formatted as YYYY-MM-DDTHH:MM.
+var ErrInvalidTime = errors.New("time must be formatted as YYYY-MM-DDTHH:MM")
+
+// ErrDueTimeTooOld is returned when a reminder would first be due more than
+// reminder.MaxOverdue before it is set.
+var ErrDueTimeTooOld = errors.New("due time is too far in the past")
+
+// ErrInvalidTimeZone is returned when a time zone is not a known IANA time zone name.
+var ErrInvalidTimeZone = errors.New("unknown time zone")
+
+// ErrInvalidRecurrence is returned when a recurrence has an unknown frequency, a negative
+// interval, or ends before the reminder is first due.
+var ErrInvalidRecurrence = errors.New("recurrence must be daily, weekly, monthly or yearly, with a positive interval and an end after the due time")
+
+// ErrConflict is returned when a version conflict occurs.
+var ErrConflict = errors.New("conflict")
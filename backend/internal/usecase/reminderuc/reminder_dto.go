@@ -0,0 +1,48 @@
+package reminderuc
+
+import "time"
+
+// RecurrenceDTO represents how a reminder repeats. Frequency is daily, weekly, monthly or
+// yearly, and Until an optional last local date and time, formatted as YYYY-MM-DDTHH:MM.
+type RecurrenceDTO struct {
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval"`
+	Until     string `json:"until,omitempty"`
+}
+
+// ReminderInputDTO represents the schedule and message of a reminder. DueAt is the local date
+// and time of its first occurrence in TimeZone, formatted as YYYY-MM-DDTHH:MM. An empty time
+// zone stands for UTC, and a missing recurrence for a reminder that fires once.
+type ReminderInputDTO struct {
+	DueAt      string         `json:"due_at"`
+	TimeZone   string         `json:"time_zone"`
+	Recurrence *RecurrenceDTO `json:"recurrence,omitempty"`
+	Message    string         `json:"message"`
+}
+
+// ReminderDTO represents a reminder. NextDueAt is missing once no occurrence is left.
+type ReminderDTO struct {
+	ID          string         `json:"id"`
+	NoteID      string         `json:"note_id"`
+	UserID      string         `json:"user_id"`
+	DueAt       string         `json:"due_at"`
+	TimeZone    string         `json:"time_zone"`
+	Recurrence  *RecurrenceDTO `json:"recurrence,omitempty"`
+	Message     string         `json:"message"`
+	NextDueAt   *time.Time     `json:"next_due_at,omitempty"`
+	LastFiredAt *time.Time     `json:"last_fired_at,omitempty"`
+	Version     int            `json:"version"`
+}
+
+// FiredReminderDTO represents an occurrence of a reminder that has fired. DueAt is when the
+// occurrence was due, in the reminder's time zone.
+type FiredReminderDTO struct {
+	ReminderID string     `json:"reminder_id"`
+	NoteID     string     `json:"note_id"`
+	UserID     string     `json:"user_id"`
+	NoteTitle  string     `json:"note_title"`
+	Message    string     `json:"message"`
+	DueAt      time.Time  `json:"due_at"`
+	FiredAt    time.Time  `json:"fired_at"`
+	NextDueAt  *time.Time `json:"next_due_at,omitempty"`
+}
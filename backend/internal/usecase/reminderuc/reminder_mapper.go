@@ -0,0 +1,107 @@
+package reminderuc
+
+import (
+	"time"
+
+	"noteapp/internal/domain/reminder"
+	"noteapp/internal/repository/reminderrepo"
+)
+
+// ReminderMapper handles mapping between reminder.Reminder and other representations.
+type ReminderMapper struct{}
+
+// NewReminderMapper creates a new ReminderMapper.
+func NewReminderMapper() *ReminderMapper {
+	return &ReminderMapper{}
+}
+
+// ToPO converts a reminder.Reminder to a reminderrepo.ReminderPO.
+func (m *ReminderMapper) ToPO(r *reminder.Reminder) *reminderrepo.ReminderPO {
+	return &reminderrepo.ReminderPO{
+		ID:          r.ID,
+		NoteID:      r.NoteID,
+		UserID:      r.UserID,
+		DueAt:       r.DueAt,
+		TimeZone:    r.TimeZone,
+		Frequency:   r.Recurrence.Frequency,
+		Interval:    r.Recurrence.Interval,
+		Until:       r.Recurrence.Until,
+		Message:     r.Message,
+		Occurrence:  r.Occurrence,
+		NextAt:      r.NextAt,
+		LastFiredAt: r.LastFiredAt,
+		Version:     r.Version,
+	}
+}
+
+// ToDomain converts a reminderrepo.ReminderPO to a reminder.Reminder.
+func (m *ReminderMapper) ToDomain(po *reminderrepo.ReminderPO) *reminder.Reminder {
+	r := &reminder.Reminder{
+		ID:         po.ID,
+		NoteID:     po.NoteID,
+		UserID:     po.UserID,
+		TimeZone:   po.TimeZone,
+		Recurrence: reminder.Recurrence{Frequency: po.Frequency, Interval: po.Interval, Until: po.Until},
+		Message:    po.Message,
+		Occurrence: po.Occurrence,
+		Version:    po.Version,
+	}
+	loc := r.Location()
+	r.DueAt = po.DueAt.In(loc)
+	if !po.NextAt.IsZero() {
+		r.NextAt = po.NextAt.In(loc)
+	}
+	if !po.LastFiredAt.IsZero() {
+		r.LastFiredAt = po.LastFiredAt.In(loc)
+	}
+	return r
+}
+
+// ToDTO converts a reminder.Reminder to a ReminderDTO.
+func (m *ReminderMapper) ToDTO(r *reminder.Reminder) *ReminderDTO {
+	dto := &ReminderDTO{
+		ID:          r.ID,
+		NoteID:      r.NoteID,
+		UserID:      r.UserID,
+		DueAt:       r.DueAt.In(r.Location()).Format(reminder.LocalTimeLayout),
+		TimeZone:    r.TimeZone,
+		Message:     r.Message,
+		NextDueAt:   optionalTime(r.NextAt),
+		LastFiredAt: optionalTime(r.LastFiredAt),
+		Version:     r.Version,
+	}
+	if r.Recurrence.Frequency != reminder.Once {
+		dto.Recurrence = &RecurrenceDTO{Frequency: r.Recurrence.Frequency, Interval: r.Recurrence.Interval}
+		if !r.Recurrence.Until.IsZero() {
+			dto.Recurrence.Until = r.Recurrence.Until.In(r.Location()).Format(reminder.LocalTimeLayout)
+		}
+	}
+	return dto
+}
+
+// ToSchedule converts the schedule of a ReminderInputDTO to a due time and a recurrence in the
+// input's time zone.
+func (m *ReminderMapper) ToSchedule(input *ReminderInputDTO) (time.Time, reminder.Recurrence, error) {
+	dueAt, err := reminder.ParseLocalTime(input.DueAt, input.TimeZone)
+	if err != nil {
+		return time.Time{}, reminder.Recurrence{}, err
+	}
+	if input.Recurrence == nil {
+		return dueAt, reminder.Recurrence{}, nil
+	}
+	recurrence := reminder.Recurrence{Frequency: input.Recurrence.Frequency, Interval: input.Recurrence.Interval}
+	if input.Recurrence.Until != "" {
+		recurrence.Until, err = reminder.ParseLocalTime(input.Recurrence.Until, input.TimeZone)
+		if err != nil {
+			return time.Time{}, reminder.Recurrence{}, err
+		}
+	}
+	return dueAt, recurrence, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
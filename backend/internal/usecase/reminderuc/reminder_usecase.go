@@ -0,0 +1,291 @@
+package reminderuc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"noteapp/internal/clock"
+	"noteapp/internal/domain/reminder"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/reminderrepo"
+)
+
+// ReminderUsecase handles the business logic for reminders. A reminder belongs to the user who
+// set it, on a note the user can access, and is forgotten once the note is deleted or the
+// user loses access to it.
+type ReminderUsecase struct {
+	repo     reminderrepo.ReminderRepository
+	noteRepo noterepo.NoteRepository
+	mapper   *ReminderMapper
+	clock    clock.Clock
+}
+
+// NewReminderUsecase creates a new ReminderUsecase.
+func NewReminderUsecase(repo reminderrepo.ReminderRepository, noteRepo noterepo.NoteRepository) *ReminderUsecase {
+	return NewReminderUsecaseWithClock(repo, noteRepo, clock.NewSystemClock())
+}
+
+// NewReminderUsecaseWithClock creates a new ReminderUsecase that reads the current time from c.
+func NewReminderUsecaseWithClock(repo reminderrepo.ReminderRepository, noteRepo noterepo.NoteRepository, c clock.Clock) *ReminderUsecase {
+	return &ReminderUsecase{repo: repo, noteRepo: noteRepo, mapper: NewReminderMapper(), clock: c}
+}
+
+// CreateReminder sets a reminder for a user on a note the user can access.
+func (uc *ReminderUsecase) CreateReminder(noteID, userID string, input *ReminderInputDTO) (string, error) {
+	if noteID == "" || userID == "" {
+		return "", ErrInvalidID
+	}
+	if _, err := uc.getAccessibleNote(noteID, userID); err != nil {
+		return "", err
+	}
+	dueAt, recurrence, err := uc.mapper.ToSchedule(input)
+	if err != nil {
+		return "", mapDomainError(err)
+	}
+	if err := reminder.CheckDueAt(dueAt, uc.clock.Now()); err != nil {
+		return "", mapDomainError(err)
+	}
+	r, err := reminder.NewReminder("", noteID, userID, dueAt, input.TimeZone, recurrence, input.Message, 0)
+	if err != nil {
+		return "", mapDomainError(err)
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(r)); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return r.ID, nil
+}
+
+// GetReminder retrieves a reminder of the user.
+func (uc *ReminderUsecase) GetReminder(id, userID string) (*ReminderDTO, error) {
+	r, err := uc.getReminder(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapper.ToDTO(r), nil
+}
+
+// ListReminders retrieves the reminders of a user, or only those on a note if noteID is not
+// empty, ordered by when they are next due, with the reminders that have no occurrence left
+// last.
+func (uc *ReminderUsecase) ListReminders(userID, noteID string) ([]*ReminderDTO, error) {
+	if userID == "" {
+		return nil, ErrInvalidID
+	}
+	pos, err := uc.repo.FindByUser(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	dtos := []*ReminderDTO{}
+	for _, po := range pos {
+		if noteID != "" && po.NoteID != noteID {
+			continue
+		}
+		if _, err := uc.getLiveNote(po); err != nil {
+			if errors.Is(err, ErrReminderNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		dtos = append(dtos, uc.mapper.ToDTO(uc.mapper.ToDomain(po)))
+	}
+	return dtos, nil
+}
+
+// UpdateReminder changes the schedule and message of a reminder of the user. A reminder whose
+// schedule changes starts over from its new due time.
+func (uc *ReminderUsecase) UpdateReminder(id, userID string, input *ReminderInputDTO, version int) error {
+	r, err := uc.getReminderAndCheckVersion(id, userID, version)
+	if err != nil {
+		return err
+	}
+	dueAt, recurrence, err := uc.mapper.ToSchedule(input)
+	if err != nil {
+		return mapDomainError(err)
+	}
+	scheduled, err := reminder.NewReminder(r.ID, r.NoteID, r.UserID, dueAt, input.TimeZone, recurrence, input.Message, r.Version)
+	if err != nil {
+		return mapDomainError(err)
+	}
+	if sameSchedule(r, scheduled) {
+		r.Message = scheduled.Message
+	} else {
+		// Only new schedules are checked, so that the message of a reminder that has been
+		// recurring for long can still be changed.
+		if err := reminder.CheckDueAt(dueAt, uc.clock.Now()); err != nil {
+			return mapDomainError(err)
+		}
+		r = scheduled
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(r)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// DeleteReminder deletes a reminder of the user.
+func (uc *ReminderUsecase) DeleteReminder(id, userID string, version int) error {
+	if _, err := uc.getReminderAndCheckVersion(id, userID, version); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(id); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// FireDueReminders fires the occurrences of reminders due at the current time, and returns
+// them ordered by when they were due. Each occurrence is recorded as fired before it is
+// returned, so it fires at most once even if the server restarts before delivering it.
+// Reminders changed while they fire are left to the next call. Reminders that could not
+// be fired are reported together in the error, along with those that were.
+func (uc *ReminderUsecase) FireDueReminders() ([]*FiredReminderDTO, error) {
+	now := uc.clock.Now()
+	pos, err := uc.repo.FindDue(now)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	fired := []*FiredReminderDTO{}
+	var errs []error
+	for _, po := range pos {
+		dto, err := uc.fire(po, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reminder %s: %w", po.ID, err))
+			continue
+		}
+		if dto != nil {
+			fired = append(fired, dto)
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+// fire fires the due occurrences of a reminder, returning nil if it has nothing left to fire.
+func (uc *ReminderUsecase) fire(po *reminderrepo.ReminderPO, now time.Time) (*FiredReminderDTO, error) {
+	notePO, err := uc.getLiveNote(po)
+	if errors.Is(err, ErrReminderNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := uc.mapper.ToDomain(po)
+	dueAt, ok := r.Fire(now)
+	if !ok {
+		return nil, nil
+	}
+	err = uc.repo.Save(uc.mapper.ToPO(r))
+	if errors.Is(err, reminderrepo.ErrReminderConflict) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	return &FiredReminderDTO{
+		ReminderID: r.ID,
+		NoteID:     r.NoteID,
+		UserID:     r.UserID,
+		NoteTitle:  notePO.Title,
+		Message:    r.Message,
+		DueAt:      dueAt,
+		FiredAt:    now,
+		NextDueAt:  optionalTime(r.NextAt),
+	}, nil
+}
+
+// getLiveNote returns the note of a reminder. A reminder whose note has been deleted, or whose
+// user can no longer access it, is forgotten and reported as not found.
+func (uc *ReminderUsecase) getLiveNote(po *reminderrepo.ReminderPO) (*noterepo.NotePO, error) {
+	notePO, err := uc.getAccessibleNote(po.NoteID, po.UserID)
+	if !errors.Is(err, ErrNoteNotFound) && !errors.Is(err, ErrPermissionDenied) {
+		return notePO, err
+	}
+	if err := uc.repo.Delete(po.ID); err != nil && !errors.Is(err, reminderrepo.ErrReminderNotFound) {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return nil, ErrReminderNotFound
+}
+
+// getAccessibleNote loads a note and checks that the user owns it or is a collaborator.
+func (uc *ReminderUsecase) getAccessibleNote(noteID, userID string) (*noterepo.NotePO, error) {
+	notePO, err := uc.noteRepo.FindByID(noteID)
+	if errors.Is(err, noterepo.ErrNoteNotFound) {
+		return nil, ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+	if notePO.OwnerID != userID {
+		if _, ok := notePO.Collaborators[userID]; !ok {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return notePO, nil
+}
+
+func (uc *ReminderUsecase) getReminder(id, userID string) (*reminder.Reminder, error) {
+	if id == "" || userID == "" {
+		return nil, ErrInvalidID
+	}
+	po, err := uc.repo.FindByID(id)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if po.UserID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return uc.mapper.ToDomain(po), nil
+}
+
+func (uc *ReminderUsecase) getReminderAndCheckVersion(id, userID string, version int) (*reminder.Reminder, error) {
+	r, err := uc.getReminder(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Version != version {
+		return nil, ErrConflict
+	}
+	return r, nil
+}
+
+// sameSchedule reports whether two reminders are due at the same times.
+func sameSchedule(a, b *reminder.Reminder) bool {
+	return a.DueAt.Equal(b.DueAt) &&
+		a.TimeZone == b.TimeZone &&
+		a.Recurrence.Frequency == b.Recurrence.Frequency &&
+		a.Recurrence.Interval == b.Recurrence.Interval &&
+		a.Recurrence.Until.Equal(b.Recurrence.Until)
+}
+
+func (uc *ReminderUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, reminderrepo.ErrReminderNotFound):
+		return ErrReminderNotFound
+	case errors.Is(err, reminderrepo.ErrReminderConflict):
+		return ErrConflict
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
+
+func mapDomainError(err error) error {
+	switch {
+	case errors.Is(err, reminder.ErrInvalidTime):
+		return ErrInvalidTime
+	case errors.Is(err, reminder.ErrDueTimeTooOld):
+		return ErrDueTimeTooOld
+	case errors.Is(err, reminder.ErrInvalidTimeZone):
+		return ErrInvalidTimeZone
+	case errors.Is(err, reminder.ErrInvalidRecurrence):
+		return ErrInvalidRecurrence
+	default:
+		return fmt.Errorf("an unexpected domain error occurred: %w", err)
+	}
+}
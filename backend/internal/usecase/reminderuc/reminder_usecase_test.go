@@ -0,0 +1,189 @@
+package reminderuc_test
+
+import (
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/reminderrepo"
+	"noteapp/internal/usecase/noteuc"
+	"noteapp/internal/usecase/reminderuc"
+	"testing"
+	"time"
+)
+
+func setUpReminderUsecase() (*reminderuc.ReminderUsecase, *noteuc.NoteUsecase, *clock.FakeClock) {
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 20, 15, 0, 0, time.UTC))
+	return reminderuc.NewReminderUsecaseWithClock(reminderrepo.NewInMemoryReminderRepository(), noteRepo, c), noteuc.NewNoteUsecase(noteRepo), c
+}
+
+func TestReminderUsecase_CreateReminder(t *testing.T) {
+	usecase, nuc, _ := setUpReminderUsecase()
+	noteID, _ := nuc.CreateNote("", "Taxes", "user-1")
+
+	tests := []struct {
+		name    string
+		noteID  string
+		userID  string
+		input   reminderuc.ReminderInputDTO
+		wantErr error
+	}{
+		{"missing note", "missing", "user-1", reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00"}, reminderuc.ErrNoteNotFound},
+		{"inaccessible note", noteID, "user-2", reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00"}, reminderuc.ErrPermissionDenied},
+		{"invalid due time", noteID, "user-1", reminderuc.ReminderInputDTO{DueAt: "tomorrow"}, reminderuc.ErrInvalidTime},
+		{"unknown time zone", noteID, "user-1", reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00", TimeZone: "Mars/Olympus_Mons"}, reminderuc.ErrInvalidTimeZone},
+		{"due time far in the past", noteID, "user-1", reminderuc.ReminderInputDTO{DueAt: "1924-03-05T09:00"}, reminderuc.ErrDueTimeTooOld},
+		{"invalid recurrence", noteID, "user-1", reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "hourly"}}, reminderuc.ErrInvalidRecurrence},
+		{"valid reminder", noteID, "user-1", reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00", TimeZone: "Asia/Tokyo", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "weekly"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := usecase.CreateReminder(tt.noteID, tt.userID, &tt.input)
+
+			if err != tt.wantErr {
+				t.Fatalf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			r, _ := usecase.GetReminder(id, tt.userID)
+			if r.DueAt != "2024-03-05T09:00" || r.TimeZone != "Asia/Tokyo" || r.Recurrence == nil || r.Recurrence.Interval != 1 {
+				t.Errorf("Expected the reminder as created, got %+v", r)
+			}
+			if want := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC); r.NextDueAt == nil || !r.NextDueAt.Equal(want) {
+				t.Errorf("Expected the reminder next due at %v, got %v", want, r.NextDueAt)
+			}
+		})
+	}
+}
+
+func TestReminderUsecase_UpdateAndDelete(t *testing.T) {
+	// Arrange
+	usecase, nuc, c := setUpReminderUsecase()
+	noteID, _ := nuc.CreateNote("", "Taxes", "user-1")
+	id, _ := usecase.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T20:00", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "daily"}})
+	usecase.FireDueReminders()
+	c.Advance(time.Hour)
+
+	// Act
+	err := usecase.UpdateReminder(id, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T20:00", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "daily"}, Message: "File them"}, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateReminder returned an unexpected error: %v", err)
+	}
+	r, _ := usecase.GetReminder(id, "user-1")
+	if r.Message != "File them" || r.NextDueAt == nil || r.NextDueAt.Day() != 5 {
+		t.Errorf("Expected a new message without firing again, got %+v", r)
+	}
+	if err := usecase.UpdateReminder(id, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T20:00"}, 1); err != reminderuc.ErrConflict {
+		t.Errorf("Expected error '%v', got '%v'", reminderuc.ErrConflict, err)
+	}
+	if err := usecase.UpdateReminder(id, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-04T20:00"}, 2); err != nil {
+		t.Fatalf("UpdateReminder returned an unexpected error: %v", err)
+	}
+	if fired, _ := usecase.FireDueReminders(); len(fired) != 1 {
+		t.Errorf("Expected a rescheduled reminder to fire from its new due time, got %+v", fired)
+	}
+	if err := usecase.DeleteReminder(id, "user-2", 4); err != reminderuc.ErrPermissionDenied {
+		t.Errorf("Expected error '%v', got '%v'", reminderuc.ErrPermissionDenied, err)
+	}
+	if err := usecase.DeleteReminder(id, "user-1", 4); err != nil {
+		t.Fatalf("DeleteReminder returned an unexpected error: %v", err)
+	}
+	if _, err := usecase.GetReminder(id, "user-1"); err != reminderuc.ErrReminderNotFound {
+		t.Errorf("Expected error '%v', got '%v'", reminderuc.ErrReminderNotFound, err)
+	}
+}
+
+func TestReminderUsecase_UpdateReminder_DueTimeTooOld(t *testing.T) {
+	// Arrange
+	usecase, nuc, c := setUpReminderUsecase()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	input := reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "daily"}}
+	id, _ := usecase.CreateReminder(noteID, "user-1", &input)
+	c.Advance(90 * 24 * time.Hour)
+
+	// Act
+	input.Message = "Join the call"
+	renamed := usecase.UpdateReminder(id, "user-1", &input, 0)
+	rescheduled := usecase.UpdateReminder(id, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-06T09:00"}, 1)
+
+	// Assert
+	if renamed != nil {
+		t.Errorf("Expected the message of a long recurring reminder to change, got '%v'", renamed)
+	}
+	if rescheduled != reminderuc.ErrDueTimeTooOld {
+		t.Errorf("Expected error '%v', got '%v'", reminderuc.ErrDueTimeTooOld, rescheduled)
+	}
+}
+
+func TestReminderUsecase_FireDueReminders(t *testing.T) {
+	// Arrange
+	usecase, nuc, c := setUpReminderUsecase()
+	noteID, _ := nuc.CreateNote("", "Standup", "user-1")
+	deletedID, _ := nuc.CreateNote("", "Deleted", "user-1")
+	dailyID, _ := usecase.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00", TimeZone: "Europe/Paris", Recurrence: &reminderuc.RecurrenceDTO{Frequency: "daily", Until: "2024-03-06T09:00"}, Message: "Standup"})
+	onceID, _ := usecase.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-05T07:00"})
+	usecase.CreateReminder(deletedID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-05T07:00"})
+	nuc.DeleteNote(deletedID, 0)
+
+	// Act
+	early, _ := usecase.FireDueReminders()
+	c.Set(time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC))
+	first, err := usecase.FireDueReminders()
+	again, _ := usecase.FireDueReminders()
+	c.Set(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC))
+	last, _ := usecase.FireDueReminders()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("FireDueReminders returned an unexpected error: %v", err)
+	}
+	if len(early) != 0 || len(again) != 0 {
+		t.Errorf("Expected nothing to fire before reminders are due or twice, got %+v and %+v", early, again)
+	}
+	if len(first) != 2 || first[0].ReminderID != onceID || first[1].ReminderID != dailyID {
+		t.Fatalf("Expected both reminders on the note to fire in order, got %+v", first)
+	}
+	if first[1].NoteTitle != "Standup" || first[1].DueAt.Format("15:04 MST") != "09:00 CET" || first[1].NextDueAt == nil {
+		t.Errorf("Expected the daily reminder to fire at 09:00 in Paris with an occurrence left, got %+v", first[1])
+	}
+	if len(last) != 1 || last[0].DueAt.Day() != 6 || last[0].NextDueAt != nil {
+		t.Errorf("Expected the missed occurrences to fire once as the last one, got %+v", last)
+	}
+	reminders, _ := usecase.ListReminders("user-1", "")
+	if len(reminders) != 2 || reminders[0].NextDueAt != nil || reminders[1].NextDueAt != nil {
+		t.Errorf("Expected the reminders of the deleted note forgotten and no occurrence left, got %+v", reminders)
+	}
+}
+
+func TestReminderUsecase_ListReminders(t *testing.T) {
+	// Arrange
+	usecase, nuc, _ := setUpReminderUsecase()
+	noteID, _ := nuc.CreateNote("", "Shared", "owner-1")
+	otherID, _ := nuc.CreateNote("", "Other", "user-1")
+	nuc.ShareNote(noteID, "owner-1", "user-1", "read", 0)
+	laterID, _ := usecase.CreateReminder(noteID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-06T09:00"})
+	soonerID, _ := usecase.CreateReminder(otherID, "user-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00"})
+	usecase.CreateReminder(noteID, "owner-1", &reminderuc.ReminderInputDTO{DueAt: "2024-03-05T09:00"})
+
+	// Act
+	all, err := usecase.ListReminders("user-1", "")
+	onNote, _ := usecase.ListReminders("user-1", noteID)
+	nuc.RevokeAccess(noteID, "owner-1", "user-1", 1)
+	afterRevoke, _ := usecase.ListReminders("user-1", "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListReminders returned an unexpected error: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != soonerID || all[1].ID != laterID {
+		t.Errorf("Expected the user's reminders by due time, got %+v", all)
+	}
+	if len(onNote) != 1 || onNote[0].ID != laterID {
+		t.Errorf("Expected only the reminder on the note, got %+v", onNote)
+	}
+	if len(afterRevoke) != 1 || afterRevoke[0].ID != soonerID {
+		t.Errorf("Expected the reminder on a note no longer shared to be forgotten, got %+v", afterRevoke)
+	}
+}
@@ -0,0 +1,27 @@
+package savedsearchuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrSavedSearchNotFound is returned when a saved search is not found.
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// ErrEmptyName is returned when a saved search has an empty name.
+var ErrEmptyName = errors.New("saved search name cannot be empty")
+
+// ErrEmptyQuery is returned when a saved search query has no terms.
+var ErrEmptyQuery = errors.New("saved search query cannot be empty")
+
+// ErrInvalidQuery is returned when a saved search query contains an invalid keyword.
+var ErrInvalidQuery = errors.New("saved search query contains an invalid keyword")
+
+// ErrUnsupportedSortOrder is returned when an unknown sort order is requested.
+var ErrUnsupportedSortOrder = errors.New("unsupported sort order")
+
+// ErrPermissionDenied is returned when a user acts on a saved search they do not own.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrConflict is returned when a version conflict occurs.
+var ErrConflict = errors.New("conflict")
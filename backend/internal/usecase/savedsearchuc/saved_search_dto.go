@@ -0,0 +1,29 @@
+package savedsearchuc
+
+// SavedSearchDTO represents a saved search.
+type SavedSearchDTO struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	Sort    string `json:"sort"`
+	Version int    `json:"version"`
+}
+
+// SavedSearchNoteDTO represents a note in the result set of a saved search,
+// with the keywords the search owner applied to it.
+type SavedSearchNoteDTO struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	OwnerID  string   `json:"owner_id"`
+	Version  int      `json:"version"`
+	Keywords []string `json:"keywords"`
+}
+
+// MembershipChangeDTO describes a note entering or leaving the result set of a user's saved search.
+type MembershipChangeDTO struct {
+	UserID        string `json:"user_id"`
+	SavedSearchID string `json:"saved_search_id"`
+	NoteID        string `json:"note_id"`
+	Entered       bool   `json:"entered"`
+}
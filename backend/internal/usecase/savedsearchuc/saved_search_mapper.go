@@ -0,0 +1,45 @@
+package savedsearchuc
+
+import (
+	"noteapp/internal/domain/savedsearch"
+	"noteapp/internal/repository/savedsearchrepo"
+)
+
+// SavedSearchMapper handles mapping between savedsearch.SavedSearch and other representations.
+type SavedSearchMapper struct{}
+
+// NewSavedSearchMapper creates a new SavedSearchMapper.
+func NewSavedSearchMapper() *SavedSearchMapper {
+	return &SavedSearchMapper{}
+}
+
+// ToPO converts a savedsearch.SavedSearch to a savedsearchrepo.SavedSearchPO.
+func (m *SavedSearchMapper) ToPO(s *savedsearch.SavedSearch) *savedsearchrepo.SavedSearchPO {
+	return &savedsearchrepo.SavedSearchPO{
+		ID:      s.ID,
+		OwnerID: s.OwnerID,
+		Name:    s.Name,
+		Query:   s.Query.String(),
+		Sort:    string(s.Sort),
+		Version: s.Version,
+	}
+}
+
+// ToDomain converts a savedsearchrepo.SavedSearchPO to a savedsearch.SavedSearch.
+func (m *SavedSearchMapper) ToDomain(po *savedsearchrepo.SavedSearchPO) *savedsearch.SavedSearch {
+	query, _ := savedsearch.ParseQuery(po.Query)
+	s, _ := savedsearch.NewSavedSearch(po.ID, po.OwnerID, po.Name, query, savedsearch.SortOrder(po.Sort), po.Version)
+	return s
+}
+
+// ToDTO converts a savedsearch.SavedSearch to a SavedSearchDTO.
+func (m *SavedSearchMapper) ToDTO(s *savedsearch.SavedSearch) *SavedSearchDTO {
+	return &SavedSearchDTO{
+		ID:      s.ID,
+		OwnerID: s.OwnerID,
+		Name:    s.Name,
+		Query:   s.Query.String(),
+		Sort:    string(s.Sort),
+		Version: s.Version,
+	}
+}
@@ -0,0 +1,339 @@
+package savedsearchuc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	domainnote "noteapp/internal/domain/note"
+	"noteapp/internal/domain/savedsearch"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/savedsearchrepo"
+)
+
+// membership is the last known result set of a saved search.
+type membership struct {
+	ownerID string
+	noteIDs map[string]bool
+}
+
+// SavedSearchUsecase handles the business logic for saved searches.
+// It remembers the last evaluated result set of each saved search so that
+// it can report notes entering or leaving a search as keywords change.
+type SavedSearchUsecase struct {
+	repo     savedsearchrepo.SavedSearchRepository
+	noteRepo noterepo.NoteRepository
+	mapper   *SavedSearchMapper
+
+	mu          sync.Mutex
+	memberships map[string]*membership
+}
+
+// NewSavedSearchUsecase creates a new SavedSearchUsecase.
+func NewSavedSearchUsecase(repo savedsearchrepo.SavedSearchRepository, noteRepo noterepo.NoteRepository) *SavedSearchUsecase {
+	return &SavedSearchUsecase{
+		repo:        repo,
+		noteRepo:    noteRepo,
+		mapper:      NewSavedSearchMapper(),
+		memberships: make(map[string]*membership),
+	}
+}
+
+// CreateSavedSearch creates a new saved search for a user.
+func (uc *SavedSearchUsecase) CreateSavedSearch(ownerID, name, queryStr, sortStr string) (string, error) {
+	if ownerID == "" {
+		return "", ErrInvalidID
+	}
+	query, sortOrder, err := parseQueryAndSort(queryStr, sortStr)
+	if err != nil {
+		return "", err
+	}
+
+	s, err := savedsearch.NewSavedSearch("", ownerID, name, query, sortOrder, 0)
+	if err != nil {
+		return "", mapDomainError(err)
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(s)); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+
+	if _, err := uc.evaluateAndRemember(s); err != nil {
+		return "", err
+	}
+	return s.ID, nil
+}
+
+// GetSavedSearch retrieves a saved search owned by the user.
+func (uc *SavedSearchUsecase) GetSavedSearch(id, userID string) (*SavedSearchDTO, error) {
+	s, err := uc.getOwnedSavedSearch(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapper.ToDTO(s), nil
+}
+
+// ListSavedSearches retrieves all saved searches of a user.
+func (uc *SavedSearchUsecase) ListSavedSearches(ownerID string) ([]*SavedSearchDTO, error) {
+	if ownerID == "" {
+		return nil, ErrInvalidID
+	}
+	pos, err := uc.repo.FindByOwnerID(ownerID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	dtos := []*SavedSearchDTO{}
+	for _, po := range pos {
+		dtos = append(dtos, uc.mapper.ToDTO(uc.mapper.ToDomain(po)))
+	}
+	return dtos, nil
+}
+
+// UpdateSavedSearch changes the name, query and sort order of a saved search.
+func (uc *SavedSearchUsecase) UpdateSavedSearch(id, userID, name, queryStr, sortStr string, version int) error {
+	s, err := uc.getSavedSearchAndCheckVersion(id, version)
+	if err != nil {
+		return err
+	}
+	query, sortOrder, err := parseQueryAndSort(queryStr, sortStr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Update(userID, name, query, sortOrder); err != nil {
+		return mapDomainError(err)
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(s)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+
+	// The result set of the new query becomes the baseline for later changes.
+	_, err = uc.evaluateAndRemember(s)
+	return err
+}
+
+// DeleteSavedSearch deletes a saved search owned by the user.
+func (uc *SavedSearchUsecase) DeleteSavedSearch(id, userID string, version int) error {
+	s, err := uc.getSavedSearchAndCheckVersion(id, version)
+	if err != nil {
+		return err
+	}
+	if s.OwnerID != userID {
+		return ErrPermissionDenied
+	}
+
+	if err := uc.repo.Delete(id); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+
+	uc.mu.Lock()
+	delete(uc.memberships, id)
+	uc.mu.Unlock()
+	return nil
+}
+
+// EvaluateSavedSearch runs a saved search live and returns the matching notes in its sort order.
+func (uc *SavedSearchUsecase) EvaluateSavedSearch(id, userID string) ([]*SavedSearchNoteDTO, error) {
+	s, err := uc.getOwnedSavedSearch(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.evaluateAndRemember(s)
+}
+
+// RefreshMemberships re-evaluates all saved searches of a user and reports every note that
+// entered or left a result set since the previous evaluation. Searches evaluated for the
+// first time only establish a baseline and report no changes.
+func (uc *SavedSearchUsecase) RefreshMemberships(userID string) ([]*MembershipChangeDTO, error) {
+	pos, err := uc.repo.FindByOwnerID(userID)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	notePOs, err := uc.noteRepo.FindTaggedByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+
+	changes := []*MembershipChangeDTO{}
+	for _, po := range pos {
+		s := uc.mapper.ToDomain(po)
+		results := evaluate(s, notePOs)
+		changes = append(changes, uc.remember(s, results, true)...)
+	}
+	return changes, nil
+}
+
+// RemoveNoteFromMemberships drops a deleted note from every remembered result set and
+// reports the saved searches it left.
+func (uc *SavedSearchUsecase) RemoveNoteFromMemberships(noteID string) []*MembershipChangeDTO {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	changes := []*MembershipChangeDTO{}
+	for searchID, m := range uc.memberships {
+		if m.noteIDs[noteID] {
+			delete(m.noteIDs, noteID)
+			changes = append(changes, &MembershipChangeDTO{UserID: m.ownerID, SavedSearchID: searchID, NoteID: noteID, Entered: false})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+func (uc *SavedSearchUsecase) evaluateAndRemember(s *savedsearch.SavedSearch) ([]*SavedSearchNoteDTO, error) {
+	notePOs, err := uc.noteRepo.FindTaggedByUser(s.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+	results := evaluate(s, notePOs)
+	uc.remember(s, results, false)
+	return results, nil
+}
+
+// remember stores the result set of a saved search. When diff is set and a previous result
+// set is known, it returns the notes that entered or left the search.
+func (uc *SavedSearchUsecase) remember(s *savedsearch.SavedSearch, results []*SavedSearchNoteDTO, diff bool) []*MembershipChangeDTO {
+	noteIDs := make(map[string]bool, len(results))
+	for _, result := range results {
+		noteIDs[result.ID] = true
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	previous, known := uc.memberships[s.ID]
+	uc.memberships[s.ID] = &membership{ownerID: s.OwnerID, noteIDs: noteIDs}
+
+	changes := []*MembershipChangeDTO{}
+	if !diff || !known {
+		return changes
+	}
+	for noteID := range noteIDs {
+		if !previous.noteIDs[noteID] {
+			changes = append(changes, &MembershipChangeDTO{UserID: s.OwnerID, SavedSearchID: s.ID, NoteID: noteID, Entered: true})
+		}
+	}
+	for noteID := range previous.noteIDs {
+		if !noteIDs[noteID] {
+			changes = append(changes, &MembershipChangeDTO{UserID: s.OwnerID, SavedSearchID: s.ID, NoteID: noteID, Entered: false})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// evaluate returns the notes matching a saved search, ordered by its sort order.
+func evaluate(s *savedsearch.SavedSearch, notePOs []*noterepo.NotePO) []*SavedSearchNoteDTO {
+	results := []*SavedSearchNoteDTO{}
+	for _, notePO := range notePOs {
+		var keywords []domainnote.Keyword
+		for _, keywordStr := range notePO.Keywords[s.OwnerID] {
+			keyword, err := domainnote.NewKeyword(keywordStr)
+			if err != nil {
+				continue
+			}
+			keywords = append(keywords, keyword)
+		}
+		if !s.Query.Matches(keywords) {
+			continue
+		}
+		results = append(results, &SavedSearchNoteDTO{
+			ID:       notePO.ID,
+			Title:    notePO.Title,
+			OwnerID:  notePO.OwnerID,
+			Version:  notePO.Version,
+			Keywords: append([]string{}, notePO.Keywords[s.OwnerID]...),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Title != results[j].Title {
+			if s.Sort == savedsearch.SortByTitleDesc {
+				return results[i].Title > results[j].Title
+			}
+			return results[i].Title < results[j].Title
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+func sortChanges(changes []*MembershipChangeDTO) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].SavedSearchID != changes[j].SavedSearchID {
+			return changes[i].SavedSearchID < changes[j].SavedSearchID
+		}
+		return changes[i].NoteID < changes[j].NoteID
+	})
+}
+
+func parseQueryAndSort(queryStr, sortStr string) (savedsearch.Query, savedsearch.SortOrder, error) {
+	query, err := savedsearch.ParseQuery(queryStr)
+	if err != nil {
+		return savedsearch.Query{}, "", mapDomainError(err)
+	}
+	sortOrder, err := savedsearch.ParseSortOrder(sortStr)
+	if err != nil {
+		return savedsearch.Query{}, "", mapDomainError(err)
+	}
+	return query, sortOrder, nil
+}
+
+func (uc *SavedSearchUsecase) getOwnedSavedSearch(id, userID string) (*savedsearch.SavedSearch, error) {
+	if id == "" {
+		return nil, ErrInvalidID
+	}
+	po, err := uc.repo.FindByID(id)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if po.OwnerID != userID {
+		return nil, ErrPermissionDenied
+	}
+	return uc.mapper.ToDomain(po), nil
+}
+
+func (uc *SavedSearchUsecase) getSavedSearchAndCheckVersion(id string, version int) (*savedsearch.SavedSearch, error) {
+	if id == "" {
+		return nil, ErrInvalidID
+	}
+	po, err := uc.repo.FindByID(id)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	if po.Version != version {
+		return nil, ErrConflict
+	}
+	return uc.mapper.ToDomain(po), nil
+}
+
+func (uc *SavedSearchUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, savedsearchrepo.ErrSavedSearchNotFound):
+		return ErrSavedSearchNotFound
+	case errors.Is(err, savedsearchrepo.ErrSavedSearchConflict):
+		return ErrConflict
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
+
+func mapDomainError(err error) error {
+	switch {
+	case errors.Is(err, savedsearch.ErrEmptyName):
+		return ErrEmptyName
+	case errors.Is(err, savedsearch.ErrEmptyQuery):
+		return ErrEmptyQuery
+	case errors.Is(err, savedsearch.ErrInvalidQuery):
+		return ErrInvalidQuery
+	case errors.Is(err, savedsearch.ErrUnsupportedSortOrder):
+		return ErrUnsupportedSortOrder
+	case errors.Is(err, savedsearch.ErrPermissionDenied):
+		return ErrPermissionDenied
+	default:
+		return fmt.Errorf("an unexpected domain error occurred: %w", err)
+	}
+}
@@ -0,0 +1,239 @@
+package savedsearchuc_test
+
+import (
+	"errors"
+	"noteapp/internal/repository/noterepo"
+	"noteapp/internal/repository/savedsearchrepo"
+	"noteapp/internal/usecase/savedsearchuc"
+	"testing"
+)
+
+func setUpSavedSearch() (*noterepo.InMemoryNoteRepository, *savedsearchuc.SavedSearchUsecase) {
+	noteRepo := noterepo.NewInMemoryNoteRepository()
+	repo := savedsearchrepo.NewInMemorySavedSearchRepository()
+	return noteRepo, savedsearchuc.NewSavedSearchUsecase(repo, noteRepo)
+}
+
+func TestSavedSearchUsecase_CreateAndGetSavedSearch(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+
+	// Act
+	id, err := uc.CreateSavedSearch("user-1", "Go work", "go work/*", "title_desc")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreateSavedSearch() returned an unexpected error: %v", err)
+	}
+	dto, err := uc.GetSavedSearch(id, "user-1")
+	if err != nil {
+		t.Fatalf("GetSavedSearch() returned an unexpected error: %v", err)
+	}
+	if dto.Name != "Go work" || dto.Query != "go work/*" || dto.Sort != "title_desc" || dto.Version != 0 {
+		t.Errorf("Unexpected saved search: %+v", dto)
+	}
+}
+
+func TestSavedSearchUsecase_CreateSavedSearch_InvalidInput(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+
+	testCases := []struct {
+		name        string
+		searchName  string
+		query       string
+		sort        string
+		expectedErr error
+	}{
+		{"empty name", "", "go", "", savedsearchuc.ErrEmptyName},
+		{"empty query", "Go", "  ", "", savedsearchuc.ErrEmptyQuery},
+		{"invalid query", "Go", "a//b", "", savedsearchuc.ErrInvalidQuery},
+		{"unsupported sort", "Go", "go", "created", savedsearchuc.ErrUnsupportedSortOrder},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := uc.CreateSavedSearch("user-1", tc.searchName, tc.query, tc.sort)
+
+			// Assert
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSavedSearchUsecase_GetSavedSearch_PermissionDenied(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// Act
+	_, err := uc.GetSavedSearch(id, "user-2")
+
+	// Assert
+	if !errors.Is(err, savedsearchuc.ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, but got %v", err)
+	}
+}
+
+func TestSavedSearchUsecase_ListSavedSearches(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+	uc.CreateSavedSearch("user-1", "Work", "work", "")
+	uc.CreateSavedSearch("user-1", "Go", "go", "")
+	uc.CreateSavedSearch("user-2", "Other", "go", "")
+
+	// Act
+	dtos, err := uc.ListSavedSearches("user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListSavedSearches() returned an unexpected error: %v", err)
+	}
+	if len(dtos) != 2 || dtos[0].Name != "Go" || dtos[1].Name != "Work" {
+		t.Errorf("Expected saved searches [Go Work], got %+v", dtos)
+	}
+}
+
+func TestSavedSearchUsecase_UpdateSavedSearch(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// Act
+	err := uc.UpdateSavedSearch(id, "user-1", "Rust", "rust", "title_desc", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateSavedSearch() returned an unexpected error: %v", err)
+	}
+	dto, _ := uc.GetSavedSearch(id, "user-1")
+	if dto.Name != "Rust" || dto.Query != "rust" || dto.Sort != "title_desc" || dto.Version != 1 {
+		t.Errorf("Unexpected saved search after update: %+v", dto)
+	}
+}
+
+func TestSavedSearchUsecase_UpdateSavedSearch_Errors(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// Act
+	conflictErr := uc.UpdateSavedSearch(id, "user-1", "Rust", "rust", "", 5)
+	permissionErr := uc.UpdateSavedSearch(id, "user-2", "Rust", "rust", "", 0)
+	notFoundErr := uc.UpdateSavedSearch("missing", "user-1", "Rust", "rust", "", 0)
+
+	// Assert
+	if !errors.Is(conflictErr, savedsearchuc.ErrConflict) {
+		t.Errorf("Expected ErrConflict, but got %v", conflictErr)
+	}
+	if !errors.Is(permissionErr, savedsearchuc.ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, but got %v", permissionErr)
+	}
+	if !errors.Is(notFoundErr, savedsearchuc.ErrSavedSearchNotFound) {
+		t.Errorf("Expected ErrSavedSearchNotFound, but got %v", notFoundErr)
+	}
+}
+
+func TestSavedSearchUsecase_DeleteSavedSearch(t *testing.T) {
+	// Arrange
+	_, uc := setUpSavedSearch()
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// Act
+	permissionErr := uc.DeleteSavedSearch(id, "user-2", 0)
+	err := uc.DeleteSavedSearch(id, "user-1", 0)
+
+	// Assert
+	if !errors.Is(permissionErr, savedsearchuc.ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied, but got %v", permissionErr)
+	}
+	if err != nil {
+		t.Fatalf("DeleteSavedSearch() returned an unexpected error: %v", err)
+	}
+	if _, err := uc.GetSavedSearch(id, "user-1"); !errors.Is(err, savedsearchuc.ErrSavedSearchNotFound) {
+		t.Errorf("Expected ErrSavedSearchNotFound after delete, but got %v", err)
+	}
+}
+
+func TestSavedSearchUsecase_EvaluateSavedSearch(t *testing.T) {
+	// Arrange
+	noteRepo, uc := setUpSavedSearch()
+	noteRepo.Save(&noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Alpha", Keywords: map[string][]string{"user-1": {"go", "work/backend"}}})
+	noteRepo.Save(&noterepo.NotePO{ID: "n2", OwnerID: "user-2", Title: "Beta", Keywords: map[string][]string{"user-1": {"go", "work"}}})
+	noteRepo.Save(&noterepo.NotePO{ID: "n3", OwnerID: "user-1", Title: "Gamma", Keywords: map[string][]string{"user-1": {"go"}}})
+	noteRepo.Save(&noterepo.NotePO{ID: "n4", OwnerID: "user-1", Title: "Delta", Keywords: map[string][]string{"user-2": {"go", "work"}}})
+	id, _ := uc.CreateSavedSearch("user-1", "Go work", "go work/*", "title_desc")
+
+	// Act
+	notes, err := uc.EvaluateSavedSearch(id, "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("EvaluateSavedSearch() returned an unexpected error: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].ID != "n2" || notes[1].ID != "n1" {
+		t.Errorf("Expected notes [n2 n1] in descending title order, got [%s %s]", notes[0].ID, notes[1].ID)
+	}
+}
+
+func TestSavedSearchUsecase_RefreshMemberships(t *testing.T) {
+	// Arrange
+	noteRepo, uc := setUpSavedSearch()
+	noteRepo.Save(&noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Alpha", Keywords: map[string][]string{"user-1": {"go"}}})
+	noteRepo.Save(&noterepo.NotePO{ID: "n2", OwnerID: "user-1", Title: "Beta"})
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// n1 leaves the search and n2 enters it.
+	n1, _ := noteRepo.FindByID("n1")
+	n1.Keywords = map[string][]string{"user-1": {"rust"}}
+	noteRepo.Save(n1)
+	n2, _ := noteRepo.FindByID("n2")
+	n2.Keywords = map[string][]string{"user-1": {"go"}}
+	noteRepo.Save(n2)
+
+	// Act
+	changes, err := uc.RefreshMemberships("user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RefreshMemberships() returned an unexpected error: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 membership changes, got %d", len(changes))
+	}
+	if changes[0].NoteID != "n1" || changes[0].Entered || changes[0].SavedSearchID != id {
+		t.Errorf("Expected n1 to leave the saved search, got %+v", changes[0])
+	}
+	if changes[1].NoteID != "n2" || !changes[1].Entered || changes[1].UserID != "user-1" {
+		t.Errorf("Expected n2 to enter the saved search, got %+v", changes[1])
+	}
+
+	again, _ := uc.RefreshMemberships("user-1")
+	if len(again) != 0 {
+		t.Errorf("Expected no changes on a second refresh, got %d", len(again))
+	}
+}
+
+func TestSavedSearchUsecase_RemoveNoteFromMemberships(t *testing.T) {
+	// Arrange
+	noteRepo, uc := setUpSavedSearch()
+	noteRepo.Save(&noterepo.NotePO{ID: "n1", OwnerID: "user-1", Title: "Alpha", Keywords: map[string][]string{"user-1": {"go"}}})
+	id, _ := uc.CreateSavedSearch("user-1", "Go", "go", "")
+
+	// Act
+	changes := uc.RemoveNoteFromMemberships("n1")
+
+	// Assert
+	if len(changes) != 1 || changes[0].SavedSearchID != id || changes[0].Entered {
+		t.Fatalf("Expected n1 to leave saved search %s, got %+v", id, changes)
+	}
+	if again := uc.RemoveNoteFromMemberships("n1"); len(again) != 0 {
+		t.Errorf("Expected no changes for an already removed note, got %d", len(again))
+	}
+}
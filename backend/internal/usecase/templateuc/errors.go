@@ -0,0 +1,31 @@
+package templateuc
+
+import "errors"
+
+// ErrInvalidID is returned when an invalid ID is provided.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrTemplateNotFound is returned when a template is not found.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// ErrEmptyName is returned when a template has an empty name.
+var ErrEmptyName = errors.New("template name cannot be empty")
+
+// ErrEmptyTitlePattern is returned when a template has an empty title pattern.
+var ErrEmptyTitlePattern = errors.New("template title pattern cannot be empty")
+
+// ErrUnsupportedBlockType is returned when a template block has a type that cannot be
+// instantiated, such as an image or an attachment.
+var ErrUnsupportedBlockType = errors.New("unsupported template block type")
+
+// ErrUnknownPlaceholder is returned when a template uses an unknown placeholder.
+var ErrUnknownPlaceholder = errors.New("unknown template placeholder")
+
+// ErrInvalidBlock is returned when the data of a checklist or table block is not valid.
+var ErrInvalidBlock = errors.New("template block data is not valid for its type")
+
+// ErrPermissionDenied is returned when a user acts on a template they do not own.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrConflict is returned when a version conflict occurs.
+var ErrConflict = errors.New("conflict")
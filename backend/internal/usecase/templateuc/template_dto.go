@@ -0,0 +1,26 @@
+package templateuc
+
+// TemplateDTO represents a note template.
+type TemplateDTO struct {
+	ID           string              `json:"id"`
+	OwnerID      string              `json:"owner_id"`
+	Name         string              `json:"name"`
+	TitlePattern string              `json:"title_pattern"`
+	Blocks       []*TemplateBlockDTO `json:"blocks"`
+	Version      int                 `json:"version"`
+}
+
+// TemplateBlockDTO represents a content block of a template, or of a note drafted from one.
+type TemplateBlockDTO struct {
+	Type     string `json:"type"`
+	Data     string `json:"data"`
+	Language string `json:"language,omitempty"`
+	Format   string `json:"format,omitempty"`
+}
+
+// NoteDraftDTO represents the title and contents of a note instantiated from a template, with
+// its placeholders expanded.
+type NoteDraftDTO struct {
+	Title  string              `json:"title"`
+	Blocks []*TemplateBlockDTO `json:"blocks"`
+}
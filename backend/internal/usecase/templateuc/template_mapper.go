@@ -0,0 +1,78 @@
+package templateuc
+
+import (
+	"noteapp/internal/domain/template"
+	"noteapp/internal/repository/templaterepo"
+)
+
+// TemplateMapper handles mapping between template.Template and other representations.
+type TemplateMapper struct{}
+
+// NewTemplateMapper creates a new TemplateMapper.
+func NewTemplateMapper() *TemplateMapper {
+	return &TemplateMapper{}
+}
+
+// ToPO converts a template.Template to a templaterepo.TemplatePO.
+func (m *TemplateMapper) ToPO(t *template.Template) *templaterepo.TemplatePO {
+	blocks := make([]templaterepo.BlockPO, len(t.Blocks))
+	for i, block := range t.Blocks {
+		blocks[i] = templaterepo.BlockPO{Type: block.Type, Data: block.Data, Language: block.Language, Format: block.Format}
+	}
+	return &templaterepo.TemplatePO{
+		ID:           t.ID,
+		OwnerID:      t.OwnerID,
+		Name:         t.Name,
+		TitlePattern: t.TitlePattern,
+		Blocks:       blocks,
+		Version:      t.Version,
+	}
+}
+
+// ToDomain converts a templaterepo.TemplatePO to a template.Template.
+func (m *TemplateMapper) ToDomain(po *templaterepo.TemplatePO) *template.Template {
+	blocks := make([]template.Block, len(po.Blocks))
+	for i, block := range po.Blocks {
+		blocks[i] = template.Block{Type: block.Type, Data: block.Data, Language: block.Language, Format: block.Format}
+	}
+	return &template.Template{
+		ID:           po.ID,
+		OwnerID:      po.OwnerID,
+		Name:         po.Name,
+		TitlePattern: po.TitlePattern,
+		Blocks:       blocks,
+		Version:      po.Version,
+	}
+}
+
+// ToDTO converts a template.Template to a TemplateDTO.
+func (m *TemplateMapper) ToDTO(t *template.Template) *TemplateDTO {
+	return &TemplateDTO{
+		ID:           t.ID,
+		OwnerID:      t.OwnerID,
+		Name:         t.Name,
+		TitlePattern: t.TitlePattern,
+		Blocks:       m.ToBlockDTOs(t.Blocks),
+		Version:      t.Version,
+	}
+}
+
+// ToBlockDTOs converts template blocks to TemplateBlockDTOs.
+func (m *TemplateMapper) ToBlockDTOs(blocks []template.Block) []*TemplateBlockDTO {
+	dtos := make([]*TemplateBlockDTO, len(blocks))
+	for i, block := range blocks {
+		dtos[i] = &TemplateBlockDTO{Type: block.Type, Data: block.Data, Language: block.Language, Format: block.Format}
+	}
+	return dtos
+}
+
+// ToBlocks converts TemplateBlockDTOs to template blocks.
+func (m *TemplateMapper) ToBlocks(dtos []*TemplateBlockDTO) []template.Block {
+	blocks := make([]template.Block, 0, len(dtos))
+	for _, dto := range dtos {
+		if dto != nil {
+			blocks = append(blocks, template.Block{Type: dto.Type, Data: dto.Data, Language: dto.Language, Format: dto.Format})
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,193 @@
+package templateuc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"noteapp/internal/clock"
+	"noteapp/internal/domain/content"
+	"noteapp/internal/domain/template"
+	"noteapp/internal/repository/templaterepo"
+)
+
+// TemplateUsecase handles the business logic for note templates. Templates are shared by all
+// users, but only their owner may change or delete them.
+type TemplateUsecase struct {
+	repo   templaterepo.TemplateRepository
+	mapper *TemplateMapper
+	clock  clock.Clock
+}
+
+// NewTemplateUsecase creates a new TemplateUsecase.
+func NewTemplateUsecase(repo templaterepo.TemplateRepository) *TemplateUsecase {
+	return NewTemplateUsecaseWithClock(repo, clock.NewSystemClock())
+}
+
+// NewTemplateUsecaseWithClock creates a new TemplateUsecase that reads the time from c.
+func NewTemplateUsecaseWithClock(repo templaterepo.TemplateRepository, c clock.Clock) *TemplateUsecase {
+	return &TemplateUsecase{repo: repo, mapper: NewTemplateMapper(), clock: c}
+}
+
+// CreateTemplate creates a new template owned by a user.
+func (uc *TemplateUsecase) CreateTemplate(ownerID, name, titlePattern string, blocks []*TemplateBlockDTO) (string, error) {
+	if ownerID == "" {
+		return "", ErrInvalidID
+	}
+	t, err := template.NewTemplate("", ownerID, name, titlePattern, uc.mapper.ToBlocks(blocks), 0)
+	if err != nil {
+		return "", mapDomainError(err)
+	}
+	if err := validateBlockData(t); err != nil {
+		return "", err
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(t)); err != nil {
+		return "", uc.mapRepositoryError(err)
+	}
+	return t.ID, nil
+}
+
+// GetTemplate retrieves a template.
+func (uc *TemplateUsecase) GetTemplate(id string) (*TemplateDTO, error) {
+	t, err := uc.getTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapper.ToDTO(t), nil
+}
+
+// ListTemplates retrieves all templates, ordered by name.
+func (uc *TemplateUsecase) ListTemplates() ([]*TemplateDTO, error) {
+	pos, err := uc.repo.FindAll()
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+
+	dtos := []*TemplateDTO{}
+	for _, po := range pos {
+		dtos = append(dtos, uc.mapper.ToDTO(uc.mapper.ToDomain(po)))
+	}
+	return dtos, nil
+}
+
+// UpdateTemplate changes the name, title pattern and blocks of a template owned by the user.
+func (uc *TemplateUsecase) UpdateTemplate(id, userID, name, titlePattern string, blocks []*TemplateBlockDTO, version int) error {
+	t, err := uc.getTemplateAndCheckVersion(id, version)
+	if err != nil {
+		return err
+	}
+	if err := t.Update(userID, name, titlePattern, uc.mapper.ToBlocks(blocks)); err != nil {
+		return mapDomainError(err)
+	}
+	if err := validateBlockData(t); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Save(uc.mapper.ToPO(t)); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// DeleteTemplate deletes a template owned by the user.
+func (uc *TemplateUsecase) DeleteTemplate(id, userID string, version int) error {
+	t, err := uc.getTemplateAndCheckVersion(id, version)
+	if err != nil {
+		return err
+	}
+	if t.OwnerID != userID {
+		return ErrPermissionDenied
+	}
+
+	if err := uc.repo.Delete(id); err != nil {
+		return uc.mapRepositoryError(err)
+	}
+	return nil
+}
+
+// InstantiateTemplate drafts a note for a user from a template, expanding its placeholders
+// with the current time and the user's ID.
+func (uc *TemplateUsecase) InstantiateTemplate(id, userID string) (*NoteDraftDTO, error) {
+	return uc.InstantiateTemplateAt(id, userID, uc.clock.Now())
+}
+
+// InstantiateTemplateAt drafts a note for a user from a template as if it were created at a
+// given time, which also sets the time zone dates and times are shown in.
+func (uc *TemplateUsecase) InstantiateTemplateAt(id, userID string, at time.Time) (*NoteDraftDTO, error) {
+	t, err := uc.getTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	title, blocks := t.Instantiate(template.Values{Now: at, UserID: userID})
+	return &NoteDraftDTO{Title: title, Blocks: uc.mapper.ToBlockDTOs(blocks)}, nil
+}
+
+// validateBlockData checks that the checklist and table blocks of a template will still be
+// valid once their placeholders are expanded.
+func validateBlockData(t *template.Template) error {
+	_, blocks := t.Instantiate(template.Values{UserID: "user"})
+	for _, block := range blocks {
+		var err error
+		switch block.Type {
+		case template.ChecklistBlockType:
+			_, err = content.NormalizeChecklist(block.Data)
+		case template.TableBlockType:
+			_, err = content.NormalizeTable(block.Data)
+		}
+		if err != nil {
+			return ErrInvalidBlock
+		}
+	}
+	return nil
+}
+
+func (uc *TemplateUsecase) getTemplate(id string) (*template.Template, error) {
+	if id == "" {
+		return nil, ErrInvalidID
+	}
+	po, err := uc.repo.FindByID(id)
+	if err != nil {
+		return nil, uc.mapRepositoryError(err)
+	}
+	return uc.mapper.ToDomain(po), nil
+}
+
+func (uc *TemplateUsecase) getTemplateAndCheckVersion(id string, version int) (*template.Template, error) {
+	t, err := uc.getTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Version != version {
+		return nil, ErrConflict
+	}
+	return t, nil
+}
+
+func (uc *TemplateUsecase) mapRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, templaterepo.ErrTemplateNotFound):
+		return ErrTemplateNotFound
+	case errors.Is(err, templaterepo.ErrTemplateConflict):
+		return ErrConflict
+	default:
+		return fmt.Errorf("an unexpected repository error occurred: %w", err)
+	}
+}
+
+func mapDomainError(err error) error {
+	switch {
+	case errors.Is(err, template.ErrEmptyName):
+		return ErrEmptyName
+	case errors.Is(err, template.ErrEmptyTitlePattern):
+		return ErrEmptyTitlePattern
+	case errors.Is(err, template.ErrUnsupportedBlockType):
+		return ErrUnsupportedBlockType
+	case errors.Is(err, template.ErrUnknownPlaceholder):
+		return ErrUnknownPlaceholder
+	case errors.Is(err, template.ErrPermissionDenied):
+		return ErrPermissionDenied
+	default:
+		return fmt.Errorf("an unexpected domain error occurred: %w", err)
+	}
+}
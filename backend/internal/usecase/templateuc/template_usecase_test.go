@@ -0,0 +1,136 @@
+package templateuc_test
+
+import (
+	"noteapp/internal/clock"
+	"noteapp/internal/repository/templaterepo"
+	"noteapp/internal/usecase/templateuc"
+	"testing"
+	"time"
+)
+
+func setUpTemplateUsecase() (*templateuc.TemplateUsecase, *clock.FakeClock) {
+	c := clock.NewFakeClock(time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+	return templateuc.NewTemplateUsecaseWithClock(templaterepo.NewInMemoryTemplateRepository(), c), c
+}
+
+func meetingBlocks() []*templateuc.TemplateBlockDTO {
+	return []*templateuc.TemplateBlockDTO{
+		{Type: "text", Data: "# Meeting of {{date}}", Format: "markdown"},
+		{Type: "checklist", Data: `[{"text":"Send minutes","assignee":"{{user}}"}]`},
+	}
+}
+
+func TestTemplateUsecase_CreateAndGetTemplate(t *testing.T) {
+	// Arrange
+	usecase, _ := setUpTemplateUsecase()
+
+	// Act
+	id, err := usecase.CreateTemplate("user-1", "Meeting", "Meeting {{date}}", meetingBlocks())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreateTemplate returned an unexpected error: %v", err)
+	}
+	tmpl, err := usecase.GetTemplate(id)
+	if err != nil {
+		t.Fatalf("GetTemplate returned an unexpected error: %v", err)
+	}
+	if tmpl.OwnerID != "user-1" || tmpl.TitlePattern != "Meeting {{date}}" || len(tmpl.Blocks) != 2 || tmpl.Version != 0 {
+		t.Errorf("Expected the template as created at version 0, got %+v", tmpl)
+	}
+	if tmpl.Blocks[0].Format != "markdown" || tmpl.Blocks[1].Type != "checklist" {
+		t.Errorf("Expected the blocks in order, got %+v, %+v", tmpl.Blocks[0], tmpl.Blocks[1])
+	}
+}
+
+func TestTemplateUsecase_CreateTemplate_Errors(t *testing.T) {
+	usecase, _ := setUpTemplateUsecase()
+
+	tests := []struct {
+		name    string
+		ownerID string
+		blocks  []*templateuc.TemplateBlockDTO
+		pattern string
+		wantErr error
+	}{
+		{"no owner", "", nil, "Meeting", templateuc.ErrInvalidID},
+		{"no title pattern", "user-1", nil, "", templateuc.ErrEmptyTitlePattern},
+		{"unknown placeholder", "user-1", nil, "Meeting {{room}}", templateuc.ErrUnknownPlaceholder},
+		{"image block", "user-1", []*templateuc.TemplateBlockDTO{{Type: "image", Data: "hash"}}, "Meeting", templateuc.ErrUnsupportedBlockType},
+		{"invalid checklist", "user-1", []*templateuc.TemplateBlockDTO{{Type: "checklist", Data: "{{user}}"}}, "Meeting", templateuc.ErrInvalidBlock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := usecase.CreateTemplate(tt.ownerID, "Meeting", tt.pattern, tt.blocks); err != tt.wantErr {
+				t.Errorf("Expected error '%v', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTemplateUsecase_UpdateAndDeleteTemplate(t *testing.T) {
+	usecase, _ := setUpTemplateUsecase()
+	id, _ := usecase.CreateTemplate("user-1", "Meeting", "Meeting", nil)
+
+	if err := usecase.UpdateTemplate(id, "user-2", "Stand-up", "Stand-up", nil, 0); err != templateuc.ErrPermissionDenied {
+		t.Errorf("Expected error '%v', got '%v'", templateuc.ErrPermissionDenied, err)
+	}
+	if err := usecase.UpdateTemplate(id, "user-1", "Stand-up", "Stand-up {{date}}", meetingBlocks(), 0); err != nil {
+		t.Fatalf("UpdateTemplate returned an unexpected error: %v", err)
+	}
+	if err := usecase.UpdateTemplate(id, "user-1", "Stand-up", "Stand-up", nil, 0); err != templateuc.ErrConflict {
+		t.Errorf("Expected error '%v', got '%v'", templateuc.ErrConflict, err)
+	}
+	if templates, _ := usecase.ListTemplates(); len(templates) != 1 || templates[0].Name != "Stand-up" || templates[0].Version != 1 {
+		t.Errorf("Expected the updated template at version 1, got %+v", templates)
+	}
+
+	if err := usecase.DeleteTemplate(id, "user-2", 1); err != templateuc.ErrPermissionDenied {
+		t.Errorf("Expected error '%v', got '%v'", templateuc.ErrPermissionDenied, err)
+	}
+	if err := usecase.DeleteTemplate(id, "user-1", 1); err != nil {
+		t.Fatalf("DeleteTemplate returned an unexpected error: %v", err)
+	}
+	if _, err := usecase.GetTemplate(id); err != templateuc.ErrTemplateNotFound {
+		t.Errorf("Expected error '%v', got '%v'", templateuc.ErrTemplateNotFound, err)
+	}
+}
+
+func TestTemplateUsecase_InstantiateTemplate(t *testing.T) {
+	// Arrange
+	usecase, c := setUpTemplateUsecase()
+	id, _ := usecase.CreateTemplate("user-1", "Meeting", "Meeting {{date}}", meetingBlocks())
+	c.Advance(24 * time.Hour)
+
+	// Act
+	draft, err := usecase.InstantiateTemplate(id, "user-2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("InstantiateTemplate returned an unexpected error: %v", err)
+	}
+	if draft.Title != "Meeting 2024-03-05" {
+		t.Errorf("Expected title 'Meeting 2024-03-05', got '%s'", draft.Title)
+	}
+	if draft.Blocks[0].Data != "# Meeting of 2024-03-05" || draft.Blocks[1].Data != `[{"text":"Send minutes","assignee":"user-2"}]` {
+		t.Errorf("Expected the placeholders of the blocks to be expanded, got %+v, %+v", draft.Blocks[0], draft.Blocks[1])
+	}
+	if _, err := usecase.InstantiateTemplate("missing", "user-2"); err != templateuc.ErrTemplateNotFound {
+		t.Errorf("Expected error '%v', got '%v'", templateuc.ErrTemplateNotFound, err)
+	}
+}
+
+func TestTemplateUsecase_InstantiateTemplateAt(t *testing.T) {
+	usecase, _ := setUpTemplateUsecase()
+	id, _ := usecase.CreateTemplate("user-1", "Journal", "{{weekday}} {{date}} {{time}}", nil)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	draft, err := usecase.InstantiateTemplateAt(id, "user-1", time.Date(2024, 2, 29, 7, 5, 0, 0, tokyo))
+
+	if err != nil {
+		t.Fatalf("InstantiateTemplateAt returned an unexpected error: %v", err)
+	}
+	if draft.Title != "Thursday 2024-02-29 07:05" {
+		t.Errorf("Expected title 'Thursday 2024-02-29 07:05', got '%s'", draft.Title)
+	}
+}